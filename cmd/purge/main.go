@@ -0,0 +1,84 @@
+// Команда purge однократно выполняет окончательное удаление ссылок, пролежавших в корзине
+// дольше срока хранения, и завершает работу. Использует те же настройки, что и сервис
+// (переменные окружения, флаги и файл конфигурации), в том числе DELETED_URLS_RETENTION.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/config/db"
+	baseRepo "yp-go-short-url-service/internal/repository/base"
+	"yp-go-short-url-service/internal/service"
+	urlCacheService "yp-go-short-url-service/internal/service/urls/cache"
+	urlDestructorService "yp-go-short-url-service/internal/service/urls/destructor"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	defer stop()
+
+	logger, err := config.NewLogger(false)
+	if err != nil {
+		fmt.Printf("failed to initialize logger: %s\n", err)
+		os.Exit(1)
+	}
+	defer config.SyncLogger(logger)
+
+	settings, err := config.NewSettings()
+	if err != nil {
+		logger.Errorw("Failed to load settings", "error", err)
+		os.Exit(1)
+	}
+
+	dbPool := db.Setup(ctx, logger, &db.SetupParams{
+		PostgresDSN:      settings.GetPostgresDSN(),
+		SQLiteDSN:        settings.EnvSettings.SQLite.SQLiteDBPath,
+		PGMigrationsPath: settings.EnvSettings.PG.MigrationsPath,
+	})
+	if err, ok := dbPool.(error); ok {
+		logger.Errorw("Failed to setup database", "error", err)
+		os.Exit(1)
+	}
+
+	// Кэш в памяти процесса принадлежит экземплярам сервиса, сбросить его отсюда нельзя;
+	// общий кэш в Redis сбрасывается, чтобы коды удаленных ссылок перестали считаться удаленными
+	var urlCache service.Cache
+	if settings.GetURLCacheSize() > 0 && settings.GetURLCacheBackend() == urlCacheService.BackendRedis {
+		urlCache, err = urlCacheService.NewCache(ctx, urlCacheService.BackendRedis, settings.GetRedisURL(), urlCacheService.Options{
+			Size:        settings.GetURLCacheSize(),
+			TTL:         settings.GetURLCacheTTL(),
+			NegativeTTL: settings.GetURLCacheNegativeTTL(),
+		}, logger)
+		if err != nil {
+			logger.Errorw("Failed to connect to url cache", "error", err)
+			os.Exit(1)
+		}
+		defer func() { _ = urlCache.Close() }()
+	}
+
+	// Нулевой интервал отключает фоновый запуск - выполняем один проход
+	purger := urlDestructorService.NewDeletedURLsPurger(
+		baseRepo.NewURLsRepository(dbPool),
+		settings.GetDeletedURLsRetention(),
+		0,
+		urlCache,
+		logger,
+	)
+	defer purger.Stop()
+
+	purged, err := purger.Purge(ctx)
+	if err != nil {
+		logger.Errorw("Failed to purge deleted URLs",
+			"urls", purged.URLs,
+			"user_links", purged.UserLinks,
+			"error", err,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Purged URLs: %d, user links: %d\n", purged.URLs, purged.UserLinks)
+}
@@ -56,7 +56,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос",
+                        "description": "Неверный запрос или URL запрещен политикой адресов назначения",
                         "schema": {
                             "type": "string"
                         }
@@ -76,6 +76,146 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/internal/broken-links": {
+            "get": {
+                "description": "Возвращает ссылки, адреса назначения которых оказались недоступны не менее failures проверок подряд, начиная с ссылок с наибольшим числом неудач. Доступно только из доверенной подсети.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "internal"
+                ],
+                "summary": "Получить нерабочие ссылки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Минимальное число неудачных проверок подряд (по умолчанию из настроек)",
+                        "name": "failures",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Нерабочие ссылки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/linkcheck.BrokenLinkDTOOut"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Запрос не из доверенной подсети",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/qr/{shortURL}": {
+            "get": {
+                "description": "Возвращает QR-код полного короткого URL в формате PNG или SVG. Запрос не считается переходом по ссылке и не расходует лимит переходов.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "qr"
+                ],
+                "summary": "Получить QR-код короткой ссылки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "abc123",
+                        "description": "Короткий URL",
+                        "name": "shortURL",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "svg",
+                        "description": "Формат изображения: png или svg (по умолчанию png)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 512,
+                        "description": "Ширина и высота изображения в пикселях, от 64 до 2048 (по умолчанию 256)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2,
+                        "description": "Ширина пустой зоны вокруг кода в модулях, от 0 до 16 (по умолчанию 4)",
+                        "name": "margin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "H",
+                        "description": "Уровень коррекции ошибок: L, M, Q или H (по умолчанию M)",
+                        "name": "level",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изображение QR-кода",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Ссылка не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "410": {
+                        "description": "Ссылка удалена, отключена владельцем, срок ее действия истек или исчерпан лимит переходов",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
         "/api/shorten": {
             "post": {
                 "description": "Создает короткую ссылку из длинного URL",
@@ -108,14 +248,14 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос",
+                        "description": "Неверный запрос или URL запрещен политикой адресов назначения (код причины в поле reason)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "409": {
-                        "description": "URL уже существует в системе",
+                        "description": "URL уже существует в системе или пользовательский код занят",
                         "schema": {
                             "$ref": "#/definitions/json.CreatingShortURLsDTOOut"
                         }
@@ -175,7 +315,14 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос",
+                        "description": "Неверный запрос или URL запрещен политикой адресов назначения (код причины в поле reason)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Пользовательский код уже занят",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -253,7 +400,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаляет указанные короткие URL пользователя. Требует JWT аутентификации.",
+                "description": "Асинхронно удаляет указанные короткие URL пользователя. Возвращает идентификатор задачи, статус которой доступен по /api/user/urls/delete-jobs/{id}. Требует JWT аутентификации.",
                 "consumes": [
                     "application/json"
                 ],
@@ -280,7 +427,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "202": {
-                        "description": "URL успешно удалены",
+                        "description": "Запрос на удаление принят, в job_id возвращается идентификатор задачи",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -317,146 +464,1186 @@ const docTemplate = `{
                 }
             }
         },
-        "/ping": {
+        "/api/user/urls/delete-jobs/{id}": {
             "get": {
-                "description": "Проверяет доступность базы данных и возвращает статус сервиса",
-                "consumes": [
-                    "text/plain"
-                ],
+                "description": "Возвращает статус задачи пакетного удаления (queued, running, done, failed) и результат для каждого короткого URL (deleted, already_deleted, not_owned, not_found). Если задача в статусе queued содержит error, предыдущая попытка завершилась ошибкой и задача ожидает повтора. Завершенные задачи хранятся ограниченное время. Требует JWT аутентификации.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "user"
+                ],
+                "summary": "Получить статус задачи удаления",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор задачи удаления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Проверка здоровья сервиса",
                 "responses": {
                     "200": {
-                        "description": "pong",
+                        "description": "Статус задачи удаления",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/destructor.DeleteJobDTOOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена или принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "health check failed",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/{shortURL}": {
-            "get": {
-                "description": "Перенаправляет пользователя на оригинальный длинный URL по короткой ссылке",
+        "/api/user/urls/restore": {
+            "post": {
+                "description": "Восстанавливает указанные удаленные короткие URL пользователя из корзины. Чужие и неудаленные ссылки игнорируются. Требует JWT аутентификации.",
                 "consumes": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "redirect"
+                    "user"
                 ],
-                "summary": "Перенаправление на длинный URL",
+                "summary": "Восстановить удаленные URL пользователя",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "abc123",
-                        "description": "Короткий URL",
-                        "name": "shortURL",
-                        "in": "path",
-                        "required": true
+                        "description": "Массив коротких URL для восстановления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     }
                 ],
                 "responses": {
-                    "307": {
-                        "description": "Перенаправление на длинный URL",
+                    "202": {
+                        "description": "Запрос на восстановление принят",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
                         "description": "Неверный запрос",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Неподдерживаемый тип контента",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "batch.URLRequest": {
-            "type": "object",
-            "required": [
-                "correlation_id",
-                "original_url"
-            ],
-            "properties": {
-                "correlation_id": {
-                    "description": "CorrelationID - уникальный идентификатор для корреляции запроса/ответа\nrequired: true\nexample: \"1\"",
-                    "type": "string"
-                },
-                "original_url": {
-                    "description": "OriginalURL - длинный URL для сокращения\nrequired: true\nexample: \"https://www.example.com/very/long/url/that/needs/to/be/shortened\"",
-                    "type": "string"
-                }
-            }
-        },
-        "batch.URLResponse": {
-            "type": "object",
-            "properties": {
-                "correlation_id": {
-                    "description": "CorrelationID - уникальный идентификатор для корреляции запроса/ответа\nexample: \"1\"",
-                    "type": "string"
-                },
-                "short_url": {
-                    "description": "ShortURL - сокращенный URL\nexample: \"http://localhost:8080/abc123\"",
-                    "type": "string"
-                }
-            }
-        },
-        "json.CreatingShortURLsDTOIn": {
-            "type": "object",
-            "required": [
-                "url"
-            ],
-            "properties": {
-                "url": {
-                    "description": "URL - длинный URL для сокращения\nrequired: true\nexample: \"https://www.example.com/very/long/url/that/needs/to/be/shortened\"",
-                    "type": "string"
-                }
-            }
-        },
-        "json.CreatingShortURLsDTOOut": {
-            "type": "object",
-            "properties": {
-                "result": {
-                    "description": "Result - сокращенный URL\nexample: \"http://localhost:8080/abc123\"",
-                    "type": "string"
-                }
-            }
         },
-        "user.UserURLResponse": {
-            "description": "Ответ с URL пользователя",
+        "/api/user/urls/trash": {
+            "get": {
+                "description": "Возвращает удаленные URL пользователя (корзину), которые можно восстановить. Требует JWT аутентификации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получить удаленные URL пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT токен в заголовке Authorization (Bearer \u003ctoken\u003e)",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список удаленных URL пользователя успешно получен",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.UserURLResponse"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "Корзина пользователя пуста",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.UserURLResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован - JWT токен отсутствует или недействителен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/urls/{shortURL}": {
+            "delete": {
+                "description": "Удаляет одну короткую ссылку пользователя. В отличие от пакетного удаления выполняется синхронно и сообщает результат. Требует JWT аутентификации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Удалить ссылку пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "abc123",
+                        "description": "Короткий URL",
+                        "name": "shortURL",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ссылка удалена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный короткий URL",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Ссылка принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Ссылка не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "410": {
+                        "description": "Ссылка уже удалена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Изменяет адрес назначения, срок действия, активность, заголовок, страницу предупреждения, правила условного перенаправления или варианты адреса назначения короткой ссылки. Доступно только владельцу ссылки, требует JWT аутентификации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Изменить ссылку пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "abc123",
+                        "description": "Короткий URL",
+                        "name": "shortURL",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменения ссылки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/editor.UpdatingURLDTOIn"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ссылка успешно изменена",
+                        "schema": {
+                            "$ref": "#/definitions/editor.UpdatingURLDTOOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Ссылка не найдена или принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Неподдерживаемый тип контента",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/urls/{shortURL}/stats": {
+            "get": {
+                "description": "Возвращает общее число переходов и уникальных посетителей по короткой ссылке, а также разбивку по дням (UTC) за последние days дней. Доступно только владельцу ссылки, требует JWT аутентификации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получить статистику переходов по ссылке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "abc123",
+                        "description": "Короткий URL",
+                        "name": "shortURL",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 7,
+                        "description": "Период разбивки по дням, от 1 до 365 (по умолчанию 30)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статистика переходов",
+                        "schema": {
+                            "$ref": "#/definitions/analytics.URLStatsDTOOut"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Ссылка принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Ссылка не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ping": {
+            "get": {
+                "description": "Проверяет доступность базы данных и возвращает статус сервиса",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Проверка здоровья сервиса",
+                "responses": {
+                    "200": {
+                        "description": "pong",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "health check failed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/{shortURL}": {
+            "get": {
+                "description": "Перенаправляет пользователя на оригинальный длинный URL по короткой ссылке.\nЕсли к короткому коду добавлен суффикс \"+\" или передан параметр preview=1, вместо перехода\nотображается страница предпросмотра с адресом назначения, датой создания и заголовком ссылки.\nДля ссылки со страницей предупреждения переход выполняется только с параметром confirm=1.\nСтатус перенаправления (301, 302, 307 или 308) задается для каждой ссылки; остальные параметры запроса\nпереносятся в адрес назначения, если для ссылки включен режим query_passthrough.\nДля ссылки-префикса путь после короткого кода добавляется к адресу назначения: запрос /{shortURL}/docs/page?x=1\nперенаправляется на \u003cадрес назначения\u003e/docs/page?x=1. Для обычной ссылки такой путь приводит к 404.\nЕсли для ссылки заданы правила условного перенаправления, адрес назначения выбирается по первому правилу,\nкоторому соответствуют User-Agent, Accept-Language и IP-адрес клиента.\nЕсли для ссылки заданы варианты адреса назначения, вариант выбирается по весам и закрепляется\nза посетителем в cookie split_variant, чтобы следующие переходы вели на тот же вариант.",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "text/plain",
+                    "text/html"
+                ],
+                "tags": [
+                    "redirect"
+                ],
+                "summary": "Перенаправление на длинный URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "abc123",
+                        "description": "Короткий URL, при необходимости с суффиксом +",
+                        "name": "shortURL",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "1",
+                        "description": "Показать страницу предпросмотра вместо перехода",
+                        "name": "preview",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1",
+                        "description": "Подтверждение перехода по ссылке со страницей предупреждения",
+                        "name": "confirm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль защищенной ссылки",
+                        "name": "X-Link-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница предпросмотра или предупреждения перед переходом",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "301": {
+                        "description": "Постоянное перенаправление на длинный URL",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "302": {
+                        "description": "Перенаправление на длинный URL",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "307": {
+                        "description": "Перенаправление на длинный URL",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "308": {
+                        "description": "Постоянное перенаправление на длинный URL",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Ссылка защищена паролем или указан неверный пароль",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Ссылка не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Ссылка удалена, отключена владельцем, срок ее действия истек или исчерпан лимит переходов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено число попыток ввода пароля",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/{shortURL}/unlock": {
+            "post": {
+                "description": "Проверяет пароль защищенной ссылки и перенаправляет пользователя на оригинальный длинный URL",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "redirect"
+                ],
+                "summary": "Ввод пароля защищенной ссылки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "abc123",
+                        "description": "Короткий URL",
+                        "name": "shortURL",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль ссылки",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "/docs/page",
+                        "description": "Путь после короткого кода для ссылки-префикса",
+                        "name": "path",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "303": {
+                        "description": "Перенаправление на длинный URL",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Указан неверный пароль",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Ссылка удалена, отключена владельцем, срок ее действия истек или исчерпан лимит переходов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено число попыток ввода пароля",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "analytics.DailyClicksDTOOut": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "description": "Clicks - число переходов за день\nexample: 5",
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "Date - дата в формате YYYY-MM-DD (UTC)\nexample: \"2025-03-04\"",
+                    "type": "string"
+                },
+                "unique_visitors": {
+                    "description": "UniqueVisitors - число уникальных посетителей за день\nexample: 3",
+                    "type": "integer"
+                }
+            }
+        },
+        "analytics.URLStatsDTOOut": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "description": "Daily - разбивка по дням (UTC) за запрошенный период, включая дни без переходов",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.DailyClicksDTOOut"
+                    }
+                },
+                "short_url": {
+                    "description": "ShortURL - сокращенный URL\nexample: \"http://localhost:8080/abc123\"",
+                    "type": "string"
+                },
+                "total_clicks": {
+                    "description": "TotalClicks - число переходов за все время\nexample: 42",
+                    "type": "integer"
+                },
+                "unique_visitors": {
+                    "description": "UniqueVisitors - число уникальных посетителей за все время (пар обезличенного IP и User-Agent)\nexample: 17",
+                    "type": "integer"
+                },
+                "variants": {
+                    "description": "Variants - разбивка по вариантам адреса назначения за все время; отсутствует, если переходов по вариантам не было",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.VariantClicksDTOOut"
+                    }
+                }
+            }
+        },
+        "analytics.VariantClicksDTOOut": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "description": "Clicks - число переходов на вариант\nexample: 30",
+                    "type": "integer"
+                },
+                "unique_visitors": {
+                    "description": "UniqueVisitors - число уникальных посетителей варианта\nexample: 12",
+                    "type": "integer"
+                },
+                "variant": {
+                    "description": "Variant - имя варианта\nexample: \"a\"",
+                    "type": "string"
+                }
+            }
+        },
+        "batch.URLRequest": {
+            "type": "object",
+            "required": [
+                "correlation_id",
+                "original_url"
+            ],
+            "properties": {
+                "alias": {
+                    "description": "Alias - пользовательский короткий код (необязательно)\nexample: \"q4-report\"",
+                    "type": "string"
+                },
+                "correlation_id": {
+                    "description": "CorrelationID - уникальный идентификатор для корреляции запроса/ответа\nrequired: true\nexample: \"1\"",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt - момент истечения ссылки в формате RFC 3339 (необязательно, не совместим с TTL)\nexample: \"2030-01-01T00:00:00Z\"",
+                    "type": "string"
+                },
+                "max_clicks": {
+                    "description": "MaxClicks - количество переходов, после которого ссылка перестает работать (необязательно)\nexample: 1",
+                    "type": "integer"
+                },
+                "original_url": {
+                    "description": "OriginalURL - длинный URL для сокращения\nrequired: true\nexample: \"https://www.example.com/very/long/url/that/needs/to/be/shortened\"",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password - пароль, который нужно ввести перед переходом по ссылке (необязательно, от 4 до 72 байт)\nexample: \"s3cret\"",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix - ссылка-префикс: путь после короткого кода добавляется к адресу назначения,\nнапример /{code}/docs/page ведет на \u003coriginal_url\u003e/docs/page (необязательно)\nexample: true",
+                    "type": "boolean"
+                },
+                "query_passthrough": {
+                    "description": "QueryPassthrough - перенос параметров запроса в адрес назначения: merge - при совпадении имени\nсохраняются значения адреса назначения, override - пришедшие значения заменяют их (необязательно)\nexample: \"merge\"",
+                    "type": "string"
+                },
+                "redirect_code": {
+                    "description": "RedirectCode - HTTP статус перенаправления: 301, 302, 307 или 308 (необязательно, по умолчанию 307)\nexample: 301",
+                    "type": "integer"
+                },
+                "ttl": {
+                    "description": "TTL - время жизни ссылки в секундах (необязательно, не совместим с ExpiresAt)\nexample: 86400",
+                    "type": "integer"
+                }
+            }
+        },
+        "batch.URLResponse": {
+            "type": "object",
+            "properties": {
+                "correlation_id": {
+                    "description": "CorrelationID - уникальный идентификатор для корреляции запроса/ответа\nexample: \"1\"",
+                    "type": "string"
+                },
+                "short_url": {
+                    "description": "ShortURL - сокращенный URL\nexample: \"http://localhost:8080/abc123\"",
+                    "type": "string"
+                }
+            }
+        },
+        "destructor.DeleteJobDTOOut": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2b9c4e-8d1a-4e6b-9f0c-2a7d5e1b8c3f"
+                },
+                "results": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.DeleteOutcome"
+                    }
+                },
+                "short_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.DeleteJobStatus"
+                        }
+                    ],
+                    "example": "done"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "editor.UpdatingURLDTOIn": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active - включить (true) или отключить (false) переходы по ссылке (необязательно)\nexample: false",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt - новый момент истечения ссылки в формате RFC 3339 (необязательно, не совместим с TTL и NoExpiration)\nexample: \"2030-01-01T00:00:00Z\"",
+                    "type": "string"
+                },
+                "interstitial": {
+                    "description": "Interstitial - включить (true) или отключить (false) страницу предупреждения перед переходом (необязательно)\nexample: true",
+                    "type": "boolean"
+                },
+                "no_expiration": {
+                    "description": "NoExpiration - сделать ссылку бессрочной (необязательно)\nexample: false",
+                    "type": "boolean"
+                },
+                "rules": {
+                    "description": "Rules - новый список правил условного перенаправления; пустой список удаляет все правила (необязательно)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RoutingRule"
+                    }
+                },
+                "title": {
+                    "description": "Title - новый заголовок ссылки для страницы предпросмотра; пустая строка удаляет заголовок (необязательно)\nexample: \"Квартальный отчет\"",
+                    "type": "string"
+                },
+                "ttl": {
+                    "description": "TTL - новое время жизни ссылки в секундах, отсчитываемое от момента изменения (необязательно)\nexample: 86400",
+                    "type": "integer"
+                },
+                "url": {
+                    "description": "URL - новый адрес назначения ссылки (необязательно)\nexample: \"https://www.example.com/new/destination\"",
+                    "type": "string"
+                },
+                "variants": {
+                    "description": "Variants - новый список вариантов адреса назначения для A/B теста; пустой список отключает разделение\nтрафика (необязательно)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SplitVariant"
+                    }
+                }
+            }
+        },
+        "editor.UpdatingURLDTOOut": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active - признак того, что переходы по ссылке разрешены\nexample: true",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt - момент истечения ссылки, если срок действия задан\nexample: \"2030-01-01T00:00:00Z\"",
+                    "type": "string"
+                },
+                "interstitial": {
+                    "description": "Interstitial - признак того, что перед переходом показывается страница предупреждения\nexample: false",
+                    "type": "boolean"
+                },
+                "original_url": {
+                    "description": "OriginalURL - адрес назначения ссылки\nexample: \"https://www.example.com/new/destination\"",
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "Rules - правила условного перенаправления ссылки, если они заданы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RoutingRule"
+                    }
+                },
+                "short_url": {
+                    "description": "ShortURL - сокращенный URL\nexample: \"http://localhost:8080/abc123\"",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title - заголовок ссылки, если задан\nexample: \"Квартальный отчет\"",
+                    "type": "string"
+                },
+                "variants": {
+                    "description": "Variants - варианты адреса назначения ссылки, если они заданы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SplitVariant"
+                    }
+                }
+            }
+        },
+        "json.CreatingShortURLsDTOIn": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "alias": {
+                    "description": "Alias - пользовательский короткий код (необязательно)\nexample: \"q4-report\"",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt - момент истечения ссылки в формате RFC 3339 (необязательно, не совместим с TTL)\nexample: \"2030-01-01T00:00:00Z\"",
+                    "type": "string"
+                },
+                "interstitial": {
+                    "description": "Interstitial - показывать страницу предупреждения перед каждым переходом по ссылке (необязательно)\nexample: true",
+                    "type": "boolean"
+                },
+                "max_clicks": {
+                    "description": "MaxClicks - количество переходов, после которого ссылка перестает работать (необязательно)\nexample: 1",
+                    "type": "integer"
+                },
+                "password": {
+                    "description": "Password - пароль, который нужно ввести перед переходом по ссылке (необязательно, от 4 до 72 байт)\nexample: \"s3cret\"",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix - ссылка-префикс: путь после короткого кода добавляется к адресу назначения,\nнапример /{code}/docs/page ведет на \u003coriginal_url\u003e/docs/page (необязательно)\nexample: true",
+                    "type": "boolean"
+                },
+                "query_passthrough": {
+                    "description": "QueryPassthrough - перенос параметров запроса в адрес назначения: merge - при совпадении имени\nсохраняются значения адреса назначения, override - пришедшие значения заменяют их (необязательно)\nexample: \"merge\"",
+                    "type": "string"
+                },
+                "redirect_code": {
+                    "description": "RedirectCode - HTTP статус перенаправления: 301, 302, 307 или 308 (необязательно, по умолчанию 307)\nexample: 301",
+                    "type": "integer"
+                },
+                "rules": {
+                    "description": "Rules - правила условного перенаправления по устройству (ios, android, desktop), языку из Accept-Language\nи диапазонам IP-адресов клиента; срабатывает первое подходящее правило, иначе переход выполняется\nна URL (необязательно, не более 20 правил)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RoutingRule"
+                    }
+                },
+                "title": {
+                    "description": "Title - заголовок ссылки для страницы предпросмотра (необязательно, не длиннее 200 символов)\nexample: \"Квартальный отчет\"",
+                    "type": "string"
+                },
+                "ttl": {
+                    "description": "TTL - время жизни ссылки в секундах (необязательно, не совместим с ExpiresAt)\nexample: 86400",
+                    "type": "integer"
+                },
+                "url": {
+                    "description": "URL - длинный URL для сокращения\nrequired: true\nexample: \"https://www.example.com/very/long/url/that/needs/to/be/shortened\"",
+                    "type": "string"
+                },
+                "variants": {
+                    "description": "Variants - варианты адреса назначения для A/B теста: переходы распределяются между ними пропорционально\nвесам, а выбранный вариант закрепляется за посетителем; URL при этом только отображается владельцу\n(необязательно, от 2 до 10 вариантов)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SplitVariant"
+                    }
+                }
+            }
+        },
+        "json.CreatingShortURLsDTOOut": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "description": "Result - сокращенный URL\nexample: \"http://localhost:8080/abc123\"",
+                    "type": "string"
+                }
+            }
+        },
+        "linkcheck.BrokenLinkDTOOut": {
+            "type": "object",
+            "properties": {
+                "health": {
+                    "description": "Health - результат последней проверки доступности адреса назначения",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.LinkHealth"
+                        }
+                    ]
+                },
+                "original_url": {
+                    "description": "OriginalURL - адрес назначения ссылки\nexample: \"https://example.com/removed-page\"",
+                    "type": "string"
+                },
+                "short_url": {
+                    "description": "ShortURL - сокращенный URL\nexample: \"http://localhost:8080/abc123\"",
+                    "type": "string"
+                }
+            }
+        },
+        "model.DeleteJobStatus": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "running",
+                "done",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "DeleteJobQueued",
+                "DeleteJobRunning",
+                "DeleteJobDone",
+                "DeleteJobFailed"
+            ]
+        },
+        "model.DeleteOutcome": {
+            "type": "string",
+            "enum": [
+                "deleted",
+                "already_deleted",
+                "not_owned",
+                "not_found"
+            ],
+            "x-enum-varnames": [
+                "DeleteOutcomeDeleted",
+                "DeleteOutcomeAlreadyDeleted",
+                "DeleteOutcomeNotOwned",
+                "DeleteOutcomeNotFound"
+            ]
+        },
+        "model.DeviceFamily": {
+            "type": "string",
+            "enum": [
+                "ios",
+                "android",
+                "desktop"
+            ],
+            "x-enum-varnames": [
+                "DeviceIOS",
+                "DeviceAndroid",
+                "DeviceDesktop"
+            ]
+        },
+        "model.LinkHealth": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "description": "CheckedAt - момент проверки.",
+                    "type": "string"
+                },
+                "consecutive_failures": {
+                    "description": "ConsecutiveFailures - число неудачных проверок подряд; успешная проверка его сбрасывает.",
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error - описание ошибки, если ответ не получен или перенаправлений оказалось слишком много.",
+                    "type": "string"
+                },
+                "redirect_chain": {
+                    "description": "RedirectChain - адреса, на которые последовательно перенаправлял адрес назначения.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status_code": {
+                    "description": "StatusCode - HTTP статус последнего полученного ответа; 0, если ответ не получен.",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RoutingRule": {
+            "type": "object",
+            "properties": {
+                "cidrs": {
+                    "description": "CIDRs - диапазоны IP-адресов клиента в нотации CIDR, например \"10.0.0.0/8\".",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "destination": {
+                    "description": "Destination - адрес назначения при срабатывании правила.",
+                    "type": "string"
+                },
+                "device": {
+                    "description": "Device - семейство устройства клиента. Пустое значение означает любое устройство.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.DeviceFamily"
+                        }
+                    ]
+                },
+                "languages": {
+                    "description": "Languages - языковые теги, например \"ru\" или \"en-US\"; правило срабатывает, если наиболее предпочтительный\nязык из Accept-Language совпадает с одним из тегов или уточняет его (\"en\" подходит для \"en-GB\").",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.SplitVariant": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "description": "Destination - адрес назначения варианта.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name - имя варианта, уникальное в пределах ссылки, например \"a\" или \"landing-b\".\nИспользуется для закрепления варианта за посетителем и в статистике переходов.",
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Weight - вес варианта, положительное число.",
+                    "type": "integer"
+                }
+            }
+        },
+        "user.UserURLResponse": {
+            "description": "Ответ с URL пользователя",
             "type": "object",
             "properties": {
+                "clicks": {
+                    "description": "@Description Общее количество переходов по ссылке; обновляется с задержкой до периода сброса буфера переходов\n@Example 42",
+                    "type": "integer",
+                    "example": 42
+                },
+                "clicks_left": {
+                    "description": "@Description Оставшееся количество переходов для ссылки с лимитом\n@Example 1",
+                    "type": "integer",
+                    "example": 1
+                },
+                "disabled": {
+                    "description": "@Description Признак того, что ссылка отключена владельцем\n@Example true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "expires_at": {
+                    "description": "@Description Момент истечения ссылки, если срок действия задан\n@Example 2030-01-01T00:00:00Z",
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "health": {
+                    "description": "@Description Результат последней проверки доступности адреса назначения; отсутствует, пока ссылка не проверялась",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.LinkHealth"
+                        }
+                    ]
+                },
+                "interstitial": {
+                    "description": "@Description Признак того, что перед переходом по ссылке показывается страница предупреждения\n@Example true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_clicks": {
+                    "description": "@Description Лимит переходов по ссылке, если он задан\n@Example 1",
+                    "type": "integer",
+                    "example": 1
+                },
                 "original_url": {
                     "description": "@Description Оригинальный длинный URL\n@Example https://www.example.com/very/long/url/that/needs/to/be/shortened",
                     "type": "string",
                     "example": "https://www.example.com/very/long/url/that/needs/to/be/shortened"
                 },
+                "password_protected": {
+                    "description": "@Description Признак того, что ссылка защищена паролем\n@Example true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "prefix": {
+                    "description": "@Description Признак ссылки-префикса, которая переносит путь после короткого кода в адрес назначения\n@Example true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "query_passthrough": {
+                    "description": "@Description Режим переноса параметров запроса в адрес назначения (merge или override), если он включен\n@Example merge",
+                    "type": "string",
+                    "example": "merge"
+                },
+                "redirect_code": {
+                    "description": "@Description HTTP статус перенаправления по ссылке: 301, 302, 307 или 308\n@Example 301",
+                    "type": "integer",
+                    "example": 301
+                },
+                "rules": {
+                    "description": "@Description Правила условного перенаправления по устройству, языку и IP-адресу клиента, если они заданы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RoutingRule"
+                    }
+                },
                 "short_url": {
                     "description": "@Description Сокращенный URL пользователя\n@Example http://localhost:8080/abc123",
                     "type": "string",
                     "example": "http://localhost:8080/abc123"
+                },
+                "title": {
+                    "description": "@Description Заголовок ссылки для страницы предпросмотра, если он задан\n@Example Квартальный отчет",
+                    "type": "string",
+                    "example": "Квартальный отчет"
+                },
+                "variants": {
+                    "description": "@Description Варианты адреса назначения для A/B теста, если они заданы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SplitVariant"
+                    }
                 }
             }
         }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/netip"
 	"yp-go-short-url-service/internal/config"
 	"yp-go-short-url-service/internal/config/db"
 	pb "yp-go-short-url-service/internal/generated/api/proto"
@@ -11,9 +12,13 @@ import (
 	grpcImpl "yp-go-short-url-service/internal/handler/grpc"
 	"yp-go-short-url-service/internal/handler/health"
 	statsHandler "yp-go-short-url-service/internal/handler/stats"
+	urlsAnalyticsAPIHandler "yp-go-short-url-service/internal/handler/urls/analytics"
 	urlsDestructorAPIHandler "yp-go-short-url-service/internal/handler/urls/destructor"
+	urlsEditorAPIHandler "yp-go-short-url-service/internal/handler/urls/editor"
 	urlExtractorHandler "yp-go-short-url-service/internal/handler/urls/extractor"
 	userURLsHandler "yp-go-short-url-service/internal/handler/urls/extractor/user"
+	urlsLinkCheckHandler "yp-go-short-url-service/internal/handler/urls/linkcheck"
+	urlsQRHandler "yp-go-short-url-service/internal/handler/urls/qr"
 	shortenBatchAPI "yp-go-short-url-service/internal/handler/urls/shortener/batch"
 	shortenAPI "yp-go-short-url-service/internal/handler/urls/shortener/json"
 	urlShortenerHandler "yp-go-short-url-service/internal/handler/urls/shortener/text"
@@ -30,8 +35,14 @@ import (
 	initService "yp-go-short-url-service/internal/service/init"
 	jwtService "yp-go-short-url-service/internal/service/jwt"
 	statsService "yp-go-short-url-service/internal/service/stats"
+	urlAnalyticsService "yp-go-short-url-service/internal/service/urls/analytics"
+	urlCacheService "yp-go-short-url-service/internal/service/urls/cache"
 	urlDestructorService "yp-go-short-url-service/internal/service/urls/destructor"
+	urlEditorService "yp-go-short-url-service/internal/service/urls/editor"
+	urlExpirationService "yp-go-short-url-service/internal/service/urls/expiration"
 	urlExtractorService "yp-go-short-url-service/internal/service/urls/extractor"
+	urlLinkCheckService "yp-go-short-url-service/internal/service/urls/linkcheck"
+	urlPolicyService "yp-go-short-url-service/internal/service/urls/policy"
 	urlShortenerService "yp-go-short-url-service/internal/service/urls/shortener"
 
 	_ "yp-go-short-url-service/docs"
@@ -56,10 +67,19 @@ type App struct {
 	shortLinksHandlerAPI      handler.Handler
 	shortLinksBatchHandlerAPI handler.Handler
 	destructorAPIHandler      handler.Handler
+	restoreAPIHandler         handler.Handler
+	deleteURLAPIHandler       handler.Handler
+	deleteJobAPIHandler       handler.Handler
+	editorAPIHandler          handler.Handler
+	urlStatsAPIHandler        handler.Handler
+	qrCodeHandler             handler.Handler
 	fullLinkHandler           handler.Handler
+	unlockLinkHandler         handler.Handler
 	userURLsHandler           handler.Handler
+	userTrashHandler          handler.Handler
 	pingHandler               handler.Handler
 	statsHandler              handler.Handler
+	brokenLinksHandler        handler.Handler
 	services                  Services
 	settings                  *config.Settings
 	logger                    *zap.SugaredLogger
@@ -67,11 +87,18 @@ type App struct {
 }
 
 // Services содержит коллекцию сервисов приложения.
-// Используется для доступа к сервисам аутентификации, JWT и удаления URL.
+// Используется для доступа к сервисам аутентификации, JWT, удаления URL, пометки истекших ссылок,
+// буферу переходов, политике адресов назначения, проверке доступности адресов назначения и кэшу ссылок.
 type Services struct {
-	auth          service.AuthService
-	jwt           service.JWTService
-	urlDestructor service.URLDestructorService
+	auth              service.AuthService
+	jwt               service.JWTService
+	urlDestructor     service.URLDestructorService
+	expiredURLSweeper service.ExpiredURLsSweeper
+	deletedURLsPurger service.DeletedURLsPurger
+	clickAggregator   service.ClickAggregator
+	destinationPolicy service.DestinationPolicy
+	linkChecker       service.LinkChecker
+	urlCache          service.Cache
 }
 
 // DataBus содержит все шины событий для передачи данных между компонентами приложения.
@@ -88,6 +115,15 @@ func NewApp(logger *zap.SugaredLogger, ctx context.Context) (*App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to load settings: %w", err)
 	}
+	// Адрес клиента из X-Forwarded-For принимается только от доверенных прокси-серверов
+	trustedProxies := settings.GetTrustedProxies()
+	if err = router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, fmt.Errorf("failed to set trusted proxies: %w", err)
+	}
+	grpcTrustedProxies, err := grpcMiddleware.ParseTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trusted proxies: %w", err)
+	}
 	jwtSettings := settings.EnvSettings.JWT
 
 	dbPool := db.Setup(ctx, logger, &db.SetupParams{
@@ -102,6 +138,9 @@ func NewApp(logger *zap.SugaredLogger, ctx context.Context) (*App, error) {
 	repoURLs := baseRepo.NewURLsRepository(dbPool)
 	userRepo := baseRepo.NewUsersRepository(dbPool)
 	userURLsRepo := baseRepo.NewUserURLsRepository(dbPool)
+	deleteJobsRepo := baseRepo.NewDeleteJobsRepository(dbPool)
+	clicksRepo := baseRepo.NewClicksRepository(dbPool)
+	linkHealthRepo := baseRepo.NewLinkHealthRepository(dbPool)
 	InitService := initService.NewDataInitializerService(repoURLs, logger)
 	if err := InitService.Setup(ctx, settings.GetFileStoragePath()); err != nil {
 		return nil, fmt.Errorf("failed to initialize data: %w", err)
@@ -112,24 +151,94 @@ func NewApp(logger *zap.SugaredLogger, ctx context.Context) (*App, error) {
 
 	auditEventBus := audit.NewEventBus(settings.GetAuditFilePath(), settings.GetAuditURL(), logger)
 
+	codeGenerator, err := urlShortenerService.NewCodeGenerator(settings.GetShortCodeStrategy(), settings.GetShortCodeLength())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create short code generator: %w", err)
+	}
+
+	dedupPolicy, err := urlShortenerService.ParseDedupPolicy(settings.GetURLDedupPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	DestinationPolicy, err := urlPolicyService.NewDestinationPolicy(settings.GetDestinationPolicyFile(), settings.GetBaseURL(), logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load destination policy: %w", err)
+	}
+
+	// Кэш поиска по короткому коду сбрасывается сервисами, изменяющими ссылки
+	var URLCache service.Cache
+	if cacheSize := settings.GetURLCacheSize(); cacheSize > 0 {
+		URLCache, err = urlCacheService.NewCache(ctx, settings.GetURLCacheBackend(), settings.GetRedisURL(), urlCacheService.Options{
+			Size:        cacheSize,
+			TTL:         settings.GetURLCacheTTL(),
+			NegativeTTL: settings.GetURLCacheNegativeTTL(),
+		}, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create url cache: %w", err)
+		}
+	}
+
 	pingService := healthService.NewHealthCheckService(repoURLs)
-	URLShortenerService := urlShortenerService.NewURLShortenerService(repoURLs, userURLsRepo, auditEventBus)
-	URLExtractorService := urlExtractorService.NewLinkExtractorService(repoURLs, userURLsRepo, auditEventBus)
-	URLDestructorService := urlDestructorService.NewURLDestructorService(repoURLs, userURLsRepo)
-	StatsService := statsService.New(userRepo, repoURLs)
+	URLShortenerService := urlPolicyService.NewPolicyShortenerService(
+		urlShortenerService.NewURLShortenerService(repoURLs, userURLsRepo, auditEventBus, codeGenerator, dedupPolicy, URLCache),
+		DestinationPolicy,
+	)
+	ClickAggregator := urlAnalyticsService.NewClickAggregator(
+		clicksRepo,
+		settings.GetClickFlushInterval(),
+		settings.GetClickBufferSize(),
+		logger,
+	)
+	URLExtractorService := urlExtractorService.NewLinkExtractorService(repoURLs, repoURLs, userURLsRepo, ClickAggregator, auditEventBus, URLCache)
+	URLDestructorService := urlDestructorService.NewURLDestructorService(
+		repoURLs,
+		userURLsRepo,
+		deleteJobsRepo,
+		settings.GetDeleteBatchWindow(),
+		settings.GetDeleteBatchSize(),
+		URLCache,
+		logger,
+	)
+	URLEditorService := urlEditorService.NewURLEditorService(repoURLs, auditEventBus, DestinationPolicy, URLCache)
+	URLStatsService := urlAnalyticsService.NewURLStatsService(clicksRepo)
+	DeletedURLsPurger := urlDestructorService.NewDeletedURLsPurger(
+		repoURLs,
+		settings.GetDeletedURLsRetention(),
+		settings.GetDeletedURLsPurgeInterval(),
+		URLCache,
+		logger,
+	)
+	StatsService := statsService.New(userRepo, repoURLs, DeletedURLsPurger, URLCache)
+	ExpiredURLsSweeper := urlExpirationService.NewExpiredURLsSweeper(repoURLs, settings.GetExpiredURLsSweepInterval(), logger)
+	LinkChecker := urlLinkCheckService.NewLinkChecker(linkHealthRepo, urlLinkCheckService.NewPublicHTTPClient(), urlLinkCheckService.Options{
+		Interval:     settings.GetLinkCheckInterval(),
+		Concurrency:  settings.GetLinkCheckConcurrency(),
+		HostInterval: settings.GetLinkCheckHostInterval(),
+		Timeout:      settings.GetLinkCheckTimeout(),
+	}, logger)
 
 	URLExtractorHandler := urlExtractorHandler.NewExtractingFullLinkHandler(URLExtractorService)
+	URLUnlockHandler := urlExtractorHandler.NewUnlockFullLinkHandler(URLExtractorService)
 	UserURLsHandler := userURLsHandler.NewExtractingUserURLsHandler(URLExtractorService, settings)
+	UserTrashHandler := userURLsHandler.NewExtractingUserTrashHandler(URLExtractorService, settings)
 	URLShortenerHandler := urlShortenerHandler.NewCreatingShortLinksHandler(URLShortenerService, settings)
 	URLShortenerAPIHandler := shortenAPI.NewCreatingShortURLsAPIHandler(URLShortenerService, settings)
 	URLShortenerBatchAPIHandler := shortenBatchAPI.NewCreatingShortURLsByBatchAPIHandler(URLShortenerService, settings)
 	URLDestructorAPIHandler := urlsDestructorAPIHandler.NewUsersURLsDestructorAPIHandler(URLDestructorService)
+	URLRestoreAPIHandler := urlsDestructorAPIHandler.NewUsersURLsRestoreAPIHandler(URLDestructorService)
+	URLDeleteAPIHandler := urlsDestructorAPIHandler.NewUserURLDestructorAPIHandler(URLDestructorService)
+	DeleteJobAPIHandler := urlsDestructorAPIHandler.NewDeleteJobStatusAPIHandler(URLDestructorService)
+	URLEditorAPIHandler := urlsEditorAPIHandler.NewUpdatingUserURLHandler(URLEditorService, settings)
+	URLStatsAPIHandler := urlsAnalyticsAPIHandler.NewURLStatsAPIHandler(URLStatsService, settings)
+	QRCodeHandler := urlsQRHandler.NewQRCodeHandler(URLExtractorService, settings)
 	HealthHandler := health.NewPingHandler(pingService)
 	StatsHandler := statsHandler.New(StatsService, settings.GetTrustedSubnet())
+	BrokenLinksHandler := urlsLinkCheckHandler.NewBrokenLinksHandler(LinkChecker, settings)
 
 	// Создаем и настраиваем gRPC сервер
-	grpcServer := createGRPCServer(JWTService, AuthService, logger)
-	grpcShortenerImpl := grpcImpl.NewRPCService(URLShortenerService, URLExtractorService, settings)
+	grpcServer := createGRPCServer(JWTService, AuthService, grpcTrustedProxies, logger)
+	grpcShortenerImpl := grpcImpl.NewRPCService(URLShortenerService, URLExtractorService, URLEditorService, URLDestructorService, URLStatsService, settings)
 
 	// Регистрируем gRPC сервис
 	pb.RegisterShortenerServiceServer(grpcServer, grpcShortenerImpl)
@@ -146,14 +255,29 @@ func NewApp(logger *zap.SugaredLogger, ctx context.Context) (*App, error) {
 		shortLinksHandlerAPI:      URLShortenerAPIHandler,
 		shortLinksBatchHandlerAPI: URLShortenerBatchAPIHandler,
 		destructorAPIHandler:      URLDestructorAPIHandler,
+		restoreAPIHandler:         URLRestoreAPIHandler,
+		deleteURLAPIHandler:       URLDeleteAPIHandler,
+		deleteJobAPIHandler:       DeleteJobAPIHandler,
+		editorAPIHandler:          URLEditorAPIHandler,
+		urlStatsAPIHandler:        URLStatsAPIHandler,
+		qrCodeHandler:             QRCodeHandler,
 		fullLinkHandler:           URLExtractorHandler,
+		unlockLinkHandler:         URLUnlockHandler,
 		userURLsHandler:           UserURLsHandler,
+		userTrashHandler:          UserTrashHandler,
 		pingHandler:               HealthHandler,
 		statsHandler:              StatsHandler,
+		brokenLinksHandler:        BrokenLinksHandler,
 		services: Services{
-			auth:          AuthService,
-			jwt:           JWTService,
-			urlDestructor: URLDestructorService,
+			auth:              AuthService,
+			jwt:               JWTService,
+			urlDestructor:     URLDestructorService,
+			expiredURLSweeper: ExpiredURLsSweeper,
+			deletedURLsPurger: DeletedURLsPurger,
+			clickAggregator:   ClickAggregator,
+			destinationPolicy: DestinationPolicy,
+			linkChecker:       LinkChecker,
+			urlCache:          URLCache,
 		},
 		settings: settings,
 		logger:   logger,
@@ -164,9 +288,10 @@ func NewApp(logger *zap.SugaredLogger, ctx context.Context) (*App, error) {
 }
 
 // SetupCommonMiddlewares настраивает общие middleware для всех маршрутов.
-// Добавляет middleware для request ID, логирования и сжатия ответов (gzip).
+// Добавляет middleware для request ID, сведений о клиенте, логирования и сжатия ответов (gzip).
 func (a *App) SetupCommonMiddlewares() {
 	a.router.Use(middleware.RequestIDMiddleware(a.logger))
+	a.router.Use(middleware.ClientInfoMiddleware())
 	a.router.Use(middleware.LoggerMiddleware(a.logger))
 	a.router.Use(gzip.Middleware(a.logger))
 }
@@ -196,6 +321,7 @@ func (a *App) SetupRoutes() {
 	internalGroup.Use(internalMiddleware)
 	{
 		internalGroup.GET("/stats", a.statsHandler.Handle)
+		internalGroup.GET("/broken-links", a.brokenLinksHandler.Handle)
 	}
 
 	privateGroup := a.router.Group("/")
@@ -203,9 +329,20 @@ func (a *App) SetupRoutes() {
 	{
 		privateGroup.GET("/api/user/urls", a.userURLsHandler.Handle)
 		privateGroup.DELETE("/api/user/urls", a.destructorAPIHandler.Handle)
+		privateGroup.GET("/api/user/urls/trash", a.userTrashHandler.Handle)
+		privateGroup.GET("/api/user/urls/delete-jobs/:id", a.deleteJobAPIHandler.Handle)
+		privateGroup.POST("/api/user/urls/restore", a.restoreAPIHandler.Handle)
+		privateGroup.PATCH("/api/user/urls/:shortURL", a.editorAPIHandler.Handle)
+		privateGroup.DELETE("/api/user/urls/:shortURL", a.deleteURLAPIHandler.Handle)
+		privateGroup.GET("/api/user/urls/:shortURL/stats", a.urlStatsAPIHandler.Handle)
 	}
 
+	a.router.GET("/api/qr/:shortURL", a.qrCodeHandler.Handle)
 	a.router.GET("/:shortURL", a.fullLinkHandler.Handle)
+	// Путь после короткого кода переносится в адрес назначения ссылки-префикса; статические маршруты
+	// /api/..., /swagger/* и /ping имеют приоритет, а их имена нельзя занять пользовательским кодом
+	a.router.GET("/:shortURL/*path", a.fullLinkHandler.Handle)
+	a.router.POST("/:shortURL/unlock", a.unlockLinkHandler.Handle)
 	a.router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Добавляем pprof роуты для профилирования если не в проде
@@ -250,6 +387,7 @@ func (a *App) RunGRPC() error {
 func createGRPCServer(
 	jwtService service.JWTService,
 	authService service.AuthService,
+	trustedProxies []netip.Prefix,
 	logger *zap.SugaredLogger,
 ) *grpc.Server {
 	publicInterceptor := grpcMiddleware.JWTAuthInterceptor(
@@ -259,7 +397,7 @@ func createGRPCServer(
 		logger,
 	)
 
-	chain := grpc.ChainUnaryInterceptor(publicInterceptor)
+	chain := grpc.ChainUnaryInterceptor(grpcMiddleware.ClientInfoInterceptor(trustedProxies), publicInterceptor)
 
 	return grpc.NewServer(chain)
 }
@@ -292,10 +430,38 @@ func (a *App) Stop() {
 		a.grpcServer.GracefulStop()
 	}
 
+	// Буфер переходов сбрасывается до остановки остальных сервисов, пока база данных доступна
+	if a.services.clickAggregator != nil {
+		a.services.clickAggregator.Stop()
+	}
+
 	if a.services.urlDestructor != nil {
 		a.services.urlDestructor.Stop()
 	}
 
+	if a.services.linkChecker != nil {
+		a.services.linkChecker.Stop()
+	}
+
+	if a.services.expiredURLSweeper != nil {
+		a.services.expiredURLSweeper.Stop()
+	}
+
+	if a.services.deletedURLsPurger != nil {
+		a.services.deletedURLsPurger.Stop()
+	}
+
+	if a.services.destinationPolicy != nil {
+		a.services.destinationPolicy.Stop()
+	}
+
+	// Кэш закрывается после остановки сервисов, которые сбрасывают его записи
+	if a.services.urlCache != nil {
+		if err := a.services.urlCache.Close(); err != nil {
+			a.logger.Errorw("Failed to close url cache", "error", err)
+		}
+	}
+
 	a.dataBus.auditEventBus.UnsubscribeAll()
 
 	a.logger.Info("Application stopped")
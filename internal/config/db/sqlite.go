@@ -14,6 +14,34 @@ type SQLiteSettings struct {
 	SQLiteDBPath string `envconfig:"SQLITE_DB_PATH" default:"db/test.db" required:"true"`
 }
 
+// sqliteColumn описывает колонку, добавленную в существующую таблицу SQLite после ее первоначального создания.
+type sqliteColumn struct {
+	table      string
+	column     string
+	definition string
+}
+
+// sqliteColumnMigrations содержит колонки, которые добавляются в уже существующие базы данных SQLite.
+// SQLite не поддерживает ADD COLUMN IF NOT EXISTS, поэтому наличие колонки проверяется через PRAGMA table_info.
+var sqliteColumnMigrations = []sqliteColumn{
+	{table: "urls", column: "is_deleted", definition: "BOOLEAN DEFAULT FALSE"},
+	{table: "urls", column: "expires_at", definition: "DATETIME"},
+	{table: "urls", column: "is_expired", definition: "BOOLEAN DEFAULT FALSE"},
+	{table: "urls", column: "max_clicks", definition: "INTEGER"},
+	{table: "urls", column: "clicks_left", definition: "INTEGER"},
+	{table: "urls", column: "password_hash", definition: "TEXT"},
+	{table: "urls", column: "is_disabled", definition: "BOOLEAN DEFAULT FALSE"},
+	{table: "urls", column: "title", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "urls", column: "show_interstitial", definition: "BOOLEAN DEFAULT FALSE"},
+	{table: "urls", column: "redirect_code", definition: "INTEGER NOT NULL DEFAULT 307"},
+	{table: "urls", column: "query_passthrough", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "urls", column: "is_prefix", definition: "BOOLEAN DEFAULT FALSE"},
+	{table: "urls", column: "routing_rules", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "urls", column: "variants", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "clicks", column: "variant", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "urls", column: "deleted_at", definition: "DATETIME"},
+}
+
 // InitSQLiteDB инициализирует соединение с SQLite базой данных
 func InitSQLiteDB(dbPath string) (*sql.DB, error) {
 	db, err := sql.Open("sqlite3", dbPath)
@@ -85,6 +113,85 @@ func SetupSQLiteDB(dbPath string, log *zap.SugaredLogger) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to create user_urls table: %w", err)
 	}
 
+	// Создаем таблицу очереди задач удаления
+	createDeleteJobsTableSQL := `
+	CREATE TABLE IF NOT EXISTS delete_jobs (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		action TEXT NOT NULL,
+		short_urls TEXT NOT NULL,
+		status TEXT NOT NULL,
+		results TEXT,
+		error TEXT,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		next_run_at DATETIME NOT NULL,
+		lease_until DATETIME,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	);`
+
+	_, err = db.Exec(createDeleteJobsTableSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create delete_jobs table: %w", err)
+	}
+
+	// Создаем таблицу журнала переходов
+	createClicksTableSQL := `
+	CREATE TABLE IF NOT EXISTS clicks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		url_id INTEGER NOT NULL,
+		clicked_at DATETIME NOT NULL,
+		referrer TEXT NOT NULL DEFAULT '',
+		user_agent TEXT NOT NULL DEFAULT '',
+		ip TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (url_id) REFERENCES urls(id) ON DELETE CASCADE
+	);`
+
+	_, err = db.Exec(createClicksTableSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clicks table: %w", err)
+	}
+
+	// Создаем таблицу счетчиков переходов
+	createClickCountersTableSQL := `
+	CREATE TABLE IF NOT EXISTS click_counters (
+		url_id INTEGER PRIMARY KEY,
+		clicks INTEGER NOT NULL DEFAULT 0,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (url_id) REFERENCES urls(id) ON DELETE CASCADE
+	);`
+
+	_, err = db.Exec(createClickCountersTableSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create click_counters table: %w", err)
+	}
+
+	// Создаем таблицу результатов проверки доступности адресов назначения
+	createLinkHealthTableSQL := `
+	CREATE TABLE IF NOT EXISTS link_health (
+		url_id INTEGER PRIMARY KEY,
+		status_code INTEGER NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT '',
+		redirect_chain TEXT NOT NULL DEFAULT '',
+		consecutive_failures INTEGER NOT NULL DEFAULT 0,
+		checked_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (url_id) REFERENCES urls(id) ON DELETE CASCADE
+	);`
+
+	_, err = db.Exec(createLinkHealthTableSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create link_health table: %w", err)
+	}
+
+	if err = migrateSQLiteColumns(db, sqliteColumnMigrations); err != nil {
+		return nil, err
+	}
+
+	// Для ссылок, удаленных до появления deleted_at, момент удаления берется из updated_at
+	if _, err = db.Exec(`UPDATE urls SET deleted_at = updated_at WHERE is_deleted = 1 AND deleted_at IS NULL`); err != nil {
+		return nil, fmt.Errorf("failed to backfill urls.deleted_at: %w", err)
+	}
+
 	// Создаем индексы для улучшения производительности
 	indexes := []string{
 		"CREATE INDEX IF NOT EXISTS idx_urls_short_url ON urls(short_url);",
@@ -92,6 +199,14 @@ func SetupSQLiteDB(dbPath string, log *zap.SugaredLogger) (*sql.DB, error) {
 		"CREATE INDEX IF NOT EXISTS idx_user_urls_user_id ON user_urls(user_id);",
 		"CREATE INDEX IF NOT EXISTS idx_user_urls_url_id ON user_urls(url_id);",
 		"CREATE UNIQUE INDEX IF NOT EXISTS idx_user_urls_user_id_url_id ON user_urls(user_id, url_id);",
+		// Каждая запись URL принадлежит не более чем одному пользователю
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_user_urls_url_id_unique ON user_urls(url_id);",
+		"CREATE INDEX IF NOT EXISTS idx_urls_is_deleted ON urls(is_deleted);",
+		"CREATE INDEX IF NOT EXISTS idx_urls_deleted_at ON urls(deleted_at) WHERE is_deleted = 1;",
+		"CREATE INDEX IF NOT EXISTS idx_urls_expires_at ON urls(expires_at) WHERE is_expired = 0;",
+		"CREATE INDEX IF NOT EXISTS idx_delete_jobs_status_next_run_at ON delete_jobs(status, next_run_at);",
+		"CREATE INDEX IF NOT EXISTS idx_clicks_url_id_clicked_at ON clicks(url_id, clicked_at);",
+		"CREATE INDEX IF NOT EXISTS idx_link_health_failures ON link_health(consecutive_failures) WHERE consecutive_failures > 0;",
 	}
 
 	for _, indexSQL := range indexes {
@@ -104,3 +219,52 @@ func SetupSQLiteDB(dbPath string, log *zap.SugaredLogger) (*sql.DB, error) {
 	log.Info("Successfully initialized SQLite database")
 	return db, nil
 }
+
+// migrateSQLiteColumns добавляет в таблицы недостающие колонки из списка миграций.
+// Уже существующие колонки пропускаются, поэтому функция безопасна для повторного вызова.
+func migrateSQLiteColumns(db *sql.DB, columns []sqliteColumn) error {
+	for _, c := range columns {
+		exists, err := sqliteColumnExists(db, c.table, c.column)
+		if err != nil {
+			return fmt.Errorf("failed to inspect table %s: %w", c.table, err)
+		}
+		if exists {
+			continue
+		}
+
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.column, c.definition)
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", c.table, c.column, err)
+		}
+	}
+
+	return nil
+}
+
+// sqliteColumnExists проверяет наличие колонки в таблице SQLite.
+func sqliteColumnExists(db *sql.DB, table, column string) (bool, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid          int
+			name         string
+			columnType   string
+			notNull      int
+			defaultValue sql.NullString
+			primaryKey   int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+
+	return false, rows.Err()
+}
@@ -1,20 +1,45 @@
 package config
 
-import "flag"
+import (
+	"flag"
+	"time"
+)
 
 // Flags содержит флаги командной строки приложения.
 // Позволяет переопределить настройки из переменных окружения через аргументы командной строки.
 type Flags struct {
-	ServerAddress   string
-	GRPCAddress     string
-	BaseURL         string
-	FileStoragePath string
-	DatabaseDSN     string
-	AuditFile       string
-	AuditURL        string
-	EnableHTTPS     bool
-	JSONConfigPath  string
-	TrustedSubnet   string
+	ServerAddress            string
+	GRPCAddress              string
+	BaseURL                  string
+	FileStoragePath          string
+	DatabaseDSN              string
+	AuditFile                string
+	AuditURL                 string
+	EnableHTTPS              bool
+	JSONConfigPath           string
+	TrustedSubnet            string
+	TrustedProxies           string
+	ShortCodeStrategy        string
+	ShortCodeLength          int
+	URLDedupPolicy           string
+	ExpiredURLsSweepInterval time.Duration
+	DeletedURLsRetention     time.Duration
+	DeletedURLsPurgeInterval time.Duration
+	DeleteBatchWindow        time.Duration
+	DeleteBatchSize          int
+	ClickFlushInterval       time.Duration
+	ClickBufferSize          int
+	DestinationPolicyFile    string
+	LinkCheckInterval        time.Duration
+	LinkCheckConcurrency     int
+	LinkCheckHostInterval    time.Duration
+	LinkCheckTimeout         time.Duration
+	BrokenLinkThreshold      int
+	URLCacheSize             int
+	URLCacheTTL              time.Duration
+	URLCacheNegativeTTL      time.Duration
+	URLCacheBackend          string
+	RedisURL                 string
 }
 
 // NewFlags создает новый экземпляр флагов командной строки.
@@ -84,19 +109,160 @@ func NewFlags() *Flags {
 		"",
 		"Доверенная подсеть для получения статистики в формате CIDR",
 	)
+	trustedProxies := flag.String(
+		"trusted-proxies",
+		"",
+		"Адреса и подсети (CIDR) доверенных прокси-серверов через запятую; "+
+			"только от них принимается адрес клиента из X-Forwarded-For",
+	)
+
+	shortCodeStrategy := flag.String(
+		"short-code-strategy",
+		"",
+		"Стратегия генерации коротких кодов: hash, random или counter",
+	)
+	shortCodeLength := flag.Int(
+		"short-code-length",
+		0,
+		"Длина генерируемых коротких кодов",
+	)
+	urlDedupPolicy := flag.String(
+		"url-dedup-policy",
+		"",
+		"Политика дедупликации длинных URL: user (в пределах пользователя) или global",
+	)
+
+	expiredURLsSweepInterval := flag.Duration(
+		"expired-urls-sweep-interval",
+		0,
+		"Период фоновой пометки истекших ссылок (например, 1m)",
+	)
+
+	deletedURLsRetention := flag.Duration(
+		"deleted-urls-retention",
+		0,
+		"Срок хранения удаленных ссылок в корзине до окончательного удаления (например, 720h)",
+	)
+	deletedURLsPurgeInterval := flag.Duration(
+		"deleted-urls-purge-interval",
+		0,
+		"Период фонового окончательного удаления ссылок из корзины (например, 1h)",
+	)
+
+	deleteBatchWindow := flag.Duration(
+		"delete-batch-window",
+		0,
+		"Время сбора задач удаления в пачку перед выполнением (например, 20ms)",
+	)
+	deleteBatchSize := flag.Int(
+		"delete-batch-size",
+		0,
+		"Максимальное количество задач удаления в одной пачке",
+	)
+
+	clickFlushInterval := flag.Duration(
+		"click-flush-interval",
+		0,
+		"Период сброса буфера переходов по ссылкам в базу данных (например, 1s)",
+	)
+	clickBufferSize := flag.Int(
+		"click-buffer-size",
+		0,
+		"Максимальное количество переходов и различных кодов в буфере переходов",
+	)
+
+	destinationPolicyFile := flag.String(
+		"destination-policy-file",
+		"",
+		"Путь до JSON-файла политики адресов назначения (списки разрешенных и запрещенных адресов)",
+	)
+
+	linkCheckInterval := flag.Duration(
+		"link-check-interval",
+		0,
+		"Период фоновой проверки доступности адресов назначения (например, 1h); отрицательное значение отключает проверку",
+	)
+	linkCheckConcurrency := flag.Int(
+		"link-check-concurrency",
+		0,
+		"Максимальное количество одновременных запросов при проверке адресов назначения",
+	)
+	linkCheckHostInterval := flag.Duration(
+		"link-check-host-interval",
+		0,
+		"Минимальный интервал между запросами проверки к одному хосту (например, 1s)",
+	)
+	linkCheckTimeout := flag.Duration(
+		"link-check-timeout",
+		0,
+		"Ограничение времени одного запроса проверки адреса назначения (например, 10s)",
+	)
+	brokenLinkThreshold := flag.Int(
+		"broken-link-threshold",
+		0,
+		"Количество неудачных проверок подряд, после которого ссылка считается нерабочей",
+	)
+
+	urlCacheSize := flag.Int(
+		"url-cache-size",
+		0,
+		"Максимальное количество ссылок в кэше поиска по короткому коду; отрицательное значение отключает кэш",
+	)
+	urlCacheTTL := flag.Duration(
+		"url-cache-ttl",
+		0,
+		"Срок хранения найденной ссылки в кэше поиска по короткому коду (например, 1m)",
+	)
+	urlCacheNegativeTTL := flag.Duration(
+		"url-cache-negative-ttl",
+		0,
+		"Срок хранения в кэше записи о ненайденном коротком коде (например, 10s); отрицательное значение отключает такие записи",
+	)
+	urlCacheBackend := flag.String(
+		"url-cache-backend",
+		"",
+		"Хранилище кэша поиска по короткому коду: memory или redis (общий для экземпляров сервиса)",
+	)
+	redisURL := flag.String(
+		"redis-url",
+		"",
+		"Адрес сервера Redis для кэша поиска по короткому коду (например, redis://localhost:6379/0)",
+	)
 
 	flag.Parse()
 
 	return &Flags{
-		ServerAddress:   *connectionAddr,
-		GRPCAddress:     *grpcAddr,
-		BaseURL:         *redirectURL,
-		FileStoragePath: *fileStoragePath,
-		DatabaseDSN:     *databaseDSN,
-		AuditFile:       *auditFile,
-		AuditURL:        *auditURL,
-		EnableHTTPS:     *enableHTTPS,
-		JSONConfigPath:  configPath,
-		TrustedSubnet:   *trustedSubnet,
+		ServerAddress:            *connectionAddr,
+		GRPCAddress:              *grpcAddr,
+		BaseURL:                  *redirectURL,
+		FileStoragePath:          *fileStoragePath,
+		DatabaseDSN:              *databaseDSN,
+		AuditFile:                *auditFile,
+		AuditURL:                 *auditURL,
+		EnableHTTPS:              *enableHTTPS,
+		JSONConfigPath:           configPath,
+		TrustedSubnet:            *trustedSubnet,
+		TrustedProxies:           *trustedProxies,
+		ShortCodeStrategy:        *shortCodeStrategy,
+		ShortCodeLength:          *shortCodeLength,
+		URLDedupPolicy:           *urlDedupPolicy,
+		ExpiredURLsSweepInterval: *expiredURLsSweepInterval,
+		DeletedURLsRetention:     *deletedURLsRetention,
+		DeletedURLsPurgeInterval: *deletedURLsPurgeInterval,
+		DeleteBatchWindow:        *deleteBatchWindow,
+		DeleteBatchSize:          *deleteBatchSize,
+		ClickFlushInterval:       *clickFlushInterval,
+		ClickBufferSize:          *clickBufferSize,
+		DestinationPolicyFile:    *destinationPolicyFile,
+		LinkCheckInterval:        *linkCheckInterval,
+		LinkCheckConcurrency:     *linkCheckConcurrency,
+		LinkCheckHostInterval:    *linkCheckHostInterval,
+		LinkCheckTimeout:         *linkCheckTimeout,
+		BrokenLinkThreshold:      *brokenLinkThreshold,
+		URLCacheSize:             *urlCacheSize,
+		URLCacheTTL:              *urlCacheTTL,
+		URLCacheNegativeTTL:      *urlCacheNegativeTTL,
+		URLCacheBackend:          *urlCacheBackend,
+		RedisURL:                 *redisURL,
 	}
 }
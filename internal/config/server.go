@@ -24,6 +24,9 @@ type ServerSettings struct {
 	Environment   string `envconfig:"ENVIRONMENT" default:"development" required:"false"`
 	EnableHTTPS   bool   `envconfig:"ENABLE_HTTPS" default:"false"`
 	TrustedSubnet string `envconfig:"TRUSTED_SUBNET" default:"" required:"false"`
+	// TrustedProxies - перечисленные через запятую адреса и подсети (CIDR) прокси-серверов,
+	// которым разрешено передавать адрес клиента в X-Forwarded-For
+	TrustedProxies string `envconfig:"TRUSTED_PROXIES" default:"" required:"false"`
 }
 
 // IsProd возвращает true, если текущее окружение является производственным (production).
@@ -9,6 +9,7 @@ import (
 	"github.com/samber/lo"
 
 	"strings"
+	"time"
 	"yp-go-short-url-service/internal/config/db"
 )
 
@@ -29,20 +30,62 @@ type ENVSettings struct {
 	FileStorage    *db.FileStorageSettings
 	Audit          *AuditSettings
 	JWT            *JWTSettings
+	Shortener      *ShortenerSettings
 	ConfigJSONPath string `envconfig:"CONFIG" default:"" required:"false"`
 }
 
 // SettingsFromJSON используется для загрузки настроек из JSON-файла.
 // Включает настройки сервера, базы данных, файлового хранилища, аудита и JWT.
 type SettingsFromJSON struct {
-	ServerAddress   string `json:"server_address"`
-	GRPCAddress     string `json:"grpc_address"`
-	BaseURL         string `json:"base_url"`
-	FileStoragePath string `json:"file_storage_path"`
-	DatabaseDSN     string `json:"database_dsn"`
-	AuditFilePath   string `json:"audit_file_path"`
-	EnableHTTPS     bool   `json:"enable_https"`
-	TrustedSubnet   string `json:"trusted_subnet"`
+	ServerAddress     string `json:"server_address"`
+	GRPCAddress       string `json:"grpc_address"`
+	BaseURL           string `json:"base_url"`
+	FileStoragePath   string `json:"file_storage_path"`
+	DatabaseDSN       string `json:"database_dsn"`
+	AuditFilePath     string `json:"audit_file_path"`
+	EnableHTTPS       bool   `json:"enable_https"`
+	TrustedSubnet     string `json:"trusted_subnet"`
+	ShortCodeStrategy string `json:"short_code_strategy"`
+	ShortCodeLength   int    `json:"short_code_length"`
+	URLDedupPolicy    string `json:"url_dedup_policy"`
+	// TrustedProxies - адреса и подсети (CIDR) доверенных прокси-серверов через запятую
+	TrustedProxies string `json:"trusted_proxies"`
+	// ExpiredURLsSweepInterval - период фоновой пометки истекших ссылок в формате time.ParseDuration
+	ExpiredURLsSweepInterval string `json:"expired_urls_sweep_interval"`
+	// DeletedURLsRetention - срок хранения удаленных ссылок в корзине в формате time.ParseDuration
+	DeletedURLsRetention string `json:"deleted_urls_retention"`
+	// DeletedURLsPurgeInterval - период окончательного удаления ссылок из корзины в формате time.ParseDuration
+	DeletedURLsPurgeInterval string `json:"deleted_urls_purge_interval"`
+	// DeleteBatchWindow - время сбора задач удаления в пачку в формате time.ParseDuration
+	DeleteBatchWindow string `json:"delete_batch_window"`
+	// DeleteBatchSize - максимальное количество задач удаления в пачке
+	DeleteBatchSize int `json:"delete_batch_size"`
+	// ClickFlushInterval - период сброса буфера переходов в базу данных в формате time.ParseDuration
+	ClickFlushInterval string `json:"click_flush_interval"`
+	// ClickBufferSize - максимальное количество переходов и различных кодов в буфере
+	ClickBufferSize int `json:"click_buffer_size"`
+	// DestinationPolicyFile - путь к JSON-файлу политики адресов назначения
+	DestinationPolicyFile string `json:"destination_policy_file"`
+	// LinkCheckInterval - период фоновой проверки доступности адресов назначения в формате time.ParseDuration
+	LinkCheckInterval string `json:"link_check_interval"`
+	// LinkCheckConcurrency - максимальное количество одновременных запросов проверки
+	LinkCheckConcurrency int `json:"link_check_concurrency"`
+	// LinkCheckHostInterval - минимальный интервал между запросами к одному хосту в формате time.ParseDuration
+	LinkCheckHostInterval string `json:"link_check_host_interval"`
+	// LinkCheckTimeout - ограничение времени одного запроса проверки в формате time.ParseDuration
+	LinkCheckTimeout string `json:"link_check_timeout"`
+	// BrokenLinkThreshold - количество неудачных проверок подряд, после которого ссылка считается нерабочей
+	BrokenLinkThreshold int `json:"broken_link_threshold"`
+	// URLCacheSize - максимальное количество ссылок в кэше поиска по короткому коду
+	URLCacheSize int `json:"url_cache_size"`
+	// URLCacheTTL - срок хранения найденной ссылки в кэше в формате time.ParseDuration
+	URLCacheTTL string `json:"url_cache_ttl"`
+	// URLCacheNegativeTTL - срок хранения записи о ненайденном коротком коде в формате time.ParseDuration
+	URLCacheNegativeTTL string `json:"url_cache_negative_ttl"`
+	// URLCacheBackend - хранилище кэша поиска по короткому коду: memory или redis
+	URLCacheBackend string `json:"url_cache_backend"`
+	// RedisURL - адрес сервера Redis для кэша поиска по короткому коду
+	RedisURL string `json:"redis_url"`
 }
 
 // NewSettings создает новый экземпляр настроек приложения.
@@ -296,3 +339,485 @@ func (s *Settings) GetTrustedSubnet() string {
 		defaultTrustedSubnet,
 	)
 }
+
+// GetTrustedProxies возвращает адреса и подсети доверенных прокси-серверов.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация.
+// Пустой список означает, что адрес клиента из X-Forwarded-For не принимается ни от кого.
+func (s *Settings) GetTrustedProxies() []string {
+	var envProxies, flagProxies, confProxies string
+
+	if s.EnvSettings != nil && s.EnvSettings.Server != nil {
+		envProxies = strings.TrimSpace(s.EnvSettings.Server.TrustedProxies)
+	}
+
+	if s.Flags != nil {
+		flagProxies = strings.TrimSpace(s.Flags.TrustedProxies)
+	}
+
+	if s.JSONConfig != nil {
+		confProxies = strings.TrimSpace(s.JSONConfig.TrustedProxies)
+	}
+
+	proxies := lo.CoalesceOrEmpty(envProxies, flagProxies, confProxies)
+	if proxies == "" {
+		return nil
+	}
+
+	return lo.Compact(lo.Map(strings.Split(proxies, ","), func(proxy string, _ int) string {
+		return strings.TrimSpace(proxy)
+	}))
+}
+
+// GetShortCodeStrategy возвращает стратегию генерации коротких кодов.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+func (s *Settings) GetShortCodeStrategy() string {
+	var envStrategy, flagStrategy, confStrategy string
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envStrategy = strings.TrimSpace(s.EnvSettings.Shortener.CodeStrategy)
+	}
+
+	if s.Flags != nil {
+		flagStrategy = strings.TrimSpace(s.Flags.ShortCodeStrategy)
+	}
+
+	if s.JSONConfig != nil {
+		confStrategy = strings.TrimSpace(s.JSONConfig.ShortCodeStrategy)
+	}
+
+	return lo.CoalesceOrEmpty(envStrategy, flagStrategy, confStrategy, defaultShortCodeStrategy)
+}
+
+// GetShortCodeLength возвращает длину генерируемых коротких кодов.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+func (s *Settings) GetShortCodeLength() int {
+	var envLength, flagLength, confLength int
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envLength = s.EnvSettings.Shortener.CodeLength
+	}
+
+	if s.Flags != nil {
+		flagLength = s.Flags.ShortCodeLength
+	}
+
+	if s.JSONConfig != nil {
+		confLength = s.JSONConfig.ShortCodeLength
+	}
+
+	return lo.CoalesceOrEmpty(envLength, flagLength, confLength, defaultShortCodeLength)
+}
+
+// GetURLDedupPolicy возвращает политику дедупликации длинных URL.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+func (s *Settings) GetURLDedupPolicy() string {
+	var envPolicy, flagPolicy, confPolicy string
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envPolicy = strings.TrimSpace(s.EnvSettings.Shortener.DedupPolicy)
+	}
+
+	if s.Flags != nil {
+		flagPolicy = strings.TrimSpace(s.Flags.URLDedupPolicy)
+	}
+
+	if s.JSONConfig != nil {
+		confPolicy = strings.TrimSpace(s.JSONConfig.URLDedupPolicy)
+	}
+
+	return lo.CoalesceOrEmpty(envPolicy, flagPolicy, confPolicy, defaultURLDedupPolicy)
+}
+
+// GetExpiredURLsSweepInterval возвращает период фоновой пометки истекших ссылок.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+func (s *Settings) GetExpiredURLsSweepInterval() time.Duration {
+	var envInterval, flagInterval, confInterval time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envInterval = s.EnvSettings.Shortener.ExpiredURLsSweepInterval
+	}
+
+	if s.Flags != nil {
+		flagInterval = s.Flags.ExpiredURLsSweepInterval
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.ExpiredURLsSweepInterval != "" {
+		if interval, err := time.ParseDuration(s.JSONConfig.ExpiredURLsSweepInterval); err == nil {
+			confInterval = interval
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envInterval, flagInterval, confInterval, defaultExpiredURLsSweepInterval)
+}
+
+// GetDeletedURLsRetention возвращает срок хранения удаленных ссылок в корзине до окончательного удаления.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+func (s *Settings) GetDeletedURLsRetention() time.Duration {
+	var envRetention, flagRetention, confRetention time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envRetention = s.EnvSettings.Shortener.DeletedURLsRetention
+	}
+
+	if s.Flags != nil {
+		flagRetention = s.Flags.DeletedURLsRetention
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.DeletedURLsRetention != "" {
+		if retention, err := time.ParseDuration(s.JSONConfig.DeletedURLsRetention); err == nil {
+			confRetention = retention
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envRetention, flagRetention, confRetention, defaultDeletedURLsRetention)
+}
+
+// GetDeletedURLsPurgeInterval возвращает период фонового окончательного удаления ссылок из корзины.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+func (s *Settings) GetDeletedURLsPurgeInterval() time.Duration {
+	var envInterval, flagInterval, confInterval time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envInterval = s.EnvSettings.Shortener.DeletedURLsPurgeInterval
+	}
+
+	if s.Flags != nil {
+		flagInterval = s.Flags.DeletedURLsPurgeInterval
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.DeletedURLsPurgeInterval != "" {
+		if interval, err := time.ParseDuration(s.JSONConfig.DeletedURLsPurgeInterval); err == nil {
+			confInterval = interval
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envInterval, flagInterval, confInterval, defaultDeletedURLsPurgeInterval)
+}
+
+// GetDeleteBatchWindow возвращает время, в течение которого воркер удаления собирает задачи в пачку.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+func (s *Settings) GetDeleteBatchWindow() time.Duration {
+	var envWindow, flagWindow, confWindow time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envWindow = s.EnvSettings.Shortener.DeleteBatchWindow
+	}
+
+	if s.Flags != nil {
+		flagWindow = s.Flags.DeleteBatchWindow
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.DeleteBatchWindow != "" {
+		if window, err := time.ParseDuration(s.JSONConfig.DeleteBatchWindow); err == nil {
+			confWindow = window
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envWindow, flagWindow, confWindow, defaultDeleteBatchWindow)
+}
+
+// GetDeleteBatchSize возвращает максимальное количество задач удаления, объединяемых в одну пачку.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+func (s *Settings) GetDeleteBatchSize() int {
+	var envSize, flagSize, confSize int
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envSize = s.EnvSettings.Shortener.DeleteBatchSize
+	}
+
+	if s.Flags != nil {
+		flagSize = s.Flags.DeleteBatchSize
+	}
+
+	if s.JSONConfig != nil {
+		confSize = s.JSONConfig.DeleteBatchSize
+	}
+
+	return lo.CoalesceOrEmpty(envSize, flagSize, confSize, defaultDeleteBatchSize)
+}
+
+// GetClickFlushInterval возвращает период сброса буфера переходов в базу данных.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+func (s *Settings) GetClickFlushInterval() time.Duration {
+	var envInterval, flagInterval, confInterval time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envInterval = s.EnvSettings.Shortener.ClickFlushInterval
+	}
+
+	if s.Flags != nil {
+		flagInterval = s.Flags.ClickFlushInterval
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.ClickFlushInterval != "" {
+		if interval, err := time.ParseDuration(s.JSONConfig.ClickFlushInterval); err == nil {
+			confInterval = interval
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envInterval, flagInterval, confInterval, defaultClickFlushInterval)
+}
+
+// GetClickBufferSize возвращает максимальное количество переходов и различных кодов в буфере переходов.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+func (s *Settings) GetClickBufferSize() int {
+	var envSize, flagSize, confSize int
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envSize = s.EnvSettings.Shortener.ClickBufferSize
+	}
+
+	if s.Flags != nil {
+		flagSize = s.Flags.ClickBufferSize
+	}
+
+	if s.JSONConfig != nil {
+		confSize = s.JSONConfig.ClickBufferSize
+	}
+
+	return lo.CoalesceOrEmpty(envSize, flagSize, confSize, defaultClickBufferSize)
+}
+
+// GetDestinationPolicyFile возвращает путь к JSON-файлу политики адресов назначения.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > пустая строка
+// (списки правил не заданы).
+func (s *Settings) GetDestinationPolicyFile() string {
+	var envPath, flagPath, confPath string
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envPath = strings.TrimSpace(s.EnvSettings.Shortener.DestinationPolicyFile)
+	}
+
+	if s.Flags != nil {
+		flagPath = strings.TrimSpace(s.Flags.DestinationPolicyFile)
+	}
+
+	if s.JSONConfig != nil {
+		confPath = strings.TrimSpace(s.JSONConfig.DestinationPolicyFile)
+	}
+
+	return lo.CoalesceOrEmpty(envPath, flagPath, confPath)
+}
+
+// GetLinkCheckInterval возвращает период фоновой проверки доступности адресов назначения.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+// Отрицательное значение отключает фоновую проверку.
+func (s *Settings) GetLinkCheckInterval() time.Duration {
+	var envInterval, flagInterval, confInterval time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envInterval = s.EnvSettings.Shortener.LinkCheckInterval
+	}
+
+	if s.Flags != nil {
+		flagInterval = s.Flags.LinkCheckInterval
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.LinkCheckInterval != "" {
+		if interval, err := time.ParseDuration(s.JSONConfig.LinkCheckInterval); err == nil {
+			confInterval = interval
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envInterval, flagInterval, confInterval, defaultLinkCheckInterval)
+}
+
+// GetLinkCheckConcurrency возвращает максимальное количество одновременных запросов при проверке адресов назначения.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+func (s *Settings) GetLinkCheckConcurrency() int {
+	var envConcurrency, flagConcurrency, confConcurrency int
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envConcurrency = s.EnvSettings.Shortener.LinkCheckConcurrency
+	}
+
+	if s.Flags != nil {
+		flagConcurrency = s.Flags.LinkCheckConcurrency
+	}
+
+	if s.JSONConfig != nil {
+		confConcurrency = s.JSONConfig.LinkCheckConcurrency
+	}
+
+	return lo.CoalesceOrEmpty(envConcurrency, flagConcurrency, confConcurrency, defaultLinkCheckConcurrency)
+}
+
+// GetLinkCheckHostInterval возвращает минимальный интервал между запросами проверки к одному хосту.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+func (s *Settings) GetLinkCheckHostInterval() time.Duration {
+	var envInterval, flagInterval, confInterval time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envInterval = s.EnvSettings.Shortener.LinkCheckHostInterval
+	}
+
+	if s.Flags != nil {
+		flagInterval = s.Flags.LinkCheckHostInterval
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.LinkCheckHostInterval != "" {
+		if interval, err := time.ParseDuration(s.JSONConfig.LinkCheckHostInterval); err == nil {
+			confInterval = interval
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envInterval, flagInterval, confInterval, defaultLinkCheckHostInterval)
+}
+
+// GetLinkCheckTimeout возвращает ограничение времени одного запроса проверки адреса назначения.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+func (s *Settings) GetLinkCheckTimeout() time.Duration {
+	var envTimeout, flagTimeout, confTimeout time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envTimeout = s.EnvSettings.Shortener.LinkCheckTimeout
+	}
+
+	if s.Flags != nil {
+		flagTimeout = s.Flags.LinkCheckTimeout
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.LinkCheckTimeout != "" {
+		if timeout, err := time.ParseDuration(s.JSONConfig.LinkCheckTimeout); err == nil {
+			confTimeout = timeout
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envTimeout, flagTimeout, confTimeout, defaultLinkCheckTimeout)
+}
+
+// GetBrokenLinkThreshold возвращает количество неудачных проверок подряд, после которого ссылка считается нерабочей.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+func (s *Settings) GetBrokenLinkThreshold() int {
+	var envThreshold, flagThreshold, confThreshold int
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envThreshold = s.EnvSettings.Shortener.BrokenLinkThreshold
+	}
+
+	if s.Flags != nil {
+		flagThreshold = s.Flags.BrokenLinkThreshold
+	}
+
+	if s.JSONConfig != nil {
+		confThreshold = s.JSONConfig.BrokenLinkThreshold
+	}
+
+	return lo.CoalesceOrEmpty(envThreshold, flagThreshold, confThreshold, defaultBrokenLinkThreshold)
+}
+
+// GetURLCacheSize возвращает максимальное количество ссылок в кэше поиска по короткому коду.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Отрицательное значение отключает кэш.
+func (s *Settings) GetURLCacheSize() int {
+	var envSize, flagSize, confSize int
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envSize = s.EnvSettings.Shortener.URLCacheSize
+	}
+
+	if s.Flags != nil {
+		flagSize = s.Flags.URLCacheSize
+	}
+
+	if s.JSONConfig != nil {
+		confSize = s.JSONConfig.URLCacheSize
+	}
+
+	return lo.CoalesceOrEmpty(envSize, flagSize, confSize, defaultURLCacheSize)
+}
+
+// GetURLCacheTTL возвращает срок хранения найденной ссылки в кэше поиска по короткому коду.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+func (s *Settings) GetURLCacheTTL() time.Duration {
+	var envTTL, flagTTL, confTTL time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envTTL = s.EnvSettings.Shortener.URLCacheTTL
+	}
+
+	if s.Flags != nil {
+		flagTTL = s.Flags.URLCacheTTL
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.URLCacheTTL != "" {
+		if ttl, err := time.ParseDuration(s.JSONConfig.URLCacheTTL); err == nil {
+			confTTL = ttl
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envTTL, flagTTL, confTTL, defaultURLCacheTTL)
+}
+
+// GetURLCacheNegativeTTL возвращает срок хранения в кэше записи о ненайденном коротком коде.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+// Некорректное значение в JSON-конфигурации игнорируется.
+// Отрицательное значение отключает кэширование ненайденных кодов.
+func (s *Settings) GetURLCacheNegativeTTL() time.Duration {
+	var envTTL, flagTTL, confTTL time.Duration
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envTTL = s.EnvSettings.Shortener.URLCacheNegativeTTL
+	}
+
+	if s.Flags != nil {
+		flagTTL = s.Flags.URLCacheNegativeTTL
+	}
+
+	if s.JSONConfig != nil && s.JSONConfig.URLCacheNegativeTTL != "" {
+		if ttl, err := time.ParseDuration(s.JSONConfig.URLCacheNegativeTTL); err == nil {
+			confTTL = ttl
+		}
+	}
+
+	return lo.CoalesceOrEmpty(envTTL, flagTTL, confTTL, defaultURLCacheNegativeTTL)
+}
+
+// GetURLCacheBackend возвращает хранилище кэша поиска по короткому коду: memory или redis.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация > значение по умолчанию.
+func (s *Settings) GetURLCacheBackend() string {
+	var envBackend, flagBackend, confBackend string
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envBackend = strings.TrimSpace(s.EnvSettings.Shortener.URLCacheBackend)
+	}
+
+	if s.Flags != nil {
+		flagBackend = strings.TrimSpace(s.Flags.URLCacheBackend)
+	}
+
+	if s.JSONConfig != nil {
+		confBackend = strings.TrimSpace(s.JSONConfig.URLCacheBackend)
+	}
+
+	return strings.ToLower(lo.CoalesceOrEmpty(envBackend, flagBackend, confBackend, defaultURLCacheBackend))
+}
+
+// GetRedisURL возвращает адрес сервера Redis для кэша поиска по короткому коду.
+// Приоритет: переменная окружения > флаг командной строки > JSON-конфигурация.
+func (s *Settings) GetRedisURL() string {
+	var envURL, flagURL, confURL string
+
+	if s.EnvSettings != nil && s.EnvSettings.Shortener != nil {
+		envURL = strings.TrimSpace(s.EnvSettings.Shortener.RedisURL)
+	}
+
+	if s.Flags != nil {
+		flagURL = strings.TrimSpace(s.Flags.RedisURL)
+	}
+
+	if s.JSONConfig != nil {
+		confURL = strings.TrimSpace(s.JSONConfig.RedisURL)
+	}
+
+	return lo.CoalesceOrEmpty(envURL, flagURL, confURL)
+}
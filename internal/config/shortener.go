@@ -0,0 +1,56 @@
+package config
+
+import "time"
+
+const (
+	defaultShortCodeStrategy        = "hash"
+	defaultShortCodeLength          = 8
+	defaultURLDedupPolicy           = "user"
+	defaultExpiredURLsSweepInterval = time.Minute
+	defaultDeletedURLsRetention     = 30 * 24 * time.Hour
+	defaultDeletedURLsPurgeInterval = time.Hour
+	defaultDeleteBatchWindow        = 20 * time.Millisecond
+	defaultDeleteBatchSize          = 100
+	defaultClickFlushInterval       = time.Second
+	defaultClickBufferSize          = 10000
+	defaultLinkCheckInterval        = time.Hour
+	defaultLinkCheckConcurrency     = 8
+	defaultLinkCheckHostInterval    = time.Second
+	defaultLinkCheckTimeout         = 10 * time.Second
+	defaultBrokenLinkThreshold      = 3
+	defaultURLCacheSize             = 10000
+	defaultURLCacheTTL              = time.Minute
+	defaultURLCacheNegativeTTL      = 10 * time.Second
+	defaultURLCacheBackend          = "memory"
+)
+
+// ShortenerSettings содержит настройки генерации коротких кодов и жизненного цикла ссылок.
+// Определяет стратегию генерации (hash, random, counter), длину сгенерированного кода,
+// политику дедупликации длинных URL (user, global), период пометки истекших ссылок,
+// срок хранения удаленных ссылок в корзине, период их окончательного удаления, параметры
+// объединения задач удаления в пачки, параметры буфера переходов, путь к файлу политики адресов назначения
+// параметры фоновой проверки доступности адресов назначения и параметры кэша ссылок по короткому коду,
+// в том числе хранилище кэша (memory, redis) и адрес сервера Redis.
+type ShortenerSettings struct {
+	CodeStrategy             string        `envconfig:"SHORT_CODE_STRATEGY" default:"" required:"false"`
+	CodeLength               int           `envconfig:"SHORT_CODE_LENGTH" default:"0" required:"false"`
+	DedupPolicy              string        `envconfig:"URL_DEDUP_POLICY" default:"" required:"false"`
+	ExpiredURLsSweepInterval time.Duration `envconfig:"EXPIRED_URLS_SWEEP_INTERVAL" default:"0" required:"false"`
+	DeletedURLsRetention     time.Duration `envconfig:"DELETED_URLS_RETENTION" default:"0" required:"false"`
+	DeletedURLsPurgeInterval time.Duration `envconfig:"DELETED_URLS_PURGE_INTERVAL" default:"0" required:"false"`
+	DeleteBatchWindow        time.Duration `envconfig:"DELETE_BATCH_WINDOW" default:"0" required:"false"`
+	DeleteBatchSize          int           `envconfig:"DELETE_BATCH_SIZE" default:"0" required:"false"`
+	ClickFlushInterval       time.Duration `envconfig:"CLICK_FLUSH_INTERVAL" default:"0" required:"false"`
+	ClickBufferSize          int           `envconfig:"CLICK_BUFFER_SIZE" default:"0" required:"false"`
+	DestinationPolicyFile    string        `envconfig:"DESTINATION_POLICY_FILE" default:"" required:"false"`
+	LinkCheckInterval        time.Duration `envconfig:"LINK_CHECK_INTERVAL" default:"0" required:"false"`
+	LinkCheckConcurrency     int           `envconfig:"LINK_CHECK_CONCURRENCY" default:"0" required:"false"`
+	LinkCheckHostInterval    time.Duration `envconfig:"LINK_CHECK_HOST_INTERVAL" default:"0" required:"false"`
+	LinkCheckTimeout         time.Duration `envconfig:"LINK_CHECK_TIMEOUT" default:"0" required:"false"`
+	BrokenLinkThreshold      int           `envconfig:"BROKEN_LINK_THRESHOLD" default:"0" required:"false"`
+	URLCacheSize             int           `envconfig:"URL_CACHE_SIZE" default:"0" required:"false"`
+	URLCacheTTL              time.Duration `envconfig:"URL_CACHE_TTL" default:"0" required:"false"`
+	URLCacheNegativeTTL      time.Duration `envconfig:"URL_CACHE_NEGATIVE_TTL" default:"0" required:"false"`
+	URLCacheBackend          string        `envconfig:"URL_CACHE_BACKEND" default:"" required:"false"`
+	RedisURL                 string        `envconfig:"REDIS_URL" default:"" required:"false"`
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -23,10 +24,22 @@ const (
 
 // Запрос на создание короткой ссылки
 type URLShortenRequest struct {
-	state          protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Url string                 `protobuf:"bytes,1,opt,name=url"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url              string                 `protobuf:"bytes,1,opt,name=url"`
+	xxx_hidden_Alias            string                 `protobuf:"bytes,2,opt,name=alias"`
+	xxx_hidden_ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt"`
+	xxx_hidden_TtlSeconds       int64                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds"`
+	xxx_hidden_MaxClicks        int64                  `protobuf:"varint,5,opt,name=max_clicks,json=maxClicks"`
+	xxx_hidden_Password         string                 `protobuf:"bytes,6,opt,name=password"`
+	xxx_hidden_Title            string                 `protobuf:"bytes,7,opt,name=title"`
+	xxx_hidden_Interstitial     bool                   `protobuf:"varint,8,opt,name=interstitial"`
+	xxx_hidden_RedirectCode     int32                  `protobuf:"varint,9,opt,name=redirect_code,json=redirectCode"`
+	xxx_hidden_QueryPassthrough string                 `protobuf:"bytes,10,opt,name=query_passthrough,json=queryPassthrough"`
+	xxx_hidden_Prefix           bool                   `protobuf:"varint,11,opt,name=prefix"`
+	xxx_hidden_Rules            *[]*RoutingRule        `protobuf:"bytes,12,rep,name=rules"`
+	xxx_hidden_Variants         *[]*SplitVariant       `protobuf:"bytes,13,rep,name=variants"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
 }
 
 func (x *URLShortenRequest) Reset() {
@@ -61,14 +74,173 @@ func (x *URLShortenRequest) GetUrl() string {
 	return ""
 }
 
+func (x *URLShortenRequest) GetAlias() string {
+	if x != nil {
+		return x.xxx_hidden_Alias
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_TtlSeconds
+	}
+	return 0
+}
+
+func (x *URLShortenRequest) GetMaxClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxClicks
+	}
+	return 0
+}
+
+func (x *URLShortenRequest) GetPassword() string {
+	if x != nil {
+		return x.xxx_hidden_Password
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetTitle() string {
+	if x != nil {
+		return x.xxx_hidden_Title
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetInterstitial() bool {
+	if x != nil {
+		return x.xxx_hidden_Interstitial
+	}
+	return false
+}
+
+func (x *URLShortenRequest) GetRedirectCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_RedirectCode
+	}
+	return 0
+}
+
+func (x *URLShortenRequest) GetQueryPassthrough() string {
+	if x != nil {
+		return x.xxx_hidden_QueryPassthrough
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetPrefix() bool {
+	if x != nil {
+		return x.xxx_hidden_Prefix
+	}
+	return false
+}
+
+func (x *URLShortenRequest) GetRules() []*RoutingRule {
+	if x != nil {
+		if x.xxx_hidden_Rules != nil {
+			return *x.xxx_hidden_Rules
+		}
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetVariants() []*SplitVariant {
+	if x != nil {
+		if x.xxx_hidden_Variants != nil {
+			return *x.xxx_hidden_Variants
+		}
+	}
+	return nil
+}
+
 func (x *URLShortenRequest) SetUrl(v string) {
 	x.xxx_hidden_Url = v
 }
 
+func (x *URLShortenRequest) SetAlias(v string) {
+	x.xxx_hidden_Alias = v
+}
+
+func (x *URLShortenRequest) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *URLShortenRequest) SetTtlSeconds(v int64) {
+	x.xxx_hidden_TtlSeconds = v
+}
+
+func (x *URLShortenRequest) SetMaxClicks(v int64) {
+	x.xxx_hidden_MaxClicks = v
+}
+
+func (x *URLShortenRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = v
+}
+
+func (x *URLShortenRequest) SetTitle(v string) {
+	x.xxx_hidden_Title = v
+}
+
+func (x *URLShortenRequest) SetInterstitial(v bool) {
+	x.xxx_hidden_Interstitial = v
+}
+
+func (x *URLShortenRequest) SetRedirectCode(v int32) {
+	x.xxx_hidden_RedirectCode = v
+}
+
+func (x *URLShortenRequest) SetQueryPassthrough(v string) {
+	x.xxx_hidden_QueryPassthrough = v
+}
+
+func (x *URLShortenRequest) SetPrefix(v bool) {
+	x.xxx_hidden_Prefix = v
+}
+
+func (x *URLShortenRequest) SetRules(v []*RoutingRule) {
+	x.xxx_hidden_Rules = &v
+}
+
+func (x *URLShortenRequest) SetVariants(v []*SplitVariant) {
+	x.xxx_hidden_Variants = &v
+}
+
+func (x *URLShortenRequest) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *URLShortenRequest) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
 type URLShortenRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Url string
+	Url              string
+	Alias            string
+	ExpiresAt        *timestamppb.Timestamp
+	TtlSeconds       int64
+	MaxClicks        int64
+	Password         string
+	Title            string
+	Interstitial     bool
+	RedirectCode     int32
+	QueryPassthrough string
+	Prefix           bool
+	Rules            []*RoutingRule
+	Variants         []*SplitVariant
 }
 
 func (b0 URLShortenRequest_builder) Build() *URLShortenRequest {
@@ -76,35 +248,46 @@ func (b0 URLShortenRequest_builder) Build() *URLShortenRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_Url = b.Url
+	x.xxx_hidden_Alias = b.Alias
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_TtlSeconds = b.TtlSeconds
+	x.xxx_hidden_MaxClicks = b.MaxClicks
+	x.xxx_hidden_Password = b.Password
+	x.xxx_hidden_Title = b.Title
+	x.xxx_hidden_Interstitial = b.Interstitial
+	x.xxx_hidden_RedirectCode = b.RedirectCode
+	x.xxx_hidden_QueryPassthrough = b.QueryPassthrough
+	x.xxx_hidden_Prefix = b.Prefix
+	x.xxx_hidden_Rules = &b.Rules
+	x.xxx_hidden_Variants = &b.Variants
 	return m0
 }
 
-// Ответ с короткой ссылкой
-type URLShortenResponse struct {
+// Правило условного перенаправления; заданные условия должны выполняться одновременно, нужно хотя бы одно условие
+type RoutingRule struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Result      string                 `protobuf:"bytes,1,opt,name=result"`
-	xxx_hidden_StatusCode  int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode"`
-	xxx_hidden_Error       *string                `protobuf:"bytes,3,opt,name=error"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
+	xxx_hidden_Device      string                 `protobuf:"bytes,1,opt,name=device"`
+	xxx_hidden_Languages   []string               `protobuf:"bytes,2,rep,name=languages"`
+	xxx_hidden_Cidrs       []string               `protobuf:"bytes,3,rep,name=cidrs"`
+	xxx_hidden_Destination string                 `protobuf:"bytes,4,opt,name=destination"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *URLShortenResponse) Reset() {
-	*x = URLShortenResponse{}
+func (x *RoutingRule) Reset() {
+	*x = RoutingRule{}
 	mi := &file_api_proto_shortener_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLShortenResponse) String() string {
+func (x *RoutingRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLShortenResponse) ProtoMessage() {}
+func (*RoutingRule) ProtoMessage() {}
 
-func (x *URLShortenResponse) ProtoReflect() protoreflect.Message {
+func (x *RoutingRule) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_shortener_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -116,98 +299,92 @@ func (x *URLShortenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLShortenResponse) GetResult() string {
+func (x *RoutingRule) GetDevice() string {
 	if x != nil {
-		return x.xxx_hidden_Result
+		return x.xxx_hidden_Device
 	}
 	return ""
 }
 
-func (x *URLShortenResponse) GetStatusCode() int32 {
+func (x *RoutingRule) GetLanguages() []string {
 	if x != nil {
-		return x.xxx_hidden_StatusCode
+		return x.xxx_hidden_Languages
 	}
-	return 0
+	return nil
 }
 
-func (x *URLShortenResponse) GetError() string {
+func (x *RoutingRule) GetCidrs() []string {
 	if x != nil {
-		if x.xxx_hidden_Error != nil {
-			return *x.xxx_hidden_Error
-		}
-		return ""
+		return x.xxx_hidden_Cidrs
 	}
-	return ""
+	return nil
 }
 
-func (x *URLShortenResponse) SetResult(v string) {
-	x.xxx_hidden_Result = v
+func (x *RoutingRule) GetDestination() string {
+	if x != nil {
+		return x.xxx_hidden_Destination
+	}
+	return ""
 }
 
-func (x *URLShortenResponse) SetStatusCode(v int32) {
-	x.xxx_hidden_StatusCode = v
+func (x *RoutingRule) SetDevice(v string) {
+	x.xxx_hidden_Device = v
 }
 
-func (x *URLShortenResponse) SetError(v string) {
-	x.xxx_hidden_Error = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+func (x *RoutingRule) SetLanguages(v []string) {
+	x.xxx_hidden_Languages = v
 }
 
-func (x *URLShortenResponse) HasError() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+func (x *RoutingRule) SetCidrs(v []string) {
+	x.xxx_hidden_Cidrs = v
 }
 
-func (x *URLShortenResponse) ClearError() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Error = nil
+func (x *RoutingRule) SetDestination(v string) {
+	x.xxx_hidden_Destination = v
 }
 
-type URLShortenResponse_builder struct {
+type RoutingRule_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Result     string
-	StatusCode int32
-	Error      *string
+	Device      string
+	Languages   []string
+	Cidrs       []string
+	Destination string
 }
 
-func (b0 URLShortenResponse_builder) Build() *URLShortenResponse {
-	m0 := &URLShortenResponse{}
+func (b0 RoutingRule_builder) Build() *RoutingRule {
+	m0 := &RoutingRule{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Result = b.Result
-	x.xxx_hidden_StatusCode = b.StatusCode
-	if b.Error != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
-		x.xxx_hidden_Error = b.Error
-	}
+	x.xxx_hidden_Device = b.Device
+	x.xxx_hidden_Languages = b.Languages
+	x.xxx_hidden_Cidrs = b.Cidrs
+	x.xxx_hidden_Destination = b.Destination
 	return m0
 }
 
-// Запрос на извлечение длинного URL
-type URLExpandRequest struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id string                 `protobuf:"bytes,1,opt,name=id"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Список правил условного перенаправления
+type RoutingRules struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Items *[]*RoutingRule        `protobuf:"bytes,1,rep,name=items"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *URLExpandRequest) Reset() {
-	*x = URLExpandRequest{}
+func (x *RoutingRules) Reset() {
+	*x = RoutingRules{}
 	mi := &file_api_proto_shortener_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLExpandRequest) String() string {
+func (x *RoutingRules) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLExpandRequest) ProtoMessage() {}
+func (*RoutingRules) ProtoMessage() {}
 
-func (x *URLExpandRequest) ProtoReflect() protoreflect.Message {
+func (x *RoutingRules) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_shortener_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -219,57 +396,57 @@ func (x *URLExpandRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLExpandRequest) GetId() string {
+func (x *RoutingRules) GetItems() []*RoutingRule {
 	if x != nil {
-		return x.xxx_hidden_Id
+		if x.xxx_hidden_Items != nil {
+			return *x.xxx_hidden_Items
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *URLExpandRequest) SetId(v string) {
-	x.xxx_hidden_Id = v
+func (x *RoutingRules) SetItems(v []*RoutingRule) {
+	x.xxx_hidden_Items = &v
 }
 
-type URLExpandRequest_builder struct {
+type RoutingRules_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id string
+	Items []*RoutingRule
 }
 
-func (b0 URLExpandRequest_builder) Build() *URLExpandRequest {
-	m0 := &URLExpandRequest{}
+func (b0 RoutingRules_builder) Build() *RoutingRules {
+	m0 := &RoutingRules{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_Items = &b.Items
 	return m0
 }
 
-// Ответ с длинным URL
-type URLExpandResponse struct {
+// Вариант адреса назначения; доля переходов пропорциональна весу варианта относительно суммы весов
+type SplitVariant struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Result      string                 `protobuf:"bytes,1,opt,name=result"`
-	xxx_hidden_StatusCode  int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode"`
-	xxx_hidden_Error       *string                `protobuf:"bytes,3,opt,name=error"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
+	xxx_hidden_Name        string                 `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Destination string                 `protobuf:"bytes,2,opt,name=destination"`
+	xxx_hidden_Weight      int32                  `protobuf:"varint,3,opt,name=weight"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *URLExpandResponse) Reset() {
-	*x = URLExpandResponse{}
+func (x *SplitVariant) Reset() {
+	*x = SplitVariant{}
 	mi := &file_api_proto_shortener_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLExpandResponse) String() string {
+func (x *SplitVariant) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLExpandResponse) ProtoMessage() {}
+func (*SplitVariant) ProtoMessage() {}
 
-func (x *URLExpandResponse) ProtoReflect() protoreflect.Message {
+func (x *SplitVariant) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_shortener_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -281,102 +458,79 @@ func (x *URLExpandResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLExpandResponse) GetResult() string {
+func (x *SplitVariant) GetName() string {
 	if x != nil {
-		return x.xxx_hidden_Result
+		return x.xxx_hidden_Name
 	}
 	return ""
 }
 
-func (x *URLExpandResponse) GetStatusCode() int32 {
+func (x *SplitVariant) GetDestination() string {
 	if x != nil {
-		return x.xxx_hidden_StatusCode
+		return x.xxx_hidden_Destination
 	}
-	return 0
+	return ""
 }
 
-func (x *URLExpandResponse) GetError() string {
+func (x *SplitVariant) GetWeight() int32 {
 	if x != nil {
-		if x.xxx_hidden_Error != nil {
-			return *x.xxx_hidden_Error
-		}
-		return ""
+		return x.xxx_hidden_Weight
 	}
-	return ""
-}
-
-func (x *URLExpandResponse) SetResult(v string) {
-	x.xxx_hidden_Result = v
-}
-
-func (x *URLExpandResponse) SetStatusCode(v int32) {
-	x.xxx_hidden_StatusCode = v
+	return 0
 }
 
-func (x *URLExpandResponse) SetError(v string) {
-	x.xxx_hidden_Error = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+func (x *SplitVariant) SetName(v string) {
+	x.xxx_hidden_Name = v
 }
 
-func (x *URLExpandResponse) HasError() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+func (x *SplitVariant) SetDestination(v string) {
+	x.xxx_hidden_Destination = v
 }
 
-func (x *URLExpandResponse) ClearError() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Error = nil
+func (x *SplitVariant) SetWeight(v int32) {
+	x.xxx_hidden_Weight = v
 }
 
-type URLExpandResponse_builder struct {
+type SplitVariant_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Result     string
-	StatusCode int32
-	Error      *string
+	Name        string
+	Destination string
+	Weight      int32
 }
 
-func (b0 URLExpandResponse_builder) Build() *URLExpandResponse {
-	m0 := &URLExpandResponse{}
+func (b0 SplitVariant_builder) Build() *SplitVariant {
+	m0 := &SplitVariant{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Result = b.Result
-	x.xxx_hidden_StatusCode = b.StatusCode
-	if b.Error != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
-		x.xxx_hidden_Error = b.Error
-	}
+	x.xxx_hidden_Name = b.Name
+	x.xxx_hidden_Destination = b.Destination
+	x.xxx_hidden_Weight = b.Weight
 	return m0
 }
 
-// Представление URL пользователя
-type UserURLsResponse struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Url         *[]*URLData            `protobuf:"bytes,1,rep,name=url"`
-	xxx_hidden_StatusCode  int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode"`
-	xxx_hidden_Error       *string                `protobuf:"bytes,3,opt,name=error"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+// Список вариантов адреса назначения
+type SplitVariants struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Items *[]*SplitVariant       `protobuf:"bytes,1,rep,name=items"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *UserURLsResponse) Reset() {
-	*x = UserURLsResponse{}
+func (x *SplitVariants) Reset() {
+	*x = SplitVariants{}
 	mi := &file_api_proto_shortener_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserURLsResponse) String() string {
+func (x *SplitVariants) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserURLsResponse) ProtoMessage() {}
+func (*SplitVariants) ProtoMessage() {}
 
-func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
+func (x *SplitVariants) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_shortener_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -388,102 +542,2076 @@ func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *UserURLsResponse) GetUrl() []*URLData {
+func (x *SplitVariants) GetItems() []*SplitVariant {
 	if x != nil {
-		if x.xxx_hidden_Url != nil {
-			return *x.xxx_hidden_Url
+		if x.xxx_hidden_Items != nil {
+			return *x.xxx_hidden_Items
 		}
 	}
 	return nil
 }
 
-func (x *UserURLsResponse) GetStatusCode() int32 {
-	if x != nil {
-		return x.xxx_hidden_StatusCode
-	}
-	return 0
+func (x *SplitVariants) SetItems(v []*SplitVariant) {
+	x.xxx_hidden_Items = &v
 }
 
-func (x *UserURLsResponse) GetError() string {
-	if x != nil {
-		if x.xxx_hidden_Error != nil {
-			return *x.xxx_hidden_Error
-		}
+type SplitVariants_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Items []*SplitVariant
+}
+
+func (b0 SplitVariants_builder) Build() *SplitVariants {
+	m0 := &SplitVariants{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Items = &b.Items
+	return m0
+}
+
+// Ответ с короткой ссылкой
+type URLShortenResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Result      string                 `protobuf:"bytes,1,opt,name=result"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,3,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLShortenResponse) Reset() {
+	*x = URLShortenResponse{}
+	mi := &file_api_proto_shortener_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLShortenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLShortenResponse) ProtoMessage() {}
+
+func (x *URLShortenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLShortenResponse) GetResult() string {
+	if x != nil {
+		return x.xxx_hidden_Result
+	}
+	return ""
+}
+
+func (x *URLShortenResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *URLShortenResponse) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLShortenResponse) SetResult(v string) {
+	x.xxx_hidden_Result = v
+}
+
+func (x *URLShortenResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+}
+
+func (x *URLShortenResponse) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *URLShortenResponse) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *URLShortenResponse) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Error = nil
+}
+
+type URLShortenResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Result     string
+	StatusCode int32
+	Error      *string
+}
+
+func (b0 URLShortenResponse_builder) Build() *URLShortenResponse {
+	m0 := &URLShortenResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Result = b.Result
+	x.xxx_hidden_StatusCode = b.StatusCode
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+// Запрос на извлечение длинного URL
+type URLExpandRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id             string                 `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Password       string                 `protobuf:"bytes,2,opt,name=password"`
+	xxx_hidden_Confirm        bool                   `protobuf:"varint,3,opt,name=confirm"`
+	xxx_hidden_Query          string                 `protobuf:"bytes,4,opt,name=query"`
+	xxx_hidden_Path           string                 `protobuf:"bytes,5,opt,name=path"`
+	xxx_hidden_UserAgent      string                 `protobuf:"bytes,6,opt,name=user_agent,json=userAgent"`
+	xxx_hidden_AcceptLanguage string                 `protobuf:"bytes,7,opt,name=accept_language,json=acceptLanguage"`
+	xxx_hidden_ClientIp       string                 `protobuf:"bytes,8,opt,name=client_ip,json=clientIp"`
+	xxx_hidden_Variant        string                 `protobuf:"bytes,9,opt,name=variant"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *URLExpandRequest) Reset() {
+	*x = URLExpandRequest{}
+	mi := &file_api_proto_shortener_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLExpandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLExpandRequest) ProtoMessage() {}
+
+func (x *URLExpandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLExpandRequest) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetPassword() string {
+	if x != nil {
+		return x.xxx_hidden_Password
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetConfirm() bool {
+	if x != nil {
+		return x.xxx_hidden_Confirm
+	}
+	return false
+}
+
+func (x *URLExpandRequest) GetQuery() string {
+	if x != nil {
+		return x.xxx_hidden_Query
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetUserAgent() string {
+	if x != nil {
+		return x.xxx_hidden_UserAgent
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetAcceptLanguage() string {
+	if x != nil {
+		return x.xxx_hidden_AcceptLanguage
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetClientIp() string {
+	if x != nil {
+		return x.xxx_hidden_ClientIp
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetVariant() string {
+	if x != nil {
+		return x.xxx_hidden_Variant
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+func (x *URLExpandRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = v
+}
+
+func (x *URLExpandRequest) SetConfirm(v bool) {
+	x.xxx_hidden_Confirm = v
+}
+
+func (x *URLExpandRequest) SetQuery(v string) {
+	x.xxx_hidden_Query = v
+}
+
+func (x *URLExpandRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+func (x *URLExpandRequest) SetUserAgent(v string) {
+	x.xxx_hidden_UserAgent = v
+}
+
+func (x *URLExpandRequest) SetAcceptLanguage(v string) {
+	x.xxx_hidden_AcceptLanguage = v
+}
+
+func (x *URLExpandRequest) SetClientIp(v string) {
+	x.xxx_hidden_ClientIp = v
+}
+
+func (x *URLExpandRequest) SetVariant(v string) {
+	x.xxx_hidden_Variant = v
+}
+
+type URLExpandRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id             string
+	Password       string
+	Confirm        bool
+	Query          string
+	Path           string
+	UserAgent      string
+	AcceptLanguage string
+	ClientIp       string
+	Variant        string
+}
+
+func (b0 URLExpandRequest_builder) Build() *URLExpandRequest {
+	m0 := &URLExpandRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_Password = b.Password
+	x.xxx_hidden_Confirm = b.Confirm
+	x.xxx_hidden_Query = b.Query
+	x.xxx_hidden_Path = b.Path
+	x.xxx_hidden_UserAgent = b.UserAgent
+	x.xxx_hidden_AcceptLanguage = b.AcceptLanguage
+	x.xxx_hidden_ClientIp = b.ClientIp
+	x.xxx_hidden_Variant = b.Variant
+	return m0
+}
+
+// Ответ с длинным URL
+type URLExpandResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Result      string                 `protobuf:"bytes,1,opt,name=result"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,3,opt,name=error"`
+	xxx_hidden_Variant     string                 `protobuf:"bytes,4,opt,name=variant"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLExpandResponse) Reset() {
+	*x = URLExpandResponse{}
+	mi := &file_api_proto_shortener_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLExpandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLExpandResponse) ProtoMessage() {}
+
+func (x *URLExpandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLExpandResponse) GetResult() string {
+	if x != nil {
+		return x.xxx_hidden_Result
+	}
+	return ""
+}
+
+func (x *URLExpandResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *URLExpandResponse) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLExpandResponse) GetVariant() string {
+	if x != nil {
+		return x.xxx_hidden_Variant
+	}
+	return ""
+}
+
+func (x *URLExpandResponse) SetResult(v string) {
+	x.xxx_hidden_Result = v
+}
+
+func (x *URLExpandResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+}
+
+func (x *URLExpandResponse) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *URLExpandResponse) SetVariant(v string) {
+	x.xxx_hidden_Variant = v
+}
+
+func (x *URLExpandResponse) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *URLExpandResponse) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Error = nil
+}
+
+type URLExpandResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Result     string
+	StatusCode int32
+	Error      *string
+	Variant    string
+}
+
+func (b0 URLExpandResponse_builder) Build() *URLExpandResponse {
+	m0 := &URLExpandResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Result = b.Result
+	x.xxx_hidden_StatusCode = b.StatusCode
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Error = b.Error
+	}
+	x.xxx_hidden_Variant = b.Variant
+	return m0
+}
+
+// Представление URL пользователя
+type UserURLsResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url         *[]*URLData            `protobuf:"bytes,1,rep,name=url"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,3,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UserURLsResponse) Reset() {
+	*x = UserURLsResponse{}
+	mi := &file_api_proto_shortener_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserURLsResponse) ProtoMessage() {}
+
+func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserURLsResponse) GetUrl() []*URLData {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+	}
+	return nil
+}
+
+func (x *UserURLsResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *UserURLsResponse) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UserURLsResponse) SetUrl(v []*URLData) {
+	x.xxx_hidden_Url = &v
+}
+
+func (x *UserURLsResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+}
+
+func (x *UserURLsResponse) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *UserURLsResponse) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *UserURLsResponse) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Error = nil
+}
+
+type UserURLsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Url        []*URLData
+	StatusCode int32
+	Error      *string
+}
+
+func (b0 UserURLsResponse_builder) Build() *UserURLsResponse {
+	m0 := &UserURLsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = &b.Url
+	x.xxx_hidden_StatusCode = b.StatusCode
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+// Представление URL пользователя
+type URLData struct {
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShortUrl          string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl"`
+	xxx_hidden_OriginalUrl       string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
+	xxx_hidden_ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt"`
+	xxx_hidden_MaxClicks         int64                  `protobuf:"varint,4,opt,name=max_clicks,json=maxClicks"`
+	xxx_hidden_ClicksLeft        int64                  `protobuf:"varint,5,opt,name=clicks_left,json=clicksLeft"`
+	xxx_hidden_PasswordProtected bool                   `protobuf:"varint,6,opt,name=password_protected,json=passwordProtected"`
+	xxx_hidden_Disabled          bool                   `protobuf:"varint,7,opt,name=disabled"`
+	xxx_hidden_Clicks            int64                  `protobuf:"varint,8,opt,name=clicks"`
+	xxx_hidden_Title             string                 `protobuf:"bytes,9,opt,name=title"`
+	xxx_hidden_ShowInterstitial  bool                   `protobuf:"varint,10,opt,name=show_interstitial,json=showInterstitial"`
+	xxx_hidden_RedirectCode      int32                  `protobuf:"varint,11,opt,name=redirect_code,json=redirectCode"`
+	xxx_hidden_QueryPassthrough  string                 `protobuf:"bytes,12,opt,name=query_passthrough,json=queryPassthrough"`
+	xxx_hidden_Prefix            bool                   `protobuf:"varint,13,opt,name=prefix"`
+	xxx_hidden_Rules             *[]*RoutingRule        `protobuf:"bytes,14,rep,name=rules"`
+	xxx_hidden_Variants          *[]*SplitVariant       `protobuf:"bytes,15,rep,name=variants"`
+	xxx_hidden_Health            *LinkHealth            `protobuf:"bytes,16,opt,name=health"`
+	XXX_raceDetectHookData       protoimpl.RaceDetectHookData
+	XXX_presence                 [1]uint32
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *URLData) Reset() {
+	*x = URLData{}
+	mi := &file_api_proto_shortener_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLData) ProtoMessage() {}
+
+func (x *URLData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLData) GetShortUrl() string {
+	if x != nil {
+		return x.xxx_hidden_ShortUrl
+	}
+	return ""
+}
+
+func (x *URLData) GetOriginalUrl() string {
+	if x != nil {
+		return x.xxx_hidden_OriginalUrl
+	}
+	return ""
+}
+
+func (x *URLData) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *URLData) GetMaxClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxClicks
+	}
+	return 0
+}
+
+func (x *URLData) GetClicksLeft() int64 {
+	if x != nil {
+		return x.xxx_hidden_ClicksLeft
+	}
+	return 0
+}
+
+func (x *URLData) GetPasswordProtected() bool {
+	if x != nil {
+		return x.xxx_hidden_PasswordProtected
+	}
+	return false
+}
+
+func (x *URLData) GetDisabled() bool {
+	if x != nil {
+		return x.xxx_hidden_Disabled
+	}
+	return false
+}
+
+func (x *URLData) GetClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Clicks
+	}
+	return 0
+}
+
+func (x *URLData) GetTitle() string {
+	if x != nil {
+		return x.xxx_hidden_Title
+	}
+	return ""
+}
+
+func (x *URLData) GetShowInterstitial() bool {
+	if x != nil {
+		return x.xxx_hidden_ShowInterstitial
+	}
+	return false
+}
+
+func (x *URLData) GetRedirectCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_RedirectCode
+	}
+	return 0
+}
+
+func (x *URLData) GetQueryPassthrough() string {
+	if x != nil {
+		return x.xxx_hidden_QueryPassthrough
+	}
+	return ""
+}
+
+func (x *URLData) GetPrefix() bool {
+	if x != nil {
+		return x.xxx_hidden_Prefix
+	}
+	return false
+}
+
+func (x *URLData) GetRules() []*RoutingRule {
+	if x != nil {
+		if x.xxx_hidden_Rules != nil {
+			return *x.xxx_hidden_Rules
+		}
+	}
+	return nil
+}
+
+func (x *URLData) GetVariants() []*SplitVariant {
+	if x != nil {
+		if x.xxx_hidden_Variants != nil {
+			return *x.xxx_hidden_Variants
+		}
+	}
+	return nil
+}
+
+func (x *URLData) GetHealth() *LinkHealth {
+	if x != nil {
+		return x.xxx_hidden_Health
+	}
+	return nil
+}
+
+func (x *URLData) SetShortUrl(v string) {
+	x.xxx_hidden_ShortUrl = v
+}
+
+func (x *URLData) SetOriginalUrl(v string) {
+	x.xxx_hidden_OriginalUrl = v
+}
+
+func (x *URLData) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *URLData) SetMaxClicks(v int64) {
+	x.xxx_hidden_MaxClicks = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 16)
+}
+
+func (x *URLData) SetClicksLeft(v int64) {
+	x.xxx_hidden_ClicksLeft = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 16)
+}
+
+func (x *URLData) SetPasswordProtected(v bool) {
+	x.xxx_hidden_PasswordProtected = v
+}
+
+func (x *URLData) SetDisabled(v bool) {
+	x.xxx_hidden_Disabled = v
+}
+
+func (x *URLData) SetClicks(v int64) {
+	x.xxx_hidden_Clicks = v
+}
+
+func (x *URLData) SetTitle(v string) {
+	x.xxx_hidden_Title = v
+}
+
+func (x *URLData) SetShowInterstitial(v bool) {
+	x.xxx_hidden_ShowInterstitial = v
+}
+
+func (x *URLData) SetRedirectCode(v int32) {
+	x.xxx_hidden_RedirectCode = v
+}
+
+func (x *URLData) SetQueryPassthrough(v string) {
+	x.xxx_hidden_QueryPassthrough = v
+}
+
+func (x *URLData) SetPrefix(v bool) {
+	x.xxx_hidden_Prefix = v
+}
+
+func (x *URLData) SetRules(v []*RoutingRule) {
+	x.xxx_hidden_Rules = &v
+}
+
+func (x *URLData) SetVariants(v []*SplitVariant) {
+	x.xxx_hidden_Variants = &v
+}
+
+func (x *URLData) SetHealth(v *LinkHealth) {
+	x.xxx_hidden_Health = v
+}
+
+func (x *URLData) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *URLData) HasMaxClicks() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *URLData) HasClicksLeft() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *URLData) HasHealth() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Health != nil
+}
+
+func (x *URLData) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *URLData) ClearMaxClicks() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_MaxClicks = 0
+}
+
+func (x *URLData) ClearClicksLeft() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ClicksLeft = 0
+}
+
+func (x *URLData) ClearHealth() {
+	x.xxx_hidden_Health = nil
+}
+
+type URLData_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ShortUrl          string
+	OriginalUrl       string
+	ExpiresAt         *timestamppb.Timestamp
+	MaxClicks         *int64
+	ClicksLeft        *int64
+	PasswordProtected bool
+	Disabled          bool
+	Clicks            int64
+	Title             string
+	ShowInterstitial  bool
+	RedirectCode      int32
+	QueryPassthrough  string
+	Prefix            bool
+	Rules             []*RoutingRule
+	Variants          []*SplitVariant
+	Health            *LinkHealth
+}
+
+func (b0 URLData_builder) Build() *URLData {
+	m0 := &URLData{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ShortUrl = b.ShortUrl
+	x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	if b.MaxClicks != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 16)
+		x.xxx_hidden_MaxClicks = *b.MaxClicks
+	}
+	if b.ClicksLeft != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 16)
+		x.xxx_hidden_ClicksLeft = *b.ClicksLeft
+	}
+	x.xxx_hidden_PasswordProtected = b.PasswordProtected
+	x.xxx_hidden_Disabled = b.Disabled
+	x.xxx_hidden_Clicks = b.Clicks
+	x.xxx_hidden_Title = b.Title
+	x.xxx_hidden_ShowInterstitial = b.ShowInterstitial
+	x.xxx_hidden_RedirectCode = b.RedirectCode
+	x.xxx_hidden_QueryPassthrough = b.QueryPassthrough
+	x.xxx_hidden_Prefix = b.Prefix
+	x.xxx_hidden_Rules = &b.Rules
+	x.xxx_hidden_Variants = &b.Variants
+	x.xxx_hidden_Health = b.Health
+	return m0
+}
+
+// Результат фоновой проверки доступности адреса назначения ссылки
+type LinkHealth struct {
+	state                          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_StatusCode          int32                  `protobuf:"varint,1,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Error               string                 `protobuf:"bytes,2,opt,name=error"`
+	xxx_hidden_RedirectChain       []string               `protobuf:"bytes,3,rep,name=redirect_chain,json=redirectChain"`
+	xxx_hidden_ConsecutiveFailures int32                  `protobuf:"varint,4,opt,name=consecutive_failures,json=consecutiveFailures"`
+	xxx_hidden_CheckedAt           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=checked_at,json=checkedAt"`
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
+}
+
+func (x *LinkHealth) Reset() {
+	*x = LinkHealth{}
+	mi := &file_api_proto_shortener_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkHealth) ProtoMessage() {}
+
+func (x *LinkHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *LinkHealth) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *LinkHealth) GetError() string {
+	if x != nil {
+		return x.xxx_hidden_Error
+	}
+	return ""
+}
+
+func (x *LinkHealth) GetRedirectChain() []string {
+	if x != nil {
+		return x.xxx_hidden_RedirectChain
+	}
+	return nil
+}
+
+func (x *LinkHealth) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.xxx_hidden_ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *LinkHealth) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CheckedAt
+	}
+	return nil
+}
+
+func (x *LinkHealth) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+}
+
+func (x *LinkHealth) SetError(v string) {
+	x.xxx_hidden_Error = v
+}
+
+func (x *LinkHealth) SetRedirectChain(v []string) {
+	x.xxx_hidden_RedirectChain = v
+}
+
+func (x *LinkHealth) SetConsecutiveFailures(v int32) {
+	x.xxx_hidden_ConsecutiveFailures = v
+}
+
+func (x *LinkHealth) SetCheckedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CheckedAt = v
+}
+
+func (x *LinkHealth) HasCheckedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CheckedAt != nil
+}
+
+func (x *LinkHealth) ClearCheckedAt() {
+	x.xxx_hidden_CheckedAt = nil
+}
+
+type LinkHealth_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	StatusCode          int32
+	Error               string
+	RedirectChain       []string
+	ConsecutiveFailures int32
+	CheckedAt           *timestamppb.Timestamp
+}
+
+func (b0 LinkHealth_builder) Build() *LinkHealth {
+	m0 := &LinkHealth{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_StatusCode = b.StatusCode
+	x.xxx_hidden_Error = b.Error
+	x.xxx_hidden_RedirectChain = b.RedirectChain
+	x.xxx_hidden_ConsecutiveFailures = b.ConsecutiveFailures
+	x.xxx_hidden_CheckedAt = b.CheckedAt
+	return m0
+}
+
+// Запрос на изменение ссылки пользователя; незаданные поля не изменяются
+type URLUpdateRequest struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id           string                 `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Url          *string                `protobuf:"bytes,2,opt,name=url"`
+	xxx_hidden_ExpiresAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt"`
+	xxx_hidden_TtlSeconds   int64                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds"`
+	xxx_hidden_NoExpiration bool                   `protobuf:"varint,5,opt,name=no_expiration,json=noExpiration"`
+	xxx_hidden_Active       bool                   `protobuf:"varint,6,opt,name=active"`
+	xxx_hidden_Title        *string                `protobuf:"bytes,7,opt,name=title"`
+	xxx_hidden_Interstitial bool                   `protobuf:"varint,8,opt,name=interstitial"`
+	xxx_hidden_Rules        *RoutingRules          `protobuf:"bytes,9,opt,name=rules"`
+	xxx_hidden_Variants     *SplitVariants         `protobuf:"bytes,10,opt,name=variants"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *URLUpdateRequest) Reset() {
+	*x = URLUpdateRequest{}
+	mi := &file_api_proto_shortener_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLUpdateRequest) ProtoMessage() {}
+
+func (x *URLUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLUpdateRequest) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *URLUpdateRequest) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLUpdateRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *URLUpdateRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_TtlSeconds
+	}
+	return 0
+}
+
+func (x *URLUpdateRequest) GetNoExpiration() bool {
+	if x != nil {
+		return x.xxx_hidden_NoExpiration
+	}
+	return false
+}
+
+func (x *URLUpdateRequest) GetActive() bool {
+	if x != nil {
+		return x.xxx_hidden_Active
+	}
+	return false
+}
+
+func (x *URLUpdateRequest) GetTitle() string {
+	if x != nil {
+		if x.xxx_hidden_Title != nil {
+			return *x.xxx_hidden_Title
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLUpdateRequest) GetInterstitial() bool {
+	if x != nil {
+		return x.xxx_hidden_Interstitial
+	}
+	return false
+}
+
+func (x *URLUpdateRequest) GetRules() *RoutingRules {
+	if x != nil {
+		return x.xxx_hidden_Rules
+	}
+	return nil
+}
+
+func (x *URLUpdateRequest) GetVariants() *SplitVariants {
+	if x != nil {
+		return x.xxx_hidden_Variants
+	}
+	return nil
+}
+
+func (x *URLUpdateRequest) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+func (x *URLUpdateRequest) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *URLUpdateRequest) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *URLUpdateRequest) SetTtlSeconds(v int64) {
+	x.xxx_hidden_TtlSeconds = v
+}
+
+func (x *URLUpdateRequest) SetNoExpiration(v bool) {
+	x.xxx_hidden_NoExpiration = v
+}
+
+func (x *URLUpdateRequest) SetActive(v bool) {
+	x.xxx_hidden_Active = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
+}
+
+func (x *URLUpdateRequest) SetTitle(v string) {
+	x.xxx_hidden_Title = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
+}
+
+func (x *URLUpdateRequest) SetInterstitial(v bool) {
+	x.xxx_hidden_Interstitial = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 10)
+}
+
+func (x *URLUpdateRequest) SetRules(v *RoutingRules) {
+	x.xxx_hidden_Rules = v
+}
+
+func (x *URLUpdateRequest) SetVariants(v *SplitVariants) {
+	x.xxx_hidden_Variants = v
+}
+
+func (x *URLUpdateRequest) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLUpdateRequest) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *URLUpdateRequest) HasActive() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *URLUpdateRequest) HasTitle() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *URLUpdateRequest) HasInterstitial() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *URLUpdateRequest) HasRules() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Rules != nil
+}
+
+func (x *URLUpdateRequest) HasVariants() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Variants != nil
+}
+
+func (x *URLUpdateRequest) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Url = nil
+}
+
+func (x *URLUpdateRequest) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *URLUpdateRequest) ClearActive() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Active = false
+}
+
+func (x *URLUpdateRequest) ClearTitle() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Title = nil
+}
+
+func (x *URLUpdateRequest) ClearInterstitial() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Interstitial = false
+}
+
+func (x *URLUpdateRequest) ClearRules() {
+	x.xxx_hidden_Rules = nil
+}
+
+func (x *URLUpdateRequest) ClearVariants() {
+	x.xxx_hidden_Variants = nil
+}
+
+type URLUpdateRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id           string
+	Url          *string
+	ExpiresAt    *timestamppb.Timestamp
+	TtlSeconds   int64
+	NoExpiration bool
+	Active       *bool
+	Title        *string
+	Interstitial *bool
+	Rules        *RoutingRules
+	Variants     *SplitVariants
+}
+
+func (b0 URLUpdateRequest_builder) Build() *URLUpdateRequest {
+	m0 := &URLUpdateRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Url = b.Url
+	}
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_TtlSeconds = b.TtlSeconds
+	x.xxx_hidden_NoExpiration = b.NoExpiration
+	if b.Active != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
+		x.xxx_hidden_Active = *b.Active
+	}
+	if b.Title != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
+		x.xxx_hidden_Title = b.Title
+	}
+	if b.Interstitial != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 10)
+		x.xxx_hidden_Interstitial = *b.Interstitial
+	}
+	x.xxx_hidden_Rules = b.Rules
+	x.xxx_hidden_Variants = b.Variants
+	return m0
+}
+
+// Ответ с измененной ссылкой
+type URLUpdateResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url         *URLData               `protobuf:"bytes,1,opt,name=url"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,3,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLUpdateResponse) Reset() {
+	*x = URLUpdateResponse{}
+	mi := &file_api_proto_shortener_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLUpdateResponse) ProtoMessage() {}
+
+func (x *URLUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLUpdateResponse) GetUrl() *URLData {
+	if x != nil {
+		return x.xxx_hidden_Url
+	}
+	return nil
+}
+
+func (x *URLUpdateResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *URLUpdateResponse) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLUpdateResponse) SetUrl(v *URLData) {
+	x.xxx_hidden_Url = v
+}
+
+func (x *URLUpdateResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+}
+
+func (x *URLUpdateResponse) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *URLUpdateResponse) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Url != nil
+}
+
+func (x *URLUpdateResponse) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *URLUpdateResponse) ClearUrl() {
+	x.xxx_hidden_Url = nil
+}
+
+func (x *URLUpdateResponse) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Error = nil
+}
+
+type URLUpdateResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Url        *URLData
+	StatusCode int32
+	Error      *string
+}
+
+func (b0 URLUpdateResponse_builder) Build() *URLUpdateResponse {
+	m0 := &URLUpdateResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = b.Url
+	x.xxx_hidden_StatusCode = b.StatusCode
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+// Запрос на восстановление удаленных URL пользователя
+type URLRestoreRequest struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Ids []string               `protobuf:"bytes,1,rep,name=ids"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *URLRestoreRequest) Reset() {
+	*x = URLRestoreRequest{}
+	mi := &file_api_proto_shortener_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLRestoreRequest) ProtoMessage() {}
+
+func (x *URLRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLRestoreRequest) GetIds() []string {
+	if x != nil {
+		return x.xxx_hidden_Ids
+	}
+	return nil
+}
+
+func (x *URLRestoreRequest) SetIds(v []string) {
+	x.xxx_hidden_Ids = v
+}
+
+type URLRestoreRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Ids []string
+}
+
+func (b0 URLRestoreRequest_builder) Build() *URLRestoreRequest {
+	m0 := &URLRestoreRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Ids = b.Ids
+	return m0
+}
+
+// Ответ на запрос восстановления; восстановление выполняется асинхронно
+type URLRestoreResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,1,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,2,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLRestoreResponse) Reset() {
+	*x = URLRestoreResponse{}
+	mi := &file_api_proto_shortener_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLRestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLRestoreResponse) ProtoMessage() {}
+
+func (x *URLRestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLRestoreResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *URLRestoreResponse) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLRestoreResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+}
+
+func (x *URLRestoreResponse) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *URLRestoreResponse) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLRestoreResponse) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Error = nil
+}
+
+type URLRestoreResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	StatusCode int32
+	Error      *string
+}
+
+func (b0 URLRestoreResponse_builder) Build() *URLRestoreResponse {
+	m0 := &URLRestoreResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_StatusCode = b.StatusCode
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+// Запрос на удаление одной ссылки пользователя
+type URLDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id string                 `protobuf:"bytes,1,opt,name=id"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *URLDeleteRequest) Reset() {
+	*x = URLDeleteRequest{}
+	mi := &file_api_proto_shortener_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLDeleteRequest) ProtoMessage() {}
+
+func (x *URLDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLDeleteRequest) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *URLDeleteRequest) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+type URLDeleteRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id string
+}
+
+func (b0 URLDeleteRequest_builder) Build() *URLDeleteRequest {
+	m0 := &URLDeleteRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	return m0
+}
+
+// Ответ на запрос удаления; удаление выполняется синхронно
+type URLDeleteResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,1,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,2,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLDeleteResponse) Reset() {
+	*x = URLDeleteResponse{}
+	mi := &file_api_proto_shortener_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLDeleteResponse) ProtoMessage() {}
+
+func (x *URLDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLDeleteResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *URLDeleteResponse) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
 		return ""
 	}
 	return ""
 }
 
-func (x *UserURLsResponse) SetUrl(v []*URLData) {
-	x.xxx_hidden_Url = &v
+func (x *URLDeleteResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+}
+
+func (x *URLDeleteResponse) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *URLDeleteResponse) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLDeleteResponse) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Error = nil
+}
+
+type URLDeleteResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	StatusCode int32
+	Error      *string
+}
+
+func (b0 URLDeleteResponse_builder) Build() *URLDeleteResponse {
+	m0 := &URLDeleteResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_StatusCode = b.StatusCode
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+// Запрос статуса задачи пакетного удаления
+type DeleteJobRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id string                 `protobuf:"bytes,1,opt,name=id"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobRequest) Reset() {
+	*x = DeleteJobRequest{}
+	mi := &file_api_proto_shortener_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobRequest) ProtoMessage() {}
+
+func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteJobRequest) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *DeleteJobRequest) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+type DeleteJobRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id string
+}
+
+func (b0 DeleteJobRequest_builder) Build() *DeleteJobRequest {
+	m0 := &DeleteJobRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	return m0
+}
+
+// Результат удаления одной ссылки в задаче
+type DeleteJobResult struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id      string                 `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Outcome string                 `protobuf:"bytes,2,opt,name=outcome"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DeleteJobResult) Reset() {
+	*x = DeleteJobResult{}
+	mi := &file_api_proto_shortener_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobResult) ProtoMessage() {}
+
+func (x *DeleteJobResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteJobResult) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *DeleteJobResult) GetOutcome() string {
+	if x != nil {
+		return x.xxx_hidden_Outcome
+	}
+	return ""
+}
+
+func (x *DeleteJobResult) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+func (x *DeleteJobResult) SetOutcome(v string) {
+	x.xxx_hidden_Outcome = v
+}
+
+type DeleteJobResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id      string
+	Outcome string
+}
+
+func (b0 DeleteJobResult_builder) Build() *DeleteJobResult {
+	m0 := &DeleteJobResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_Outcome = b.Outcome
+	return m0
+}
+
+// Статус задачи пакетного удаления
+type DeleteJobResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          string                 `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Status      string                 `protobuf:"bytes,2,opt,name=status"`
+	xxx_hidden_Results     *[]*DeleteJobResult    `protobuf:"bytes,3,rep,name=results"`
+	xxx_hidden_JobError    *string                `protobuf:"bytes,4,opt,name=job_error,json=jobError"`
+	xxx_hidden_CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt"`
+	xxx_hidden_UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,7,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,8,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DeleteJobResponse) Reset() {
+	*x = DeleteJobResponse{}
+	mi := &file_api_proto_shortener_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobResponse) ProtoMessage() {}
+
+func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteJobResponse) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *DeleteJobResponse) GetStatus() string {
+	if x != nil {
+		return x.xxx_hidden_Status
+	}
+	return ""
+}
+
+func (x *DeleteJobResponse) GetResults() []*DeleteJobResult {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *DeleteJobResponse) GetJobError() string {
+	if x != nil {
+		if x.xxx_hidden_JobError != nil {
+			return *x.xxx_hidden_JobError
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteJobResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *DeleteJobResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_UpdatedAt
+	}
+	return nil
+}
+
+func (x *DeleteJobResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *DeleteJobResponse) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteJobResponse) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+func (x *DeleteJobResponse) SetStatus(v string) {
+	x.xxx_hidden_Status = v
+}
+
+func (x *DeleteJobResponse) SetResults(v []*DeleteJobResult) {
+	x.xxx_hidden_Results = &v
+}
+
+func (x *DeleteJobResponse) SetJobError(v string) {
+	x.xxx_hidden_JobError = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
+}
+
+func (x *DeleteJobResponse) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *DeleteJobResponse) SetUpdatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_UpdatedAt = v
+}
+
+func (x *DeleteJobResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+}
+
+func (x *DeleteJobResponse) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
+}
+
+func (x *DeleteJobResponse) HasJobError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *DeleteJobResponse) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *DeleteJobResponse) HasUpdatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UpdatedAt != nil
+}
+
+func (x *DeleteJobResponse) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
 }
 
-func (x *UserURLsResponse) SetStatusCode(v int32) {
-	x.xxx_hidden_StatusCode = v
+func (x *DeleteJobResponse) ClearJobError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_JobError = nil
 }
 
-func (x *UserURLsResponse) SetError(v string) {
-	x.xxx_hidden_Error = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+func (x *DeleteJobResponse) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
 }
 
-func (x *UserURLsResponse) HasError() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+func (x *DeleteJobResponse) ClearUpdatedAt() {
+	x.xxx_hidden_UpdatedAt = nil
 }
 
-func (x *UserURLsResponse) ClearError() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+func (x *DeleteJobResponse) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
 	x.xxx_hidden_Error = nil
 }
 
-type UserURLsResponse_builder struct {
+type DeleteJobResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Url        []*URLData
+	Id         string
+	Status     string
+	Results    []*DeleteJobResult
+	JobError   *string
+	CreatedAt  *timestamppb.Timestamp
+	UpdatedAt  *timestamppb.Timestamp
 	StatusCode int32
 	Error      *string
 }
 
-func (b0 UserURLsResponse_builder) Build() *UserURLsResponse {
-	m0 := &UserURLsResponse{}
+func (b0 DeleteJobResponse_builder) Build() *DeleteJobResponse {
+	m0 := &DeleteJobResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Url = &b.Url
+	x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_Status = b.Status
+	x.xxx_hidden_Results = &b.Results
+	if b.JobError != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
+		x.xxx_hidden_JobError = b.JobError
+	}
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_UpdatedAt = b.UpdatedAt
 	x.xxx_hidden_StatusCode = b.StatusCode
 	if b.Error != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
 		x.xxx_hidden_Error = b.Error
 	}
 	return m0
 }
 
-// Представление URL пользователя
-type URLData struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl"`
-	xxx_hidden_OriginalUrl string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+// Запрос статистики переходов по ссылке
+type URLStatsRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id   string                 `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Days int32                  `protobuf:"varint,2,opt,name=days"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *URLData) Reset() {
-	*x = URLData{}
-	mi := &file_api_proto_shortener_proto_msgTypes[5]
+func (x *URLStatsRequest) Reset() {
+	*x = URLStatsRequest{}
+	mi := &file_api_proto_shortener_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLData) String() string {
+func (x *URLStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLData) ProtoMessage() {}
+func (*URLStatsRequest) ProtoMessage() {}
 
-func (x *URLData) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_shortener_proto_msgTypes[5]
+func (x *URLStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -494,41 +2622,366 @@ func (x *URLData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLData) GetShortUrl() string {
+func (x *URLStatsRequest) GetId() string {
 	if x != nil {
-		return x.xxx_hidden_ShortUrl
+		return x.xxx_hidden_Id
 	}
 	return ""
 }
 
-func (x *URLData) GetOriginalUrl() string {
+func (x *URLStatsRequest) GetDays() int32 {
 	if x != nil {
-		return x.xxx_hidden_OriginalUrl
+		return x.xxx_hidden_Days
+	}
+	return 0
+}
+
+func (x *URLStatsRequest) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+func (x *URLStatsRequest) SetDays(v int32) {
+	x.xxx_hidden_Days = v
+}
+
+type URLStatsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id   string
+	Days int32
+}
+
+func (b0 URLStatsRequest_builder) Build() *URLStatsRequest {
+	m0 := &URLStatsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_Days = b.Days
+	return m0
+}
+
+// Статистика переходов за один день (UTC)
+type DailyClicks struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Date           string                 `protobuf:"bytes,1,opt,name=date"`
+	xxx_hidden_Clicks         int64                  `protobuf:"varint,2,opt,name=clicks"`
+	xxx_hidden_UniqueVisitors int64                  `protobuf:"varint,3,opt,name=unique_visitors,json=uniqueVisitors"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *DailyClicks) Reset() {
+	*x = DailyClicks{}
+	mi := &file_api_proto_shortener_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyClicks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyClicks) ProtoMessage() {}
+
+func (x *DailyClicks) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DailyClicks) GetDate() string {
+	if x != nil {
+		return x.xxx_hidden_Date
 	}
 	return ""
 }
 
-func (x *URLData) SetShortUrl(v string) {
-	x.xxx_hidden_ShortUrl = v
+func (x *DailyClicks) GetClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Clicks
+	}
+	return 0
 }
 
-func (x *URLData) SetOriginalUrl(v string) {
-	x.xxx_hidden_OriginalUrl = v
+func (x *DailyClicks) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.xxx_hidden_UniqueVisitors
+	}
+	return 0
 }
 
-type URLData_builder struct {
+func (x *DailyClicks) SetDate(v string) {
+	x.xxx_hidden_Date = v
+}
+
+func (x *DailyClicks) SetClicks(v int64) {
+	x.xxx_hidden_Clicks = v
+}
+
+func (x *DailyClicks) SetUniqueVisitors(v int64) {
+	x.xxx_hidden_UniqueVisitors = v
+}
+
+type DailyClicks_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	ShortUrl    string
-	OriginalUrl string
+	Date           string
+	Clicks         int64
+	UniqueVisitors int64
 }
 
-func (b0 URLData_builder) Build() *URLData {
-	m0 := &URLData{}
+func (b0 DailyClicks_builder) Build() *DailyClicks {
+	m0 := &DailyClicks{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_ShortUrl = b.ShortUrl
-	x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	x.xxx_hidden_Date = b.Date
+	x.xxx_hidden_Clicks = b.Clicks
+	x.xxx_hidden_UniqueVisitors = b.UniqueVisitors
+	return m0
+}
+
+// Статистика переходов по одному варианту адреса назначения
+type VariantClicks struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Variant        string                 `protobuf:"bytes,1,opt,name=variant"`
+	xxx_hidden_Clicks         int64                  `protobuf:"varint,2,opt,name=clicks"`
+	xxx_hidden_UniqueVisitors int64                  `protobuf:"varint,3,opt,name=unique_visitors,json=uniqueVisitors"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *VariantClicks) Reset() {
+	*x = VariantClicks{}
+	mi := &file_api_proto_shortener_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantClicks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantClicks) ProtoMessage() {}
+
+func (x *VariantClicks) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *VariantClicks) GetVariant() string {
+	if x != nil {
+		return x.xxx_hidden_Variant
+	}
+	return ""
+}
+
+func (x *VariantClicks) GetClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Clicks
+	}
+	return 0
+}
+
+func (x *VariantClicks) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.xxx_hidden_UniqueVisitors
+	}
+	return 0
+}
+
+func (x *VariantClicks) SetVariant(v string) {
+	x.xxx_hidden_Variant = v
+}
+
+func (x *VariantClicks) SetClicks(v int64) {
+	x.xxx_hidden_Clicks = v
+}
+
+func (x *VariantClicks) SetUniqueVisitors(v int64) {
+	x.xxx_hidden_UniqueVisitors = v
+}
+
+type VariantClicks_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Variant        string
+	Clicks         int64
+	UniqueVisitors int64
+}
+
+func (b0 VariantClicks_builder) Build() *VariantClicks {
+	m0 := &VariantClicks{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Variant = b.Variant
+	x.xxx_hidden_Clicks = b.Clicks
+	x.xxx_hidden_UniqueVisitors = b.UniqueVisitors
+	return m0
+}
+
+// Статистика переходов по ссылке
+type URLStatsResponse struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TotalClicks    int64                  `protobuf:"varint,1,opt,name=total_clicks,json=totalClicks"`
+	xxx_hidden_UniqueVisitors int64                  `protobuf:"varint,2,opt,name=unique_visitors,json=uniqueVisitors"`
+	xxx_hidden_Daily          *[]*DailyClicks        `protobuf:"bytes,3,rep,name=daily"`
+	xxx_hidden_StatusCode     int32                  `protobuf:"varint,4,opt,name=status_code,json=statusCode"`
+	xxx_hidden_Error          *string                `protobuf:"bytes,5,opt,name=error"`
+	xxx_hidden_Variants       *[]*VariantClicks      `protobuf:"bytes,6,rep,name=variants"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *URLStatsResponse) Reset() {
+	*x = URLStatsResponse{}
+	mi := &file_api_proto_shortener_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLStatsResponse) ProtoMessage() {}
+
+func (x *URLStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLStatsResponse) GetTotalClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalClicks
+	}
+	return 0
+}
+
+func (x *URLStatsResponse) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.xxx_hidden_UniqueVisitors
+	}
+	return 0
+}
+
+func (x *URLStatsResponse) GetDaily() []*DailyClicks {
+	if x != nil {
+		if x.xxx_hidden_Daily != nil {
+			return *x.xxx_hidden_Daily
+		}
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_StatusCode
+	}
+	return 0
+}
+
+func (x *URLStatsResponse) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLStatsResponse) GetVariants() []*VariantClicks {
+	if x != nil {
+		if x.xxx_hidden_Variants != nil {
+			return *x.xxx_hidden_Variants
+		}
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) SetTotalClicks(v int64) {
+	x.xxx_hidden_TotalClicks = v
+}
+
+func (x *URLStatsResponse) SetUniqueVisitors(v int64) {
+	x.xxx_hidden_UniqueVisitors = v
+}
+
+func (x *URLStatsResponse) SetDaily(v []*DailyClicks) {
+	x.xxx_hidden_Daily = &v
+}
+
+func (x *URLStatsResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
+}
+
+func (x *URLStatsResponse) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *URLStatsResponse) SetVariants(v []*VariantClicks) {
+	x.xxx_hidden_Variants = &v
+}
+
+func (x *URLStatsResponse) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *URLStatsResponse) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Error = nil
+}
+
+type URLStatsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TotalClicks    int64
+	UniqueVisitors int64
+	Daily          []*DailyClicks
+	StatusCode     int32
+	Error          *string
+	Variants       []*VariantClicks
+}
+
+func (b0 URLStatsResponse_builder) Build() *URLStatsResponse {
+	m0 := &URLStatsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_TotalClicks = b.TotalClicks
+	x.xxx_hidden_UniqueVisitors = b.UniqueVisitors
+	x.xxx_hidden_Daily = &b.Daily
+	x.xxx_hidden_StatusCode = b.StatusCode
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Error = b.Error
+	}
+	x.xxx_hidden_Variants = &b.Variants
 	return m0
 }
 
@@ -536,58 +2989,247 @@ var File_api_proto_shortener_proto protoreflect.FileDescriptor
 
 const file_api_proto_shortener_proto_rawDesc = "" +
 	"\n" +
-	"\x19api/proto/shortener.proto\x12\tshortener\x1a\x1bgoogle/protobuf/empty.proto\"%\n" +
+	"\x19api/proto/shortener.proto\x12\tshortener\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd9\x03\n" +
 	"\x11URLShortenRequest\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url\"j\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x03R\n" +
+	"ttlSeconds\x12\x1d\n" +
+	"\n" +
+	"max_clicks\x18\x05 \x01(\x03R\tmaxClicks\x12\x1a\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\x12\x14\n" +
+	"\x05title\x18\a \x01(\tR\x05title\x12\"\n" +
+	"\finterstitial\x18\b \x01(\bR\finterstitial\x12#\n" +
+	"\rredirect_code\x18\t \x01(\x05R\fredirectCode\x12+\n" +
+	"\x11query_passthrough\x18\n" +
+	" \x01(\tR\x10queryPassthrough\x12\x16\n" +
+	"\x06prefix\x18\v \x01(\bR\x06prefix\x12,\n" +
+	"\x05rules\x18\f \x03(\v2\x16.shortener.RoutingRuleR\x05rules\x123\n" +
+	"\bvariants\x18\r \x03(\v2\x17.shortener.SplitVariantR\bvariants\"{\n" +
+	"\vRoutingRule\x12\x16\n" +
+	"\x06device\x18\x01 \x01(\tR\x06device\x12\x1c\n" +
+	"\tlanguages\x18\x02 \x03(\tR\tlanguages\x12\x14\n" +
+	"\x05cidrs\x18\x03 \x03(\tR\x05cidrs\x12 \n" +
+	"\vdestination\x18\x04 \x01(\tR\vdestination\"<\n" +
+	"\fRoutingRules\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.shortener.RoutingRuleR\x05items\"\\\n" +
+	"\fSplitVariant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\">\n" +
+	"\rSplitVariants\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.shortener.SplitVariantR\x05items\"j\n" +
 	"\x12URLShortenResponse\x12\x16\n" +
 	"\x06result\x18\x01 \x01(\tR\x06result\x12\x1f\n" +
 	"\vstatus_code\x18\x02 \x01(\x05R\n" +
 	"statusCode\x12\x1b\n" +
-	"\x05error\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\"\"\n" +
+	"\x05error\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\"\x81\x02\n" +
 	"\x10URLExpandRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"i\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x18\n" +
+	"\aconfirm\x18\x03 \x01(\bR\aconfirm\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x06 \x01(\tR\tuserAgent\x12'\n" +
+	"\x0faccept_language\x18\a \x01(\tR\x0eacceptLanguage\x12\x1b\n" +
+	"\tclient_ip\x18\b \x01(\tR\bclientIp\x12\x18\n" +
+	"\avariant\x18\t \x01(\tR\avariant\"\x83\x01\n" +
 	"\x11URLExpandResponse\x12\x16\n" +
 	"\x06result\x18\x01 \x01(\tR\x06result\x12\x1f\n" +
 	"\vstatus_code\x18\x02 \x01(\x05R\n" +
 	"statusCode\x12\x1b\n" +
-	"\x05error\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\"v\n" +
+	"\x05error\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\x12\x18\n" +
+	"\avariant\x18\x04 \x01(\tR\avariant\"v\n" +
 	"\x10UserURLsResponse\x12$\n" +
 	"\x03url\x18\x01 \x03(\v2\x12.shortener.URLDataR\x03url\x12\x1f\n" +
 	"\vstatus_code\x18\x02 \x01(\x05R\n" +
 	"statusCode\x12\x1b\n" +
-	"\x05error\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\"I\n" +
+	"\x05error\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\"\xf4\x04\n" +
 	"\aURLData\x12\x1b\n" +
 	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
-	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl2\xea\x01\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12$\n" +
+	"\n" +
+	"max_clicks\x18\x04 \x01(\x03B\x05\xaa\x01\x02\b\x01R\tmaxClicks\x12&\n" +
+	"\vclicks_left\x18\x05 \x01(\x03B\x05\xaa\x01\x02\b\x01R\n" +
+	"clicksLeft\x12-\n" +
+	"\x12password_protected\x18\x06 \x01(\bR\x11passwordProtected\x12\x1a\n" +
+	"\bdisabled\x18\a \x01(\bR\bdisabled\x12\x16\n" +
+	"\x06clicks\x18\b \x01(\x03R\x06clicks\x12\x14\n" +
+	"\x05title\x18\t \x01(\tR\x05title\x12+\n" +
+	"\x11show_interstitial\x18\n" +
+	" \x01(\bR\x10showInterstitial\x12#\n" +
+	"\rredirect_code\x18\v \x01(\x05R\fredirectCode\x12+\n" +
+	"\x11query_passthrough\x18\f \x01(\tR\x10queryPassthrough\x12\x16\n" +
+	"\x06prefix\x18\r \x01(\bR\x06prefix\x12,\n" +
+	"\x05rules\x18\x0e \x03(\v2\x16.shortener.RoutingRuleR\x05rules\x123\n" +
+	"\bvariants\x18\x0f \x03(\v2\x17.shortener.SplitVariantR\bvariants\x12-\n" +
+	"\x06health\x18\x10 \x01(\v2\x15.shortener.LinkHealthR\x06health\"\xd8\x01\n" +
+	"\n" +
+	"LinkHealth\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12%\n" +
+	"\x0eredirect_chain\x18\x03 \x03(\tR\rredirectChain\x121\n" +
+	"\x14consecutive_failures\x18\x04 \x01(\x05R\x13consecutiveFailures\x129\n" +
+	"\n" +
+	"checked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\"\x88\x03\n" +
+	"\x10URLUpdateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x03url\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x01R\x03url\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x03R\n" +
+	"ttlSeconds\x12#\n" +
+	"\rno_expiration\x18\x05 \x01(\bR\fnoExpiration\x12\x1d\n" +
+	"\x06active\x18\x06 \x01(\bB\x05\xaa\x01\x02\b\x01R\x06active\x12\x1b\n" +
+	"\x05title\x18\a \x01(\tB\x05\xaa\x01\x02\b\x01R\x05title\x12)\n" +
+	"\finterstitial\x18\b \x01(\bB\x05\xaa\x01\x02\b\x01R\finterstitial\x12-\n" +
+	"\x05rules\x18\t \x01(\v2\x17.shortener.RoutingRulesR\x05rules\x124\n" +
+	"\bvariants\x18\n" +
+	" \x01(\v2\x18.shortener.SplitVariantsR\bvariants\"w\n" +
+	"\x11URLUpdateResponse\x12$\n" +
+	"\x03url\x18\x01 \x01(\v2\x12.shortener.URLDataR\x03url\x12\x1f\n" +
+	"\vstatus_code\x18\x02 \x01(\x05R\n" +
+	"statusCode\x12\x1b\n" +
+	"\x05error\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\"%\n" +
+	"\x11URLRestoreRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"R\n" +
+	"\x12URLRestoreResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\x05R\n" +
+	"statusCode\x12\x1b\n" +
+	"\x05error\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\"\"\n" +
+	"\x10URLDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Q\n" +
+	"\x11URLDeleteResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\x05R\n" +
+	"statusCode\x12\x1b\n" +
+	"\x05error\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\"\"\n" +
+	"\x10DeleteJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
+	"\x0fDeleteJobResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aoutcome\x18\x02 \x01(\tR\aoutcome\"\xc9\x02\n" +
+	"\x11DeleteJobResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x124\n" +
+	"\aresults\x18\x03 \x03(\v2\x1a.shortener.DeleteJobResultR\aresults\x12\"\n" +
+	"\tjob_error\x18\x04 \x01(\tB\x05\xaa\x01\x02\b\x01R\bjobError\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1f\n" +
+	"\vstatus_code\x18\a \x01(\x05R\n" +
+	"statusCode\x12\x1b\n" +
+	"\x05error\x18\b \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\"5\n" +
+	"\x0fURLStatsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"b\n" +
+	"\vDailyClicks\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\x12'\n" +
+	"\x0funique_visitors\x18\x03 \x01(\x03R\x0euniqueVisitors\"j\n" +
+	"\rVariantClicks\x12\x18\n" +
+	"\avariant\x18\x01 \x01(\tR\avariant\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\x12'\n" +
+	"\x0funique_visitors\x18\x03 \x01(\x03R\x0euniqueVisitors\"\x80\x02\n" +
+	"\x10URLStatsResponse\x12!\n" +
+	"\ftotal_clicks\x18\x01 \x01(\x03R\vtotalClicks\x12'\n" +
+	"\x0funique_visitors\x18\x02 \x01(\x03R\x0euniqueVisitors\x12,\n" +
+	"\x05daily\x18\x03 \x03(\v2\x16.shortener.DailyClicksR\x05daily\x12\x1f\n" +
+	"\vstatus_code\x18\x04 \x01(\x05R\n" +
+	"statusCode\x12\x1b\n" +
+	"\x05error\x18\x05 \x01(\tB\x05\xaa\x01\x02\b\x01R\x05error\x124\n" +
+	"\bvariants\x18\x06 \x03(\v2\x18.shortener.VariantClicksR\bvariants2\xa1\x05\n" +
 	"\x10ShortenerService\x12I\n" +
 	"\n" +
 	"ShortenURL\x12\x1c.shortener.URLShortenRequest\x1a\x1d.shortener.URLShortenResponse\x12F\n" +
 	"\tExpandURL\x12\x1b.shortener.URLExpandRequest\x1a\x1c.shortener.URLExpandResponse\x12C\n" +
-	"\fListUserURLs\x12\x16.google.protobuf.Empty\x1a\x1b.shortener.UserURLsResponseB2Z+yp-go-short-url-service/api/proto/shortener\x92\x03\x02\b\x02b\beditionsp\xe9\a"
-
-var file_api_proto_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+	"\fListUserURLs\x12\x16.google.protobuf.Empty\x1a\x1b.shortener.UserURLsResponse\x12F\n" +
+	"\tUpdateURL\x12\x1b.shortener.URLUpdateRequest\x1a\x1c.shortener.URLUpdateResponse\x12F\n" +
+	"\x0fListDeletedURLs\x12\x16.google.protobuf.Empty\x1a\x1b.shortener.UserURLsResponse\x12J\n" +
+	"\vRestoreURLs\x12\x1c.shortener.URLRestoreRequest\x1a\x1d.shortener.URLRestoreResponse\x12F\n" +
+	"\tDeleteURL\x12\x1b.shortener.URLDeleteRequest\x1a\x1c.shortener.URLDeleteResponse\x12I\n" +
+	"\fGetDeleteJob\x12\x1b.shortener.DeleteJobRequest\x1a\x1c.shortener.DeleteJobResponse\x12F\n" +
+	"\vGetURLStats\x12\x1a.shortener.URLStatsRequest\x1a\x1b.shortener.URLStatsResponseB2Z+yp-go-short-url-service/api/proto/shortener\x92\x03\x02\b\x02b\beditionsp\xe9\a"
+
+var file_api_proto_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_api_proto_shortener_proto_goTypes = []any{
-	(*URLShortenRequest)(nil),  // 0: shortener.URLShortenRequest
-	(*URLShortenResponse)(nil), // 1: shortener.URLShortenResponse
-	(*URLExpandRequest)(nil),   // 2: shortener.URLExpandRequest
-	(*URLExpandResponse)(nil),  // 3: shortener.URLExpandResponse
-	(*UserURLsResponse)(nil),   // 4: shortener.UserURLsResponse
-	(*URLData)(nil),            // 5: shortener.URLData
-	(*emptypb.Empty)(nil),      // 6: google.protobuf.Empty
+	(*URLShortenRequest)(nil),     // 0: shortener.URLShortenRequest
+	(*RoutingRule)(nil),           // 1: shortener.RoutingRule
+	(*RoutingRules)(nil),          // 2: shortener.RoutingRules
+	(*SplitVariant)(nil),          // 3: shortener.SplitVariant
+	(*SplitVariants)(nil),         // 4: shortener.SplitVariants
+	(*URLShortenResponse)(nil),    // 5: shortener.URLShortenResponse
+	(*URLExpandRequest)(nil),      // 6: shortener.URLExpandRequest
+	(*URLExpandResponse)(nil),     // 7: shortener.URLExpandResponse
+	(*UserURLsResponse)(nil),      // 8: shortener.UserURLsResponse
+	(*URLData)(nil),               // 9: shortener.URLData
+	(*LinkHealth)(nil),            // 10: shortener.LinkHealth
+	(*URLUpdateRequest)(nil),      // 11: shortener.URLUpdateRequest
+	(*URLUpdateResponse)(nil),     // 12: shortener.URLUpdateResponse
+	(*URLRestoreRequest)(nil),     // 13: shortener.URLRestoreRequest
+	(*URLRestoreResponse)(nil),    // 14: shortener.URLRestoreResponse
+	(*URLDeleteRequest)(nil),      // 15: shortener.URLDeleteRequest
+	(*URLDeleteResponse)(nil),     // 16: shortener.URLDeleteResponse
+	(*DeleteJobRequest)(nil),      // 17: shortener.DeleteJobRequest
+	(*DeleteJobResult)(nil),       // 18: shortener.DeleteJobResult
+	(*DeleteJobResponse)(nil),     // 19: shortener.DeleteJobResponse
+	(*URLStatsRequest)(nil),       // 20: shortener.URLStatsRequest
+	(*DailyClicks)(nil),           // 21: shortener.DailyClicks
+	(*VariantClicks)(nil),         // 22: shortener.VariantClicks
+	(*URLStatsResponse)(nil),      // 23: shortener.URLStatsResponse
+	(*timestamppb.Timestamp)(nil), // 24: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 25: google.protobuf.Empty
 }
 var file_api_proto_shortener_proto_depIdxs = []int32{
-	5, // 0: shortener.UserURLsResponse.url:type_name -> shortener.URLData
-	0, // 1: shortener.ShortenerService.ShortenURL:input_type -> shortener.URLShortenRequest
-	2, // 2: shortener.ShortenerService.ExpandURL:input_type -> shortener.URLExpandRequest
-	6, // 3: shortener.ShortenerService.ListUserURLs:input_type -> google.protobuf.Empty
-	1, // 4: shortener.ShortenerService.ShortenURL:output_type -> shortener.URLShortenResponse
-	3, // 5: shortener.ShortenerService.ExpandURL:output_type -> shortener.URLExpandResponse
-	4, // 6: shortener.ShortenerService.ListUserURLs:output_type -> shortener.UserURLsResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	24, // 0: shortener.URLShortenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: shortener.URLShortenRequest.rules:type_name -> shortener.RoutingRule
+	3,  // 2: shortener.URLShortenRequest.variants:type_name -> shortener.SplitVariant
+	1,  // 3: shortener.RoutingRules.items:type_name -> shortener.RoutingRule
+	3,  // 4: shortener.SplitVariants.items:type_name -> shortener.SplitVariant
+	9,  // 5: shortener.UserURLsResponse.url:type_name -> shortener.URLData
+	24, // 6: shortener.URLData.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 7: shortener.URLData.rules:type_name -> shortener.RoutingRule
+	3,  // 8: shortener.URLData.variants:type_name -> shortener.SplitVariant
+	10, // 9: shortener.URLData.health:type_name -> shortener.LinkHealth
+	24, // 10: shortener.LinkHealth.checked_at:type_name -> google.protobuf.Timestamp
+	24, // 11: shortener.URLUpdateRequest.expires_at:type_name -> google.protobuf.Timestamp
+	2,  // 12: shortener.URLUpdateRequest.rules:type_name -> shortener.RoutingRules
+	4,  // 13: shortener.URLUpdateRequest.variants:type_name -> shortener.SplitVariants
+	9,  // 14: shortener.URLUpdateResponse.url:type_name -> shortener.URLData
+	18, // 15: shortener.DeleteJobResponse.results:type_name -> shortener.DeleteJobResult
+	24, // 16: shortener.DeleteJobResponse.created_at:type_name -> google.protobuf.Timestamp
+	24, // 17: shortener.DeleteJobResponse.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 18: shortener.URLStatsResponse.daily:type_name -> shortener.DailyClicks
+	22, // 19: shortener.URLStatsResponse.variants:type_name -> shortener.VariantClicks
+	0,  // 20: shortener.ShortenerService.ShortenURL:input_type -> shortener.URLShortenRequest
+	6,  // 21: shortener.ShortenerService.ExpandURL:input_type -> shortener.URLExpandRequest
+	25, // 22: shortener.ShortenerService.ListUserURLs:input_type -> google.protobuf.Empty
+	11, // 23: shortener.ShortenerService.UpdateURL:input_type -> shortener.URLUpdateRequest
+	25, // 24: shortener.ShortenerService.ListDeletedURLs:input_type -> google.protobuf.Empty
+	13, // 25: shortener.ShortenerService.RestoreURLs:input_type -> shortener.URLRestoreRequest
+	15, // 26: shortener.ShortenerService.DeleteURL:input_type -> shortener.URLDeleteRequest
+	17, // 27: shortener.ShortenerService.GetDeleteJob:input_type -> shortener.DeleteJobRequest
+	20, // 28: shortener.ShortenerService.GetURLStats:input_type -> shortener.URLStatsRequest
+	5,  // 29: shortener.ShortenerService.ShortenURL:output_type -> shortener.URLShortenResponse
+	7,  // 30: shortener.ShortenerService.ExpandURL:output_type -> shortener.URLExpandResponse
+	8,  // 31: shortener.ShortenerService.ListUserURLs:output_type -> shortener.UserURLsResponse
+	12, // 32: shortener.ShortenerService.UpdateURL:output_type -> shortener.URLUpdateResponse
+	8,  // 33: shortener.ShortenerService.ListDeletedURLs:output_type -> shortener.UserURLsResponse
+	14, // 34: shortener.ShortenerService.RestoreURLs:output_type -> shortener.URLRestoreResponse
+	16, // 35: shortener.ShortenerService.DeleteURL:output_type -> shortener.URLDeleteResponse
+	19, // 36: shortener.ShortenerService.GetDeleteJob:output_type -> shortener.DeleteJobResponse
+	23, // 37: shortener.ShortenerService.GetURLStats:output_type -> shortener.URLStatsResponse
+	29, // [29:38] is the sub-list for method output_type
+	20, // [20:29] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_shortener_proto_init() }
@@ -601,7 +3243,7 @@ func file_api_proto_shortener_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_shortener_proto_rawDesc), len(file_api_proto_shortener_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
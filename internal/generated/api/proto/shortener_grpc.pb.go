@@ -20,9 +20,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShortenerService_ShortenURL_FullMethodName   = "/shortener.ShortenerService/ShortenURL"
-	ShortenerService_ExpandURL_FullMethodName    = "/shortener.ShortenerService/ExpandURL"
-	ShortenerService_ListUserURLs_FullMethodName = "/shortener.ShortenerService/ListUserURLs"
+	ShortenerService_ShortenURL_FullMethodName      = "/shortener.ShortenerService/ShortenURL"
+	ShortenerService_ExpandURL_FullMethodName       = "/shortener.ShortenerService/ExpandURL"
+	ShortenerService_ListUserURLs_FullMethodName    = "/shortener.ShortenerService/ListUserURLs"
+	ShortenerService_UpdateURL_FullMethodName       = "/shortener.ShortenerService/UpdateURL"
+	ShortenerService_ListDeletedURLs_FullMethodName = "/shortener.ShortenerService/ListDeletedURLs"
+	ShortenerService_RestoreURLs_FullMethodName     = "/shortener.ShortenerService/RestoreURLs"
+	ShortenerService_DeleteURL_FullMethodName       = "/shortener.ShortenerService/DeleteURL"
+	ShortenerService_GetDeleteJob_FullMethodName    = "/shortener.ShortenerService/GetDeleteJob"
+	ShortenerService_GetURLStats_FullMethodName     = "/shortener.ShortenerService/GetURLStats"
 )
 
 // ShortenerServiceClient is the client API for ShortenerService service.
@@ -37,6 +43,18 @@ type ShortenerServiceClient interface {
 	ExpandURL(ctx context.Context, in *URLExpandRequest, opts ...grpc.CallOption) (*URLExpandResponse, error)
 	// Получить все URL пользователя
 	ListUserURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UserURLsResponse, error)
+	// Изменить ссылку пользователя (только для владельца)
+	UpdateURL(ctx context.Context, in *URLUpdateRequest, opts ...grpc.CallOption) (*URLUpdateResponse, error)
+	// Получить удаленные URL пользователя (корзину)
+	ListDeletedURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UserURLsResponse, error)
+	// Восстановить удаленные URL пользователя
+	RestoreURLs(ctx context.Context, in *URLRestoreRequest, opts ...grpc.CallOption) (*URLRestoreResponse, error)
+	// Удалить одну ссылку пользователя
+	DeleteURL(ctx context.Context, in *URLDeleteRequest, opts ...grpc.CallOption) (*URLDeleteResponse, error)
+	// Получить статус задачи пакетного удаления
+	GetDeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error)
+	// Получить статистику переходов по ссылке (только для владельца)
+	GetURLStats(ctx context.Context, in *URLStatsRequest, opts ...grpc.CallOption) (*URLStatsResponse, error)
 }
 
 type shortenerServiceClient struct {
@@ -77,6 +95,66 @@ func (c *shortenerServiceClient) ListUserURLs(ctx context.Context, in *emptypb.E
 	return out, nil
 }
 
+func (c *shortenerServiceClient) UpdateURL(ctx context.Context, in *URLUpdateRequest, opts ...grpc.CallOption) (*URLUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(URLUpdateResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_UpdateURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ListDeletedURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UserURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserURLsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_ListDeletedURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) RestoreURLs(ctx context.Context, in *URLRestoreRequest, opts ...grpc.CallOption) (*URLRestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(URLRestoreResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_RestoreURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) DeleteURL(ctx context.Context, in *URLDeleteRequest, opts ...grpc.CallOption) (*URLDeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(URLDeleteResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_DeleteURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) GetDeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteJobResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetDeleteJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) GetURLStats(ctx context.Context, in *URLStatsRequest, opts ...grpc.CallOption) (*URLStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(URLStatsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetURLStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortenerServiceServer is the server API for ShortenerService service.
 // All implementations must embed UnimplementedShortenerServiceServer
 // for forward compatibility.
@@ -89,6 +167,18 @@ type ShortenerServiceServer interface {
 	ExpandURL(context.Context, *URLExpandRequest) (*URLExpandResponse, error)
 	// Получить все URL пользователя
 	ListUserURLs(context.Context, *emptypb.Empty) (*UserURLsResponse, error)
+	// Изменить ссылку пользователя (только для владельца)
+	UpdateURL(context.Context, *URLUpdateRequest) (*URLUpdateResponse, error)
+	// Получить удаленные URL пользователя (корзину)
+	ListDeletedURLs(context.Context, *emptypb.Empty) (*UserURLsResponse, error)
+	// Восстановить удаленные URL пользователя
+	RestoreURLs(context.Context, *URLRestoreRequest) (*URLRestoreResponse, error)
+	// Удалить одну ссылку пользователя
+	DeleteURL(context.Context, *URLDeleteRequest) (*URLDeleteResponse, error)
+	// Получить статус задачи пакетного удаления
+	GetDeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error)
+	// Получить статистику переходов по ссылке (только для владельца)
+	GetURLStats(context.Context, *URLStatsRequest) (*URLStatsResponse, error)
 	mustEmbedUnimplementedShortenerServiceServer()
 }
 
@@ -108,6 +198,24 @@ func (UnimplementedShortenerServiceServer) ExpandURL(context.Context, *URLExpand
 func (UnimplementedShortenerServiceServer) ListUserURLs(context.Context, *emptypb.Empty) (*UserURLsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListUserURLs not implemented")
 }
+func (UnimplementedShortenerServiceServer) UpdateURL(context.Context, *URLUpdateRequest) (*URLUpdateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateURL not implemented")
+}
+func (UnimplementedShortenerServiceServer) ListDeletedURLs(context.Context, *emptypb.Empty) (*UserURLsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeletedURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) RestoreURLs(context.Context, *URLRestoreRequest) (*URLRestoreResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) DeleteURL(context.Context, *URLDeleteRequest) (*URLDeleteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteURL not implemented")
+}
+func (UnimplementedShortenerServiceServer) GetDeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeleteJob not implemented")
+}
+func (UnimplementedShortenerServiceServer) GetURLStats(context.Context, *URLStatsRequest) (*URLStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetURLStats not implemented")
+}
 func (UnimplementedShortenerServiceServer) mustEmbedUnimplementedShortenerServiceServer() {}
 func (UnimplementedShortenerServiceServer) testEmbeddedByValue()                          {}
 
@@ -183,6 +291,114 @@ func _ShortenerService_ListUserURLs_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortenerService_UpdateURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).UpdateURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_UpdateURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).UpdateURL(ctx, req.(*URLUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ListDeletedURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).ListDeletedURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_ListDeletedURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).ListDeletedURLs(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_RestoreURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).RestoreURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_RestoreURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).RestoreURLs(ctx, req.(*URLRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_DeleteURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).DeleteURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_DeleteURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).DeleteURL(ctx, req.(*URLDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_GetDeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetDeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetDeleteJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetDeleteJob(ctx, req.(*DeleteJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_GetURLStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetURLStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetURLStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetURLStats(ctx, req.(*URLStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShortenerService_ServiceDesc is the grpc.ServiceDesc for ShortenerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -202,6 +418,30 @@ var ShortenerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUserURLs",
 			Handler:    _ShortenerService_ListUserURLs_Handler,
 		},
+		{
+			MethodName: "UpdateURL",
+			Handler:    _ShortenerService_UpdateURL_Handler,
+		},
+		{
+			MethodName: "ListDeletedURLs",
+			Handler:    _ShortenerService_ListDeletedURLs_Handler,
+		},
+		{
+			MethodName: "RestoreURLs",
+			Handler:    _ShortenerService_RestoreURLs_Handler,
+		},
+		{
+			MethodName: "DeleteURL",
+			Handler:    _ShortenerService_DeleteURL_Handler,
+		},
+		{
+			MethodName: "GetDeleteJob",
+			Handler:    _ShortenerService_GetDeleteJob_Handler,
+		},
+		{
+			MethodName: "GetURLStats",
+			Handler:    _ShortenerService_GetURLStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/shortener.proto",
@@ -12,20 +12,29 @@ type RPCService struct {
 }
 
 type dependencies struct {
-	shortenerService service.URLShortenerService
-	extractorService service.URLExtractorService
-	baseURL          string
+	shortenerService  service.URLShortenerService
+	extractorService  service.URLExtractorService
+	editorService     service.URLEditorService
+	destructorService service.URLDestructorService
+	statsService      service.URLStatsService
+	baseURL           string
 }
 
 func NewRPCService(
 	shortenerService service.URLShortenerService,
 	extractorService service.URLExtractorService,
+	editorService service.URLEditorService,
+	destructorService service.URLDestructorService,
+	statsService service.URLStatsService,
 	settings *config.Settings,
 ) *RPCService {
 	deps := dependencies{
-		shortenerService: shortenerService,
-		extractorService: extractorService,
-		baseURL:          settings.GetBaseURL(),
+		shortenerService:  shortenerService,
+		extractorService:  extractorService,
+		editorService:     editorService,
+		destructorService: destructorService,
+		statsService:      statsService,
+		baseURL:           settings.GetBaseURL(),
 	}
 	return &RPCService{
 		deps: deps,
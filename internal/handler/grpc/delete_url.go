@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RPCService) DeleteURL(
+	ctx context.Context,
+	req *pb.URLDeleteRequest,
+) (*pb.URLDeleteResponse, error) {
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return pb.URLDeleteResponse_builder{
+			StatusCode: http.StatusUnauthorized,
+			Error:      &[]string{"user not found"}[0],
+		}.Build(), status.Error(codes.Unauthenticated, "user not found")
+	}
+
+	shortURL := strings.TrimSpace(req.GetId())
+	if shortURL == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	err := s.deps.destructorService.DeleteURL(ctx, shortURL)
+	if err != nil {
+		switch {
+		case service.IsNotFoundError(err):
+			return pb.URLDeleteResponse_builder{
+				StatusCode: http.StatusNotFound,
+				Error:      &[]string{"Ссылка не найдена"}[0],
+			}.Build(), nil
+		case service.IsNotOwnedError(err):
+			return pb.URLDeleteResponse_builder{
+				StatusCode: http.StatusForbidden,
+				Error:      &[]string{"Ссылка принадлежит другому пользователю"}[0],
+			}.Build(), nil
+		case service.IsDeletedError(err):
+			return pb.URLDeleteResponse_builder{
+				StatusCode: http.StatusGone,
+				Error:      &[]string{"Ссылка уже удалена"}[0],
+			}.Build(), nil
+		}
+		return pb.URLDeleteResponse_builder{
+			StatusCode: http.StatusInternalServerError,
+			Error:      &[]string{"failed to delete URL"}[0],
+		}.Build(), status.Error(codes.Internal, err.Error())
+	}
+
+	return pb.URLDeleteResponse_builder{
+		StatusCode: http.StatusOK,
+	}.Build(), nil
+}
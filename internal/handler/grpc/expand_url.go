@@ -1,9 +1,13 @@
 package grpc
 
 import (
+	"cmp"
 	"context"
 	"net/http"
+	"net/url"
 	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/service"
 
 	"google.golang.org/grpc/codes"
@@ -18,21 +22,100 @@ func (s *RPCService) ExpandURL(
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
 
-	longURL, err := s.deps.extractorService.ExtractLongURL(ctx, req.GetId())
+	query, err := url.ParseQuery(req.GetQuery())
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "query must be a valid query string")
+	}
+	// Сведения о клиенте из запроса имеют приоритет: вызывающий сервис может передавать их от имени посетителя
+	client := middleware.GetClientInfo(ctx)
+	redirectReq := model.RedirectRequest{
+		Query:          query,
+		PathSuffix:     req.GetPath(),
+		UserAgent:      cmp.Or(req.GetUserAgent(), client.UserAgent),
+		AcceptLanguage: cmp.Or(req.GetAcceptLanguage(), client.AcceptLanguage),
+		ClientIP:       cmp.Or(req.GetClientIp(), client.IP),
+		Variant:        req.GetVariant(),
+	}
+
+	var redirect *model.Redirect
+	switch {
+	case req.GetPassword() != "":
+		redirect, err = s.deps.extractorService.UnlockLongURL(ctx, req.GetId(), req.GetPassword(), redirectReq)
+	case req.GetConfirm():
+		redirect, err = s.deps.extractorService.ConfirmLongURL(ctx, req.GetId(), redirectReq)
+	default:
+		redirect, err = s.deps.extractorService.ExtractLongURL(ctx, req.GetId(), redirectReq)
+	}
+	if err != nil {
+		if service.IsInterstitialRequiredError(err) && redirect != nil {
+			return pb.URLExpandResponse_builder{
+				Result:     redirect.Location,
+				StatusCode: http.StatusPreconditionRequired,
+				Error:      &[]string{"Перед переходом требуется подтверждение"}[0],
+				Variant:    redirect.Variant,
+			}.Build(), nil
+		}
+		if service.IsNotFoundError(err) {
+			return pb.URLExpandResponse_builder{
+				StatusCode: http.StatusNotFound,
+				Error:      &[]string{"Ссылка не найдена"}[0],
+			}.Build(), nil
+		}
+		if service.IsInvalidPathSuffixError(err) {
+			return pb.URLExpandResponse_builder{
+				StatusCode: http.StatusBadRequest,
+				Error:      &[]string{"Некорректный путь после короткого кода"}[0],
+			}.Build(), nil
+		}
 		if service.IsDeletedError(err) {
 			return pb.URLExpandResponse_builder{
 				StatusCode: http.StatusGone,
 				Error:      &[]string{"Ссылка была удалена"}[0],
 			}.Build(), nil
 		}
+		if service.IsDisabledError(err) {
+			return pb.URLExpandResponse_builder{
+				StatusCode: http.StatusGone,
+				Error:      &[]string{"Ссылка отключена владельцем"}[0],
+			}.Build(), nil
+		}
+		if service.IsExpiredError(err) {
+			return pb.URLExpandResponse_builder{
+				StatusCode: http.StatusGone,
+				Error:      &[]string{"Срок действия ссылки истек"}[0],
+			}.Build(), nil
+		}
+		if service.IsClickLimitReachedError(err) {
+			return pb.URLExpandResponse_builder{
+				StatusCode: http.StatusGone,
+				Error:      &[]string{"Лимит переходов по ссылке исчерпан"}[0],
+			}.Build(), nil
+		}
+		if service.IsPasswordRequiredError(err) {
+			return pb.URLExpandResponse_builder{
+				StatusCode: http.StatusUnauthorized,
+				Error:      &[]string{"Ссылка защищена паролем"}[0],
+			}.Build(), nil
+		}
+		if service.IsWrongPasswordError(err) {
+			return pb.URLExpandResponse_builder{
+				StatusCode: http.StatusUnauthorized,
+				Error:      &[]string{"Неверный пароль"}[0],
+			}.Build(), nil
+		}
+		if service.IsTooManyPasswordAttemptsError(err) {
+			return pb.URLExpandResponse_builder{
+				StatusCode: http.StatusTooManyRequests,
+				Error:      &[]string{"Превышено число попыток ввода пароля, попробуйте позже"}[0],
+			}.Build(), nil
+		}
 		return pb.URLExpandResponse_builder{
 			StatusCode: http.StatusInternalServerError,
 			Error:      &[]string{err.Error()}[0],
 		}.Build(), status.Error(codes.Internal, err.Error())
 	}
 
-	if longURL == "" {
+	if redirect == nil || redirect.Location == "" {
 		return pb.URLExpandResponse_builder{
 			StatusCode: http.StatusBadRequest,
 			Error:      &[]string{"Ссылка не найдена"}[0],
@@ -40,7 +123,8 @@ func (s *RPCService) ExpandURL(
 	}
 
 	return pb.URLExpandResponse_builder{
-		Result:     longURL,
-		StatusCode: http.StatusTemporaryRedirect,
+		Result:     redirect.Location,
+		StatusCode: int32(redirect.StatusCode),
+		Variant:    redirect.Variant,
 	}.Build(), nil
 }
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *RPCService) GetDeleteJob(
+	ctx context.Context,
+	req *pb.DeleteJobRequest,
+) (*pb.DeleteJobResponse, error) {
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return pb.DeleteJobResponse_builder{
+			StatusCode: http.StatusUnauthorized,
+			Error:      &[]string{"user not found"}[0],
+		}.Build(), status.Error(codes.Unauthenticated, "user not found")
+	}
+
+	jobID := strings.TrimSpace(req.GetId())
+	if jobID == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	job, err := s.deps.destructorService.GetDeleteJob(ctx, jobID)
+	if err != nil {
+		if service.IsDeleteJobNotFoundError(err) {
+			return pb.DeleteJobResponse_builder{
+				StatusCode: http.StatusNotFound,
+				Error:      &[]string{"Задача удаления не найдена"}[0],
+			}.Build(), nil
+		}
+		return pb.DeleteJobResponse_builder{
+			StatusCode: http.StatusInternalServerError,
+			Error:      &[]string{"failed to get delete job"}[0],
+		}.Build(), status.Error(codes.Internal, err.Error())
+	}
+
+	response := pb.DeleteJobResponse_builder{
+		Id:         job.ID,
+		Status:     string(job.Status),
+		Results:    buildDeleteJobResults(job),
+		CreatedAt:  timestamppb.New(job.CreatedAt),
+		UpdatedAt:  timestamppb.New(job.UpdatedAt),
+		StatusCode: http.StatusOK,
+	}
+	if job.Error != "" {
+		response.JobError = &job.Error
+	}
+
+	return response.Build(), nil
+}
+
+// buildDeleteJobResults возвращает результаты задачи в порядке кодов из исходного запроса.
+func buildDeleteJobResults(job *model.DeleteJob) []*pb.DeleteJobResult {
+	results := make([]*pb.DeleteJobResult, 0, len(job.Results))
+	seen := make(map[string]struct{}, len(job.Results))
+	for _, shortURL := range job.ShortURLs {
+		outcome, ok := job.Results[shortURL]
+		if !ok {
+			continue
+		}
+		if _, duplicate := seen[shortURL]; duplicate {
+			continue
+		}
+		seen[shortURL] = struct{}{}
+
+		results = append(results, pb.DeleteJobResult_builder{
+			Id:      shortURL,
+			Outcome: string(outcome),
+		}.Build())
+	}
+	return results
+}
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RPCService) GetURLStats(
+	ctx context.Context,
+	req *pb.URLStatsRequest,
+) (*pb.URLStatsResponse, error) {
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return pb.URLStatsResponse_builder{
+			StatusCode: http.StatusUnauthorized,
+			Error:      &[]string{"user not found"}[0],
+		}.Build(), status.Error(codes.Unauthenticated, "user not found")
+	}
+
+	shortURL := strings.TrimSpace(req.GetId())
+	if shortURL == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	stats, err := s.deps.statsService.GetURLStats(ctx, shortURL, int(req.GetDays()))
+	if err != nil {
+		switch {
+		case service.IsInvalidStatsPeriodError(err):
+			return pb.URLStatsResponse_builder{
+				StatusCode: http.StatusBadRequest,
+				Error:      &[]string{err.Error()}[0],
+			}.Build(), nil
+		case service.IsNotFoundError(err):
+			return pb.URLStatsResponse_builder{
+				StatusCode: http.StatusNotFound,
+				Error:      &[]string{"Ссылка не найдена"}[0],
+			}.Build(), nil
+		case service.IsNotOwnedError(err):
+			return pb.URLStatsResponse_builder{
+				StatusCode: http.StatusForbidden,
+				Error:      &[]string{"Ссылка принадлежит другому пользователю"}[0],
+			}.Build(), nil
+		}
+		return pb.URLStatsResponse_builder{
+			StatusCode: http.StatusInternalServerError,
+			Error:      &[]string{"failed to get URL stats"}[0],
+		}.Build(), status.Error(codes.Internal, err.Error())
+	}
+
+	daily := make([]*pb.DailyClicks, 0, len(stats.Daily))
+	for _, day := range stats.Daily {
+		daily = append(daily, pb.DailyClicks_builder{
+			Date:           day.Date.Format(time.DateOnly),
+			Clicks:         day.Clicks,
+			UniqueVisitors: day.UniqueVisitors,
+		}.Build())
+	}
+
+	variants := make([]*pb.VariantClicks, 0, len(stats.Variants))
+	for _, variant := range stats.Variants {
+		variants = append(variants, pb.VariantClicks_builder{
+			Variant:        variant.Variant,
+			Clicks:         variant.Clicks,
+			UniqueVisitors: variant.UniqueVisitors,
+		}.Build())
+	}
+
+	return pb.URLStatsResponse_builder{
+		TotalClicks:    stats.TotalClicks,
+		UniqueVisitors: stats.UniqueVisitors,
+		Daily:          daily,
+		Variants:       variants,
+		StatusCode:     http.StatusOK,
+	}.Build(), nil
+}
@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/middleware"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *RPCService) ListDeletedURLs(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.UserURLsResponse, error) {
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return pb.UserURLsResponse_builder{
+			StatusCode: http.StatusUnauthorized,
+			Error:      &[]string{"user not found"}[0],
+		}.Build(), status.Error(codes.Unauthenticated, "user not found")
+	}
+
+	deletedURLs, err := s.deps.extractorService.ExtractUserDeletedURLs(ctx, user.ID)
+	if err != nil {
+		return pb.UserURLsResponse_builder{
+			StatusCode: http.StatusInternalServerError,
+			Error:      &[]string{"failed to extract user deleted URLs"}[0],
+		}.Build(), status.Error(codes.Internal, err.Error())
+	}
+
+	if len(deletedURLs) == 0 {
+		return pb.UserURLsResponse_builder{
+			Url:        []*pb.URLData{},
+			StatusCode: http.StatusNoContent,
+		}.Build(), nil
+	}
+
+	urls := make([]*pb.URLData, len(deletedURLs))
+	for i, url := range deletedURLs {
+		urls[i] = s.buildURLData(url)
+	}
+
+	return pb.UserURLsResponse_builder{
+		Url:        urls,
+		StatusCode: http.StatusOK,
+	}.Build(), nil
+}
@@ -5,10 +5,12 @@ import (
 	"net/http"
 	pb "yp-go-short-url-service/internal/generated/api/proto"
 	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *RPCService) ListUserURLs(
@@ -41,10 +43,7 @@ func (s *RPCService) ListUserURLs(
 	// Преобразуем данные
 	urls := make([]*pb.URLData, len(userURLs))
 	for i, url := range userURLs {
-		urls[i] = pb.URLData_builder{
-			ShortUrl:    s.buildShortURL(url.ShortURL),
-			OriginalUrl: url.LongURL,
-		}.Build()
+		urls[i] = s.buildURLData(url)
 	}
 
 	return pb.UserURLsResponse_builder{
@@ -52,3 +51,36 @@ func (s *RPCService) ListUserURLs(
 		StatusCode: http.StatusOK,
 	}.Build(), nil
 }
+
+// buildURLData преобразует модель ссылки в ее представление для gRPC API.
+func (s *RPCService) buildURLData(url *model.URLsModel) *pb.URLData {
+	data := pb.URLData_builder{
+		ShortUrl:          s.buildShortURL(url.ShortURL),
+		OriginalUrl:       url.LongURL,
+		PasswordProtected: url.IsPasswordProtected(),
+		Disabled:          url.IsDisabled,
+		Clicks:            url.Clicks,
+		Title:             url.Title,
+		ShowInterstitial:  url.ShowInterstitial,
+		RedirectCode:      int32(url.RedirectStatus()),
+		QueryPassthrough:  string(url.QueryPassthrough),
+		Prefix:            url.IsPrefix,
+		Rules:             routingRulesToProto(url.RoutingRules),
+		Variants:          splitVariantsToProto(url.Variants),
+	}
+	if url.ExpiresAt != nil {
+		data.ExpiresAt = timestamppb.New(*url.ExpiresAt)
+	}
+	if url.Health != nil {
+		data.Health = pb.LinkHealth_builder{
+			StatusCode:          int32(url.Health.StatusCode),
+			Error:               url.Health.Error,
+			RedirectChain:       url.Health.RedirectChain,
+			ConsecutiveFailures: int32(url.Health.ConsecutiveFailures),
+			CheckedAt:           timestamppb.New(url.Health.CheckedAt),
+		}.Build()
+	}
+	data.MaxClicks = url.MaxClicks
+	data.ClicksLeft = url.ClicksLeft
+	return data.Build()
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/middleware"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RPCService) RestoreURLs(
+	ctx context.Context,
+	req *pb.URLRestoreRequest,
+) (*pb.URLRestoreResponse, error) {
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return pb.URLRestoreResponse_builder{
+			StatusCode: http.StatusUnauthorized,
+			Error:      &[]string{"user not found"}[0],
+		}.Build(), status.Error(codes.Unauthenticated, "user not found")
+	}
+
+	shortURLs := make([]string, 0, len(req.GetIds()))
+	for _, id := range req.GetIds() {
+		if id = strings.TrimSpace(id); id != "" {
+			shortURLs = append(shortURLs, id)
+		}
+	}
+	if len(shortURLs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "ids are required")
+	}
+
+	if err := s.deps.destructorService.RestoreURLsByBatch(ctx, shortURLs); err != nil {
+		return pb.URLRestoreResponse_builder{
+			StatusCode: http.StatusInternalServerError,
+			Error:      &[]string{"failed to restore URLs"}[0],
+		}.Build(), status.Error(codes.Internal, err.Error())
+	}
+
+	return pb.URLRestoreResponse_builder{
+		StatusCode: http.StatusAccepted,
+	}.Build(), nil
+}
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/model"
+)
+
+// routingRulesFromProto преобразует правила условного перенаправления из запроса gRPC API в модель.
+func routingRulesFromProto(rules []*pb.RoutingRule) model.RoutingRules {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make(model.RoutingRules, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, model.RoutingRule{
+			Device:      model.DeviceFamily(rule.GetDevice()),
+			Languages:   rule.GetLanguages(),
+			CIDRs:       rule.GetCidrs(),
+			Destination: rule.GetDestination(),
+		})
+	}
+	return result
+}
+
+// routingRulesToProto преобразует правила условного перенаправления ссылки в их представление для gRPC API.
+func routingRulesToProto(rules model.RoutingRules) []*pb.RoutingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]*pb.RoutingRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, pb.RoutingRule_builder{
+			Device:      string(rule.Device),
+			Languages:   rule.Languages,
+			Cidrs:       rule.CIDRs,
+			Destination: rule.Destination,
+		}.Build())
+	}
+	return result
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/service"
 
 	"google.golang.org/grpc/codes"
@@ -20,8 +22,44 @@ func (s *RPCService) ShortenURL(
 		return nil, status.Error(codes.InvalidArgument, "url is required")
 	}
 
-	shortURL, err := s.deps.shortenerService.ShortURL(ctx, req.GetUrl())
+	opts := service.ShortenOptions{
+		Alias:            strings.TrimSpace(req.GetAlias()),
+		TTL:              time.Duration(req.GetTtlSeconds()) * time.Second,
+		MaxClicks:        req.GetMaxClicks(),
+		Password:         req.GetPassword(),
+		Title:            req.GetTitle(),
+		ShowInterstitial: req.GetInterstitial(),
+		RedirectCode:     int(req.GetRedirectCode()),
+		QueryPassthrough: model.QueryPassthrough(req.GetQueryPassthrough()),
+		Prefix:           req.GetPrefix(),
+		RoutingRules:     routingRulesFromProto(req.GetRules()),
+		Variants:         splitVariantsFromProto(req.GetVariants()),
+	}
+	if req.HasExpiresAt() {
+		expiresAt := req.GetExpiresAt().AsTime()
+		opts.ExpiresAt = &expiresAt
+	}
+
+	shortURL, err := s.deps.shortenerService.ShortURLWithOptions(ctx, req.GetUrl(), opts)
 	if err != nil {
+		if service.IsInvalidAliasError(err) || service.IsInvalidExpirationError(err) ||
+			service.IsInvalidMaxClicksError(err) || service.IsInvalidPasswordError(err) ||
+			service.IsInvalidTitleError(err) || service.IsInvalidRedirectError(err) ||
+			service.IsInvalidRoutingRulesError(err) || service.IsInvalidVariantsError(err) ||
+			service.IsPolicyViolationError(err) {
+			return pb.URLShortenResponse_builder{
+				Result:     "",
+				StatusCode: http.StatusBadRequest,
+				Error:      &[]string{err.Error()}[0],
+			}.Build(), status.Error(codes.InvalidArgument, err.Error())
+		}
+		if service.IsAliasAlreadyExistsError(err) {
+			return pb.URLShortenResponse_builder{
+				Result:     "",
+				StatusCode: http.StatusConflict,
+				Error:      &[]string{err.Error()}[0],
+			}.Build(), status.Error(codes.AlreadyExists, err.Error())
+		}
 		if service.IsAlreadyExistsError(err) && shortURL != "" {
 			resultURL := s.buildShortURL(shortURL)
 			return pb.URLShortenResponse_builder{
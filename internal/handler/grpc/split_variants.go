@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/model"
+)
+
+// splitVariantsFromProto преобразует варианты адреса назначения из запроса gRPC API в модель.
+func splitVariantsFromProto(variants []*pb.SplitVariant) model.SplitVariants {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	result := make(model.SplitVariants, 0, len(variants))
+	for _, variant := range variants {
+		result = append(result, model.SplitVariant{
+			Name:        variant.GetName(),
+			Destination: variant.GetDestination(),
+			Weight:      int(variant.GetWeight()),
+		})
+	}
+	return result
+}
+
+// splitVariantsToProto преобразует варианты адреса назначения ссылки в их представление для gRPC API.
+func splitVariantsToProto(variants model.SplitVariants) []*pb.SplitVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	result := make([]*pb.SplitVariant, 0, len(variants))
+	for _, variant := range variants {
+		result = append(result, pb.SplitVariant_builder{
+			Name:        variant.Name,
+			Destination: variant.Destination,
+			Weight:      int32(variant.Weight),
+		}.Build())
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+	pb "yp-go-short-url-service/internal/generated/api/proto"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RPCService) UpdateURL(
+	ctx context.Context,
+	req *pb.URLUpdateRequest,
+) (*pb.URLUpdateResponse, error) {
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return pb.URLUpdateResponse_builder{
+			StatusCode: http.StatusUnauthorized,
+			Error:      &[]string{"user not found"}[0],
+		}.Build(), status.Error(codes.Unauthenticated, "user not found")
+	}
+
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	opts := service.UpdateOptions{
+		TTL:             time.Duration(req.GetTtlSeconds()) * time.Second,
+		ClearExpiration: req.GetNoExpiration(),
+	}
+	if req.HasUrl() {
+		longURL := req.GetUrl()
+		opts.LongURL = &longURL
+	}
+	if req.HasExpiresAt() {
+		expiresAt := req.GetExpiresAt().AsTime()
+		opts.ExpiresAt = &expiresAt
+	}
+	if req.HasActive() {
+		active := req.GetActive()
+		opts.Active = &active
+	}
+	if req.HasTitle() {
+		title := req.GetTitle()
+		opts.Title = &title
+	}
+	if req.HasInterstitial() {
+		interstitial := req.GetInterstitial()
+		opts.ShowInterstitial = &interstitial
+	}
+	if req.HasRules() {
+		rules := routingRulesFromProto(req.GetRules().GetItems())
+		opts.RoutingRules = &rules
+	}
+	if req.HasVariants() {
+		variants := splitVariantsFromProto(req.GetVariants().GetItems())
+		opts.Variants = &variants
+	}
+
+	url, err := s.deps.editorService.UpdateUserURL(ctx, req.GetId(), opts)
+	if err != nil {
+		if service.IsInvalidURLUpdateError(err) || service.IsInvalidExpirationError(err) ||
+			service.IsInvalidTitleError(err) || service.IsInvalidRoutingRulesError(err) ||
+			service.IsInvalidVariantsError(err) || service.IsPolicyViolationError(err) {
+			return pb.URLUpdateResponse_builder{
+				StatusCode: http.StatusBadRequest,
+				Error:      &[]string{err.Error()}[0],
+			}.Build(), nil
+		}
+		if service.IsNotFoundError(err) {
+			return pb.URLUpdateResponse_builder{
+				StatusCode: http.StatusNotFound,
+				Error:      &[]string{"Ссылка не найдена"}[0],
+			}.Build(), nil
+		}
+		return pb.URLUpdateResponse_builder{
+			StatusCode: http.StatusInternalServerError,
+			Error:      &[]string{err.Error()}[0],
+		}.Build(), status.Error(codes.Internal, err.Error())
+	}
+
+	return pb.URLUpdateResponse_builder{
+		Url:        s.buildURLData(url),
+		StatusCode: http.StatusOK,
+	}.Build(), nil
+}
@@ -1,7 +1,13 @@
 package stats
 
+import "yp-go-short-url-service/internal/model"
+
 // Response представляет структуру данных для ответа на запрос статистики.
+// Purged содержит количество ссылок, окончательно удаленных из корзины с момента запуска сервиса,
+// URLCache - счетчики кэша ссылок по короткому коду.
 type Response struct {
-	URLsCount  int64 `json:"urls"`
-	UsersCount int64 `json:"users"`
+	URLsCount  int64            `json:"urls"`
+	UsersCount int64            `json:"users"`
+	Purged     model.PurgeStats `json:"purged"`
+	URLCache   model.CacheStats `json:"url_cache"`
 }
@@ -42,6 +42,8 @@ func (h *handler) Handle(c *gin.Context) {
 	resp := Response{
 		URLsCount:  urlsCount,
 		UsersCount: usersCount,
+		Purged:     h.service.GetPurgeStats(c.Request.Context()),
+		URLCache:   h.service.GetURLCacheStats(c.Request.Context()),
 	}
 	logger.Infow("responding to request", "id", requestID, "response", resp)
 	c.JSON(http.StatusOK, resp)
@@ -0,0 +1,44 @@
+package analytics
+
+// URLStatsDTOOut представляет статистику переходов по короткой ссылке
+type URLStatsDTOOut struct {
+	// ShortURL - сокращенный URL
+	// example: "http://localhost:8080/abc123"
+	ShortURL string `json:"short_url"`
+	// TotalClicks - число переходов за все время
+	// example: 42
+	TotalClicks int64 `json:"total_clicks"`
+	// UniqueVisitors - число уникальных посетителей за все время (пар обезличенного IP и User-Agent)
+	// example: 17
+	UniqueVisitors int64 `json:"unique_visitors"`
+	// Daily - разбивка по дням (UTC) за запрошенный период, включая дни без переходов
+	Daily []DailyClicksDTOOut `json:"daily"`
+	// Variants - разбивка по вариантам адреса назначения за все время; отсутствует, если переходов по вариантам не было
+	Variants []VariantClicksDTOOut `json:"variants,omitempty"`
+}
+
+// DailyClicksDTOOut представляет статистику переходов за один день
+type DailyClicksDTOOut struct {
+	// Date - дата в формате YYYY-MM-DD (UTC)
+	// example: "2025-03-04"
+	Date string `json:"date"`
+	// Clicks - число переходов за день
+	// example: 5
+	Clicks int64 `json:"clicks"`
+	// UniqueVisitors - число уникальных посетителей за день
+	// example: 3
+	UniqueVisitors int64 `json:"unique_visitors"`
+}
+
+// VariantClicksDTOOut представляет статистику переходов по одному варианту адреса назначения
+type VariantClicksDTOOut struct {
+	// Variant - имя варианта
+	// example: "a"
+	Variant string `json:"variant"`
+	// Clicks - число переходов на вариант
+	// example: 30
+	Clicks int64 `json:"clicks"`
+	// UniqueVisitors - число уникальных посетителей варианта
+	// example: 12
+	UniqueVisitors int64 `json:"unique_visitors"`
+}
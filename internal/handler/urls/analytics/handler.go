@@ -0,0 +1,126 @@
+package analytics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/handler"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewURLStatsAPIHandler создает новый обработчик для получения статистики переходов по ссылке через API.
+// Принимает сервис статистики и настройки приложения, возвращает обработчик, реализующий интерфейс Handler.
+func NewURLStatsAPIHandler(service service.URLStatsService, settings *config.Settings) handler.Handler {
+	return &urlStatsAPIHandler{
+		service: service,
+		baseURL: settings.GetBaseURL(),
+	}
+}
+
+type urlStatsAPIHandler struct {
+	service service.URLStatsService
+	baseURL string
+}
+
+// Handle GetUserURLStats godoc
+// @Summary Получить статистику переходов по ссылке
+// @Description Возвращает общее число переходов и уникальных посетителей по короткой ссылке, а также разбивку по дням (UTC) за последние days дней. Доступно только владельцу ссылки, требует JWT аутентификации.
+// @Tags user
+// @Produce json
+// @Param shortURL path string true "Короткий URL" example(abc123)
+// @Param days query int false "Период разбивки по дням, от 1 до 365 (по умолчанию 30)" example(7)
+// @Success 200 {object} URLStatsDTOOut "Статистика переходов"
+// @Failure 400 {object} map[string]interface{} "Неверный запрос"
+// @Failure 401 {object} map[string]interface{} "Не авторизован"
+// @Failure 403 {object} map[string]interface{} "Ссылка принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Ссылка не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /api/user/urls/{shortURL}/stats [get]
+func (h *urlStatsAPIHandler) Handle(c *gin.Context) {
+	requestCtx := c.Request.Context()
+
+	logger := middleware.GetLogger(requestCtx)
+	requestID := middleware.ExtractRequestID(requestCtx)
+
+	user := middleware.GetJWTUserFromContext(requestCtx)
+	if user == nil {
+		logger.Errorw("User not found in context",
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	shortURL := strings.TrimSpace(c.Param("shortURL"))
+	if shortURL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "short url is required"})
+		return
+	}
+
+	var days int
+	if rawDays := c.Query("days"); rawDays != "" {
+		parsed, err := strconv.Atoi(rawDays)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a positive integer"})
+			return
+		}
+		days = parsed
+	}
+
+	stats, err := h.service.GetURLStats(requestCtx, shortURL, days)
+	if err != nil {
+		switch {
+		case service.IsInvalidStatsPeriodError(err):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case service.IsNotFoundError(err):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case service.IsNotOwnedError(err):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			logger.Errorw("Failed to get URL stats",
+				"error", err,
+				"short_url", shortURL,
+				"user_id", user.ID,
+				"request_id", requestID,
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to get URL stats",
+			})
+		}
+		return
+	}
+
+	daily := make([]DailyClicksDTOOut, 0, len(stats.Daily))
+	for _, day := range stats.Daily {
+		daily = append(daily, DailyClicksDTOOut{
+			Date:           day.Date.Format(time.DateOnly),
+			Clicks:         day.Clicks,
+			UniqueVisitors: day.UniqueVisitors,
+		})
+	}
+
+	var variants []VariantClicksDTOOut
+	for _, variant := range stats.Variants {
+		variants = append(variants, VariantClicksDTOOut{
+			Variant:        variant.Variant,
+			Clicks:         variant.Clicks,
+			UniqueVisitors: variant.UniqueVisitors,
+		})
+	}
+
+	c.JSON(http.StatusOK, URLStatsDTOOut{
+		ShortURL:       fmt.Sprintf("%s/%s", strings.TrimRight(h.baseURL, "/"), stats.ShortURL),
+		TotalClicks:    stats.TotalClicks,
+		UniqueVisitors: stats.UniqueVisitors,
+		Daily:          daily,
+		Variants:       variants,
+	})
+}
@@ -0,0 +1,172 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+	"yp-go-short-url-service/internal/service/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func getDefaultSettings() *config.Settings {
+	return &config.Settings{
+		EnvSettings: &config.ENVSettings{
+			Server: &config.ServerSettings{
+				ServerAddress: "testhost:1234",
+				ServerHost:    "testhost",
+				ServerPort:    1234,
+				ServerDomain:  "testdomain",
+				BaseURL:       "http://testhost:1234/",
+			},
+		},
+		Flags: &config.Flags{
+			ServerAddress: "testhost:1234",
+			BaseURL:       "http://testhost:1234/",
+		},
+	}
+}
+
+func TestURLStatsAPIHandler_Handle(t *testing.T) {
+	stats := &model.ClickStats{
+		ShortURL:       "abc123",
+		TotalClicks:    7,
+		UniqueVisitors: 4,
+		Daily: []model.DailyClicks{
+			{Date: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)},
+			{Date: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Clicks: 3, UniqueVisitors: 2},
+		},
+		Variants: []model.VariantClicks{
+			{Variant: "a", Clicks: 5, UniqueVisitors: 3},
+			{Variant: "b", Clicks: 2, UniqueVisitors: 1},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		withUser       bool
+		callService    bool
+		expectedDays   int
+		stats          *model.ClickStats
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "статистика владельца",
+			query:          "?days=2",
+			withUser:       true,
+			callService:    true,
+			expectedDays:   2,
+			stats:          stats,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "период по умолчанию",
+			withUser:       true,
+			callService:    true,
+			stats:          stats,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "без пользователя",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "некорректный период",
+			query:          "?days=week",
+			withUser:       true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "период вне диапазона",
+			query:          "?days=1000",
+			withUser:       true,
+			callService:    true,
+			expectedDays:   1000,
+			serviceErr:     service.ErrInvalidStatsPeriod,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ссылка не найдена",
+			withUser:       true,
+			callService:    true,
+			serviceErr:     service.ErrURLNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "ссылка другого пользователя",
+			withUser:       true,
+			callService:    true,
+			serviceErr:     service.ErrURLNotOwned,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "ошибка сервиса",
+			withUser:       true,
+			callService:    true,
+			serviceErr:     errors.New("unexpected error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			mockService := mock.NewMockURLStatsService(ctrl)
+			if tt.callService {
+				mockService.EXPECT().
+					GetURLStats(gomock.Any(), "abc123", tt.expectedDays).
+					Return(tt.stats, tt.serviceErr)
+			}
+
+			logger, _ := zap.NewDevelopment()
+			router := gin.New()
+			router.Use(middleware.LoggerMiddleware(logger.Sugar()))
+			router.GET("/api/user/urls/:shortURL/stats", NewURLStatsAPIHandler(mockService, getDefaultSettings()).Handle)
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/user/urls/abc123/stats"+tt.query, nil)
+			if tt.withUser {
+				ctx := context.WithValue(req.Context(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+				req = req.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response URLStatsDTOOut
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, URLStatsDTOOut{
+				ShortURL:       "http://testhost:1234/abc123",
+				TotalClicks:    7,
+				UniqueVisitors: 4,
+				Daily: []DailyClicksDTOOut{
+					{Date: "2025-03-03"},
+					{Date: "2025-03-04", Clicks: 3, UniqueVisitors: 2},
+				},
+				Variants: []VariantClicksDTOOut{
+					{Variant: "a", Clicks: 5, UniqueVisitors: 3},
+					{Variant: "b", Clicks: 2, UniqueVisitors: 1},
+				},
+			}, response)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package destructor
+
+import (
+	"net/http"
+	"strings"
+	"yp-go-short-url-service/internal/handler"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewUserURLDestructorAPIHandler создает новый обработчик для удаления одной ссылки пользователя через API.
+// Принимает сервис удаления URL и возвращает обработчик, реализующий интерфейс Handler.
+func NewUserURLDestructorAPIHandler(service service.URLDestructorService) handler.Handler {
+	return &userURLDestructorAPIHandler{
+		service: service,
+	}
+}
+
+type userURLDestructorAPIHandler struct {
+	service service.URLDestructorService
+}
+
+// Handle DeleteUserURL godoc
+// @Summary Удалить ссылку пользователя
+// @Description Удаляет одну короткую ссылку пользователя. В отличие от пакетного удаления выполняется синхронно и сообщает результат. Требует JWT аутентификации.
+// @Tags user
+// @Produce json
+// @Param shortURL path string true "Короткий URL" example(abc123)
+// @Success 200 {object} map[string]interface{} "Ссылка удалена"
+// @Failure 400 {object} map[string]interface{} "Неверный короткий URL"
+// @Failure 401 {object} map[string]interface{} "Не авторизован"
+// @Failure 403 {object} map[string]interface{} "Ссылка принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Ссылка не найдена"
+// @Failure 410 {object} map[string]interface{} "Ссылка уже удалена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /api/user/urls/{shortURL} [delete]
+func (h *userURLDestructorAPIHandler) Handle(c *gin.Context) {
+	requestCtx := c.Request.Context()
+
+	logger := middleware.GetLogger(requestCtx)
+	requestID := middleware.ExtractRequestID(requestCtx)
+
+	logger.Infow("Received delete URL request",
+		"method", c.Request.Method,
+		"path", c.Request.URL.Path,
+		"remote_addr", c.Request.RemoteAddr,
+		"request_id", requestID)
+
+	// Проверяем аутентификацию пользователя
+	user := middleware.GetJWTUserFromContext(requestCtx)
+	if user == nil {
+		logger.Errorw("User not found in context",
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	// Валидируем короткий URL по тем же правилам, что и при пакетном удалении
+	shortURL := strings.TrimSpace(c.Param("shortURL"))
+	if err := (DestructorRequestBodyDTOIn{shortURL}).Validate(); err != nil {
+		logger.Warnw("Invalid short URL in request",
+			"error", err,
+			"request_id", requestID,
+			"remote_addr", c.Request.RemoteAddr)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	err := h.service.DeleteURL(requestCtx, shortURL)
+	if err != nil {
+		switch {
+		case service.IsNotFoundError(err):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case service.IsNotOwnedError(err):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case service.IsDeletedError(err):
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		default:
+			logger.Errorw("Failed to delete URL",
+				"error", err,
+				"short_url", shortURL,
+				"user_id", user.ID,
+				"request_id", requestID,
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to delete URL",
+			})
+		}
+		return
+	}
+
+	logger.Infow("URL deleted successfully",
+		"short_url", shortURL,
+		"user_id", user.ID,
+		"request_id", requestID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "URL deleted successfully",
+		"short_url": shortURL,
+	})
+}
@@ -0,0 +1,109 @@
+package destructor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+	"yp-go-short-url-service/internal/service/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestUserURLDestructorAPIHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		shortURL       string
+		withUser       bool
+		serviceErr     error
+		callService    bool
+		expectedStatus int
+	}{
+		{
+			name:           "успешное удаление",
+			shortURL:       "6qxTVvsy",
+			withUser:       true,
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "без пользователя",
+			shortURL:       "6qxTVvsy",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "некорректный короткий URL",
+			shortURL:       "ab",
+			withUser:       true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ссылка не найдена",
+			shortURL:       "6qxTVvsy",
+			withUser:       true,
+			callService:    true,
+			serviceErr:     service.ErrURLNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "чужая ссылка",
+			shortURL:       "6qxTVvsy",
+			withUser:       true,
+			callService:    true,
+			serviceErr:     service.ErrURLNotOwned,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "ссылка уже удалена",
+			shortURL:       "6qxTVvsy",
+			withUser:       true,
+			callService:    true,
+			serviceErr:     service.ErrURLWasDeleted,
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name:           "ошибка хранилища",
+			shortURL:       "6qxTVvsy",
+			withUser:       true,
+			callService:    true,
+			serviceErr:     errors.New("database connection failed"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			mockService := mock.NewMockURLDestructorService(ctrl)
+			if tt.callService {
+				mockService.EXPECT().
+					DeleteURL(gomock.Any(), tt.shortURL).
+					Return(tt.serviceErr)
+			}
+
+			logger, _ := zap.NewDevelopment()
+			router := gin.New()
+			router.Use(middleware.LoggerMiddleware(logger.Sugar()))
+			router.DELETE("/api/user/urls/:shortURL", NewUserURLDestructorAPIHandler(mockService).Handle)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/api/user/urls/"+tt.shortURL, nil)
+			if tt.withUser {
+				ctx := context.WithValue(req.Context(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+				req = req.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
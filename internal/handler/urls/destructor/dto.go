@@ -3,6 +3,8 @@ package destructor
 import (
 	"errors"
 	"strings"
+	"time"
+	"yp-go-short-url-service/internal/model"
 )
 
 const base62Chars string = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
@@ -35,9 +37,9 @@ func (dto DestructorRequestBodyDTOIn) Validate() error {
 			return errors.New("short URL is too long")
 		}
 
-		// Проверяем, что URL содержит только допустимые символы
+		// Проверяем, что URL содержит только допустимые символы (base62, а также '-' и '_' пользовательских кодов)
 		for _, char := range shortURL {
-			if !strings.ContainsRune(base62Chars, char) {
+			if !strings.ContainsRune(base62Chars, char) && char != '-' && char != '_' {
 				return errors.New("short URL contains invalid characters")
 			}
 		}
@@ -48,3 +50,16 @@ func (dto DestructorRequestBodyDTOIn) Validate() error {
 
 	return nil
 }
+
+// DeleteJobDTOOut представляет состояние задачи пакетного удаления.
+// Results заполняется после завершения задачи: для каждого короткого URL указывается
+// deleted, already_deleted (ссылка была удалена ранее), not_owned или not_found.
+type DeleteJobDTOOut struct {
+	ID        string                         `json:"id" example:"3f2b9c4e-8d1a-4e6b-9f0c-2a7d5e1b8c3f"`
+	Status    model.DeleteJobStatus          `json:"status" example:"done"`
+	ShortURLs []string                       `json:"short_urls"`
+	Results   map[string]model.DeleteOutcome `json:"results,omitempty"`
+	Error     string                         `json:"error,omitempty"`
+	CreatedAt time.Time                      `json:"created_at"`
+	UpdatedAt time.Time                      `json:"updated_at"`
+}
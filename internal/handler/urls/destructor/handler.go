@@ -25,12 +25,12 @@ type usersURLsDestructorAPIHandler struct {
 
 // Handle DeleteUserURLs godoc
 // @Summary Удалить URL пользователя
-// @Description Удаляет указанные короткие URL пользователя. Требует JWT аутентификации.
+// @Description Асинхронно удаляет указанные короткие URL пользователя. Возвращает идентификатор задачи, статус которой доступен по /api/user/urls/delete-jobs/{id}. Требует JWT аутентификации.
 // @Tags user
 // @Accept json
 // @Produce json
 // @Param request body DestructorRequestBodyDTOIn true "Массив коротких URL для удаления"
-// @Success 202 {object} map[string]interface{} "URL успешно удалены"
+// @Success 202 {object} map[string]interface{} "Запрос на удаление принят, в job_id возвращается идентификатор задачи"
 // @Failure 400 {object} map[string]interface{} "Неверный запрос"
 // @Failure 401 {object} map[string]interface{} "Не авторизован"
 // @Failure 415 {object} map[string]interface{} "Неподдерживаемый тип контента"
@@ -61,18 +61,18 @@ func (h *usersURLsDestructorAPIHandler) Handle(c *gin.Context) {
 	}
 
 	// Валидируем запрос
-	if err := h.validateRequest(c); err != nil {
+	if err := validateRequest(c); err != nil {
 		return
 	}
 
 	// Парсим и валидируем DTO
-	dtoIn, err := h.parseAndValidateDTO(c)
+	dtoIn, err := parseAndValidateDTO(c)
 	if err != nil {
 		return
 	}
 
 	// Удаляем URL'ы
-	err = h.service.DeleteURLsByBatch(requestCtx, dtoIn)
+	job, err := h.service.DeleteURLsByBatch(requestCtx, dtoIn)
 	if err != nil {
 		logger.Errorw("Failed to delete URLs",
 			"error", err,
@@ -85,7 +85,8 @@ func (h *usersURLsDestructorAPIHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	logger.Infow("URLs deleted successfully",
+	logger.Infow("URLs deletion accepted",
+		"job_id", job.ID,
 		"user_id", user.ID,
 		"urls_count", len(dtoIn),
 		"request_id", requestID)
@@ -93,11 +94,12 @@ func (h *usersURLsDestructorAPIHandler) Handle(c *gin.Context) {
 	c.JSON(http.StatusAccepted, gin.H{
 		"message":       "URLs deleted successfully",
 		"deleted_count": len(dtoIn),
+		"job_id":        job.ID,
 	})
 }
 
 // validateRequest выполняет валидацию входящего запроса
-func (h *usersURLsDestructorAPIHandler) validateRequest(c *gin.Context) error {
+func validateRequest(c *gin.Context) error {
 	logger := middleware.GetLogger(c.Request.Context())
 	requestID := middleware.ExtractRequestID(c.Request.Context())
 
@@ -128,7 +130,7 @@ func (h *usersURLsDestructorAPIHandler) validateRequest(c *gin.Context) error {
 }
 
 // parseAndValidateDTO парсит и валидирует DTO из тела запроса
-func (h *usersURLsDestructorAPIHandler) parseAndValidateDTO(c *gin.Context) (DestructorRequestBodyDTOIn, error) {
+func parseAndValidateDTO(c *gin.Context) (DestructorRequestBodyDTOIn, error) {
 	logger := middleware.GetLogger(c.Request.Context())
 	requestID := middleware.ExtractRequestID(c.Request.Context())
 
@@ -48,6 +48,11 @@ func TestDestructorRequestBodyDTOIn_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "short URL contains invalid characters",
 		},
+		{
+			name:    "custom aliases",
+			dto:     DestructorRequestBodyDTOIn{"q4-report", "team_docs"},
+			wantErr: false,
+		},
 		{
 			name:    "URLs with spaces",
 			dto:     DestructorRequestBodyDTOIn{" 6qxTVvsy ", " RTfd56hn ", " Jlfd67ds "},
@@ -0,0 +1,87 @@
+package destructor
+
+import (
+	"net/http"
+	"strings"
+	"yp-go-short-url-service/internal/handler"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewDeleteJobStatusAPIHandler создает новый обработчик для получения статуса задачи пакетного удаления через API.
+// Принимает сервис удаления URL и возвращает обработчик, реализующий интерфейс Handler.
+func NewDeleteJobStatusAPIHandler(service service.URLDestructorService) handler.Handler {
+	return &deleteJobStatusAPIHandler{
+		service: service,
+	}
+}
+
+type deleteJobStatusAPIHandler struct {
+	service service.URLDestructorService
+}
+
+// Handle GetDeleteJob godoc
+// @Summary Получить статус задачи удаления
+// @Description Возвращает статус задачи пакетного удаления (queued, running, done, failed) и результат для каждого короткого URL (deleted, already_deleted, not_owned, not_found). Если задача в статусе queued содержит error, предыдущая попытка завершилась ошибкой и задача ожидает повтора. Завершенные задачи хранятся ограниченное время. Требует JWT аутентификации.
+// @Tags user
+// @Produce json
+// @Param id path string true "Идентификатор задачи удаления"
+// @Success 200 {object} DeleteJobDTOOut "Статус задачи удаления"
+// @Failure 400 {object} map[string]interface{} "Неверный запрос"
+// @Failure 401 {object} map[string]interface{} "Не авторизован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена или принадлежит другому пользователю"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /api/user/urls/delete-jobs/{id} [get]
+func (h *deleteJobStatusAPIHandler) Handle(c *gin.Context) {
+	requestCtx := c.Request.Context()
+
+	logger := middleware.GetLogger(requestCtx)
+	requestID := middleware.ExtractRequestID(requestCtx)
+
+	user := middleware.GetJWTUserFromContext(requestCtx)
+	if user == nil {
+		logger.Errorw("User not found in context",
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	jobID := strings.TrimSpace(c.Param("id"))
+	if jobID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job id is required"})
+		return
+	}
+
+	job, err := h.service.GetDeleteJob(requestCtx, jobID)
+	if err != nil {
+		if service.IsDeleteJobNotFoundError(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		logger.Errorw("Failed to get delete job",
+			"error", err,
+			"job_id", jobID,
+			"user_id", user.ID,
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get delete job",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, DeleteJobDTOOut{
+		ID:        job.ID,
+		Status:    job.Status,
+		ShortURLs: job.ShortURLs,
+		Results:   job.Results,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	})
+}
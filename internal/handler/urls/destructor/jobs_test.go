@@ -0,0 +1,144 @@
+package destructor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	repositoryMock "yp-go-short-url-service/internal/repository/mock"
+	"yp-go-short-url-service/internal/service"
+	"yp-go-short-url-service/internal/service/mock"
+	urlDestructorService "yp-go-short-url-service/internal/service/urls/destructor"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestDeleteJobStatusAPIHandler_Handle(t *testing.T) {
+	now := time.Now()
+	doneJob := &model.DeleteJob{
+		ID:        "job-1",
+		UserID:    "test-user-id",
+		Status:    model.DeleteJobDone,
+		ShortURLs: []string{"6qxTVvsy", "RTfd56hn"},
+		Results: map[string]model.DeleteOutcome{
+			"6qxTVvsy": model.DeleteOutcomeDeleted,
+			"RTfd56hn": model.DeleteOutcomeNotOwned,
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	tests := []struct {
+		name           string
+		withUser       bool
+		job            *model.DeleteJob
+		serviceErr     error
+		callService    bool
+		expectedStatus int
+	}{
+		{
+			name:           "задача завершена",
+			withUser:       true,
+			job:            doneJob,
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "без пользователя",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "задача не найдена",
+			withUser:       true,
+			callService:    true,
+			serviceErr:     service.ErrDeleteJobNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "ошибка сервиса",
+			withUser:       true,
+			callService:    true,
+			serviceErr:     errors.New("unexpected error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			mockService := mock.NewMockURLDestructorService(ctrl)
+			if tt.callService {
+				mockService.EXPECT().
+					GetDeleteJob(gomock.Any(), "job-1").
+					Return(tt.job, tt.serviceErr)
+			}
+
+			logger, _ := zap.NewDevelopment()
+			router := gin.New()
+			router.Use(middleware.LoggerMiddleware(logger.Sugar()))
+			router.GET("/api/user/urls/delete-jobs/:id", NewDeleteJobStatusAPIHandler(mockService).Handle)
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/user/urls/delete-jobs/job-1", nil)
+			if tt.withUser {
+				ctx := context.WithValue(req.Context(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+				req = req.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response DeleteJobDTOOut
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "job-1", response.ID)
+			assert.Equal(t, model.DeleteJobDone, response.Status)
+			assert.Equal(t, doneJob.Results, response.Results)
+		})
+	}
+}
+
+func TestDeleteJobStatusAPIHandler_Handle_MalformedID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	// Репозиторий PostgreSQL возвращает ErrDeleteJobNotFound для идентификатора, не являющегося UUID
+	jobRepo := repositoryMock.NewMockDeleteJobRepository(ctrl)
+	jobRepo.EXPECT().ClaimBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	jobRepo.EXPECT().DeleteFinished(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+	jobRepo.EXPECT().GetByID(gomock.Any(), "not-a-uuid").Return(nil, repository.ErrDeleteJobNotFound)
+
+	logger, _ := zap.NewDevelopment()
+	destructorService := urlDestructorService.NewURLDestructorService(
+		repositoryMock.NewMockURLRepository(ctrl), repositoryMock.NewMockUserURLsRepository(ctrl), jobRepo,
+		0, 0, nil, logger.Sugar(),
+	)
+	defer destructorService.Stop()
+
+	router := gin.New()
+	router.Use(middleware.LoggerMiddleware(logger.Sugar()))
+	router.GET("/api/user/urls/delete-jobs/:id", NewDeleteJobStatusAPIHandler(destructorService).Handle)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/user/urls/delete-jobs/not-a-uuid", nil)
+	ctx := context.WithValue(req.Context(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,92 @@
+package destructor
+
+import (
+	"net/http"
+	"yp-go-short-url-service/internal/handler"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewUsersURLsRestoreAPIHandler создает новый обработчик для восстановления удаленных URL пользователя через API.
+// Принимает сервис удаления URL и возвращает обработчик, реализующий интерфейс Handler.
+func NewUsersURLsRestoreAPIHandler(service service.URLDestructorService) handler.Handler {
+	return &usersURLsRestoreAPIHandler{
+		service: service,
+	}
+}
+
+type usersURLsRestoreAPIHandler struct {
+	service service.URLDestructorService
+}
+
+// Handle RestoreUserURLs godoc
+// @Summary Восстановить удаленные URL пользователя
+// @Description Восстанавливает указанные удаленные короткие URL пользователя из корзины. Чужие и неудаленные ссылки игнорируются. Требует JWT аутентификации.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body DestructorRequestBodyDTOIn true "Массив коротких URL для восстановления"
+// @Success 202 {object} map[string]interface{} "Запрос на восстановление принят"
+// @Failure 400 {object} map[string]interface{} "Неверный запрос"
+// @Failure 401 {object} map[string]interface{} "Не авторизован"
+// @Failure 415 {object} map[string]interface{} "Неподдерживаемый тип контента"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /api/user/urls/restore [post]
+func (h *usersURLsRestoreAPIHandler) Handle(c *gin.Context) {
+	requestCtx := c.Request.Context()
+
+	logger := middleware.GetLogger(requestCtx)
+	requestID := middleware.ExtractRequestID(requestCtx)
+
+	logger.Infow("Received restore URLs request",
+		"method", c.Request.Method,
+		"path", c.Request.URL.Path,
+		"remote_addr", c.Request.RemoteAddr,
+		"request_id", requestID)
+
+	// Проверяем аутентификацию пользователя
+	user := middleware.GetJWTUserFromContext(requestCtx)
+	if user == nil {
+		logger.Errorw("User not found in context",
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	if err := validateRequest(c); err != nil {
+		return
+	}
+
+	dtoIn, err := parseAndValidateDTO(c)
+	if err != nil {
+		return
+	}
+
+	err = h.service.RestoreURLsByBatch(requestCtx, dtoIn)
+	if err != nil {
+		logger.Errorw("Failed to restore URLs",
+			"error", err,
+			"user_id", user.ID,
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to restore URLs",
+		})
+		return
+	}
+
+	logger.Infow("URLs restore accepted",
+		"user_id", user.ID,
+		"urls_count", len(dtoIn),
+		"request_id", requestID)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":        "URLs restored successfully",
+		"restored_count": len(dtoIn),
+	})
+}
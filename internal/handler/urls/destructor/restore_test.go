@@ -0,0 +1,102 @@
+package destructor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestUsersURLsRestoreAPIHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		contentType    string
+		withUser       bool
+		setupMock      func(mockService *mock.MockURLDestructorService)
+		expectedStatus int
+	}{
+		{
+			name:        "успешное восстановление",
+			body:        `["6qxTVvsy", "q4-report"]`,
+			contentType: "application/json",
+			withUser:    true,
+			setupMock: func(mockService *mock.MockURLDestructorService) {
+				mockService.EXPECT().
+					RestoreURLsByBatch(gomock.Any(), []string{"6qxTVvsy", "q4-report"}).
+					Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "без пользователя",
+			body:           `["6qxTVvsy"]`,
+			contentType:    "application/json",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "неверный Content-Type",
+			body:           `["6qxTVvsy"]`,
+			contentType:    "text/plain",
+			withUser:       true,
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "пустой список",
+			body:           `[]`,
+			contentType:    "application/json",
+			withUser:       true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "очередь переполнена",
+			body:        `["6qxTVvsy"]`,
+			contentType: "application/json",
+			withUser:    true,
+			setupMock: func(mockService *mock.MockURLDestructorService) {
+				mockService.EXPECT().
+					RestoreURLsByBatch(gomock.Any(), gomock.Any()).
+					Return(errors.New("delete service is overloaded, try again later"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			mockService := mock.NewMockURLDestructorService(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockService)
+			}
+
+			logger, _ := zap.NewDevelopment()
+			router := gin.New()
+			router.Use(middleware.LoggerMiddleware(logger.Sugar()))
+			router.POST("/api/user/urls/restore", NewUsersURLsRestoreAPIHandler(mockService).Handle)
+
+			req, _ := http.NewRequest(http.MethodPost, "/api/user/urls/restore", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.withUser {
+				ctx := context.WithValue(req.Context(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+				req = req.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package editor
+
+import (
+	"time"
+	"yp-go-short-url-service/internal/model"
+)
+
+// UpdatingURLDTOIn представляет входные данные для изменения короткой ссылки.
+// Поля, которые не указаны, не изменяются; должно быть указано хотя бы одно поле.
+type UpdatingURLDTOIn struct {
+	// URL - новый адрес назначения ссылки (необязательно)
+	// example: "https://www.example.com/new/destination"
+	URL *string `json:"url,omitempty"`
+	// ExpiresAt - новый момент истечения ссылки в формате RFC 3339 (необязательно, не совместим с TTL и NoExpiration)
+	// example: "2030-01-01T00:00:00Z"
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// TTL - новое время жизни ссылки в секундах, отсчитываемое от момента изменения (необязательно)
+	// example: 86400
+	TTL int64 `json:"ttl,omitempty"`
+	// NoExpiration - сделать ссылку бессрочной (необязательно)
+	// example: false
+	NoExpiration bool `json:"no_expiration,omitempty"`
+	// Active - включить (true) или отключить (false) переходы по ссылке (необязательно)
+	// example: false
+	Active *bool `json:"active,omitempty"`
+	// Title - новый заголовок ссылки для страницы предпросмотра; пустая строка удаляет заголовок (необязательно)
+	// example: "Квартальный отчет"
+	Title *string `json:"title,omitempty"`
+	// Interstitial - включить (true) или отключить (false) страницу предупреждения перед переходом (необязательно)
+	// example: true
+	Interstitial *bool `json:"interstitial,omitempty"`
+	// Rules - новый список правил условного перенаправления; пустой список удаляет все правила (необязательно)
+	Rules *[]model.RoutingRule `json:"rules,omitempty"`
+	// Variants - новый список вариантов адреса назначения для A/B теста; пустой список отключает разделение
+	// трафика (необязательно)
+	Variants *[]model.SplitVariant `json:"variants,omitempty"`
+}
+
+// UpdatingURLDTOOut представляет состояние ссылки после изменения
+type UpdatingURLDTOOut struct {
+	// ShortURL - сокращенный URL
+	// example: "http://localhost:8080/abc123"
+	ShortURL string `json:"short_url"`
+	// OriginalURL - адрес назначения ссылки
+	// example: "https://www.example.com/new/destination"
+	OriginalURL string `json:"original_url"`
+	// ExpiresAt - момент истечения ссылки, если срок действия задан
+	// example: "2030-01-01T00:00:00Z"
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Active - признак того, что переходы по ссылке разрешены
+	// example: true
+	Active bool `json:"active"`
+	// Title - заголовок ссылки, если задан
+	// example: "Квартальный отчет"
+	Title string `json:"title,omitempty"`
+	// Interstitial - признак того, что перед переходом показывается страница предупреждения
+	// example: false
+	Interstitial bool `json:"interstitial"`
+	// Rules - правила условного перенаправления ссылки, если они заданы
+	Rules []model.RoutingRule `json:"rules,omitempty"`
+	// Variants - варианты адреса назначения ссылки, если они заданы
+	Variants []model.SplitVariant `json:"variants,omitempty"`
+}
@@ -0,0 +1,168 @@
+package editor
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/handler"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewUpdatingUserURLHandler создает новый обработчик для изменения ссылки пользователя через API.
+// Принимает сервис изменения URL и настройки приложения, возвращает обработчик, реализующий интерфейс Handler.
+func NewUpdatingUserURLHandler(service service.URLEditorService, settings *config.Settings) handler.Handler {
+	return &updatingUserURLHandler{
+		service: service,
+		baseURL: settings.GetBaseURL(),
+	}
+}
+
+type updatingUserURLHandler struct {
+	service service.URLEditorService
+	baseURL string
+}
+
+// Handle UpdateUserURL godoc
+// @Summary Изменить ссылку пользователя
+// @Description Изменяет адрес назначения, срок действия, активность, заголовок, страницу предупреждения, правила условного перенаправления или варианты адреса назначения короткой ссылки. Доступно только владельцу ссылки, требует JWT аутентификации.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param shortURL path string true "Короткий URL" example(abc123)
+// @Param request body UpdatingURLDTOIn true "Изменения ссылки"
+// @Success 200 {object} UpdatingURLDTOOut "Ссылка успешно изменена"
+// @Failure 400 {object} map[string]interface{} "Неверный запрос"
+// @Failure 401 {object} map[string]interface{} "Не авторизован"
+// @Failure 404 {object} map[string]interface{} "Ссылка не найдена или принадлежит другому пользователю"
+// @Failure 415 {object} map[string]interface{} "Неподдерживаемый тип контента"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /api/user/urls/{shortURL} [patch]
+func (h *updatingUserURLHandler) Handle(c *gin.Context) {
+	requestCtx := c.Request.Context()
+
+	logger := middleware.GetLogger(requestCtx)
+	requestID := middleware.ExtractRequestID(requestCtx)
+
+	logger.Infow("Received update URL request",
+		"method", c.Request.Method,
+		"path", c.Request.URL.Path,
+		"remote_addr", c.Request.RemoteAddr,
+		"request_id", requestID)
+
+	user := middleware.GetJWTUserFromContext(requestCtx)
+	if user == nil {
+		logger.Errorw("User not found in context",
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	contentType := c.GetHeader("Content-Type")
+	if !strings.HasPrefix(contentType, "application/json") {
+		logger.Warnw("Invalid Content-Type header",
+			"content_type", contentType,
+			"request_id", requestID,
+			"remote_addr", c.Request.RemoteAddr)
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": "Content-Type: application/json header is required",
+		})
+		return
+	}
+
+	shortURL := strings.TrimSpace(c.Param("shortURL"))
+	if shortURL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "short url is required"})
+		return
+	}
+
+	var dtoIn UpdatingURLDTOIn
+	if err := c.ShouldBindJSON(&dtoIn); err != nil {
+		logger.Warnw("Invalid JSON in request body",
+			"error", err,
+			"request_id", requestID,
+			"remote_addr", c.Request.RemoteAddr)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	opts := service.UpdateOptions{
+		LongURL:          dtoIn.URL,
+		ExpiresAt:        dtoIn.ExpiresAt,
+		TTL:              time.Duration(dtoIn.TTL) * time.Second,
+		ClearExpiration:  dtoIn.NoExpiration,
+		Active:           dtoIn.Active,
+		Title:            dtoIn.Title,
+		ShowInterstitial: dtoIn.Interstitial,
+	}
+	if dtoIn.Rules != nil {
+		rules := model.RoutingRules(*dtoIn.Rules)
+		opts.RoutingRules = &rules
+	}
+	if dtoIn.Variants != nil {
+		variants := model.SplitVariants(*dtoIn.Variants)
+		opts.Variants = &variants
+	}
+
+	url, err := h.service.UpdateUserURL(requestCtx, shortURL, opts)
+	if err != nil {
+		switch {
+		case service.IsInvalidURLUpdateError(err) || service.IsInvalidExpirationError(err) ||
+			service.IsInvalidTitleError(err) || service.IsInvalidRoutingRulesError(err) ||
+			service.IsInvalidVariantsError(err):
+			logger.Warnw("Invalid URL update in request",
+				"error", err,
+				"short_url", shortURL,
+				"request_id", requestID)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case service.IsPolicyViolationError(err):
+			logger.Warnw("URL rejected by destination policy",
+				"error", err,
+				"short_url", shortURL,
+				"request_id", requestID)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "reason": service.PolicyViolationReason(err)})
+		case service.IsNotFoundError(err):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			logger.Errorw("Failed to update URL",
+				"error", err,
+				"short_url", shortURL,
+				"user_id", user.ID,
+				"request_id", requestID)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update URL"})
+		}
+		return
+	}
+
+	logger.Infow("URL updated successfully",
+		"short_url", shortURL,
+		"user_id", user.ID,
+		"request_id", requestID)
+
+	c.JSON(http.StatusOK, UpdatingURLDTOOut{
+		ShortURL:     h.buildShortURL(url.ShortURL),
+		OriginalURL:  url.LongURL,
+		ExpiresAt:    url.ExpiresAt,
+		Active:       !url.IsDisabled,
+		Title:        url.Title,
+		Interstitial: url.ShowInterstitial,
+		Rules:        url.RoutingRules,
+		Variants:     url.Variants,
+	})
+}
+
+func (h *updatingUserURLHandler) buildShortURL(shortedURL string) string {
+	return fmt.Sprintf(
+		"%s/%s",
+		strings.TrimRight(h.baseURL, "/"),
+		shortedURL,
+	)
+}
@@ -0,0 +1,268 @@
+package editor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+	"yp-go-short-url-service/internal/service/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func getDefaultSettings() *config.Settings {
+	return &config.Settings{
+		EnvSettings: &config.ENVSettings{
+			Server: &config.ServerSettings{
+				ServerAddress: "testhost:1234",
+				ServerHost:    "testhost",
+				ServerPort:    1234,
+				ServerDomain:  "testdomain",
+				BaseURL:       "http://testhost:1234/",
+			},
+		},
+		Flags: &config.Flags{
+			ServerAddress: "testhost:1234",
+			BaseURL:       "http://testhost:1234/",
+		},
+	}
+}
+
+func setupTestHandler(t *testing.T) (*gin.Engine, *mock.MockURLEditorService) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	mockService := mock.NewMockURLEditorService(ctrl)
+
+	handler := NewUpdatingUserURLHandler(mockService, getDefaultSettings())
+
+	logger, _ := zap.NewDevelopment()
+	router := gin.New()
+	router.Use(middleware.LoggerMiddleware(logger.Sugar()))
+	router.Use(middleware.RequestIDMiddleware(logger.Sugar()))
+	router.PATCH("/api/user/urls/:shortURL", handler.Handle)
+
+	return router, mockService
+}
+
+func newUpdateRequest(body string, withUser bool) *http.Request {
+	req, _ := http.NewRequest(http.MethodPatch, "/api/user/urls/abc123", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if withUser {
+		ctx := context.WithValue(req.Context(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+		req = req.WithContext(ctx)
+	}
+	return req
+}
+
+func TestUpdatingUserURLHandler_Handle_Success(t *testing.T) {
+	router, mockService := setupTestHandler(t)
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockService.EXPECT().
+		UpdateUserURL(gomock.Any(), "abc123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, opts service.UpdateOptions) (*model.URLsModel, error) {
+			require.NotNil(t, opts.LongURL)
+			assert.Equal(t, "https://example.com/new", *opts.LongURL)
+			require.NotNil(t, opts.Active)
+			assert.False(t, *opts.Active)
+			assert.Equal(t, time.Hour, opts.TTL)
+			assert.False(t, opts.ClearExpiration)
+
+			return &model.URLsModel{
+				ShortURL:   "abc123",
+				LongURL:    "https://example.com/new",
+				ExpiresAt:  &expiresAt,
+				IsDisabled: true,
+			}, nil
+		})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpdateRequest(`{"url": "https://example.com/new", "ttl": 3600, "active": false}`, true))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response UpdatingURLDTOOut
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "http://testhost:1234/abc123", response.ShortURL)
+	assert.Equal(t, "https://example.com/new", response.OriginalURL)
+	require.NotNil(t, response.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*response.ExpiresAt))
+	assert.False(t, response.Active)
+}
+
+func TestUpdatingUserURLHandler_Handle_RoutingRules(t *testing.T) {
+	router, mockService := setupTestHandler(t)
+
+	rules := model.RoutingRules{{Device: model.DeviceIOS, Destination: "https://apps.apple.com/app/id1"}}
+	mockService.EXPECT().
+		UpdateUserURL(gomock.Any(), "abc123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, opts service.UpdateOptions) (*model.URLsModel, error) {
+			require.NotNil(t, opts.RoutingRules)
+			assert.Equal(t, rules, *opts.RoutingRules)
+			return &model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com", RoutingRules: rules}, nil
+		})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpdateRequest(`{"rules": [{"device": "ios", "destination": "https://apps.apple.com/app/id1"}]}`, true))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response UpdatingURLDTOOut
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []model.RoutingRule(rules), response.Rules)
+
+	// Пустой список передается в сервис для удаления правил, а не пропускается
+	mockService.EXPECT().
+		UpdateUserURL(gomock.Any(), "abc123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, opts service.UpdateOptions) (*model.URLsModel, error) {
+			require.NotNil(t, opts.RoutingRules)
+			assert.Empty(t, *opts.RoutingRules)
+			return &model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com"}, nil
+		})
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newUpdateRequest(`{"rules": []}`, true))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "rules")
+}
+
+func TestUpdatingUserURLHandler_Handle_Variants(t *testing.T) {
+	router, mockService := setupTestHandler(t)
+
+	variants := model.SplitVariants{
+		{Name: "a", Destination: "https://example.com/a", Weight: 70},
+		{Name: "b", Destination: "https://example.com/b", Weight: 30},
+	}
+	mockService.EXPECT().
+		UpdateUserURL(gomock.Any(), "abc123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, opts service.UpdateOptions) (*model.URLsModel, error) {
+			require.NotNil(t, opts.Variants)
+			assert.Equal(t, variants, *opts.Variants)
+			assert.Nil(t, opts.RoutingRules)
+			return &model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com", Variants: variants}, nil
+		})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpdateRequest(`{"variants": [`+
+		`{"name": "a", "destination": "https://example.com/a", "weight": 70},`+
+		`{"name": "b", "destination": "https://example.com/b", "weight": 30}]}`, true))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response UpdatingURLDTOOut
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []model.SplitVariant(variants), response.Variants)
+}
+
+func TestUpdatingUserURLHandler_Handle_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		withUser       bool
+		contentType    string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "не авторизован",
+			body:           `{"active": false}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "неверный Content-Type",
+			body:           `{"active": false}`,
+			withUser:       true,
+			contentType:    "text/plain",
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "некорректный JSON",
+			body:           `{"active": `,
+			withUser:       true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "пустое изменение",
+			body:           `{}`,
+			withUser:       true,
+			serviceErr:     service.ErrInvalidURLUpdate,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "некорректный срок действия",
+			body:           `{"ttl": -1}`,
+			withUser:       true,
+			serviceErr:     service.ErrInvalidExpiration,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "некорректные правила перенаправления",
+			body:           `{"rules": [{"destination": "https://example.com"}]}`,
+			withUser:       true,
+			serviceErr:     service.ErrInvalidRoutingRules,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "некорректные варианты адреса назначения",
+			body:           `{"variants": [{"name": "a", "destination": "https://example.com/a", "weight": 1}]}`,
+			withUser:       true,
+			serviceErr:     service.ErrInvalidVariants,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "адрес назначения запрещен политикой",
+			body:           `{"url": "https://blocked.example"}`,
+			withUser:       true,
+			serviceErr:     &service.PolicyViolationError{Reason: service.PolicyReasonDomainDenied, URL: "https://blocked.example"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "чужая или несуществующая ссылка",
+			body:           `{"active": true}`,
+			withUser:       true,
+			serviceErr:     service.ErrURLNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "ошибка хранилища",
+			body:           `{"active": true}`,
+			withUser:       true,
+			serviceErr:     errors.New("database connection failed"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupTestHandler(t)
+			if tt.serviceErr != nil {
+				mockService.EXPECT().
+					UpdateUserURL(gomock.Any(), "abc123", gomock.Any()).
+					Return(nil, tt.serviceErr)
+			}
+
+			req := newUpdateRequest(tt.body, tt.withUser)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"yp-go-short-url-service/internal/handler"
 	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -23,13 +24,36 @@ func NewExtractingFullLinkHandler(service service.URLExtractorService) handler.H
 
 // Handle RedirectToLongURL godoc
 // @Summary Перенаправление на длинный URL
-// @Description Перенаправляет пользователя на оригинальный длинный URL по короткой ссылке
+// @Description Перенаправляет пользователя на оригинальный длинный URL по короткой ссылке.
+// @Description Если к короткому коду добавлен суффикс "+" или передан параметр preview=1, вместо перехода
+// @Description отображается страница предпросмотра с адресом назначения, датой создания и заголовком ссылки.
+// @Description Для ссылки со страницей предупреждения переход выполняется только с параметром confirm=1.
+// @Description Статус перенаправления (301, 302, 307 или 308) задается для каждой ссылки; остальные параметры запроса
+// @Description переносятся в адрес назначения, если для ссылки включен режим query_passthrough.
+// @Description Для ссылки-префикса путь после короткого кода добавляется к адресу назначения: запрос /{shortURL}/docs/page?x=1
+// @Description перенаправляется на <адрес назначения>/docs/page?x=1. Для обычной ссылки такой путь приводит к 404.
+// @Description Если для ссылки заданы правила условного перенаправления, адрес назначения выбирается по первому правилу,
+// @Description которому соответствуют User-Agent, Accept-Language и IP-адрес клиента.
+// @Description Если для ссылки заданы варианты адреса назначения, вариант выбирается по весам и закрепляется
+// @Description за посетителем в cookie split_variant, чтобы следующие переходы вели на тот же вариант.
 // @Tags redirect
 // @Accept plain
 // @Produce plain
-// @Param shortURL path string true "Короткий URL" example(abc123)
+// @Produce html
+// @Param shortURL path string true "Короткий URL, при необходимости с суффиксом +" example(abc123)
+// @Param preview query string false "Показать страницу предпросмотра вместо перехода" example(1)
+// @Param confirm query string false "Подтверждение перехода по ссылке со страницей предупреждения" example(1)
+// @Param X-Link-Password header string false "Пароль защищенной ссылки"
+// @Success 200 {string} string "Страница предпросмотра или предупреждения перед переходом"
+// @Success 301 {string} string "Постоянное перенаправление на длинный URL"
+// @Success 302 {string} string "Перенаправление на длинный URL"
 // @Success 307 {string} string "Перенаправление на длинный URL"
+// @Success 308 {string} string "Постоянное перенаправление на длинный URL"
 // @Failure 400 {string} string "Неверный запрос"
+// @Failure 401 {string} string "Ссылка защищена паролем или указан неверный пароль"
+// @Failure 404 {string} string "Ссылка не найдена"
+// @Failure 410 {string} string "Ссылка удалена, отключена владельцем, срок ее действия истек или исчерпан лимит переходов"
+// @Failure 429 {string} string "Превышено число попыток ввода пароля"
 // @Failure 500 {string} string "Внутренняя ошибка сервера"
 // @Router /{shortURL} [get]
 func (h *extractingLongURLHandler) Handle(c *gin.Context) {
@@ -46,26 +70,43 @@ func (h *extractingLongURLHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	longURL, err := h.service.ExtractLongURL(c.Request.Context(), shortURL)
-	if err != nil {
-		if service.IsDeletedError(err) {
-			logger.Infow("Ссылка была удалена",
-				"request_id", requestID,
-			)
-			c.String(http.StatusGone, "Ссылка была удалена")
+	shortURL, preview := parsePreviewRequest(c, shortURL)
+	if preview {
+		link, err := h.service.ResolveURL(c.Request.Context(), shortURL)
+		if err != nil {
+			writeExtractError(c, shortURL, err)
 			return
 		}
+		renderPreview(c, link)
+		return
+	}
 
-		logger.Errorw(
-			"Ошибка при извлечении длинной ссылки",
-			"error", err,
+	req := redirectRequest(c)
+
+	var redirect *model.Redirect
+	var err error
+	switch password := c.GetHeader(passwordHeader); {
+	case password != "":
+		redirect, err = h.service.UnlockLongURL(c.Request.Context(), shortURL, password, req)
+	case isQueryFlagSet(c, confirmQueryParam):
+		redirect, err = h.service.ConfirmLongURL(c.Request.Context(), shortURL, req)
+	default:
+		redirect, err = h.service.ExtractLongURL(c.Request.Context(), shortURL, req)
+	}
+	if service.IsInterstitialRequiredError(err) && redirect != nil {
+		logger.Infow("Перед переходом по ссылке требуется подтверждение",
 			"short_url", shortURL,
 			"request_id", requestID,
 		)
-		c.String(http.StatusInternalServerError, "Ошибка при извлечении длинной ссылки: %v", err)
+		rememberVariant(c, shortURL, redirect.Variant)
+		renderInterstitial(c, shortURL, redirect.Location)
 		return
 	}
-	if len(longURL) == 0 {
+	if err != nil {
+		writeExtractError(c, shortURL, err)
+		return
+	}
+	if redirect == nil || redirect.Location == "" {
 		logger.Infow("Ссылка не найдена в базе данных",
 			"short_url", shortURL,
 			"request_id", requestID,
@@ -74,6 +115,84 @@ func (h *extractingLongURLHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	c.Redirect(http.StatusTemporaryRedirect, longURL)
-	logger.Infow("Перенаправление на длинный URL", "redirect_url", longURL, "request_id", requestID)
+	rememberVariant(c, shortURL, redirect.Variant)
+	c.Redirect(redirect.StatusCode, redirect.Location)
+	logger.Infow("Перенаправление на длинный URL",
+		"redirect_url", redirect.Location,
+		"status_code", redirect.StatusCode,
+		"variant", redirect.Variant,
+		"request_id", requestID,
+	)
+}
+
+// writeExtractError преобразует ошибку сервиса извлечения URL в HTTP-ответ.
+// Для защищенной паролем ссылки отображается форма ввода пароля.
+func writeExtractError(c *gin.Context, shortURL string, err error) {
+	logger := middleware.GetLogger(c.Request.Context())
+	requestID := middleware.ExtractRequestID(c.Request.Context())
+
+	switch {
+	case service.IsNotFoundError(err):
+		logger.Infow("Ссылка не найдена в базе данных",
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		c.String(http.StatusNotFound, "Ссылка не найдена")
+	case service.IsInvalidPathSuffixError(err):
+		logger.Infow("Путь после короткого кода нельзя перенести в адрес назначения",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		c.String(http.StatusBadRequest, "Некорректный путь после короткого кода")
+	case service.IsDeletedError(err):
+		logger.Infow("Ссылка была удалена",
+			"request_id", requestID,
+		)
+		c.String(http.StatusGone, "Ссылка была удалена")
+	case service.IsDisabledError(err):
+		logger.Infow("Ссылка отключена владельцем",
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		c.String(http.StatusGone, "Ссылка отключена владельцем")
+	case service.IsExpiredError(err):
+		logger.Infow("Срок действия ссылки истек",
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		c.String(http.StatusGone, "Срок действия ссылки истек")
+	case service.IsClickLimitReachedError(err):
+		logger.Infow("Лимит переходов по ссылке исчерпан",
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		c.String(http.StatusGone, "Лимит переходов по ссылке исчерпан")
+	case service.IsPasswordRequiredError(err):
+		logger.Infow("Ссылка защищена паролем",
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		renderPasswordForm(c, shortURL, "")
+	case service.IsWrongPasswordError(err):
+		logger.Infow("Неверный пароль ссылки",
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		renderPasswordForm(c, shortURL, "Неверный пароль")
+	case service.IsTooManyPasswordAttemptsError(err):
+		logger.Warnw("Превышено число попыток ввода пароля",
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		c.String(http.StatusTooManyRequests, "Превышено число попыток ввода пароля, попробуйте позже")
+	default:
+		logger.Errorw(
+			"Ошибка при извлечении длинной ссылки",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		c.String(http.StatusInternalServerError, "Ошибка при извлечении длинной ссылки: %v", err)
+	}
 }
@@ -5,14 +5,18 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap/zaptest"
 
 	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
 	serviceMock "yp-go-short-url-service/internal/service/mock"
 )
 
@@ -34,8 +38,8 @@ func TestExtractingLongURLHandler_Handle(t *testing.T) {
 			shortURL: "abc123",
 			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
 				mockService.EXPECT().
-					ExtractLongURL(gomock.Any(), "abc123").
-					Return("https://example.com/very/long/url", nil)
+					ExtractLongURL(gomock.Any(), "abc123", gomock.Any()).
+					Return(&model.Redirect{Location: "https://example.com/very/long/url", StatusCode: http.StatusTemporaryRedirect}, nil)
 			},
 			expectedStatus: http.StatusTemporaryRedirect,
 			expectedHeader: "Location",
@@ -55,8 +59,8 @@ func TestExtractingLongURLHandler_Handle(t *testing.T) {
 			shortURL: "error123",
 			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
 				mockService.EXPECT().
-					ExtractLongURL(gomock.Any(), "error123").
-					Return("", errors.New("database connection failed"))
+					ExtractLongURL(gomock.Any(), "error123", gomock.Any()).
+					Return(nil, errors.New("database connection failed"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   "Ошибка при извлечении длинной ссылки: database connection failed",
@@ -66,8 +70,8 @@ func TestExtractingLongURLHandler_Handle(t *testing.T) {
 			shortURL: "notfound",
 			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
 				mockService.EXPECT().
-					ExtractLongURL(gomock.Any(), "notfound").
-					Return("", nil)
+					ExtractLongURL(gomock.Any(), "notfound", gomock.Any()).
+					Return(nil, nil)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   "Ссылка не найдена",
@@ -77,19 +81,52 @@ func TestExtractingLongURLHandler_Handle(t *testing.T) {
 			shortURL: "empty",
 			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
 				mockService.EXPECT().
-					ExtractLongURL(gomock.Any(), "empty").
-					Return("", nil)
+					ExtractLongURL(gomock.Any(), "empty", gomock.Any()).
+					Return(nil, nil)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   "Ссылка не найдена",
 		},
+		{
+			name:     "срок действия ссылки истек",
+			shortURL: "expired",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "expired", gomock.Any()).
+					Return(nil, service.ErrURLExpired)
+			},
+			expectedStatus: http.StatusGone,
+			expectedBody:   "Срок действия ссылки истек",
+		},
+		{
+			name:     "лимит переходов исчерпан",
+			shortURL: "invite1",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "invite1", gomock.Any()).
+					Return(nil, service.ErrClickLimitReached)
+			},
+			expectedStatus: http.StatusGone,
+			expectedBody:   "Лимит переходов по ссылке исчерпан",
+		},
+		{
+			name:     "ссылка отключена владельцем",
+			shortURL: "paused1",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "paused1", gomock.Any()).
+					Return(nil, service.ErrURLDisabled)
+			},
+			expectedStatus: http.StatusGone,
+			expectedBody:   "Ссылка отключена владельцем",
+		},
 		{
 			name:     "специальные символы в shortURL",
 			shortURL: "test-123_456",
 			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
 				mockService.EXPECT().
-					ExtractLongURL(gomock.Any(), "test-123_456").
-					Return("https://example.com/special-chars", nil)
+					ExtractLongURL(gomock.Any(), "test-123_456", gomock.Any()).
+					Return(&model.Redirect{Location: "https://example.com/special-chars", StatusCode: http.StatusTemporaryRedirect}, nil)
 			},
 			expectedStatus: http.StatusTemporaryRedirect,
 			expectedHeader: "Location",
@@ -159,8 +196,8 @@ func TestExtractingLongURLHandler_Handle_WithRequestID(t *testing.T) {
 
 		mockService := serviceMock.NewMockURLExtractorService(ctrl)
 		mockService.EXPECT().
-			ExtractLongURL(gomock.Any(), "custom123").
-			Return("https://example.com/custom", nil)
+			ExtractLongURL(gomock.Any(), "custom123", gomock.Any()).
+			Return(&model.Redirect{Location: "https://example.com/custom", StatusCode: http.StatusTemporaryRedirect}, nil)
 
 		handler := NewExtractingFullLinkHandler(mockService)
 
@@ -195,8 +232,8 @@ func TestExtractingLongURLHandler_Handle_WithRequestID(t *testing.T) {
 
 		mockService := serviceMock.NewMockURLExtractorService(ctrl)
 		mockService.EXPECT().
-			ExtractLongURL(gomock.Any(), "no-request-id").
-			Return("https://example.com/no-request-id", nil)
+			ExtractLongURL(gomock.Any(), "no-request-id", gomock.Any()).
+			Return(&model.Redirect{Location: "https://example.com/no-request-id", StatusCode: http.StatusTemporaryRedirect}, nil)
 
 		handler := NewExtractingFullLinkHandler(mockService)
 
@@ -234,8 +271,8 @@ func TestExtractingLongURLHandler_Handle_ServiceIntegration(t *testing.T) {
 
 		// Ожидаем, что ExtractLongURL будет вызван с контекстом, содержащим логгер и request ID
 		mockService.EXPECT().
-			ExtractLongURL(gomock.Any(), "test123").
-			DoAndReturn(func(ctx context.Context, shortURL string) (string, error) {
+			ExtractLongURL(gomock.Any(), "test123", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, shortURL string, _ model.RedirectRequest) (*model.Redirect, error) {
 				// Проверяем, что в контексте есть логгер
 				log := middleware.GetLogger(ctx)
 				assert.NotNil(t, log)
@@ -247,7 +284,7 @@ func TestExtractingLongURLHandler_Handle_ServiceIntegration(t *testing.T) {
 				// Проверяем, что shortURL передается правильно
 				assert.Equal(t, "test123", shortURL)
 
-				return "https://example.com/test", nil
+				return &model.Redirect{Location: "https://example.com/test", StatusCode: http.StatusTemporaryRedirect}, nil
 			})
 
 		handler := NewExtractingFullLinkHandler(mockService)
@@ -310,8 +347,8 @@ func TestExtractingLongURLHandler_Handle_EdgeCases(t *testing.T) {
 		longShortURL := "very-long-short-url-that-exceeds-normal-length-limits-and-should-be-handled-properly"
 		mockService := serviceMock.NewMockURLExtractorService(ctrl)
 		mockService.EXPECT().
-			ExtractLongURL(gomock.Any(), longShortURL).
-			Return("https://example.com/very-long", nil)
+			ExtractLongURL(gomock.Any(), longShortURL, gomock.Any()).
+			Return(&model.Redirect{Location: "https://example.com/very-long", StatusCode: http.StatusTemporaryRedirect}, nil)
 
 		handler := NewExtractingFullLinkHandler(mockService)
 
@@ -346,8 +383,8 @@ func TestExtractingLongURLHandler_Handle_EdgeCases(t *testing.T) {
 
 		mockService := serviceMock.NewMockURLExtractorService(ctrl)
 		mockService.EXPECT().
-			ExtractLongURL(gomock.Any(), "special-123_456").
-			Return("https://example.com/special-chars", nil)
+			ExtractLongURL(gomock.Any(), "special-123_456", gomock.Any()).
+			Return(&model.Redirect{Location: "https://example.com/special-chars", StatusCode: http.StatusTemporaryRedirect}, nil)
 
 		handler := NewExtractingFullLinkHandler(mockService)
 
@@ -382,8 +419,8 @@ func TestExtractingLongURLHandler_Handle_EdgeCases(t *testing.T) {
 
 		mockService := serviceMock.NewMockURLExtractorService(ctrl)
 		mockService.EXPECT().
-			ExtractLongURL(gomock.Any(), "timeout").
-			Return("", errors.New("connection timeout"))
+			ExtractLongURL(gomock.Any(), "timeout", gomock.Any()).
+			Return(nil, errors.New("connection timeout"))
 
 		handler := NewExtractingFullLinkHandler(mockService)
 
@@ -410,3 +447,128 @@ func TestExtractingLongURLHandler_Handle_EdgeCases(t *testing.T) {
 		assert.Equal(t, "Ошибка при извлечении длинной ссылки: connection timeout", w.Body.String())
 	})
 }
+
+func TestExtractingLongURLHandler_Handle_Redirect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := serviceMock.NewMockURLExtractorService(ctrl)
+	// Служебный параметр подтверждения не передается в адрес назначения
+	mockService.EXPECT().
+		ConfirmLongURL(gomock.Any(), "landing1", model.RedirectRequest{Query: url.Values{"utm_source": {"mail"}}, ClientIP: "192.0.2.1"}).
+		Return(&model.Redirect{Location: "https://example.com/landing?utm_source=mail", StatusCode: http.StatusMovedPermanently}, nil)
+
+	handler := NewExtractingFullLinkHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	ctx := middleware.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	c.Request = httptest.NewRequest(http.MethodGet, "/landing1?utm_source=mail&confirm=1", nil).WithContext(ctx)
+	c.Params = gin.Params{gin.Param{Key: "shortURL", Value: "landing1"}}
+
+	handler.Handle(c)
+
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "https://example.com/landing?utm_source=mail", w.Header().Get("Location"))
+}
+
+func TestExtractingLongURLHandler_Handle_RoutingAttributes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := serviceMock.NewMockURLExtractorService(ctrl)
+	// Сведения о клиенте передаются в сервис для правил условного перенаправления
+	mockService.EXPECT().
+		ExtractLongURL(gomock.Any(), "app1", model.RedirectRequest{
+			Query:          url.Values{},
+			UserAgent:      "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)",
+			AcceptLanguage: "ru-RU,ru;q=0.9",
+			ClientIP:       "203.0.113.7",
+		}).
+		Return(&model.Redirect{Location: "https://apps.apple.com/app/id1", StatusCode: http.StatusTemporaryRedirect}, nil)
+
+	handler := NewExtractingFullLinkHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	ctx := middleware.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	c.Request = httptest.NewRequest(http.MethodGet, "/app1", nil).WithContext(ctx)
+	c.Request.RemoteAddr = "203.0.113.7:5123"
+	c.Request.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)")
+	c.Request.Header.Set("Accept-Language", "ru-RU,ru;q=0.9")
+	c.Params = gin.Params{gin.Param{Key: "shortURL", Value: "app1"}}
+
+	handler.Handle(c)
+
+	assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	assert.Equal(t, "https://apps.apple.com/app/id1", w.Header().Get("Location"))
+}
+
+func TestExtractingLongURLHandler_Handle_StickyVariant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := serviceMock.NewMockURLExtractorService(ctrl)
+	handler := NewExtractingFullLinkHandler(mockService)
+	ctx := middleware.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	t.Run("chosen variant is remembered in cookie", func(t *testing.T) {
+		mockService.EXPECT().
+			ExtractLongURL(gomock.Any(), "ab1", model.RedirectRequest{Query: url.Values{}, ClientIP: "192.0.2.1"}).
+			Return(&model.Redirect{Location: "https://example.com/b", StatusCode: http.StatusTemporaryRedirect, Variant: "b"}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/ab1", nil).WithContext(ctx)
+		c.Params = gin.Params{gin.Param{Key: "shortURL", Value: "ab1"}}
+
+		handler.Handle(c)
+
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Equal(t, "https://example.com/b", w.Header().Get("Location"))
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, variantCookieName, cookies[0].Name)
+		assert.Equal(t, "b", cookies[0].Value)
+		assert.Equal(t, "/ab1", cookies[0].Path)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, int(variantCookieMaxAge.Seconds()), cookies[0].MaxAge)
+	})
+
+	t.Run("remembered variant is passed to service", func(t *testing.T) {
+		mockService.EXPECT().
+			ExtractLongURL(gomock.Any(), "ab1", model.RedirectRequest{Query: url.Values{}, ClientIP: "192.0.2.1", Variant: "b"}).
+			Return(&model.Redirect{Location: "https://example.com/b", StatusCode: http.StatusTemporaryRedirect, Variant: "b"}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/ab1", nil).WithContext(ctx)
+		c.Request.AddCookie(&http.Cookie{Name: variantCookieName, Value: "b"})
+		c.Params = gin.Params{gin.Param{Key: "shortURL", Value: "ab1"}}
+
+		handler.Handle(c)
+
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Equal(t, "https://example.com/b", w.Header().Get("Location"))
+	})
+
+	t.Run("links without variants set no cookie", func(t *testing.T) {
+		mockService.EXPECT().
+			ExtractLongURL(gomock.Any(), "plain1", gomock.Any()).
+			Return(&model.Redirect{Location: "https://example.com", StatusCode: http.StatusTemporaryRedirect}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/plain1", nil).WithContext(ctx)
+		c.Params = gin.Params{gin.Param{Key: "shortURL", Value: "plain1"}}
+
+		handler.Handle(c)
+
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Empty(t, w.Result().Cookies())
+	})
+}
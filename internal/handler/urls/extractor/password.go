@@ -0,0 +1,73 @@
+package extractor
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+	"yp-go-short-url-service/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// passwordHeader - заголовок, в котором можно передать пароль защищенной ссылки
+	passwordHeader = "X-Link-Password"
+	// passwordFormField - имя поля формы с паролем защищенной ссылки
+	passwordFormField = "password"
+	// pathFormField - имя скрытого поля формы с путем после короткого кода ссылки-префикса
+	pathFormField = "path"
+)
+
+var passwordFormTemplate = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>Ссылка защищена паролем</title>
+</head>
+<body>
+<h1>Ссылка защищена паролем</h1>
+{{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+<form method="post" action="{{.Action}}">
+{{if .Path}}<input type="hidden" name="path" value="{{.Path}}">
+{{end}}<label>Пароль: <input type="password" name="password" required autofocus></label>
+<button type="submit">Перейти</button>
+</form>
+</body>
+</html>
+`))
+
+type passwordFormData struct {
+	Action string
+	Path   string
+	Error  string
+}
+
+// renderPasswordForm отображает форму ввода пароля защищенной ссылки со статусом 401.
+// Путь после короткого кода передается в скрытом поле формы, так как форма отправляется на адрес ввода пароля.
+func renderPasswordForm(c *gin.Context, shortURL, errorMessage string) {
+	c.Status(http.StatusUnauthorized)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+
+	data := passwordFormData{
+		Action: linkAction(c, "/"+url.PathEscape(shortURL)+"/unlock", false),
+		Path:   requestPathSuffix(c),
+		Error:  errorMessage,
+	}
+	if err := passwordFormTemplate.Execute(c.Writer, data); err != nil {
+		middleware.GetLogger(c.Request.Context()).Errorw("Ошибка при отображении формы ввода пароля",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", middleware.ExtractRequestID(c.Request.Context()),
+		)
+	}
+}
+
+// requestPathSuffix возвращает путь после короткого кода: из маршрута перехода или из скрытого поля формы,
+// если запрос пришел на адрес ввода пароля.
+func requestPathSuffix(c *gin.Context) string {
+	if suffix := pathSuffix(c); suffix != "" {
+		return suffix
+	}
+
+	return c.PostForm(pathFormField)
+}
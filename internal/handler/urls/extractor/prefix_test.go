@@ -0,0 +1,160 @@
+package extractor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+	serviceMock "yp-go-short-url-service/internal/service/mock"
+)
+
+func TestExtractingLongURLHandler_Handle_Prefix(t *testing.T) {
+	logger := zaptest.NewLogger(t).Sugar()
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		form           url.Values
+		setupMock      func(service *serviceMock.MockURLExtractorService)
+		expectedStatus int
+		expectedBody   []string
+		expectedValue  string
+	}{
+		{
+			name:   "путь после кода передается в исходном экранировании",
+			method: http.MethodGet,
+			target: "/docs1/guide/a%2Fb?x=1",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "docs1", model.RedirectRequest{Query: url.Values{"x": {"1"}}, PathSuffix: "/guide/a%2Fb", ClientIP: "192.0.2.1"}).
+					Return(&model.Redirect{Location: "https://example.com/guide/a%2Fb?x=1", StatusCode: http.StatusTemporaryRedirect}, nil)
+			},
+			expectedStatus: http.StatusTemporaryRedirect,
+			expectedValue:  "https://example.com/guide/a%2Fb?x=1",
+		},
+		{
+			name:   "ссылка без пути",
+			method: http.MethodGet,
+			target: "/docs1",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "docs1", model.RedirectRequest{Query: url.Values{}, ClientIP: "192.0.2.1"}).
+					Return(&model.Redirect{Location: "https://example.com", StatusCode: http.StatusTemporaryRedirect}, nil)
+			},
+			expectedStatus: http.StatusTemporaryRedirect,
+			expectedValue:  "https://example.com",
+		},
+		{
+			name:           "статический маршрут имеет приоритет",
+			method:         http.MethodGet,
+			target:         "/ping",
+			setupMock:      func(mockService *serviceMock.MockURLExtractorService) {},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"pong"},
+		},
+		{
+			name:   "путь для обычной ссылки",
+			method: http.MethodGet,
+			target: "/plain1/docs",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "plain1", gomock.Any()).
+					Return(nil, service.ErrURLNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "некорректный путь",
+			method: http.MethodGet,
+			target: "/docs1/%2e%2e/admin",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "docs1", gomock.Any()).
+					Return(nil, service.ErrInvalidPathSuffix)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{"Некорректный путь после короткого кода"},
+		},
+		{
+			name:   "страница предупреждения сохраняет путь",
+			method: http.MethodGet,
+			target: "/warn1/docs?utm_source=mail",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "warn1", gomock.Any()).
+					Return(&model.Redirect{Location: "https://example.com/docs?utm_source=mail", StatusCode: http.StatusTemporaryRedirect}, service.ErrInterstitialRequired)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`href="/warn1/docs?confirm=1&amp;utm_source=mail"`},
+		},
+		{
+			name:   "форма ввода пароля сохраняет путь",
+			method: http.MethodGet,
+			target: "/secret1/docs/page",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "secret1", gomock.Any()).
+					Return(nil, service.ErrPasswordRequired)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   []string{`action="/secret1/unlock"`, `name="path" value="/docs/page"`},
+		},
+		{
+			name:   "путь из формы ввода пароля",
+			method: http.MethodPost,
+			target: "/secret1/unlock",
+			form:   url.Values{"password": {"s3cret"}, "path": {"/docs/page"}},
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					UnlockLongURL(gomock.Any(), "secret1", "s3cret", model.RedirectRequest{Query: url.Values{}, PathSuffix: "/docs/page", ClientIP: "192.0.2.1"}).
+					Return(&model.Redirect{Location: "https://example.com/docs/page", StatusCode: http.StatusTemporaryRedirect}, nil)
+			},
+			expectedStatus: http.StatusSeeOther,
+			expectedValue:  "https://example.com/docs/page",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := serviceMock.NewMockURLExtractorService(ctrl)
+			tt.setupMock(mockService)
+
+			router := gin.New()
+			router.Use(middleware.LoggerMiddleware(logger))
+			router.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+			router.GET("/:shortURL", NewExtractingFullLinkHandler(mockService).Handle)
+			router.GET("/:shortURL/*path", NewExtractingFullLinkHandler(mockService).Handle)
+			router.POST("/:shortURL/unlock", NewUnlockFullLinkHandler(mockService).Handle)
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.form.Encode()))
+			if tt.form != nil {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, body := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), body)
+			}
+			if tt.expectedValue != "" {
+				assert.Equal(t, tt.expectedValue, w.Header().Get("Location"))
+			}
+		})
+	}
+}
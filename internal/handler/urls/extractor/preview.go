@@ -0,0 +1,188 @@
+package extractor
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// previewSuffix - суффикс короткого кода, по которому вместо перехода показывается страница предпросмотра
+	previewSuffix = "+"
+	// previewQueryParam - параметр запроса, по которому вместо перехода показывается страница предпросмотра
+	previewQueryParam = "preview"
+	// confirmQueryParam - параметр запроса, подтверждающий переход по ссылке со страницей предупреждения
+	confirmQueryParam = "confirm"
+	// pathParam - параметр маршрута с путем после короткого кода ссылки-префикса
+	pathParam = "path"
+	// previewDateLayout - формат даты создания ссылки на странице предпросмотра
+	previewDateLayout = "02.01.2006 15:04 MST"
+)
+
+var previewTemplate = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>{{if .Title}}{{.Title}}{{else}}Предпросмотр ссылки{{end}}</title>
+</head>
+<body>
+<h1>{{if .Title}}{{.Title}}{{else}}Предпросмотр ссылки{{end}}</h1>
+<dl>
+{{if .PasswordProtected}}<dt>Адрес назначения</dt><dd>Скрыт: ссылка защищена паролем</dd>
+{{else}}<dt>Адрес назначения</dt><dd>{{.LongURL}}</dd>
+{{end}}<dt>Создана</dt><dd>{{.CreatedAt}}</dd>
+</dl>
+<p><a href="{{.Action}}">Перейти</a></p>
+</body>
+</html>
+`))
+
+var interstitialTemplate = template.Must(template.New("interstitial").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>Переход на внешний сайт</title>
+</head>
+<body>
+<h1>Вы покидаете сайт</h1>
+<p role="alert">Ссылка ведет на внешний сайт. Убедитесь, что доверяете ему, прежде чем продолжить.</p>
+<p>{{.LongURL}}</p>
+<p><a href="{{.Action}}" rel="noreferrer">Продолжить</a></p>
+</body>
+</html>
+`))
+
+type previewData struct {
+	Title             string
+	LongURL           string
+	CreatedAt         string
+	PasswordProtected bool
+	Action            string
+}
+
+type interstitialData struct {
+	LongURL string
+	Action  string
+}
+
+// parsePreviewRequest определяет, запрошена ли страница предпросмотра ссылки.
+// Возвращает короткий код без суффикса "+" и признак запроса предпросмотра.
+func parsePreviewRequest(c *gin.Context, shortURL string) (string, bool) {
+	if code, ok := strings.CutSuffix(shortURL, previewSuffix); ok {
+		return code, true
+	}
+
+	return shortURL, isQueryFlagSet(c, previewQueryParam)
+}
+
+// redirectRequest собирает сведения о запросе перехода: параметры запроса без служебных параметров
+// предпросмотра и подтверждения, путь после короткого кода, User-Agent, Accept-Language
+// и IP-адрес клиента для правил условного перенаправления, а также закрепленный за посетителем вариант
+// адреса назначения.
+func redirectRequest(c *gin.Context) model.RedirectRequest {
+	query := c.Request.URL.Query()
+	query.Del(previewQueryParam)
+	query.Del(confirmQueryParam)
+
+	return model.RedirectRequest{
+		Query:          query,
+		PathSuffix:     pathSuffix(c),
+		UserAgent:      c.Request.UserAgent(),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+		ClientIP:       c.ClientIP(),
+		Variant:        stickyVariant(c),
+	}
+}
+
+// pathSuffix возвращает путь после короткого кода в исходном экранированном виде, чтобы закодированные
+// символы, например "%2F", не превращались в разделители сегментов. Для маршрута без пути возвращает пустую строку.
+func pathSuffix(c *gin.Context) string {
+	if c.Param(pathParam) == "" {
+		return ""
+	}
+
+	_, suffix, _ := strings.Cut(strings.TrimPrefix(c.Request.URL.EscapedPath(), "/"), "/")
+	return "/" + suffix
+}
+
+// linkPath возвращает путь короткой ссылки shortURL вместе с путем после короткого кода из запроса.
+func linkPath(c *gin.Context, shortURL string) string {
+	return "/" + url.PathEscape(shortURL) + pathSuffix(c)
+}
+
+// linkAction возвращает адрес path с параметрами запроса перехода, чтобы они не терялись
+// при переходе через страницу предпросмотра, предупреждения или форму ввода пароля.
+// Если confirm равен true, к адресу добавляется параметр подтверждения перехода.
+func linkAction(c *gin.Context, path string, confirm bool) string {
+	query := redirectRequest(c).Query
+	if confirm {
+		query.Set(confirmQueryParam, "1")
+	}
+	if len(query) == 0 {
+		return path
+	}
+
+	return path + "?" + query.Encode()
+}
+
+// isQueryFlagSet сообщает, передан ли в запросе флаг name со значением "1" или "true".
+func isQueryFlagSet(c *gin.Context, name string) bool {
+	value := strings.ToLower(c.Query(name))
+	return value == "1" || value == "true"
+}
+
+// confirmAction возвращает адрес, по которому пользователь подтверждает переход по ссылке.
+// Для защищенной паролем ссылки подтверждение не требуется: переход выполняется после ввода пароля.
+func confirmAction(c *gin.Context, link *model.URLsModel) string {
+	return linkAction(c, linkPath(c, link.ShortURL), !link.IsPasswordProtected())
+}
+
+// renderPreview отображает страницу предпросмотра ссылки со статусом 200.
+// Адрес назначения защищенной паролем ссылки не раскрывается.
+func renderPreview(c *gin.Context, link *model.URLsModel) {
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+
+	data := previewData{
+		Title:             link.Title,
+		CreatedAt:         link.CreatedAt.In(time.UTC).Format(previewDateLayout),
+		PasswordProtected: link.IsPasswordProtected(),
+		Action:            confirmAction(c, link),
+	}
+	if !data.PasswordProtected {
+		data.LongURL = link.LongURL
+	}
+	if err := previewTemplate.Execute(c.Writer, data); err != nil {
+		middleware.GetLogger(c.Request.Context()).Errorw("Ошибка при отображении страницы предпросмотра",
+			"error", err,
+			"short_url", link.ShortURL,
+			"request_id", middleware.ExtractRequestID(c.Request.Context()),
+		)
+	}
+}
+
+// renderInterstitial отображает страницу предупреждения перед переходом по ссылке со статусом 200.
+func renderInterstitial(c *gin.Context, shortURL, longURL string) {
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+
+	data := interstitialData{
+		LongURL: longURL,
+		Action:  linkAction(c, linkPath(c, shortURL), true),
+	}
+	if err := interstitialTemplate.Execute(c.Writer, data); err != nil {
+		middleware.GetLogger(c.Request.Context()).Errorw("Ошибка при отображении страницы предупреждения",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", middleware.ExtractRequestID(c.Request.Context()),
+		)
+	}
+}
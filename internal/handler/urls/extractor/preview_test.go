@@ -0,0 +1,169 @@
+package extractor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+	serviceMock "yp-go-short-url-service/internal/service/mock"
+)
+
+func TestExtractingLongURLHandler_Handle_Preview(t *testing.T) {
+	logger := zaptest.NewLogger(t).Sugar()
+
+	createdAt := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+	passwordHash := "hash"
+
+	tests := []struct {
+		name           string
+		shortURL       string
+		query          string
+		setupMock      func(service *serviceMock.MockURLExtractorService)
+		expectedStatus int
+		expectedBody   []string
+		unexpectedBody string
+		expectedValue  string
+	}{
+		{
+			name:     "предпросмотр по суффиксу",
+			shortURL: "report1+",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ResolveURL(gomock.Any(), "report1").
+					Return(&model.URLsModel{ShortURL: "report1", LongURL: "https://example.com/report?q=1&x=2", Title: "Отчет <Q1>", CreatedAt: createdAt}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				"<h1>Отчет &lt;Q1&gt;</h1>",
+				"https://example.com/report?q=1&amp;x=2",
+				"14.03.2026 09:30 UTC",
+				`href="/report1?confirm=1"`,
+			},
+		},
+		{
+			name:     "предпросмотр по параметру запроса",
+			shortURL: "report1",
+			query:    "?preview=1",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ResolveURL(gomock.Any(), "report1").
+					Return(&model.URLsModel{ShortURL: "report1", LongURL: "https://example.com/report", CreatedAt: createdAt}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"Предпросмотр ссылки", "https://example.com/report"},
+		},
+		{
+			name:     "адрес защищенной паролем ссылки скрыт",
+			shortURL: "secret1+",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ResolveURL(gomock.Any(), "secret1").
+					Return(&model.URLsModel{ShortURL: "secret1", LongURL: "https://example.com/private", PasswordHash: &passwordHash, CreatedAt: createdAt}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"ссылка защищена паролем", `href="/secret1"`},
+			unexpectedBody: "https://example.com/private",
+		},
+		{
+			name:     "предпросмотр несуществующей ссылки",
+			shortURL: "missing+",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ResolveURL(gomock.Any(), "missing").
+					Return(nil, service.ErrURLNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   []string{"Ссылка не найдена"},
+		},
+		{
+			name:     "предпросмотр удаленной ссылки",
+			shortURL: "gone1+",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ResolveURL(gomock.Any(), "gone1").
+					Return(nil, service.ErrURLWasDeleted)
+			},
+			expectedStatus: http.StatusGone,
+			expectedBody:   []string{"Ссылка была удалена"},
+		},
+		{
+			name:     "страница предупреждения перед переходом",
+			shortURL: "warn1",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "warn1", gomock.Any()).
+					Return(&model.Redirect{Location: "https://example.com/external", StatusCode: http.StatusTemporaryRedirect}, service.ErrInterstitialRequired)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"Вы покидаете сайт", "https://example.com/external", `href="/warn1?confirm=1"`},
+		},
+		{
+			name:     "страница предупреждения сохраняет параметры запроса",
+			shortURL: "warn1",
+			query:    "?utm_source=mail",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "warn1", model.RedirectRequest{Query: url.Values{"utm_source": {"mail"}}, ClientIP: "192.0.2.1"}).
+					Return(&model.Redirect{Location: "https://example.com/external?utm_source=mail", StatusCode: http.StatusTemporaryRedirect}, service.ErrInterstitialRequired)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`href="/warn1?confirm=1&amp;utm_source=mail"`},
+		},
+		{
+			name:     "подтвержденный переход",
+			shortURL: "warn1",
+			query:    "?confirm=1",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ConfirmLongURL(gomock.Any(), "warn1", gomock.Any()).
+					Return(&model.Redirect{Location: "https://example.com/external", StatusCode: http.StatusTemporaryRedirect}, nil)
+			},
+			expectedStatus: http.StatusTemporaryRedirect,
+			expectedValue:  "https://example.com/external",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := serviceMock.NewMockURLExtractorService(ctrl)
+			tt.setupMock(mockService)
+
+			handler := NewExtractingFullLinkHandler(mockService)
+
+			ctx := middleware.WithLogger(context.Background(), logger)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.shortURL+tt.query, nil).WithContext(ctx)
+			c.Params = gin.Params{gin.Param{Key: "shortURL", Value: tt.shortURL}}
+
+			handler.Handle(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, body := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), body)
+			}
+			if tt.unexpectedBody != "" {
+				assert.NotContains(t, w.Body.String(), tt.unexpectedBody)
+			}
+			if tt.expectedValue != "" {
+				assert.Equal(t, tt.expectedValue, w.Header().Get("Location"))
+			}
+		})
+	}
+}
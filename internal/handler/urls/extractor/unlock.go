@@ -0,0 +1,81 @@
+package extractor
+
+import (
+	"net/http"
+	"yp-go-short-url-service/internal/handler"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type unlockLongURLHandler struct {
+	service service.URLExtractorService
+}
+
+// NewUnlockFullLinkHandler создает новый обработчик для ввода пароля защищенной ссылки.
+// Принимает сервис извлечения URL и возвращает обработчик, реализующий интерфейс Handler.
+func NewUnlockFullLinkHandler(service service.URLExtractorService) handler.Handler {
+	return &unlockLongURLHandler{
+		service: service,
+	}
+}
+
+// Handle UnlockLongURL godoc
+// @Summary Ввод пароля защищенной ссылки
+// @Description Проверяет пароль защищенной ссылки и перенаправляет пользователя на оригинальный длинный URL
+// @Tags redirect
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param shortURL path string true "Короткий URL" example(abc123)
+// @Param password formData string true "Пароль ссылки"
+// @Param path formData string false "Путь после короткого кода для ссылки-префикса" example(/docs/page)
+// @Success 303 {string} string "Перенаправление на длинный URL"
+// @Failure 400 {string} string "Неверный запрос"
+// @Failure 401 {string} string "Указан неверный пароль"
+// @Failure 410 {string} string "Ссылка удалена, отключена владельцем, срок ее действия истек или исчерпан лимит переходов"
+// @Failure 429 {string} string "Превышено число попыток ввода пароля"
+// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Router /{shortURL}/unlock [post]
+func (h *unlockLongURLHandler) Handle(c *gin.Context) {
+	logger := middleware.GetLogger(c.Request.Context())
+	requestID := middleware.ExtractRequestID(c.Request.Context())
+
+	shortURL := c.Param("shortURL")
+	if shortURL == "" {
+		logger.Errorw(
+			"Параметр shortURL не может быть пустым",
+			"short_url", shortURL,
+			"request_id", requestID)
+		c.String(http.StatusBadRequest, "Параметр shortURL не может быть пустым")
+		return
+	}
+
+	password := c.PostForm(passwordFormField)
+	if password == "" {
+		renderPasswordForm(c, shortURL, "Введите пароль")
+		return
+	}
+
+	req := redirectRequest(c)
+	req.PathSuffix = requestPathSuffix(c)
+
+	redirect, err := h.service.UnlockLongURL(c.Request.Context(), shortURL, password, req)
+	if err != nil {
+		writeExtractError(c, shortURL, err)
+		return
+	}
+	if redirect == nil || redirect.Location == "" {
+		logger.Infow("Ссылка не найдена в базе данных",
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		c.String(http.StatusBadRequest, "Ссылка не найдена")
+		return
+	}
+
+	// 303 переводит POST-запрос формы в GET на длинный URL независимо от статуса перенаправления ссылки
+	rememberVariant(c, shortURL, redirect.Variant)
+	c.Redirect(http.StatusSeeOther, redirect.Location)
+	logger.Infow("Перенаправление на длинный URL после ввода пароля", "redirect_url", redirect.Location, "request_id", requestID)
+}
@@ -0,0 +1,203 @@
+package extractor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+	serviceMock "yp-go-short-url-service/internal/service/mock"
+)
+
+func TestExtractingLongURLHandler_Handle_PasswordProtected(t *testing.T) {
+	logger := zaptest.NewLogger(t).Sugar()
+
+	tests := []struct {
+		name           string
+		password       string
+		setupMock      func(service *serviceMock.MockURLExtractorService)
+		expectedStatus int
+		expectedBody   string
+		expectedValue  string
+	}{
+		{
+			name: "без пароля отображается форма",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					ExtractLongURL(gomock.Any(), "secret1", gomock.Any()).
+					Return(nil, service.ErrPasswordRequired)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `action="/secret1/unlock"`,
+		},
+		{
+			name:     "верный пароль в заголовке",
+			password: "s3cret",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					UnlockLongURL(gomock.Any(), "secret1", "s3cret", gomock.Any()).
+					Return(&model.Redirect{Location: "https://example.com/private", StatusCode: http.StatusTemporaryRedirect}, nil)
+			},
+			expectedStatus: http.StatusTemporaryRedirect,
+			expectedValue:  "https://example.com/private",
+		},
+		{
+			name:     "неверный пароль в заголовке",
+			password: "guess",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					UnlockLongURL(gomock.Any(), "secret1", "guess", gomock.Any()).
+					Return(nil, service.ErrWrongPassword)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Неверный пароль",
+		},
+		{
+			name:     "превышено число попыток",
+			password: "guess",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					UnlockLongURL(gomock.Any(), "secret1", "guess", gomock.Any()).
+					Return(nil, service.ErrTooManyPasswordAttempts)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   "Превышено число попыток ввода пароля",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := serviceMock.NewMockURLExtractorService(ctrl)
+			tt.setupMock(mockService)
+
+			handler := NewExtractingFullLinkHandler(mockService)
+
+			ctx := middleware.WithLogger(context.Background(), logger)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/secret1", nil).WithContext(ctx)
+			if tt.password != "" {
+				c.Request.Header.Set(passwordHeader, tt.password)
+			}
+			c.Params = gin.Params{gin.Param{Key: "shortURL", Value: "secret1"}}
+
+			handler.Handle(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedValue != "" {
+				assert.Equal(t, tt.expectedValue, w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestUnlockLongURLHandler_Handle(t *testing.T) {
+	logger := zaptest.NewLogger(t).Sugar()
+
+	tests := []struct {
+		name           string
+		password       string
+		setupMock      func(service *serviceMock.MockURLExtractorService)
+		expectedStatus int
+		expectedBody   string
+		expectedValue  string
+	}{
+		{
+			name: "пустой пароль",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				// Сервис не должен вызываться без пароля
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Введите пароль",
+		},
+		{
+			name:     "верный пароль",
+			password: "s3cret",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					UnlockLongURL(gomock.Any(), "secret1", "s3cret", gomock.Any()).
+					Return(&model.Redirect{Location: "https://example.com/private", StatusCode: http.StatusTemporaryRedirect}, nil)
+			},
+			expectedStatus: http.StatusSeeOther,
+			expectedValue:  "https://example.com/private",
+		},
+		{
+			name:     "неверный пароль",
+			password: "guess",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					UnlockLongURL(gomock.Any(), "secret1", "guess", gomock.Any()).
+					Return(nil, service.ErrWrongPassword)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Неверный пароль",
+		},
+		{
+			name:     "ссылка удалена",
+			password: "s3cret",
+			setupMock: func(mockService *serviceMock.MockURLExtractorService) {
+				mockService.EXPECT().
+					UnlockLongURL(gomock.Any(), "secret1", "s3cret", gomock.Any()).
+					Return(nil, service.ErrURLWasDeleted)
+			},
+			expectedStatus: http.StatusGone,
+			expectedBody:   "Ссылка была удалена",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := serviceMock.NewMockURLExtractorService(ctrl)
+			tt.setupMock(mockService)
+
+			handler := NewUnlockFullLinkHandler(mockService)
+
+			ctx := middleware.WithLogger(context.Background(), logger)
+
+			form := url.Values{}
+			if tt.password != "" {
+				form.Set(passwordFormField, tt.password)
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/secret1/unlock", strings.NewReader(form.Encode())).WithContext(ctx)
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Params = gin.Params{gin.Param{Key: "shortURL", Value: "secret1"}}
+
+			handler.Handle(c)
+			// Редирект на POST-запрос не пишет тело, поэтому статус фиксируем явно, как это делает gin после обработчика
+			c.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedValue != "" {
+				assert.Equal(t, tt.expectedValue, w.Header().Get("Location"))
+			}
+		})
+	}
+}
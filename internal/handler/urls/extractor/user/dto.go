@@ -1,5 +1,10 @@
 package user
 
+import (
+	"time"
+	"yp-go-short-url-service/internal/model"
+)
+
 // UserURLResponse представляет ответ с URL пользователя
 // @Description Ответ с URL пользователя
 type UserURLResponse struct {
@@ -10,6 +15,59 @@ type UserURLResponse struct {
 	// @Description Оригинальный длинный URL
 	// @Example https://www.example.com/very/long/url/that/needs/to/be/shortened
 	OriginalURL string `json:"original_url" example:"https://www.example.com/very/long/url/that/needs/to/be/shortened"`
+
+	// @Description Момент истечения ссылки, если срок действия задан
+	// @Example 2030-01-01T00:00:00Z
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2030-01-01T00:00:00Z"`
+
+	// @Description Лимит переходов по ссылке, если он задан
+	// @Example 1
+	MaxClicks *int64 `json:"max_clicks,omitempty" example:"1"`
+
+	// @Description Оставшееся количество переходов для ссылки с лимитом
+	// @Example 1
+	ClicksLeft *int64 `json:"clicks_left,omitempty" example:"1"`
+
+	// @Description Признак того, что ссылка защищена паролем
+	// @Example true
+	PasswordProtected bool `json:"password_protected,omitempty" example:"true"`
+
+	// @Description Признак того, что ссылка отключена владельцем
+	// @Example true
+	Disabled bool `json:"disabled,omitempty" example:"true"`
+
+	// @Description Заголовок ссылки для страницы предпросмотра, если он задан
+	// @Example Квартальный отчет
+	Title string `json:"title,omitempty" example:"Квартальный отчет"`
+
+	// @Description Признак того, что перед переходом по ссылке показывается страница предупреждения
+	// @Example true
+	Interstitial bool `json:"interstitial,omitempty" example:"true"`
+
+	// @Description HTTP статус перенаправления по ссылке: 301, 302, 307 или 308
+	// @Example 301
+	RedirectCode int `json:"redirect_code" example:"301"`
+
+	// @Description Режим переноса параметров запроса в адрес назначения (merge или override), если он включен
+	// @Example merge
+	QueryPassthrough string `json:"query_passthrough,omitempty" example:"merge"`
+
+	// @Description Признак ссылки-префикса, которая переносит путь после короткого кода в адрес назначения
+	// @Example true
+	Prefix bool `json:"prefix,omitempty" example:"true"`
+
+	// @Description Правила условного перенаправления по устройству, языку и IP-адресу клиента, если они заданы
+	Rules []model.RoutingRule `json:"rules,omitempty"`
+
+	// @Description Варианты адреса назначения для A/B теста, если они заданы
+	Variants []model.SplitVariant `json:"variants,omitempty"`
+
+	// @Description Общее количество переходов по ссылке; обновляется с задержкой до периода сброса буфера переходов
+	// @Example 42
+	Clicks int64 `json:"clicks" example:"42"`
+
+	// @Description Результат последней проверки доступности адреса назначения; отсутствует, пока ссылка не проверялась
+	Health *model.LinkHealth `json:"health,omitempty"`
 }
 
 // UserURLsResponse представляет массив ответов с URL пользователей
@@ -7,6 +7,7 @@ import (
 	"yp-go-short-url-service/internal/config"
 	"yp-go-short-url-service/internal/handler"
 	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -66,16 +67,7 @@ func (h *extractingUserURLsHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	// Преобразуем данные в нужный формат
-	response := make(UserURLsResponse, len(userURLs))
-	for i, url := range userURLs {
-		response[i] = UserURLResponse{
-			ShortURL:    h.buildShortURL(url.ShortURL),
-			OriginalURL: url.LongURL,
-		}
-	}
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, buildUserURLsResponse(h.baseURL, userURLs))
 	logger.Infow("Successfully returned user URLs",
 		"request_id", requestID,
 		"user_id", user.ID,
@@ -83,10 +75,36 @@ func (h *extractingUserURLsHandler) Handle(c *gin.Context) {
 	)
 }
 
-func (h *extractingUserURLsHandler) buildShortURL(shortedURL string) string {
+// buildUserURLsResponse преобразует модели ссылок пользователя в ответ API.
+func buildUserURLsResponse(baseURL string, urls []*model.URLsModel) UserURLsResponse {
+	response := make(UserURLsResponse, len(urls))
+	for i, url := range urls {
+		response[i] = UserURLResponse{
+			ShortURL:          buildShortURL(baseURL, url.ShortURL),
+			OriginalURL:       url.LongURL,
+			ExpiresAt:         url.ExpiresAt,
+			MaxClicks:         url.MaxClicks,
+			ClicksLeft:        url.ClicksLeft,
+			PasswordProtected: url.IsPasswordProtected(),
+			Disabled:          url.IsDisabled,
+			Title:             url.Title,
+			Interstitial:      url.ShowInterstitial,
+			RedirectCode:      url.RedirectStatus(),
+			QueryPassthrough:  string(url.QueryPassthrough),
+			Prefix:            url.IsPrefix,
+			Rules:             url.RoutingRules,
+			Variants:          url.Variants,
+			Clicks:            url.Clicks,
+			Health:            url.Health,
+		}
+	}
+	return response
+}
+
+func buildShortURL(baseURL, shortedURL string) string {
 	return fmt.Sprintf(
 		"%s/%s",
-		strings.TrimRight(h.baseURL, "/"),
+		strings.TrimRight(baseURL, "/"),
 		shortedURL,
 	)
 }
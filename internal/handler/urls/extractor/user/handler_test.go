@@ -15,6 +15,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 )
@@ -64,6 +65,13 @@ func TestExtractingUserURLsHandler_Handle_Success(t *testing.T) {
 			LongURL:   "https://example.com/long-url-1",
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
+			Clicks:    42,
+			Health: &model.LinkHealth{
+				StatusCode:          http.StatusNotFound,
+				RedirectChain:       model.RedirectChain{"https://example.com/moved"},
+				ConsecutiveFailures: 2,
+				CheckedAt:           time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC),
+			},
 		},
 		{
 			ID:        2,
@@ -100,8 +108,15 @@ func TestExtractingUserURLsHandler_Handle_Success(t *testing.T) {
 	assert.Len(t, response, 2)
 	assert.Equal(t, "http://testhost:1234/abc123", response[0].ShortURL)
 	assert.Equal(t, "https://example.com/long-url-1", response[0].OriginalURL)
+	assert.Equal(t, int64(42), response[0].Clicks)
+	require.NotNil(t, response[0].Health)
+	assert.Equal(t, http.StatusNotFound, response[0].Health.StatusCode)
+	assert.Equal(t, model.RedirectChain{"https://example.com/moved"}, response[0].Health.RedirectChain)
+	assert.Equal(t, 2, response[0].Health.ConsecutiveFailures)
 	assert.Equal(t, "http://testhost:1234/def456", response[1].ShortURL)
 	assert.Equal(t, "https://example.com/long-url-2", response[1].OriginalURL)
+	assert.Zero(t, response[1].Clicks)
+	assert.Nil(t, response[1].Health)
 }
 
 func TestExtractingUserURLsHandler_Handle_NoUser(t *testing.T) {
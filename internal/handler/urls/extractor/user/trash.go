@@ -0,0 +1,73 @@
+package user
+
+import (
+	"net/http"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/handler"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewExtractingUserTrashHandler создает новый обработчик для получения удаленных URL пользователя (корзины) через API.
+// Принимает сервис извлечения URL и настройки приложения, возвращает обработчик, реализующий интерфейс Handler.
+func NewExtractingUserTrashHandler(service service.URLExtractorService, settings *config.Settings) handler.Handler {
+	return &extractingUserTrashHandler{
+		service: service,
+		baseURL: settings.GetBaseURL(),
+	}
+}
+
+type extractingUserTrashHandler struct {
+	baseURL string
+	service service.URLExtractorService
+}
+
+// Handle GetUserTrash godoc
+// @Summary Получить удаленные URL пользователя
+// @Description Возвращает удаленные URL пользователя (корзину), которые можно восстановить. Требует JWT аутентификации.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param Authorization header string false "JWT токен в заголовке Authorization (Bearer <token>)"
+// @Success 200 {array} user.UserURLResponse "Список удаленных URL пользователя успешно получен"
+// @Success 204 {array} user.UserURLResponse "Корзина пользователя пуста"
+// @Failure 401 {object} object "Не авторизован - JWT токен отсутствует или недействителен"
+// @Failure 500 {object} object "Внутренняя ошибка сервера"
+// @Router /api/user/urls/trash [get]
+func (h *extractingUserTrashHandler) Handle(c *gin.Context) {
+	logger := middleware.GetLogger(c.Request.Context())
+	requestID := middleware.ExtractRequestID(c.Request.Context())
+	user := middleware.GetJWTUserFromContext(c.Request.Context())
+	if user == nil {
+		logger.Errorw("User not found in context",
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusUnauthorized, gin.H{})
+
+		return
+	}
+
+	deletedURLs, err := h.service.ExtractUserDeletedURLs(c.Request.Context(), user.ID)
+	if err != nil {
+		logger.Errorw("Failed to extract user deleted URLs",
+			"request_id", requestID,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{})
+		return
+	}
+
+	if len(deletedURLs) == 0 {
+		c.JSON(http.StatusNoContent, make(UserURLsResponse, 0))
+		return
+	}
+
+	c.JSON(http.StatusOK, buildUserURLsResponse(h.baseURL, deletedURLs))
+	logger.Infow("Successfully returned user deleted URLs",
+		"request_id", requestID,
+		"user_id", user.ID,
+		"urls_count", len(deletedURLs),
+	)
+}
@@ -0,0 +1,92 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func setupTrashTestHandler(t *testing.T) (*gin.Engine, *mock.MockURLExtractorService) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	mockService := mock.NewMockURLExtractorService(ctrl)
+
+	handler := NewExtractingUserTrashHandler(mockService, getDefaultSettings())
+
+	logger, _ := zap.NewDevelopment()
+	router := gin.New()
+	router.Use(middleware.LoggerMiddleware(logger.Sugar()))
+	router.Use(middleware.RequestIDMiddleware(logger.Sugar()))
+	router.GET("/api/user/urls/trash", handler.Handle)
+
+	return router, mockService
+}
+
+func newTrashRequest(withUser bool) *http.Request {
+	req, _ := http.NewRequest("GET", "/api/user/urls/trash", nil)
+	if withUser {
+		ctx := context.WithValue(req.Context(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+		req = req.WithContext(ctx)
+	}
+	return req
+}
+
+func TestExtractingUserTrashHandler_Handle(t *testing.T) {
+	t.Run("корзина пользователя", func(t *testing.T) {
+		router, mockService := setupTrashTestHandler(t)
+		mockService.EXPECT().
+			ExtractUserDeletedURLs(gomock.Any(), "test-user-id").
+			Return([]*model.URLsModel{{ShortURL: "abc123", LongURL: "https://example.com/deleted", IsDeleted: true}}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newTrashRequest(true))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response UserURLsResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 1)
+		assert.Equal(t, "http://testhost:1234/abc123", response[0].ShortURL)
+		assert.Equal(t, "https://example.com/deleted", response[0].OriginalURL)
+	})
+
+	t.Run("пустая корзина", func(t *testing.T) {
+		router, mockService := setupTrashTestHandler(t)
+		mockService.EXPECT().ExtractUserDeletedURLs(gomock.Any(), "test-user-id").Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newTrashRequest(true))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("ошибка сервиса", func(t *testing.T) {
+		router, mockService := setupTrashTestHandler(t)
+		mockService.EXPECT().ExtractUserDeletedURLs(gomock.Any(), "test-user-id").Return(nil, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newTrashRequest(true))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("без пользователя", func(t *testing.T) {
+		router, _ := setupTrashTestHandler(t)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newTrashRequest(false))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,46 @@
+package extractor
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// variantCookieName - имя cookie, в которой хранится вариант адреса назначения, закрепленный за посетителем.
+	// Cookie ограничена путем короткой ссылки, поэтому у каждой ссылки свой закрепленный вариант.
+	variantCookieName = "split_variant"
+	// variantCookieMaxAge - срок, в течение которого за посетителем сохраняется выбранный вариант
+	variantCookieMaxAge = 30 * 24 * time.Hour
+)
+
+// stickyVariant возвращает имя варианта адреса назначения, ранее закрепленного за посетителем,
+// или пустую строку, если cookie не передана.
+func stickyVariant(c *gin.Context) string {
+	variant, err := c.Cookie(variantCookieName)
+	if err != nil {
+		return ""
+	}
+	return variant
+}
+
+// rememberVariant закрепляет за посетителем вариант адреса назначения ссылки shortURL,
+// чтобы следующие переходы по ней вели на тот же вариант. Пустой вариант не сохраняется.
+func rememberVariant(c *gin.Context, shortURL, variant string) {
+	if variant == "" {
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(
+		variantCookieName,
+		variant,
+		int(variantCookieMaxAge.Seconds()),
+		"/"+url.PathEscape(shortURL),
+		"",
+		c.Request.TLS != nil,
+		true,
+	)
+}
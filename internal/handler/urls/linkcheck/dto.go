@@ -0,0 +1,15 @@
+package linkcheck
+
+import "yp-go-short-url-service/internal/model"
+
+// BrokenLinkDTOOut представляет ссылку, адрес назначения которой недоступен несколько проверок подряд
+type BrokenLinkDTOOut struct {
+	// ShortURL - сокращенный URL
+	// example: "http://localhost:8080/abc123"
+	ShortURL string `json:"short_url"`
+	// OriginalURL - адрес назначения ссылки
+	// example: "https://example.com/removed-page"
+	OriginalURL string `json:"original_url"`
+	// Health - результат последней проверки доступности адреса назначения
+	Health model.LinkHealth `json:"health"`
+}
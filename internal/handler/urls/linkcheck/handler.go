@@ -0,0 +1,82 @@
+package linkcheck
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/handler"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewBrokenLinksHandler создает новый обработчик для получения ссылок с недоступными адресами назначения.
+// Принимает сервис проверки ссылок и настройки приложения, возвращает обработчик, реализующий интерфейс Handler.
+func NewBrokenLinksHandler(service service.LinkChecker, settings *config.Settings) handler.Handler {
+	return &brokenLinksHandler{
+		service:          service,
+		baseURL:          settings.GetBaseURL(),
+		defaultThreshold: settings.GetBrokenLinkThreshold(),
+	}
+}
+
+type brokenLinksHandler struct {
+	service          service.LinkChecker
+	baseURL          string
+	defaultThreshold int
+}
+
+// Handle GetBrokenLinks godoc
+// @Summary Получить нерабочие ссылки
+// @Description Возвращает ссылки, адреса назначения которых оказались недоступны не менее failures проверок подряд, начиная с ссылок с наибольшим числом неудач. Доступно только из доверенной подсети.
+// @Tags internal
+// @Produce json
+// @Param failures query int false "Минимальное число неудачных проверок подряд (по умолчанию из настроек)" example(3)
+// @Success 200 {array} BrokenLinkDTOOut "Нерабочие ссылки"
+// @Failure 400 {object} map[string]interface{} "Неверный запрос"
+// @Failure 403 {object} map[string]interface{} "Запрос не из доверенной подсети"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /api/internal/broken-links [get]
+func (h *brokenLinksHandler) Handle(c *gin.Context) {
+	requestCtx := c.Request.Context()
+
+	logger := middleware.GetLogger(requestCtx)
+	requestID := middleware.ExtractRequestID(requestCtx)
+
+	failures := h.defaultThreshold
+	if rawFailures := c.Query("failures"); rawFailures != "" {
+		parsed, err := strconv.Atoi(rawFailures)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failures must be a positive integer"})
+			return
+		}
+		failures = parsed
+	}
+
+	links, err := h.service.GetBrokenLinks(requestCtx, failures)
+	if err != nil {
+		logger.Errorw("Failed to get broken links",
+			"error", err,
+			"failures", failures,
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get broken links",
+		})
+		return
+	}
+
+	response := make([]BrokenLinkDTOOut, 0, len(links))
+	for _, link := range links {
+		response = append(response, BrokenLinkDTOOut{
+			ShortURL:    fmt.Sprintf("%s/%s", strings.TrimRight(h.baseURL, "/"), link.ShortURL),
+			OriginalURL: link.LongURL,
+			Health:      link.Health,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
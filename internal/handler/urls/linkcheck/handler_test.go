@@ -0,0 +1,136 @@
+package linkcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func getDefaultSettings() *config.Settings {
+	return &config.Settings{
+		EnvSettings: &config.ENVSettings{
+			Server: &config.ServerSettings{
+				ServerAddress: "testhost:1234",
+				ServerHost:    "testhost",
+				ServerPort:    1234,
+				ServerDomain:  "testdomain",
+				BaseURL:       "http://testhost:1234/",
+			},
+		},
+		Flags: &config.Flags{
+			ServerAddress:       "testhost:1234",
+			BaseURL:             "http://testhost:1234/",
+			BrokenLinkThreshold: 5,
+		},
+	}
+}
+
+func TestBrokenLinksHandler_Handle(t *testing.T) {
+	checkedAt := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	links := []*model.BrokenLink{
+		{
+			ShortURL: "abc123",
+			LongURL:  "https://example.com/removed",
+			Health:   model.LinkHealth{StatusCode: http.StatusNotFound, ConsecutiveFailures: 7, CheckedAt: checkedAt},
+		},
+		{
+			ShortURL: "def456",
+			LongURL:  "https://unreachable.example",
+			Health:   model.LinkHealth{Error: "connection refused", ConsecutiveFailures: 5, CheckedAt: checkedAt},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		callService      bool
+		expectedFailures int
+		links            []*model.BrokenLink
+		serviceErr       error
+		expectedStatus   int
+	}{
+		{
+			name:             "порог из настроек",
+			callService:      true,
+			expectedFailures: 5,
+			links:            links,
+			expectedStatus:   http.StatusOK,
+		},
+		{
+			name:             "порог из запроса",
+			query:            "?failures=1",
+			callService:      true,
+			expectedFailures: 1,
+			expectedStatus:   http.StatusOK,
+		},
+		{
+			name:           "некорректный порог",
+			query:          "?failures=many",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "нулевой порог",
+			query:          "?failures=0",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "ошибка сервиса",
+			callService:      true,
+			expectedFailures: 5,
+			serviceErr:       errors.New("unexpected error"),
+			expectedStatus:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			mockService := mock.NewMockLinkChecker(ctrl)
+			if tt.callService {
+				mockService.EXPECT().
+					GetBrokenLinks(gomock.Any(), tt.expectedFailures).
+					Return(tt.links, tt.serviceErr)
+			}
+
+			logger, _ := zap.NewDevelopment()
+			router := gin.New()
+			router.Use(middleware.LoggerMiddleware(logger.Sugar()))
+			router.GET("/api/internal/broken-links", NewBrokenLinksHandler(mockService, getDefaultSettings()).Handle)
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/internal/broken-links"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response []BrokenLinkDTOOut
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			expected := make([]BrokenLinkDTOOut, 0, len(tt.links))
+			for _, link := range tt.links {
+				expected = append(expected, BrokenLinkDTOOut{
+					ShortURL:    "http://testhost:1234/" + link.ShortURL,
+					OriginalURL: link.LongURL,
+					Health:      link.Health,
+				})
+			}
+			assert.Equal(t, expected, response)
+		})
+	}
+}
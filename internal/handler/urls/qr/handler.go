@@ -0,0 +1,172 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/handler"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultSize - размер изображения QR-кода в пикселях по умолчанию
+	DefaultSize = 256
+	// MinSize - минимальный размер изображения QR-кода в пикселях
+	MinSize = 64
+	// MaxSize - максимальный размер изображения QR-кода в пикселях
+	MaxSize = 2048
+	// DefaultMargin - ширина пустой зоны вокруг QR-кода в модулях по умолчанию (минимум по стандарту)
+	DefaultMargin = 4
+	// MaxMargin - максимальная ширина пустой зоны вокруг QR-кода в модулях
+	MaxMargin = 16
+	// DefaultLevel - уровень коррекции ошибок по умолчанию
+	DefaultLevel = "M"
+)
+
+// NewQRCodeHandler создает новый обработчик для получения QR-кода короткой ссылки.
+// Принимает сервис извлечения URL и настройки приложения, возвращает обработчик, реализующий интерфейс Handler.
+func NewQRCodeHandler(service service.URLExtractorService, settings *config.Settings) handler.Handler {
+	return &qrCodeHandler{
+		service: service,
+		baseURL: settings.GetBaseURL(),
+	}
+}
+
+type qrCodeHandler struct {
+	service service.URLExtractorService
+	baseURL string
+}
+
+// Handle GetQRCode godoc
+// @Summary Получить QR-код короткой ссылки
+// @Description Возвращает QR-код полного короткого URL в формате PNG или SVG. Запрос не считается переходом по ссылке и не расходует лимит переходов.
+// @Tags qr
+// @Produce png
+// @Produce image/svg+xml
+// @Param shortURL path string true "Короткий URL" example(abc123)
+// @Param format query string false "Формат изображения: png или svg (по умолчанию png)" example(svg)
+// @Param size query int false "Ширина и высота изображения в пикселях, от 64 до 2048 (по умолчанию 256)" example(512)
+// @Param margin query int false "Ширина пустой зоны вокруг кода в модулях, от 0 до 16 (по умолчанию 4)" example(2)
+// @Param level query string false "Уровень коррекции ошибок: L, M, Q или H (по умолчанию M)" example(H)
+// @Success 200 {file} file "Изображение QR-кода"
+// @Failure 400 {object} map[string]interface{} "Неверный запрос"
+// @Failure 404 {object} map[string]interface{} "Ссылка не найдена"
+// @Failure 410 {object} map[string]interface{} "Ссылка удалена, отключена владельцем, срок ее действия истек или исчерпан лимит переходов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /api/qr/{shortURL} [get]
+func (h *qrCodeHandler) Handle(c *gin.Context) {
+	requestCtx := c.Request.Context()
+
+	logger := middleware.GetLogger(requestCtx)
+	requestID := middleware.ExtractRequestID(requestCtx)
+
+	shortURL := strings.TrimSpace(c.Param("shortURL"))
+	if shortURL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "short url is required"})
+		return
+	}
+
+	format, opts, err := parseRenderOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	url, err := h.service.ResolveURL(requestCtx, shortURL)
+	if err != nil {
+		switch {
+		case service.IsNotFoundError(err):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case service.IsDeletedError(err),
+			service.IsDisabledError(err),
+			service.IsExpiredError(err),
+			service.IsClickLimitReachedError(err):
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		default:
+			logger.Errorw("Failed to resolve short URL for QR code",
+				"error", err,
+				"short_url", shortURL,
+				"request_id", requestID,
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to resolve short url",
+			})
+		}
+		return
+	}
+
+	content := fmt.Sprintf("%s/%s", strings.TrimRight(h.baseURL, "/"), url.ShortURL)
+
+	var (
+		body        []byte
+		contentType string
+	)
+	if format == formatSVG {
+		body, err = renderSVG(content, opts)
+		contentType = "image/svg+xml"
+	} else {
+		body, err = renderPNG(content, opts)
+		contentType = "image/png"
+	}
+	if err != nil {
+		logger.Errorw("Failed to render QR code",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to render qr code",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// parseRenderOptions разбирает параметры запроса QR-кода и подставляет значения по умолчанию.
+// Возвращает ошибку, если формат, размер, пустая зона или уровень коррекции заданы некорректно.
+func parseRenderOptions(c *gin.Context) (string, renderOptions, error) {
+	format := strings.ToLower(c.DefaultQuery("format", formatPNG))
+	if format != formatPNG && format != formatSVG {
+		return "", renderOptions{}, fmt.Errorf("format must be %s or %s", formatPNG, formatSVG)
+	}
+
+	size, err := parseIntQuery(c, "size", DefaultSize, MinSize, MaxSize)
+	if err != nil {
+		return "", renderOptions{}, err
+	}
+
+	margin, err := parseIntQuery(c, "margin", DefaultMargin, 0, MaxMargin)
+	if err != nil {
+		return "", renderOptions{}, err
+	}
+
+	level, ok := recoveryLevels[strings.ToUpper(c.DefaultQuery("level", DefaultLevel))]
+	if !ok {
+		return "", renderOptions{}, errors.New("level must be one of L, M, Q, H")
+	}
+
+	return format, renderOptions{size: size, margin: margin, level: level}, nil
+}
+
+// parseIntQuery возвращает целочисленный параметр запроса name из диапазона [minValue, maxValue]
+// или defaultValue, если параметр не задан.
+func parseIntQuery(c *gin.Context, name string, defaultValue, minValue, maxValue int) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < minValue || value > maxValue {
+		return 0, fmt.Errorf("%s must be an integer from %d to %d", name, minValue, maxValue)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,195 @@
+package qr
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+	"yp-go-short-url-service/internal/service/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func getDefaultSettings() *config.Settings {
+	return &config.Settings{
+		EnvSettings: &config.ENVSettings{
+			Server: &config.ServerSettings{
+				ServerAddress: "testhost:1234",
+				ServerHost:    "testhost",
+				ServerPort:    1234,
+				ServerDomain:  "testdomain",
+				BaseURL:       "http://testhost:1234/",
+			},
+		},
+		Flags: &config.Flags{
+			ServerAddress: "testhost:1234",
+			BaseURL:       "http://testhost:1234/",
+		},
+	}
+}
+
+func TestQRCodeHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name                string
+		query               string
+		callService         bool
+		serviceErr          error
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{
+			name:                "PNG по умолчанию",
+			callService:         true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/png",
+		},
+		{
+			name:                "SVG с параметрами",
+			query:               "?format=svg&size=512&margin=0&level=h",
+			callService:         true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/svg+xml",
+		},
+		{
+			name:           "неизвестный формат",
+			query:          "?format=gif",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "размер вне диапазона",
+			query:          "?size=10000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "некорректная пустая зона",
+			query:          "?margin=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "неизвестный уровень коррекции",
+			query:          "?level=X",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ссылка не найдена",
+			callService:    true,
+			serviceErr:     service.ErrURLNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "ссылка удалена",
+			callService:    true,
+			serviceErr:     service.ErrURLWasDeleted,
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name:           "срок действия истек",
+			callService:    true,
+			serviceErr:     service.ErrURLExpired,
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name:           "ошибка сервиса",
+			callService:    true,
+			serviceErr:     errors.New("unexpected error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			mockService := mock.NewMockURLExtractorService(ctrl)
+			if tt.callService {
+				var url *model.URLsModel
+				if tt.serviceErr == nil {
+					url = &model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com"}
+				}
+				mockService.EXPECT().ResolveURL(gomock.Any(), "abc123").Return(url, tt.serviceErr)
+			}
+
+			logger, _ := zap.NewDevelopment()
+			router := gin.New()
+			router.Use(middleware.LoggerMiddleware(logger.Sugar()))
+			router.GET("/api/qr/:shortURL", NewQRCodeHandler(mockService, getDefaultSettings()).Handle)
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/qr/abc123"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+				assert.NotEmpty(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	content := "http://testhost:1234/abc123"
+
+	data, err := renderPNG(content, renderOptions{size: 300, margin: 4, level: qrcode.Medium})
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 300, img.Bounds().Dx())
+	assert.Equal(t, 300, img.Bounds().Dy())
+
+	// Угол попадает в пустую зону, а при ее отсутствии - в темную рамку поискового узора
+	r, _, _, _ := img.At(0, 0).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+
+	data, err = renderPNG(content, renderOptions{size: 300, margin: 0, level: qrcode.Medium})
+	require.NoError(t, err)
+	img, err = png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	r, _, _, _ = img.At(0, 0).RGBA()
+	assert.Zero(t, r)
+}
+
+func TestRenderSVG(t *testing.T) {
+	content := "http://testhost:1234/abc123"
+
+	withMargin, err := renderSVG(content, renderOptions{size: 512, margin: 4, level: qrcode.Highest})
+	require.NoError(t, err)
+	withoutMargin, err := renderSVG(content, renderOptions{size: 512, margin: 0, level: qrcode.Highest})
+	require.NoError(t, err)
+
+	svg := string(withMargin)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="512" height="512"`))
+	assert.True(t, strings.HasSuffix(svg, `</svg>`))
+	// Без пустой зоны путь начинается с темного модуля в левом верхнем углу
+	assert.Contains(t, string(withoutMargin), `d="M0 0h7v1h-7z`)
+	assert.Contains(t, svg, `d="M4 4h7v1h-7z`)
+}
+
+func TestEncodeModules(t *testing.T) {
+	content := "http://testhost:1234/abc123"
+
+	// Стандартная пустая зона кодировщика - 4 модуля
+	code, err := qrcode.New(content, qrcode.Medium)
+	require.NoError(t, err)
+
+	modules, err := encodeModules(content, renderOptions{margin: 4, level: qrcode.Medium})
+	require.NoError(t, err)
+	assert.Equal(t, code.Bitmap(), modules)
+
+	modules, err = encodeModules(content, renderOptions{margin: 1, level: qrcode.Medium})
+	require.NoError(t, err)
+	assert.Len(t, modules, len(code.Bitmap())-6)
+}
@@ -0,0 +1,122 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// Допустимые форматы изображения QR-кода
+const (
+	formatPNG = "png"
+	formatSVG = "svg"
+)
+
+// renderOptions описывает параметры отрисовки QR-кода.
+type renderOptions struct {
+	// size - ширина и высота изображения в пикселях
+	size int
+	// margin - ширина пустой зоны вокруг кода в модулях
+	margin int
+	// level - уровень коррекции ошибок
+	level qrcode.RecoveryLevel
+}
+
+// recoveryLevels сопоставляет обозначения уровней коррекции ошибок с уровнями кодировщика.
+var recoveryLevels = map[string]qrcode.RecoveryLevel{
+	"L": qrcode.Low,
+	"M": qrcode.Medium,
+	"Q": qrcode.High,
+	"H": qrcode.Highest,
+}
+
+// encodeModules кодирует content в матрицу модулей QR-кода с пустой зоной шириной margin модулей.
+// modules[y][x] равен true для темного модуля.
+func encodeModules(content string, opts renderOptions) ([][]bool, error) {
+	code, err := qrcode.New(content, opts.level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode qr code: %w", err)
+	}
+	// Пустая зона добавляется отдельно, чтобы ее ширину можно было задать
+	code.DisableBorder = true
+	symbol := code.Bitmap()
+
+	size := len(symbol) + 2*opts.margin
+	modules := make([][]bool, size)
+	for y := range modules {
+		modules[y] = make([]bool, size)
+	}
+	for y, row := range symbol {
+		copy(modules[y+opts.margin][opts.margin:], row)
+	}
+
+	return modules, nil
+}
+
+// renderPNG отрисовывает QR-код в PNG размером size x size пикселей.
+// Если size меньше числа модулей, изображение увеличивается до одного пикселя на модуль.
+func renderPNG(content string, opts renderOptions) ([]byte, error) {
+	modules, err := encodeModules(content, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	size := max(opts.size, len(modules))
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	// Каждый пиксель берет цвет ближайшего модуля
+	for y := range size {
+		row := modules[y*len(modules)/size]
+		for x := range size {
+			if row[x*len(modules)/size] {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode png: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// renderSVG отрисовывает QR-код в SVG с шириной и высотой size пикселей.
+// Темные модули каждой строки объединяются в горизонтальные отрезки одного пути.
+func renderSVG(content string, opts renderOptions) ([]byte, error) {
+	modules, err := encodeModules(content, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var path strings.Builder
+	for y, row := range modules {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opts.size, opts.size, len(modules), len(modules),
+	)
+	buf.WriteString(`<rect width="100%" height="100%" fill="#fff"/>`)
+	fmt.Fprintf(&buf, `<path fill="#000" d="%s"/>`, path.String())
+	buf.WriteString(`</svg>`)
+
+	return buf.Bytes(), nil
+}
@@ -1,5 +1,11 @@
 package batch
 
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
 // URLRequest представляет один элемент запроса для сокращения URL
 type URLRequest struct {
 	// CorrelationID - уникальный идентификатор для корреляции запроса/ответа
@@ -10,6 +16,32 @@ type URLRequest struct {
 	// required: true
 	// example: "https://www.example.com/very/long/url/that/needs/to/be/shortened"
 	OriginalURL string `json:"original_url" binding:"required"`
+	// Alias - пользовательский короткий код (необязательно)
+	// example: "q4-report"
+	Alias string `json:"alias,omitempty"`
+	// ExpiresAt - момент истечения ссылки в формате RFC 3339 (необязательно, не совместим с TTL)
+	// example: "2030-01-01T00:00:00Z"
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// TTL - время жизни ссылки в секундах (необязательно, не совместим с ExpiresAt)
+	// example: 86400
+	TTL int64 `json:"ttl,omitempty"`
+	// MaxClicks - количество переходов, после которого ссылка перестает работать (необязательно)
+	// example: 1
+	MaxClicks int64 `json:"max_clicks,omitempty"`
+	// Password - пароль, который нужно ввести перед переходом по ссылке (необязательно, от 4 до 72 байт)
+	// example: "s3cret"
+	Password string `json:"password,omitempty"`
+	// RedirectCode - HTTP статус перенаправления: 301, 302, 307 или 308 (необязательно, по умолчанию 307)
+	// example: 301
+	RedirectCode int `json:"redirect_code,omitempty"`
+	// QueryPassthrough - перенос параметров запроса в адрес назначения: merge - при совпадении имени
+	// сохраняются значения адреса назначения, override - пришедшие значения заменяют их (необязательно)
+	// example: "merge"
+	QueryPassthrough string `json:"query_passthrough,omitempty"`
+	// Prefix - ссылка-префикс: путь после короткого кода добавляется к адресу назначения,
+	// например /{code}/docs/page ведет на <original_url>/docs/page (необязательно)
+	// example: true
+	Prefix bool `json:"prefix,omitempty"`
 }
 
 // CreatingShortURLsByBatchDTOIn представляет массив запросов для пакетного сокращения URL
@@ -23,6 +55,30 @@ func (dto CreatingShortURLsByBatchDTOIn) ToMapSlice() []map[string]string {
 			"correlation_id": req.CorrelationID,
 			"original_url":   req.OriginalURL,
 		}
+		if alias := strings.TrimSpace(req.Alias); alias != "" {
+			result[i]["alias"] = alias
+		}
+		if req.ExpiresAt != nil {
+			result[i]["expires_at"] = req.ExpiresAt.Format(time.RFC3339Nano)
+		}
+		if req.TTL != 0 {
+			result[i]["ttl"] = strconv.FormatInt(req.TTL, 10)
+		}
+		if req.MaxClicks != 0 {
+			result[i]["max_clicks"] = strconv.FormatInt(req.MaxClicks, 10)
+		}
+		if req.Password != "" {
+			result[i]["password"] = req.Password
+		}
+		if req.RedirectCode != 0 {
+			result[i]["redirect_code"] = strconv.Itoa(req.RedirectCode)
+		}
+		if req.QueryPassthrough != "" {
+			result[i]["query_passthrough"] = req.QueryPassthrough
+		}
+		if req.Prefix {
+			result[i]["prefix"] = strconv.FormatBool(req.Prefix)
+		}
 	}
 	return result
 }
@@ -38,7 +38,8 @@ type creatingShortURLsByBatchAPIHandler struct {
 // @Produce json
 // @Param request body CreatingShortURLsByBatchDTOIn true "Массив данных для создания коротких ссылок"
 // @Success 201 {array} URLResponse "Короткие ссылки успешно созданы"
-// @Failure 400 {object} map[string]interface{} "Неверный запрос"
+// @Failure 400 {object} map[string]interface{} "Неверный запрос или URL запрещен политикой адресов назначения (код причины в поле reason)"
+// @Failure 409 {object} map[string]interface{} "Пользовательский код уже занят"
 // @Failure 415 {object} map[string]interface{} "Неподдерживаемый тип контента"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /api/shorten/batch [post]
@@ -137,6 +138,39 @@ func (h *creatingShortURLsByBatchAPIHandler) createShortURLs(c *gin.Context, dto
 
 	shortedURLs, err := h.service.ShortURLsByBatch(c.Request.Context(), dtoIn.ToMapSlice())
 	if err != nil {
+		if service.IsPolicyViolationError(err) {
+			logger.Warnw("URL rejected by destination policy",
+				"error", err,
+				"request_id", requestID,
+			)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "reason": service.PolicyViolationReason(err)})
+			return nil, err
+		}
+		if service.IsInvalidAliasError(err) {
+			logger.Warnw("Invalid alias in request",
+				"error", err,
+				"request_id", requestID,
+			)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, err
+		}
+		if service.IsInvalidExpirationError(err) || service.IsInvalidMaxClicksError(err) ||
+			service.IsInvalidPasswordError(err) || service.IsInvalidRedirectError(err) {
+			logger.Warnw("Invalid link limits in request",
+				"error", err,
+				"request_id", requestID,
+			)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, err
+		}
+		if service.IsAliasAlreadyExistsError(err) {
+			logger.Warnw("Alias is already taken",
+				"error", err,
+				"request_id", requestID,
+			)
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return nil, err
+		}
 		logger.Errorw("Failed to shorten URL",
 			"error", err,
 			"request_id", requestID,
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"yp-go-short-url-service/internal/config"
+	"yp-go-short-url-service/internal/service"
 	"yp-go-short-url-service/internal/service/mock"
 
 	"github.com/gin-gonic/gin"
@@ -67,6 +68,54 @@ func TestCreatingShortURLsByBatchAPIHandler_Handle(t *testing.T) {
 				}
 			]`,
 		},
+		{
+			name: "пользовательский код уже занят",
+			requestBody: `[
+				{
+					"correlation_id": "1",
+					"original_url": "https://example.com/q4",
+					"alias": "q4-report"
+				}
+			]`,
+			contentType: "application/json",
+			baseURL:     "http://localhost:8080",
+			mockSetup: func(mockService *mock.MockURLShortenerService) {
+				expectedInput := []map[string]string{
+					{"correlation_id": "1", "original_url": "https://example.com/q4", "alias": "q4-report"},
+				}
+				mockService.EXPECT().
+					ShortURLsByBatch(gomock.Any(), expectedInput).
+					Return(nil, service.ErrAliasAlreadyExists)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"alias already exists"}`,
+		},
+		{
+			name: "адрес запрещен политикой",
+			requestBody: `[
+				{
+					"correlation_id": "1",
+					"original_url": "https://ads.tracker.example/click"
+				}
+			]`,
+			contentType: "application/json",
+			baseURL:     "http://localhost:8080",
+			mockSetup: func(mockService *mock.MockURLShortenerService) {
+				expectedInput := []map[string]string{
+					{"correlation_id": "1", "original_url": "https://ads.tracker.example/click"},
+				}
+				mockService.EXPECT().
+					ShortURLsByBatch(gomock.Any(), expectedInput).
+					Return(nil, &service.PolicyViolationError{
+						Reason: service.PolicyReasonSuffixDenied,
+						URL:    "https://ads.tracker.example/click",
+						Detail: `host "ads.tracker.example" matches "tracker.example"`,
+					})
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"error":"url rejected by destination policy: suffix_denied: host \"ads.tracker.example\" matches \"tracker.example\"",` +
+				`"reason":"suffix_denied"}`,
+		},
 		{
 			name:           "отсутствует Content-Type",
 			requestBody:    `[]`,
@@ -254,3 +303,18 @@ func TestCreatingShortURLsByBatchDTOIn_ToMapSlice(t *testing.T) {
 	result := dto.ToMapSlice()
 	assert.Equal(t, expected, result)
 }
+
+func TestCreatingShortURLsByBatchDTOIn_ToMapSlice_WithOptions(t *testing.T) {
+	dto := CreatingShortURLsByBatchDTOIn{
+		{CorrelationID: "1", OriginalURL: "https://example.com/1", Alias: " promo ", TTL: 60},
+		{CorrelationID: "2", OriginalURL: "https://example.com/2", MaxClicks: 1},
+	}
+
+	expected := []map[string]string{
+		{"correlation_id": "1", "original_url": "https://example.com/1", "alias": "promo", "ttl": "60"},
+		{"correlation_id": "2", "original_url": "https://example.com/2", "max_clicks": "1"},
+	}
+
+	result := dto.ToMapSlice()
+	assert.Equal(t, expected, result)
+}
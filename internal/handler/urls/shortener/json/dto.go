@@ -1,11 +1,56 @@
 package json
 
+import (
+	"time"
+	"yp-go-short-url-service/internal/model"
+)
+
 // CreatingShortURLsDTOIn представляет входные данные для создания короткой ссылки
 type CreatingShortURLsDTOIn struct {
 	// URL - длинный URL для сокращения
 	// required: true
 	// example: "https://www.example.com/very/long/url/that/needs/to/be/shortened"
 	URL string `json:"url" binding:"required"`
+	// Alias - пользовательский короткий код (необязательно)
+	// example: "q4-report"
+	Alias string `json:"alias,omitempty"`
+	// ExpiresAt - момент истечения ссылки в формате RFC 3339 (необязательно, не совместим с TTL)
+	// example: "2030-01-01T00:00:00Z"
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// TTL - время жизни ссылки в секундах (необязательно, не совместим с ExpiresAt)
+	// example: 86400
+	TTL int64 `json:"ttl,omitempty"`
+	// MaxClicks - количество переходов, после которого ссылка перестает работать (необязательно)
+	// example: 1
+	MaxClicks int64 `json:"max_clicks,omitempty"`
+	// Password - пароль, который нужно ввести перед переходом по ссылке (необязательно, от 4 до 72 байт)
+	// example: "s3cret"
+	Password string `json:"password,omitempty"`
+	// Title - заголовок ссылки для страницы предпросмотра (необязательно, не длиннее 200 символов)
+	// example: "Квартальный отчет"
+	Title string `json:"title,omitempty"`
+	// Interstitial - показывать страницу предупреждения перед каждым переходом по ссылке (необязательно)
+	// example: true
+	Interstitial bool `json:"interstitial,omitempty"`
+	// RedirectCode - HTTP статус перенаправления: 301, 302, 307 или 308 (необязательно, по умолчанию 307)
+	// example: 301
+	RedirectCode int `json:"redirect_code,omitempty"`
+	// QueryPassthrough - перенос параметров запроса в адрес назначения: merge - при совпадении имени
+	// сохраняются значения адреса назначения, override - пришедшие значения заменяют их (необязательно)
+	// example: "merge"
+	QueryPassthrough string `json:"query_passthrough,omitempty"`
+	// Prefix - ссылка-префикс: путь после короткого кода добавляется к адресу назначения,
+	// например /{code}/docs/page ведет на <original_url>/docs/page (необязательно)
+	// example: true
+	Prefix bool `json:"prefix,omitempty"`
+	// Rules - правила условного перенаправления по устройству (ios, android, desktop), языку из Accept-Language
+	// и диапазонам IP-адресов клиента; срабатывает первое подходящее правило, иначе переход выполняется
+	// на URL (необязательно, не более 20 правил)
+	Rules []model.RoutingRule `json:"rules,omitempty"`
+	// Variants - варианты адреса назначения для A/B теста: переходы распределяются между ними пропорционально
+	// весам, а выбранный вариант закрепляется за посетителем; URL при этом только отображается владельцу
+	// (необязательно, от 2 до 10 вариантов)
+	Variants []model.SplitVariant `json:"variants,omitempty"`
 }
 
 // CreatingShortURLsDTOOut представляет выходные данные после создания короткой ссылки
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 	"yp-go-short-url-service/internal/config"
 	"yp-go-short-url-service/internal/handler"
 	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -37,8 +39,8 @@ func NewCreatingShortURLsAPIHandler(
 // @Produce json
 // @Param request body CreatingShortURLsDTOIn true "Данные для создания короткой ссылки"
 // @Success 201 {object} CreatingShortURLsDTOOut "Короткая ссылка успешно создана"
-// @Failure 400 {object} map[string]interface{} "Неверный запрос"
-// @Failure 409 {object} CreatingShortURLsDTOOut "URL уже существует в системе"
+// @Failure 400 {object} map[string]interface{} "Неверный запрос или URL запрещен политикой адресов назначения (код причины в поле reason)"
+// @Failure 409 {object} CreatingShortURLsDTOOut "URL уже существует в системе или пользовательский код занят"
 // @Failure 415 {object} map[string]interface{} "Неподдерживаемый тип контента"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /api/shorten [post]
@@ -94,12 +96,63 @@ func (h *creatingShortURLsAPIHandler) Handle(c *gin.Context) {
 		return
 	}
 
+	alias := strings.TrimSpace(dtoIn.Alias)
+
 	logger.Infow("Processing URL shortening request",
 		"long_url", longURL,
+		"alias", alias,
 		"request_id", requestID)
 
-	shortedURL, err := h.service.ShortURL(c.Request.Context(), longURL)
+	opts := service.ShortenOptions{
+		Alias:            alias,
+		ExpiresAt:        dtoIn.ExpiresAt,
+		TTL:              time.Duration(dtoIn.TTL) * time.Second,
+		MaxClicks:        dtoIn.MaxClicks,
+		Password:         dtoIn.Password,
+		Title:            dtoIn.Title,
+		ShowInterstitial: dtoIn.Interstitial,
+		RedirectCode:     dtoIn.RedirectCode,
+		QueryPassthrough: model.QueryPassthrough(dtoIn.QueryPassthrough),
+		Prefix:           dtoIn.Prefix,
+		RoutingRules:     dtoIn.Rules,
+		Variants:         dtoIn.Variants,
+	}
+
+	shortedURL, err := h.service.ShortURLWithOptions(c.Request.Context(), longURL, opts)
 	if err != nil {
+		if service.IsInvalidExpirationError(err) || service.IsInvalidMaxClicksError(err) ||
+			service.IsInvalidPasswordError(err) || service.IsInvalidTitleError(err) ||
+			service.IsInvalidRedirectError(err) || service.IsInvalidRoutingRulesError(err) ||
+			service.IsInvalidVariantsError(err) {
+			logger.Warnw("Invalid link limits in request",
+				"error", err,
+				"request_id", requestID)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if service.IsPolicyViolationError(err) {
+			logger.Warnw("URL rejected by destination policy",
+				"error", err,
+				"long_url", longURL,
+				"request_id", requestID)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "reason": service.PolicyViolationReason(err)})
+			return
+		}
+		if service.IsInvalidAliasError(err) {
+			logger.Warnw("Invalid alias in request",
+				"error", err,
+				"alias", alias,
+				"request_id", requestID)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if service.IsAliasAlreadyExistsError(err) {
+			logger.Warnw("Alias is already taken",
+				"alias", alias,
+				"request_id", requestID)
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		if service.IsAlreadyExistsError(err) && shortedURL != "" {
 			logger.Warnw("URL already exists in storage",
 				"long_url", longURL,
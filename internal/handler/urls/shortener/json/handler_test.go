@@ -8,9 +8,11 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"yp-go-short-url-service/internal/config"
 	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
 	"yp-go-short-url-service/internal/service/mock"
 
 	"github.com/gin-gonic/gin"
@@ -71,7 +73,7 @@ func TestNewCreatingShortLinksAPIHandler(t *testing.T) {
 
 func TestCreatingShortLinksAPIHandler_Handle_MissingContentType(t *testing.T) {
 	router, mockService, _ := setupTestHandler(t)
-	defer mockService.EXPECT().ShortURL(gomock.Any(), gomock.Any()).Times(0)
+	defer mockService.EXPECT().ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": "https://test.com"}`))
@@ -89,7 +91,7 @@ func TestCreatingShortLinksAPIHandler_Handle_MissingContentType(t *testing.T) {
 
 func TestCreatingShortLinksAPIHandler_Handle_InvalidContentType(t *testing.T) {
 	router, mockService, _ := setupTestHandler(t)
-	defer mockService.EXPECT().ShortURL(gomock.Any(), gomock.Any()).Times(0)
+	defer mockService.EXPECT().ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": "https://test.com"}`))
@@ -108,7 +110,7 @@ func TestCreatingShortLinksAPIHandler_Handle_InvalidContentType(t *testing.T) {
 
 func TestCreatingShortLinksAPIHandler_Handle_InvalidJSON(t *testing.T) {
 	router, mockService, _ := setupTestHandler(t)
-	defer mockService.EXPECT().ShortURL(gomock.Any(), gomock.Any()).Times(0)
+	defer mockService.EXPECT().ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader("invalid json"))
@@ -127,7 +129,7 @@ func TestCreatingShortLinksAPIHandler_Handle_InvalidJSON(t *testing.T) {
 
 func TestCreatingShortLinksAPIHandler_Handle_EmptyURL(t *testing.T) {
 	router, mockService, _ := setupTestHandler(t)
-	defer mockService.EXPECT().ShortURL(gomock.Any(), gomock.Any()).Times(0)
+	defer mockService.EXPECT().ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": ""}`))
@@ -146,7 +148,7 @@ func TestCreatingShortLinksAPIHandler_Handle_EmptyURL(t *testing.T) {
 
 func TestCreatingShortLinksAPIHandler_Handle_WhitespaceURL(t *testing.T) {
 	router, mockService, _ := setupTestHandler(t)
-	defer mockService.EXPECT().ShortURL(gomock.Any(), gomock.Any()).Times(0)
+	defer mockService.EXPECT().ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": "   "}`))
@@ -169,7 +171,7 @@ func TestCreatingShortLinksAPIHandler_Handle_ServiceError(t *testing.T) {
 	longURL := "https://test.com"
 
 	mockService.EXPECT().
-		ShortURL(gomock.Any(), longURL).
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{}).
 		Return("", assert.AnError)
 
 	w := httptest.NewRecorder()
@@ -195,7 +197,7 @@ func TestCreatingShortLinksAPIHandler_Handle_Success(t *testing.T) {
 	expectedResultURL := "http://testhost:1234/abc123"
 
 	mockService.EXPECT().
-		ShortURL(gomock.Any(), longURL).
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{}).
 		Return(expectedShortURL, nil)
 
 	w := httptest.NewRecorder()
@@ -221,7 +223,7 @@ func TestCreatingShortLinksAPIHandler_Handle_SuccessWithTrailingSlash(t *testing
 	expectedResultURL := "http://testhost:1234/xyz789"
 
 	mockService.EXPECT().
-		ShortURL(gomock.Any(), longURL).
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{}).
 		Return(expectedShortURL, nil)
 
 	w := httptest.NewRecorder()
@@ -245,7 +247,7 @@ func TestCreatingShortLinksAPIHandler_Handle_ContextWithLogger(t *testing.T) {
 	expectedShortURL := "abc123"
 
 	mockService.EXPECT().
-		ShortURL(gomock.Any(), longURL).
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{}).
 		Return(expectedShortURL, nil)
 
 	w := httptest.NewRecorder()
@@ -350,11 +352,11 @@ func TestCreatingShortLinksAPIHandler_Handle_EdgeCases(t *testing.T) {
 			if tt.expectedStatus == http.StatusCreated {
 				// Настраиваем мок для успешного случая
 				mockService.EXPECT().
-					ShortURL(gomock.Any(), gomock.Any()).
+					ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return("abc123", nil)
 			} else {
 				// Не ожидаем вызовов сервиса для ошибок
-				mockService.EXPECT().ShortURL(gomock.Any(), gomock.Any()).Times(0)
+				mockService.EXPECT().ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			}
 
 			w := httptest.NewRecorder()
@@ -385,7 +387,7 @@ func TestCreatingShortLinksAPIHandler_Handle_RequestIDMiddleware(t *testing.T) {
 	expectedShortURL := "abc123"
 
 	mockService.EXPECT().
-		ShortURL(gomock.Any(), longURL).
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{}).
 		Return(expectedShortURL, nil)
 
 	w := httptest.NewRecorder()
@@ -405,7 +407,7 @@ func TestCreatingShortLinksAPIHandler_Handle_RemoteAddr(t *testing.T) {
 	expectedShortURL := "abc123"
 
 	mockService.EXPECT().
-		ShortURL(gomock.Any(), longURL).
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{}).
 		Return(expectedShortURL, nil)
 
 	w := httptest.NewRecorder()
@@ -452,10 +454,10 @@ func TestCreatingShortLinksAPIHandler_Handle_ContentTypeVariations(t *testing.T)
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedStatus == http.StatusCreated {
 				mockService.EXPECT().
-					ShortURL(gomock.Any(), gomock.Any()).
+					ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return("abc123", nil)
 			} else {
-				mockService.EXPECT().ShortURL(gomock.Any(), gomock.Any()).Times(0)
+				mockService.EXPECT().ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			}
 
 			w := httptest.NewRecorder()
@@ -478,7 +480,7 @@ func TestCreatingShortLinksAPIHandler_Handle_JSONMarshalError(t *testing.T) {
 	expectedShortURL := "abc123"
 
 	mockService.EXPECT().
-		ShortURL(gomock.Any(), longURL).
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{}).
 		Return(expectedShortURL, nil)
 
 	w := httptest.NewRecorder()
@@ -504,7 +506,7 @@ func TestCreatingShortLinksAPIHandler_Handle_ServiceErrorWithDetails(t *testing.
 	expectedError := "database connection failed"
 
 	mockService.EXPECT().
-		ShortURL(gomock.Any(), longURL).
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{}).
 		Return("", fmt.Errorf("%s", expectedError))
 
 	w := httptest.NewRecorder()
@@ -521,3 +523,151 @@ func TestCreatingShortLinksAPIHandler_Handle_ServiceErrorWithDetails(t *testing.
 	assert.NoError(t, err)
 	assert.Equal(t, expectedError, response["error"])
 }
+
+func TestCreatingShortLinksAPIHandler_Handle_WithAlias(t *testing.T) {
+	router, mockService, _ := setupTestHandler(t)
+
+	longURL := "https://example.com/q4"
+	alias := "q4-report"
+
+	mockService.EXPECT().
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{Alias: alias}).
+		Return(alias, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": "`+longURL+`", "alias": "`+alias+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response CreatingShortURLsDTOOut
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://testhost:1234/q4-report", response.Result)
+}
+
+func TestCreatingShortLinksAPIHandler_Handle_AliasErrors(t *testing.T) {
+	router, mockService, _ := setupTestHandler(t)
+
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "invalid alias",
+			serviceErr:     fmt.Errorf("%w: reserved", service.ErrInvalidAlias),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "alias already taken",
+			serviceErr:     service.ErrAliasAlreadyExists,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.EXPECT().
+				ShortURLWithOptions(gomock.Any(), "https://test.com", service.ShortenOptions{Alias: "taken"}).
+				Return("", tt.serviceErr)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": "https://test.com", "alias": "taken"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.serviceErr.Error(), response["error"])
+		})
+	}
+}
+
+func TestCreatingShortLinksAPIHandler_Handle_WithTTL(t *testing.T) {
+	router, mockService, _ := setupTestHandler(t)
+
+	longURL := "https://example.com/promo"
+
+	mockService.EXPECT().
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{TTL: time.Hour}).
+		Return("promo123", nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": "`+longURL+`", "ttl": 3600}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCreatingShortLinksAPIHandler_Handle_InvalidExpiration(t *testing.T) {
+	router, mockService, _ := setupTestHandler(t)
+
+	serviceErr := fmt.Errorf("%w: expires_at must be in the future", service.ErrInvalidExpiration)
+	mockService.EXPECT().
+		ShortURLWithOptions(gomock.Any(), "https://test.com", gomock.Any()).
+		Return("", serviceErr)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": "https://test.com", "expires_at": "2020-01-01T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, serviceErr.Error(), response["error"])
+}
+
+func TestCreatingShortLinksAPIHandler_Handle_WithMaxClicks(t *testing.T) {
+	router, mockService, _ := setupTestHandler(t)
+
+	longURL := "https://example.com/invite"
+
+	mockService.EXPECT().
+		ShortURLWithOptions(gomock.Any(), longURL, service.ShortenOptions{MaxClicks: 1}).
+		Return("invite1", nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": "`+longURL+`", "max_clicks": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCreatingShortLinksAPIHandler_Handle_PolicyViolation(t *testing.T) {
+	router, mockService, _ := setupTestHandler(t)
+
+	longURL := "http://testhost:1234/abc"
+	serviceErr := &service.PolicyViolationError{Reason: service.PolicyReasonSelfReference, URL: longURL, Detail: `host "testhost"`}
+	mockService.EXPECT().
+		ShortURLWithOptions(gomock.Any(), longURL, gomock.Any()).
+		Return("", serviceErr)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(`{"url": "`+longURL+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, serviceErr.Error(), response["error"])
+	assert.Equal(t, "self_reference", response["reason"])
+}
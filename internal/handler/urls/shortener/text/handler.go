@@ -42,7 +42,7 @@ func NewCreatingShortLinksHandler(
 // @Produce plain
 // @Param url body string true "Длинный URL для сокращения"
 // @Success 201 {string} string "Сокращенный URL"
-// @Failure 400 {string} string "Неверный запрос"
+// @Failure 400 {string} string "Неверный запрос или URL запрещен политикой адресов назначения"
 // @Failure 409 {string} string "URL уже существует в системе"
 // @Failure 500 {string} string "Внутренняя ошибка сервера"
 // @Router / [post]
@@ -80,6 +80,11 @@ func (h *CreatingShortLinks) Handle(c *gin.Context) {
 
 	shortedURL, err := h.service.ShortURL(c.Request.Context(), longURL)
 	if err != nil {
+		if service.IsPolicyViolationError(err) {
+			logger.Warnw("URL rejected by destination policy", "long_url", longURL, "error", err, "request_id", requestID)
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
 		if service.IsAlreadyExistsError(err) && shortedURL != "" {
 			logger.Warnw("URL already exists in storage", "long_url", longURL, "request_id", requestID)
 			resultURL := h.buildShortURL(shortedURL)
@@ -12,6 +12,7 @@ import (
 	"yp-go-short-url-service/internal/config"
 	"yp-go-short-url-service/internal/middleware"
 	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
 	"yp-go-short-url-service/internal/service/mock"
 
 	"github.com/gin-gonic/gin"
@@ -172,6 +173,26 @@ func TestCreatingShortLinks_Handle_ServiceError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), expectedError.Error())
 }
 
+func TestCreatingShortLinks_Handle_PolicyViolation(t *testing.T) {
+	router, mockService, _ := setupTestHandler(t)
+
+	longURL := "ftp://files.example.com"
+	serviceErr := &service.PolicyViolationError{Reason: service.PolicyReasonSchemeNotAllowed, URL: longURL, Detail: `scheme "ftp"`}
+
+	mockService.EXPECT().
+		ShortURL(gomock.Any(), longURL).
+		Return("", serviceErr)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, apiPath, strings.NewReader(longURL))
+	req.Header.Set("Content-Type", "text/plain")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "scheme_not_allowed")
+}
+
 func TestCreatingShortLinks_Handle_Success(t *testing.T) {
 	router, mockService, _ := setupTestHandler(t)
 
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientInfoKeyType - пользовательский тип для ключа сведений о клиенте в контексте
+type ClientInfoKeyType struct{}
+
+// ClientInfoKey - ключ для хранения сведений о клиенте в контексте.
+var ClientInfoKey = ClientInfoKeyType{}
+
+// ClientInfo содержит сведения о клиенте, выполнившем запрос: IP-адрес, User-Agent, Referer и Accept-Language.
+// Используется для учета переходов по ссылкам и правил условного перенаправления.
+type ClientInfo struct {
+	IP             string
+	UserAgent      string
+	Referrer       string
+	AcceptLanguage string
+}
+
+// ClientInfoMiddleware сохраняет в контексте запроса IP-адрес клиента, User-Agent, Referer и Accept-Language.
+// IP-адрес определяется gin с учетом доверенных прокси.
+func ClientInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := WithClientInfo(c.Request.Context(), ClientInfo{
+			IP:             c.ClientIP(),
+			UserAgent:      c.Request.UserAgent(),
+			Referrer:       c.Request.Referer(),
+			AcceptLanguage: c.GetHeader("Accept-Language"),
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// WithClientInfo возвращает копию контекста со сведениями о клиенте.
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, ClientInfoKey, info)
+}
+
+// GetClientInfo извлекает сведения о клиенте из контекста.
+// Возвращает пустую структуру, если сведения не найдены.
+func GetClientInfo(ctx context.Context) ClientInfo {
+	if info, ok := ctx.Value(ClientInfoKey).(ClientInfo); ok {
+		return info
+	}
+	return ClientInfo{}
+}
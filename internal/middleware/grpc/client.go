@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"yp-go-short-url-service/internal/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ParseTrustedProxies разбирает адреса и подсети (CIDR) доверенных прокси-серверов.
+// Отдельный адрес рассматривается как подсеть из одного адреса.
+func ParseTrustedProxies(proxies []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, proxy := range proxies {
+		if strings.Contains(proxy, "/") {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ClientInfoInterceptor сохраняет в контексте сведения о клиенте для учета переходов по ссылкам.
+// IP-адрес берется из адреса соединения. Значение x-forwarded-for учитывается, только если соединение
+// установлено доверенным прокси-сервером из trustedProxies: тогда клиентом считается ближайший справа
+// адрес цепочки, не принадлежащий доверенным прокси-серверам.
+// User-Agent, Referer и Accept-Language - из метаданных user-agent, referer и accept-language.
+func ClientInfoInterceptor(trustedProxies []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		clientInfo := middleware.ClientInfo{
+			UserAgent:      firstMetadataValue(md, "user-agent"),
+			Referrer:       firstMetadataValue(md, "referer"),
+			AcceptLanguage: firstMetadataValue(md, "accept-language"),
+		}
+
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			host, _, err := net.SplitHostPort(p.Addr.String())
+			if err == nil {
+				clientInfo.IP = host
+			}
+		}
+
+		if isTrustedProxy(clientInfo.IP, trustedProxies) {
+			if forwarded := forwardedClientIP(firstMetadataValue(md, "x-forwarded-for"), trustedProxies); forwarded != "" {
+				clientInfo.IP = forwarded
+			}
+		}
+
+		return handler(middleware.WithClientInfo(ctx, clientInfo), req)
+	}
+}
+
+// forwardedClientIP возвращает адрес клиента из цепочки x-forwarded-for, проходя ее справа налево
+// и пропуская доверенные прокси-серверы. При некорректном адресе в цепочке возвращает пустую строку.
+func forwardedClientIP(forwarded string, trustedProxies []netip.Prefix) string {
+	if forwarded == "" {
+		return ""
+	}
+
+	items := strings.Split(forwarded, ",")
+	for i := len(items) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(items[i])
+		if _, err := netip.ParseAddr(ip); err != nil {
+			return ""
+		}
+		if i == 0 || !isTrustedProxy(ip, trustedProxies) {
+			return ip
+		}
+	}
+	return ""
+}
+
+func isTrustedProxy(ip string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientInfoInterceptor_ForwardedFor(t *testing.T) {
+	trustedProxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		peerIP       string
+		forwardedFor string
+		expectedIP   string
+	}{
+		{
+			name:         "untrusted peer spoofs x-forwarded-for",
+			peerIP:       "203.0.113.7",
+			forwardedFor: "198.51.100.1",
+			expectedIP:   "203.0.113.7",
+		},
+		{
+			name:         "trusted proxy forwards client",
+			peerIP:       "10.1.2.3",
+			forwardedFor: "198.51.100.1",
+			expectedIP:   "198.51.100.1",
+		},
+		{
+			name:         "trusted proxy forwards client through chain",
+			peerIP:       "192.168.1.1",
+			forwardedFor: "1.1.1.1, 198.51.100.1, 10.0.0.5",
+			expectedIP:   "198.51.100.1",
+		},
+		{
+			name:         "trusted proxy without x-forwarded-for",
+			peerIP:       "10.1.2.3",
+			forwardedFor: "",
+			expectedIP:   "10.1.2.3",
+		},
+		{
+			name:         "trusted proxy forwards malformed address",
+			peerIP:       "10.1.2.3",
+			forwardedFor: "not-an-ip",
+			expectedIP:   "10.1.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP(tt.peerIP), Port: 50000},
+			})
+			if tt.forwardedFor != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", tt.forwardedFor))
+			}
+
+			var clientIP string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				clientIP = middleware.GetClientInfo(ctx).IP
+				return nil, nil
+			}
+
+			_, err := ClientInfoInterceptor(trustedProxies)(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIP, clientIP)
+		})
+	}
+}
+
+func TestClientInfoInterceptor_NoTrustedProxies(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 50000},
+	})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "198.51.100.1"))
+
+	var clientIP string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		clientIP = middleware.GetClientInfo(ctx).IP
+		return nil, nil
+	}
+
+	_, err := ClientInfoInterceptor(nil)(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "10.1.2.3", clientIP)
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	_, err := ParseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+
+	_, err = ParseTrustedProxies([]string{"proxy.local"})
+	assert.Error(t, err)
+
+	prefixes, err := ParseTrustedProxies([]string{"10.0.0.1/8", "::1"})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8", prefixes[0].String())
+	assert.Equal(t, "::1/128", prefixes[1].String())
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"yp-go-short-url-service/internal/config"
 	json2 "yp-go-short-url-service/internal/handler/urls/shortener/json"
+	"yp-go-short-url-service/internal/service"
 	serviceMock "yp-go-short-url-service/internal/service/mock"
 
 	"github.com/gin-gonic/gin"
@@ -63,7 +64,7 @@ func TestCreatingShortLinksAPI_Handle_GZIPRequest_Success(t *testing.T) {
 
 	longURL := "https://ok.com"
 	mockService.EXPECT().
-		ShortURL(ctx, longURL).
+		ShortURLWithOptions(ctx, longURL, service.ShortenOptions{}).
 		Return(expectedShortURL, nil).
 		Times(1)
 
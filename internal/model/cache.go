@@ -0,0 +1,17 @@
+package model
+
+// CacheStats содержит счетчики кэша ссылок по короткому коду с момента запуска экземпляра сервиса.
+// Hits включает NegativeHits - попадания в записи о несуществующих коротких кодах - и SharedHits -
+// попадания в общее для экземпляров хранилище, которых не было в локальном кэше.
+// Evictions, Invalidations и Size относятся к локальному кэшу экземпляра; Invalidations учитывает
+// и записи, сброшенные по уведомлениям других экземпляров.
+type CacheStats struct {
+	Backend       string `json:"backend"`
+	Hits          int64  `json:"hits"`
+	NegativeHits  int64  `json:"negative_hits"`
+	SharedHits    int64  `json:"shared_hits"`
+	Misses        int64  `json:"misses"`
+	Evictions     int64  `json:"evictions"`
+	Invalidations int64  `json:"invalidations"`
+	Size          int    `json:"size"`
+}
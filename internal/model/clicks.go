@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// ClickModel представляет запись об одном переходе по короткой ссылке.
+// IP хранится в обезличенном виде: у IPv4 обнуляется последний октет, у IPv6 - все, кроме префикса /48.
+// Variant - имя варианта адреса назначения, на который выполнен переход; пусто для ссылок без вариантов.
+type ClickModel struct {
+	ShortURL  string    `json:"short_url"`
+	ClickedAt time.Time `json:"clicked_at"`
+	Referrer  string    `json:"referrer"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+	Variant   string    `json:"variant,omitempty"`
+}
+
+// ClickStats содержит статистику переходов по ссылке.
+// TotalClicks и UniqueVisitors посчитаны за все время, Daily - по дням начиная с запрошенной даты (в UTC).
+// Variants - разбивка по вариантам адреса назначения за все время, упорядоченная по имени варианта.
+// Уникальным посетителем считается пара обезличенного IP и User-Agent.
+type ClickStats struct {
+	ShortURL       string          `json:"short_url"`
+	TotalClicks    int64           `json:"total_clicks"`
+	UniqueVisitors int64           `json:"unique_visitors"`
+	Daily          []DailyClicks   `json:"daily"`
+	Variants       []VariantClicks `json:"variants,omitempty"`
+}
+
+// DailyClicks содержит количество переходов и уникальных посетителей за один день.
+// Date указывает на начало дня в UTC.
+type DailyClicks struct {
+	Date           time.Time `json:"date"`
+	Clicks         int64     `json:"clicks"`
+	UniqueVisitors int64     `json:"unique_visitors"`
+}
+
+// VariantClicks содержит количество переходов и уникальных посетителей по одному варианту адреса назначения.
+type VariantClicks struct {
+	Variant        string `json:"variant"`
+	Clicks         int64  `json:"clicks"`
+	UniqueVisitors int64  `json:"unique_visitors"`
+}
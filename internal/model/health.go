@@ -0,0 +1,91 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LinkHealth - результат последней фоновой проверки доступности адреса назначения ссылки.
+type LinkHealth struct {
+	// StatusCode - HTTP статус последнего полученного ответа; 0, если ответ не получен.
+	StatusCode int `json:"status_code"`
+	// Error - описание ошибки, если ответ не получен или перенаправлений оказалось слишком много.
+	Error string `json:"error,omitempty"`
+	// RedirectChain - адреса, на которые последовательно перенаправлял адрес назначения.
+	RedirectChain RedirectChain `json:"redirect_chain,omitempty"`
+	// ConsecutiveFailures - число неудачных проверок подряд; успешная проверка его сбрасывает.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// CheckedAt - момент проверки.
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// IsHealthy сообщает, что проверка получила ответ со статусом меньше 400.
+func (h LinkHealth) IsHealthy() bool {
+	return h.Error == "" && h.StatusCode > 0 && h.StatusCode < 400
+}
+
+// LinkCheckTarget - ссылка, адрес назначения которой проверяется фоновой проверкой доступности.
+type LinkCheckTarget struct {
+	ID       uint
+	ShortURL string
+	LongURL  string
+}
+
+// LinkCheckResult - результат проверки адреса назначения ссылки с идентификатором URLID.
+// Поле ConsecutiveFailures не используется: счетчик неудач подряд ведет хранилище.
+type LinkCheckResult struct {
+	URLID uint
+	LinkHealth
+}
+
+// BrokenLink - ссылка, адрес назначения которой недоступен несколько проверок подряд.
+type BrokenLink struct {
+	ShortURL string     `json:"short_url"`
+	LongURL  string     `json:"long_url"`
+	Health   LinkHealth `json:"health"`
+}
+
+// RedirectChain - цепочка адресов перенаправлений в порядке переходов.
+// Хранится в базе данных в виде JSON; пустая цепочка хранится пустой строкой.
+type RedirectChain []string
+
+// Value сериализует цепочку в JSON для записи в базу данных.
+func (c RedirectChain) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal([]string(c))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan читает цепочку, сохраненную в базе данных в виде JSON.
+func (c *RedirectChain) Scan(src any) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+	case string:
+		data = []byte(value)
+	case []byte:
+		data = value
+	default:
+		return fmt.Errorf("unsupported redirect chain type %T", src)
+	}
+
+	if len(data) == 0 {
+		*c = nil
+		return nil
+	}
+
+	var chain []string
+	if err := json.Unmarshal(data, &chain); err != nil {
+		return fmt.Errorf("invalid redirect chain: %w", err)
+	}
+	*c = chain
+	return nil
+}
@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// DeleteJobStatus описывает стадию обработки задачи пакетного удаления ссылок.
+type DeleteJobStatus string
+
+const (
+	// DeleteJobQueued - задача поставлена в очередь и ожидает свободного воркера.
+	DeleteJobQueued DeleteJobStatus = "queued"
+	// DeleteJobRunning - задача обрабатывается воркером.
+	DeleteJobRunning DeleteJobStatus = "running"
+	// DeleteJobDone - задача завершена, результат по каждой ссылке записан в Results.
+	DeleteJobDone DeleteJobStatus = "done"
+	// DeleteJobFailed - все попытки выполнить задачу завершились ошибкой хранилища, причина записана в Error.
+	DeleteJobFailed DeleteJobStatus = "failed"
+)
+
+// DeleteJobAction определяет операцию, которую задача выполняет над ссылками пользователя.
+type DeleteJobAction string
+
+const (
+	// DeleteJobActionDelete помечает ссылки удаленными.
+	DeleteJobActionDelete DeleteJobAction = "delete"
+	// DeleteJobActionRestore восстанавливает ссылки из корзины.
+	DeleteJobActionRestore DeleteJobAction = "restore"
+)
+
+// DeleteOutcome описывает результат удаления одной ссылки.
+type DeleteOutcome string
+
+const (
+	// DeleteOutcomeDeleted - ссылка принадлежит пользователю и помечена удаленной.
+	DeleteOutcomeDeleted DeleteOutcome = "deleted"
+	// DeleteOutcomeAlreadyDeleted - ссылка принадлежит пользователю, но уже была удалена ранее и не изменена.
+	DeleteOutcomeAlreadyDeleted DeleteOutcome = "already_deleted"
+	// DeleteOutcomeNotOwned - ссылка принадлежит другому пользователю и не изменена.
+	DeleteOutcomeNotOwned DeleteOutcome = "not_owned"
+	// DeleteOutcomeNotFound - ссылка с таким коротким кодом не существует.
+	DeleteOutcomeNotFound DeleteOutcome = "not_found"
+)
+
+// DeleteJob представляет задачу пакетного удаления или восстановления ссылок пользователя.
+// Задачи хранятся в таблице delete_jobs и обрабатываются воркерами под арендой.
+// Results заполняется после завершения задачи удаления и содержит результат для каждого короткого кода.
+// Error содержит последнюю ошибку: для задачи в очереди - ошибку предыдущей попытки, для failed - окончательную.
+type DeleteJob struct {
+	ID        string                   `json:"id"`
+	UserID    string                   `json:"-"`
+	Action    DeleteJobAction          `json:"action"`
+	Status    DeleteJobStatus          `json:"status"`
+	ShortURLs []string                 `json:"short_urls"`
+	Results   map[string]DeleteOutcome `json:"results,omitempty"`
+	Error     string                   `json:"error,omitempty"`
+	Attempts  int                      `json:"attempts"`
+	NextRunAt time.Time                `json:"-"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+}
+
+// IsFinished сообщает, завершена ли задача успешно или с ошибкой.
+func (j *DeleteJob) IsFinished() bool {
+	return j.Status == DeleteJobDone || j.Status == DeleteJobFailed
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// DefaultRedirectCode - HTTP статус перенаправления по ссылке, для которой тип перенаправления не задан.
+const DefaultRedirectCode = http.StatusTemporaryRedirect
+
+// IsValidRedirectCode сообщает, допустим ли code как тип перенаправления ссылки: 301, 302, 307 или 308.
+func IsValidRedirectCode(code int) bool {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
+	}
+}
+
+// QueryPassthrough определяет, как параметры запроса к короткой ссылке переносятся в адрес назначения.
+type QueryPassthrough string
+
+const (
+	// QueryPassthroughOff - параметры запроса к короткой ссылке отбрасываются.
+	QueryPassthroughOff QueryPassthrough = ""
+	// QueryPassthroughMerge - параметры запроса добавляются к адресу назначения; при совпадении имени
+	// сохраняются значения из адреса назначения, а пришедшие значения отбрасываются.
+	QueryPassthroughMerge QueryPassthrough = "merge"
+	// QueryPassthroughOverride - параметры запроса добавляются к адресу назначения; при совпадении имени
+	// пришедшие значения заменяют все значения из адреса назначения.
+	QueryPassthroughOverride QueryPassthrough = "override"
+)
+
+// IsValid сообщает, является ли режим переноса параметров запроса известным.
+func (p QueryPassthrough) IsValid() bool {
+	switch p {
+	case QueryPassthroughOff, QueryPassthroughMerge, QueryPassthroughOverride:
+		return true
+	default:
+		return false
+	}
+}
+
+// RedirectRequest содержит сведения о запросе перехода по короткой ссылке, от которых зависит адрес назначения.
+type RedirectRequest struct {
+	// Query - параметры запроса к короткой ссылке без служебных параметров сервиса.
+	Query url.Values
+	// PathSuffix - путь после короткого кода в экранированном виде, начинающийся с "/", например "/docs/page".
+	// Переносится в адрес назначения только для ссылки-префикса.
+	PathSuffix string
+	// UserAgent - заголовок User-Agent клиента; по нему определяется семейство устройства для правил перенаправления.
+	UserAgent string
+	// AcceptLanguage - заголовок Accept-Language клиента.
+	AcceptLanguage string
+	// ClientIP - IP-адрес клиента.
+	ClientIP string
+	// Variant - имя варианта адреса назначения, ранее закрепленного за посетителем.
+	// Если у ссылки нет варианта с таким именем, вариант выбирается заново.
+	Variant string
+}
+
+// Redirect описывает результат перехода по короткой ссылке.
+type Redirect struct {
+	// Location - итоговый адрес назначения.
+	Location string
+	// StatusCode - HTTP статус перенаправления (301, 302, 307 или 308).
+	StatusCode int
+	// Variant - имя выбранного варианта адреса назначения; пусто, если у ссылки нет вариантов
+	// или переход выполнен по правилу условного перенаправления.
+	Variant string
+}
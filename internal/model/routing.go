@@ -0,0 +1,87 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// DeviceFamily - семейство устройства клиента, определяемое по заголовку User-Agent.
+type DeviceFamily string
+
+const (
+	// DeviceIOS - устройства Apple на iOS и iPadOS: iPhone, iPad, iPod.
+	DeviceIOS DeviceFamily = "ios"
+	// DeviceAndroid - устройства на Android.
+	DeviceAndroid DeviceFamily = "android"
+	// DeviceDesktop - настольные браузеры: непустой User-Agent без признаков мобильного устройства.
+	DeviceDesktop DeviceFamily = "desktop"
+)
+
+// IsValid сообщает, является ли семейство устройства известным.
+func (d DeviceFamily) IsValid() bool {
+	switch d {
+	case DeviceIOS, DeviceAndroid, DeviceDesktop:
+		return true
+	default:
+		return false
+	}
+}
+
+// RoutingRule описывает правило условного перенаправления: если запрос перехода удовлетворяет всем заданным
+// условиям, переход выполняется на адрес Destination вместо адреса назначения ссылки.
+// Должно быть задано хотя бы одно условие.
+type RoutingRule struct {
+	// Device - семейство устройства клиента. Пустое значение означает любое устройство.
+	Device DeviceFamily `json:"device,omitempty"`
+	// Languages - языковые теги, например "ru" или "en-US"; правило срабатывает, если наиболее предпочтительный
+	// язык из Accept-Language совпадает с одним из тегов или уточняет его ("en" подходит для "en-GB").
+	Languages []string `json:"languages,omitempty"`
+	// CIDRs - диапазоны IP-адресов клиента в нотации CIDR, например "10.0.0.0/8".
+	CIDRs []string `json:"cidrs,omitempty"`
+	// Destination - адрес назначения при срабатывании правила.
+	Destination string `json:"destination"`
+}
+
+// RoutingRules - упорядоченный список правил условного перенаправления ссылки; срабатывает первое подходящее.
+// Хранится в базе данных в виде JSON; пустой список хранится пустой строкой.
+type RoutingRules []RoutingRule
+
+// Value сериализует правила в JSON для записи в базу данных.
+func (r RoutingRules) Value() (driver.Value, error) {
+	if len(r) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal([]RoutingRule(r))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan читает правила, сохраненные в базе данных в виде JSON.
+func (r *RoutingRules) Scan(src any) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+	case string:
+		data = []byte(value)
+	case []byte:
+		data = value
+	default:
+		return fmt.Errorf("unsupported routing rules type %T", src)
+	}
+
+	if len(data) == 0 {
+		*r = nil
+		return nil
+	}
+
+	var rules []RoutingRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return fmt.Errorf("invalid routing rules: %w", err)
+	}
+	*r = rules
+	return nil
+}
@@ -3,12 +3,135 @@ package model
 import "time"
 
 // URLsModel представляет модель URL в системе.
-// Содержит информацию о коротком и длинном URL, статусе удаления и временных метках.
+// Содержит информацию о коротком и длинном URL, статусе удаления, сроке действия, лимите переходов,
+// пароле, признаке отключения владельцем, заголовке для страницы предпросмотра, признаке обязательной
+// страницы-предупреждения перед переходом, типе перенаправления, режиме переноса параметров запроса,
+// признаке ссылки-префикса, правилах условного перенаправления, вариантах адреса назначения и временных метках.
+// Ссылка-префикс (IsPrefix) принимает дополнительные сегменты пути после короткого кода и переносит их
+// в конец адреса назначения.
+// RoutingRules заменяют адрес назначения в зависимости от устройства, языка или IP-адреса клиента;
+// если ни одно правило не подходит, используется LongURL.
+// Variants распределяют переходы между несколькими адресами назначения пропорционально весам (A/B тест);
+// если они заданы, LongURL используется только для отображения и не участвует в переходе.
+// Clicks - число переходов из таблицы счетчиков; заполняется только при получении ссылок пользователя
+// и отстает от реального значения на период сброса буфера переходов.
+// Health - результат последней проверки доступности адреса назначения; заполняется только при получении
+// действующих ссылок пользователя и равен nil, если ссылка еще не проверялась.
 type URLsModel struct {
-	ID        uint      `json:"id" db:"id"`
-	ShortURL  string    `json:"short_url" db:"short_url"`
-	LongURL   string    `json:"long_url" db:"long_url"`
-	IsDeleted bool      `json:"is_deleted" db:"is_deleted"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID               uint             `json:"id" db:"id"`
+	ShortURL         string           `json:"short_url" db:"short_url"`
+	LongURL          string           `json:"long_url" db:"long_url"`
+	IsDeleted        bool             `json:"is_deleted" db:"is_deleted"`
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at" db:"updated_at"`
+	ExpiresAt        *time.Time       `json:"expires_at,omitempty" db:"expires_at"`
+	IsExpired        bool             `json:"is_expired" db:"is_expired"`
+	MaxClicks        *int64           `json:"max_clicks,omitempty" db:"max_clicks"`
+	ClicksLeft       *int64           `json:"clicks_left,omitempty" db:"clicks_left"`
+	PasswordHash     *string          `json:"-" db:"password_hash"`
+	IsDisabled       bool             `json:"is_disabled" db:"is_disabled"`
+	Title            string           `json:"title" db:"title"`
+	ShowInterstitial bool             `json:"show_interstitial" db:"show_interstitial"`
+	RedirectCode     int              `json:"redirect_code" db:"redirect_code"`
+	QueryPassthrough QueryPassthrough `json:"query_passthrough" db:"query_passthrough"`
+	IsPrefix         bool             `json:"is_prefix" db:"is_prefix"`
+	RoutingRules     RoutingRules     `json:"routing_rules,omitempty" db:"routing_rules"`
+	Variants         SplitVariants    `json:"variants,omitempty" db:"variants"`
+	Clicks           int64            `json:"clicks" db:"clicks"`
+	Health           *LinkHealth      `json:"health,omitempty" db:"-"`
+}
+
+// PurgeStats содержит количество окончательно удаленных ссылок и связей пользователей с ними.
+// LastRunAt заполняется только в накопленной статистике фонового процесса, ShortURLs - только
+// в результате удаления одной пачки.
+type PurgeStats struct {
+	URLs      int64      `json:"urls"`
+	UserLinks int64      `json:"user_links"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	ShortURLs []string   `json:"-"`
+}
+
+// URLUpdate описывает изменения, которые владелец вносит в существующую короткую ссылку.
+// Поля со значением nil не изменяются.
+type URLUpdate struct {
+	// LongURL - новый адрес назначения ссылки.
+	LongURL *string
+	// UpdateExpiration указывает, что срок действия нужно заменить значением ExpiresAt.
+	UpdateExpiration bool
+	// ExpiresAt - новый момент истечения ссылки; nil при UpdateExpiration делает ссылку бессрочной.
+	ExpiresAt *time.Time
+	// IsDisabled - признак отключения ссылки владельцем.
+	IsDisabled *bool
+	// Title - новый заголовок ссылки; пустая строка удаляет заголовок.
+	Title *string
+	// ShowInterstitial - признак показа страницы-предупреждения перед переходом.
+	ShowInterstitial *bool
+	// RoutingRules - новый список правил условного перенаправления; пустой список удаляет все правила.
+	RoutingRules *RoutingRules
+	// Variants - новый список вариантов адреса назначения; пустой список отключает разделение трафика.
+	Variants *SplitVariants
+}
+
+// IsEmpty сообщает, что обновление не содержит изменений.
+func (u URLUpdate) IsEmpty() bool {
+	return u.LongURL == nil && !u.UpdateExpiration && u.IsDisabled == nil && u.Title == nil && u.ShowInterstitial == nil &&
+		u.RoutingRules == nil && u.Variants == nil
+}
+
+// ExpiredAt сообщает, истек ли срок действия ссылки к моменту now.
+// Ссылка считается истекшей, если ее уже пометил фоновый процесс или наступило время ExpiresAt.
+func (u *URLsModel) ExpiredAt(now time.Time) bool {
+	if u.IsExpired {
+		return true
+	}
+	return u.ExpiresAt != nil && !now.Before(*u.ExpiresAt)
+}
+
+// ClickLimitReached сообщает, исчерпан ли лимит переходов по ссылке.
+// Для ссылок без лимита (MaxClicks == nil) всегда возвращает false.
+func (u *URLsModel) ClickLimitReached() bool {
+	return u.MaxClicks != nil && u.ClicksLeft != nil && *u.ClicksLeft <= 0
+}
+
+// IsPasswordProtected сообщает, требует ли ссылка ввода пароля перед переходом.
+func (u *URLsModel) IsPasswordProtected() bool {
+	return u.PasswordHash != nil
+}
+
+// RedirectStatus возвращает HTTP статус перенаправления по ссылке.
+// Для ссылки, у которой тип перенаправления не задан, возвращает DefaultRedirectCode.
+func (u *URLsModel) RedirectStatus() int {
+	if u.RedirectCode == 0 {
+		return DefaultRedirectCode
+	}
+	return u.RedirectCode
+}
+
+// WithUpdate возвращает копию ссылки с примененными изменениями update.
+// При изменении срока действия признак IsExpired пересчитывается относительно момента now.
+func (u *URLsModel) WithUpdate(update URLUpdate, now time.Time) *URLsModel {
+	updated := *u
+	if update.LongURL != nil {
+		updated.LongURL = *update.LongURL
+	}
+	if update.UpdateExpiration {
+		updated.ExpiresAt = update.ExpiresAt
+		updated.IsExpired = update.ExpiresAt != nil && !now.Before(*update.ExpiresAt)
+	}
+	if update.IsDisabled != nil {
+		updated.IsDisabled = *update.IsDisabled
+	}
+	if update.Title != nil {
+		updated.Title = *update.Title
+	}
+	if update.ShowInterstitial != nil {
+		updated.ShowInterstitial = *update.ShowInterstitial
+	}
+	if update.RoutingRules != nil {
+		updated.RoutingRules = *update.RoutingRules
+	}
+	if update.Variants != nil {
+		updated.Variants = *update.Variants
+	}
+	return &updated
 }
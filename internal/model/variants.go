@@ -0,0 +1,75 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// SplitVariant описывает один из вариантов адреса назначения ссылки с разделением трафика (A/B тест).
+// Доля переходов на вариант пропорциональна его весу относительно суммы весов всех вариантов ссылки.
+type SplitVariant struct {
+	// Name - имя варианта, уникальное в пределах ссылки, например "a" или "landing-b".
+	// Используется для закрепления варианта за посетителем и в статистике переходов.
+	Name string `json:"name"`
+	// Destination - адрес назначения варианта.
+	Destination string `json:"destination"`
+	// Weight - вес варианта, положительное число.
+	Weight int `json:"weight"`
+}
+
+// SplitVariants - список вариантов адреса назначения ссылки с разделением трафика.
+// Хранится в базе данных в виде JSON; пустой список хранится пустой строкой.
+type SplitVariants []SplitVariant
+
+// Find возвращает вариант с именем name или nil, если такого варианта нет.
+func (v SplitVariants) Find(name string) *SplitVariant {
+	if name == "" {
+		return nil
+	}
+	for i := range v {
+		if v[i].Name == name {
+			return &v[i]
+		}
+	}
+	return nil
+}
+
+// Value сериализует варианты в JSON для записи в базу данных.
+func (v SplitVariants) Value() (driver.Value, error) {
+	if len(v) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal([]SplitVariant(v))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan читает варианты, сохраненные в базе данных в виде JSON.
+func (v *SplitVariants) Scan(src any) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+	case string:
+		data = []byte(value)
+	case []byte:
+		data = value
+	default:
+		return fmt.Errorf("unsupported split variants type %T", src)
+	}
+
+	if len(data) == 0 {
+		*v = nil
+		return nil
+	}
+
+	var variants []SplitVariant
+	if err := json.Unmarshal(data, &variants); err != nil {
+		return fmt.Errorf("invalid split variants: %w", err)
+	}
+	*v = variants
+	return nil
+}
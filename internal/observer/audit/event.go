@@ -9,11 +9,19 @@ const EventShortened = "shorten"
 // EventFollow - константа для действия "переход по URL".
 const EventFollow = "follow"
 
+// EventUnlockFailed - константа для действия "неудачная попытка ввода пароля ссылки".
+const EventUnlockFailed = "unlock_failed"
+
+// EventUpdated - константа для действия "изменение ссылки владельцем".
+const EventUpdated = "update"
+
 // Event представляет событие аудита в системе.
 // Содержит информацию о времени события, типе действия, пользователе и URL.
+// Для изменения ссылки PreviousURL содержит адрес назначения до изменения.
 type Event struct {
-	Timestamp int             `json:"ts"`
-	Action    EventActionType `json:"action"`
-	UserID    string          `json:"user_id"`
-	URL       string          `json:"url"`
+	Timestamp   int             `json:"ts"`
+	Action      EventActionType `json:"action"`
+	UserID      string          `json:"user_id"`
+	URL         string          `json:"url"`
+	PreviousURL string          `json:"previous_url,omitempty"`
 }
@@ -0,0 +1,23 @@
+package base
+
+import (
+	"database/sql"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/postgres"
+	"yp-go-short-url-service/internal/repository/sqlite"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewClicksRepository создает новый репозиторий журнала переходов в зависимости от типа пула соединений.
+// Поддерживает PostgreSQL (pgxpool.Pool) и SQLite (*sql.DB). Возвращает соответствующую реализацию интерфейса ClickRepository.
+func NewClicksRepository(pool any) repository.ClickRepository {
+	switch currentPool := pool.(type) {
+	case *pgxpool.Pool:
+		return postgres.NewClicksRepository(currentPool)
+	case *sql.DB:
+		return sqlite.NewClicksRepository(currentPool)
+	default:
+		panic("unsupported pool type")
+	}
+}
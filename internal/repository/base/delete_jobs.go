@@ -0,0 +1,23 @@
+package base
+
+import (
+	"database/sql"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/postgres"
+	"yp-go-short-url-service/internal/repository/sqlite"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewDeleteJobsRepository создает новый репозиторий очереди задач удаления в зависимости от типа пула соединений.
+// Поддерживает PostgreSQL (pgxpool.Pool) и SQLite (*sql.DB). Возвращает соответствующую реализацию интерфейса DeleteJobRepository.
+func NewDeleteJobsRepository(pool any) repository.DeleteJobRepository {
+	switch currentPool := pool.(type) {
+	case *pgxpool.Pool:
+		return postgres.NewDeleteJobsRepository(currentPool)
+	case *sql.DB:
+		return sqlite.NewDeleteJobsRepository(currentPool)
+	default:
+		panic("unsupported pool type")
+	}
+}
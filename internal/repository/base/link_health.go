@@ -0,0 +1,24 @@
+package base
+
+import (
+	"database/sql"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/postgres"
+	"yp-go-short-url-service/internal/repository/sqlite"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewLinkHealthRepository создает новый репозиторий результатов проверки доступности адресов назначения
+// в зависимости от типа пула соединений. Поддерживает PostgreSQL (pgxpool.Pool) и SQLite (*sql.DB).
+// Возвращает соответствующую реализацию интерфейса LinkHealthRepository.
+func NewLinkHealthRepository(pool any) repository.LinkHealthRepository {
+	switch currentPool := pool.(type) {
+	case *pgxpool.Pool:
+		return postgres.NewLinkHealthRepository(currentPool)
+	case *sql.DB:
+		return sqlite.NewLinkHealthRepository(currentPool)
+	default:
+		panic("unsupported pool type")
+	}
+}
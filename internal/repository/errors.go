@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -13,6 +14,16 @@ var (
 	ErrURLNotFound = errors.New("URL не найден")
 	// ErrURLExists возвращается, когда пытаются создать URL, который уже существует в базе данных.
 	ErrURLExists = errors.New("URL уже существует")
+	// ErrShortURLExists возвращается, когда короткий URL уже занят другой записью в базе данных.
+	ErrShortURLExists = errors.New("короткий URL уже занят")
+	// ErrURLNotOwned возвращается, когда URL принадлежит другому пользователю.
+	ErrURLNotOwned = errors.New("URL принадлежит другому пользователю")
+	// ErrURLAlreadyDeleted возвращается, когда URL уже помечен удаленным.
+	ErrURLAlreadyDeleted = errors.New("URL уже удален")
+	// ErrClickLimitReached возвращается, когда лимит переходов по ссылке исчерпан или ссылка не ограничена по переходам.
+	ErrClickLimitReached = errors.New("лимит переходов по ссылке исчерпан")
+	// ErrDeleteJobNotFound возвращается, когда задача удаления не найдена.
+	ErrDeleteJobNotFound = errors.New("задача удаления не найдена")
 	// ErrUserNotFound возвращается, когда запрашиваемый пользователь не найден в базе данных.
 	ErrUserNotFound = errors.New("пользователь не найден")
 	// ErrNoUsers возвращается, когда нет пользователей в базе данных.
@@ -24,9 +35,29 @@ func IsNotFoundError(err error) bool {
 	return errors.Is(err, pgx.ErrNoRows) || errors.Is(err, ErrURLNotFound)
 }
 
+// IsNotOwnedError проверяет, является ли ошибка ошибкой "принадлежит другому пользователю"
+func IsNotOwnedError(err error) bool {
+	return errors.Is(err, ErrURLNotOwned)
+}
+
+// IsAlreadyDeletedError проверяет, является ли ошибка ошибкой "уже удален"
+func IsAlreadyDeletedError(err error) bool {
+	return errors.Is(err, ErrURLAlreadyDeleted)
+}
+
+// IsDeleteJobNotFoundError проверяет, является ли ошибка ошибкой "задача удаления не найдена"
+func IsDeleteJobNotFoundError(err error) bool {
+	return errors.Is(err, ErrDeleteJobNotFound)
+}
+
+// IsClickLimitReachedError проверяет, является ли ошибка ошибкой "лимит переходов исчерпан"
+func IsClickLimitReachedError(err error) bool {
+	return errors.Is(err, ErrClickLimitReached)
+}
+
 // IsExistsError проверяет, является ли ошибка ошибкой "уже существует"
 func IsExistsError(err error) bool {
-	if errors.Is(err, ErrURLExists) {
+	if errors.Is(err, ErrURLExists) || errors.Is(err, ErrShortURLExists) {
 		return true
 	}
 
@@ -49,3 +80,22 @@ func IsExistsError(err error) bool {
 
 	return false
 }
+
+// IsShortURLExistsError проверяет, является ли ошибка конфликтом по короткому URL.
+// Возвращает true для ErrShortURLExists, а также для нарушения уникальности колонки urls.short_url
+// в PostgreSQL (ограничение urls_short_url_key) и SQLite.
+func IsShortURLExistsError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrShortURLExists) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505" && pgErr.ConstraintName == "urls_short_url_key"
+	}
+
+	return strings.Contains(err.Error(), "UNIQUE constraint failed: urls.short_url")
+}
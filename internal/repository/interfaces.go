@@ -26,10 +26,24 @@ type URLRepositoryReader interface {
 }
 
 // URLRepositoryWriter определяет интерфейс для записи URL в базу данных.
-// Предоставляет методы для создания одного или нескольких URL.
+// Предоставляет методы для создания одного или нескольких URL, изменения ссылки ее владельцем,
+// пометки истекших ссылок, окончательного удаления ссылок из корзины и учета переходов.
+// UpdateByUser возвращает состояние ссылки до и после изменения.
+// PurgeDeleted удаляет не более limit ссылок, помеченных удаленными раньше deletedBefore, вместе с их связями в user_urls.
 type URLRepositoryWriter interface {
+	URLClickConsumer
 	Create(ctx context.Context, url *model.URLsModel) error
 	CreateBatch(ctx context.Context, urls []*model.URLsModel) error
+	UpdateByUser(ctx context.Context, shortURL, userID string, update model.URLUpdate) (*model.URLsModel, *model.URLsModel, error)
+	MarkExpired(ctx context.Context, now time.Time) (int64, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (model.PurgeStats, error)
+}
+
+// URLClickConsumer определяет интерфейс для учета переходов по ссылкам с лимитом.
+// ConsumeClick атомарно уменьшает счетчик оставшихся переходов и возвращает его новое значение
+// или ErrClickLimitReached, если переходов не осталось.
+type URLClickConsumer interface {
+	ConsumeClick(ctx context.Context, shortURL string) (int64, error)
 }
 
 // UserRepository определяет полный интерфейс для работы с пользователями в базе данных.
@@ -61,15 +75,77 @@ type UserURLsRepository interface {
 }
 
 // UserURLsRepositoryReader определяет интерфейс для чтения URL пользователей из базы данных.
-// Предоставляет метод для получения всех URL, принадлежащих конкретному пользователю.
+// Предоставляет методы для получения всех URL, принадлежащих конкретному пользователю,
+// для поиска активной ссылки пользователя по длинному URL и для получения удаленных ссылок пользователя (корзины).
+// GetByUserID и GetDeletedByUserID заполняют у ссылок число переходов из таблицы счетчиков.
+// GetByUserID также заполняет результат последней проверки доступности адреса назначения.
 type UserURLsRepositoryReader interface {
 	GetByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error)
+	GetByUserIDAndLongURL(ctx context.Context, userID, longURL string) (*model.URLsModel, error)
+	GetDeletedByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error)
 }
 
 // UserURLsRepositoryWriter определяет интерфейс для записи связей между пользователями и URL в базу данных.
-// Предоставляет методы для создания связей и удаления URL пользователя.
+// Предоставляет методы для создания связей, удаления URL пользователя и их восстановления из корзины.
+// DeleteURLWithUser удаляет одну ссылку и возвращает ErrURLNotFound, ErrURLNotOwned или ErrURLAlreadyDeleted,
+// если ссылка не найдена, принадлежит другому пользователю или уже удалена.
+// DeleteURLsWithUser удаляет ссылки пакетом и возвращает результат удаления для каждого короткого кода.
 type UserURLsRepositoryWriter interface {
 	CreateURLWithUser(ctx context.Context, url *model.URLsModel, userID string) error
 	CreateMultipleURLsWithUser(ctx context.Context, urls []*model.URLsModel, userID string) error
-	DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) error
+	DeleteURLWithUser(ctx context.Context, shortURL, userID string) error
+	DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) (map[string]model.DeleteOutcome, error)
+	RestoreURLsWithUser(ctx context.Context, shortURLs []string, userID string) error
+}
+
+// DeleteJobRepository определяет интерфейс для хранения очереди задач удаления и восстановления ссылок.
+// ClaimBatch захватывает до limit готовых задач (в очереди с наступившим next_run_at или выполняемых
+// с истекшей арендой), переводит их в running с арендой до leaseUntil и увеличивает счетчик попыток;
+// задачи возвращаются в порядке постановки в очередь, если готовых задач нет - пустой список.
+// Retry возвращает задачу в очередь до nextRunAt, DeleteFinished удаляет завершенные задачи,
+// последний раз измененные раньше finishedBefore.
+type DeleteJobRepository interface {
+	Create(ctx context.Context, job *model.DeleteJob) error
+	GetByID(ctx context.Context, jobID string) (*model.DeleteJob, error)
+	ClaimBatch(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*model.DeleteJob, error)
+	Complete(ctx context.Context, jobID string, results map[string]model.DeleteOutcome, now time.Time) error
+	Retry(ctx context.Context, jobID, lastErr string, nextRunAt, now time.Time) error
+	Fail(ctx context.Context, jobID, lastErr string, now time.Time) error
+	DeleteFinished(ctx context.Context, finishedBefore time.Time) (int64, error)
+}
+
+// ClickRepository определяет полный интерфейс для работы с журналом переходов по ссылкам.
+// Объединяет интерфейсы для записи переходов и чтения статистики.
+type ClickRepository interface {
+	ClickRepositoryWriter
+	ClickRepositoryReader
+}
+
+// ClickRepositoryWriter определяет интерфейс для пакетной записи переходов по ссылкам.
+// RecordClicks сохраняет пачку переходов в журнал, IncrementClickCounters одним запросом увеличивает
+// счетчики переходов ссылок на указанные значения. Переходы по несуществующим ссылкам пропускаются.
+type ClickRepositoryWriter interface {
+	RecordClicks(ctx context.Context, clicks []*model.ClickModel) error
+	IncrementClickCounters(ctx context.Context, counters map[string]int64) error
+}
+
+// ClickRepositoryReader определяет интерфейс для чтения статистики переходов по ссылкам.
+// GetClickStats возвращает статистику ссылки пользователя userID: общее число переходов и уникальных посетителей
+// и разбивку по дням (UTC) начиная с since, в которой есть только дни с переходами.
+// Возвращает ErrURLNotFound или ErrURLNotOwned, если ссылка не найдена или принадлежит другому пользователю.
+type ClickRepositoryReader interface {
+	GetClickStats(ctx context.Context, shortURL, userID string, since time.Time) (*model.ClickStats, error)
+}
+
+// LinkHealthRepository определяет интерфейс для хранения результатов фоновой проверки доступности адресов назначения.
+// GetCheckTargets возвращает до limit действующих (неудаленных, неистекших, неотключенных) ссылок с идентификатором
+// больше afterID в порядке идентификаторов, что позволяет обойти все ссылки страницами.
+// SaveResults сохраняет результаты проверок: успешная проверка сбрасывает счетчик неудач подряд, неудачная
+// увеличивает его; результаты для уже удаленных из базы ссылок пропускаются.
+// GetBrokenLinks возвращает до limit действующих ссылок, проверки которых завершились неудачей не менее
+// minFailures раз подряд, начиная с самых долго недоступных.
+type LinkHealthRepository interface {
+	GetCheckTargets(ctx context.Context, afterID uint, limit int) ([]*model.LinkCheckTarget, error)
+	SaveResults(ctx context.Context, results []*model.LinkCheckResult) error
+	GetBrokenLinks(ctx context.Context, minFailures, limit int) ([]*model.BrokenLink, error)
 }
@@ -42,6 +42,21 @@ func (m *MockURLRepository) EXPECT() *MockURLRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ConsumeClick mocks base method.
+func (m *MockURLRepository) ConsumeClick(ctx context.Context, shortURL string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeClick", ctx, shortURL)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeClick indicates an expected call of ConsumeClick.
+func (mr *MockURLRepositoryMockRecorder) ConsumeClick(ctx, shortURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeClick", reflect.TypeOf((*MockURLRepository)(nil).ConsumeClick), ctx, shortURL)
+}
+
 // Create mocks base method.
 func (m *MockURLRepository) Create(ctx context.Context, url *model.URLsModel) error {
 	m.ctrl.T.Helper()
@@ -130,6 +145,21 @@ func (mr *MockURLRepositoryMockRecorder) GetTotalCount(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalCount", reflect.TypeOf((*MockURLRepository)(nil).GetTotalCount), ctx)
 }
 
+// MarkExpired mocks base method.
+func (m *MockURLRepository) MarkExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkExpired", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkExpired indicates an expected call of MarkExpired.
+func (mr *MockURLRepositoryMockRecorder) MarkExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExpired", reflect.TypeOf((*MockURLRepository)(nil).MarkExpired), ctx, now)
+}
+
 // Ping mocks base method.
 func (m *MockURLRepository) Ping(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -144,6 +174,37 @@ func (mr *MockURLRepositoryMockRecorder) Ping(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockURLRepository)(nil).Ping), ctx)
 }
 
+// PurgeDeleted mocks base method.
+func (m *MockURLRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (model.PurgeStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore, limit)
+	ret0, _ := ret[0].(model.PurgeStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockURLRepositoryMockRecorder) PurgeDeleted(ctx, deletedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockURLRepository)(nil).PurgeDeleted), ctx, deletedBefore, limit)
+}
+
+// UpdateByUser mocks base method.
+func (m *MockURLRepository) UpdateByUser(ctx context.Context, shortURL, userID string, update model.URLUpdate) (*model.URLsModel, *model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByUser", ctx, shortURL, userID, update)
+	ret0, _ := ret[0].(*model.URLsModel)
+	ret1, _ := ret[1].(*model.URLsModel)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateByUser indicates an expected call of UpdateByUser.
+func (mr *MockURLRepositoryMockRecorder) UpdateByUser(ctx, shortURL, userID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByUser", reflect.TypeOf((*MockURLRepository)(nil).UpdateByUser), ctx, shortURL, userID, update)
+}
+
 // MockURLRepositoryReader is a mock of URLRepositoryReader interface.
 type MockURLRepositoryReader struct {
 	ctrl     *gomock.Controller
@@ -266,6 +327,21 @@ func (m *MockURLRepositoryWriter) EXPECT() *MockURLRepositoryWriterMockRecorder
 	return m.recorder
 }
 
+// ConsumeClick mocks base method.
+func (m *MockURLRepositoryWriter) ConsumeClick(ctx context.Context, shortURL string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeClick", ctx, shortURL)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeClick indicates an expected call of ConsumeClick.
+func (mr *MockURLRepositoryWriterMockRecorder) ConsumeClick(ctx, shortURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeClick", reflect.TypeOf((*MockURLRepositoryWriter)(nil).ConsumeClick), ctx, shortURL)
+}
+
 // Create mocks base method.
 func (m *MockURLRepositoryWriter) Create(ctx context.Context, url *model.URLsModel) error {
 	m.ctrl.T.Helper()
@@ -294,6 +370,91 @@ func (mr *MockURLRepositoryWriterMockRecorder) CreateBatch(ctx, urls any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockURLRepositoryWriter)(nil).CreateBatch), ctx, urls)
 }
 
+// MarkExpired mocks base method.
+func (m *MockURLRepositoryWriter) MarkExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkExpired", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkExpired indicates an expected call of MarkExpired.
+func (mr *MockURLRepositoryWriterMockRecorder) MarkExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExpired", reflect.TypeOf((*MockURLRepositoryWriter)(nil).MarkExpired), ctx, now)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockURLRepositoryWriter) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (model.PurgeStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore, limit)
+	ret0, _ := ret[0].(model.PurgeStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockURLRepositoryWriterMockRecorder) PurgeDeleted(ctx, deletedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockURLRepositoryWriter)(nil).PurgeDeleted), ctx, deletedBefore, limit)
+}
+
+// UpdateByUser mocks base method.
+func (m *MockURLRepositoryWriter) UpdateByUser(ctx context.Context, shortURL, userID string, update model.URLUpdate) (*model.URLsModel, *model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByUser", ctx, shortURL, userID, update)
+	ret0, _ := ret[0].(*model.URLsModel)
+	ret1, _ := ret[1].(*model.URLsModel)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateByUser indicates an expected call of UpdateByUser.
+func (mr *MockURLRepositoryWriterMockRecorder) UpdateByUser(ctx, shortURL, userID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByUser", reflect.TypeOf((*MockURLRepositoryWriter)(nil).UpdateByUser), ctx, shortURL, userID, update)
+}
+
+// MockURLClickConsumer is a mock of URLClickConsumer interface.
+type MockURLClickConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLClickConsumerMockRecorder
+	isgomock struct{}
+}
+
+// MockURLClickConsumerMockRecorder is the mock recorder for MockURLClickConsumer.
+type MockURLClickConsumerMockRecorder struct {
+	mock *MockURLClickConsumer
+}
+
+// NewMockURLClickConsumer creates a new mock instance.
+func NewMockURLClickConsumer(ctrl *gomock.Controller) *MockURLClickConsumer {
+	mock := &MockURLClickConsumer{ctrl: ctrl}
+	mock.recorder = &MockURLClickConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLClickConsumer) EXPECT() *MockURLClickConsumerMockRecorder {
+	return m.recorder
+}
+
+// ConsumeClick mocks base method.
+func (m *MockURLClickConsumer) ConsumeClick(ctx context.Context, shortURL string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeClick", ctx, shortURL)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeClick indicates an expected call of ConsumeClick.
+func (mr *MockURLClickConsumerMockRecorder) ConsumeClick(ctx, shortURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeClick", reflect.TypeOf((*MockURLClickConsumer)(nil).ConsumeClick), ctx, shortURL)
+}
+
 // MockUserRepository is a mock of UserRepository interface.
 type MockUserRepository struct {
 	ctrl     *gomock.Controller
@@ -538,14 +699,29 @@ func (mr *MockUserURLsRepositoryMockRecorder) CreateURLWithUser(ctx, url, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateURLWithUser", reflect.TypeOf((*MockUserURLsRepository)(nil).CreateURLWithUser), ctx, url, userID)
 }
 
-// DeleteURLsWithUser mocks base method.
-func (m *MockUserURLsRepository) DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
+// DeleteURLWithUser mocks base method.
+func (m *MockUserURLsRepository) DeleteURLWithUser(ctx context.Context, shortURL, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteURLsWithUser", ctx, shortURLs, userID)
+	ret := m.ctrl.Call(m, "DeleteURLWithUser", ctx, shortURL, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// DeleteURLWithUser indicates an expected call of DeleteURLWithUser.
+func (mr *MockUserURLsRepositoryMockRecorder) DeleteURLWithUser(ctx, shortURL, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURLWithUser", reflect.TypeOf((*MockUserURLsRepository)(nil).DeleteURLWithUser), ctx, shortURL, userID)
+}
+
+// DeleteURLsWithUser mocks base method.
+func (m *MockUserURLsRepository) DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) (map[string]model.DeleteOutcome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteURLsWithUser", ctx, shortURLs, userID)
+	ret0, _ := ret[0].(map[string]model.DeleteOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DeleteURLsWithUser indicates an expected call of DeleteURLsWithUser.
 func (mr *MockUserURLsRepositoryMockRecorder) DeleteURLsWithUser(ctx, shortURLs, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -567,6 +743,50 @@ func (mr *MockUserURLsRepositoryMockRecorder) GetByUserID(ctx, userID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockUserURLsRepository)(nil).GetByUserID), ctx, userID)
 }
 
+// GetByUserIDAndLongURL mocks base method.
+func (m *MockUserURLsRepository) GetByUserIDAndLongURL(ctx context.Context, userID, longURL string) (*model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndLongURL", ctx, userID, longURL)
+	ret0, _ := ret[0].(*model.URLsModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndLongURL indicates an expected call of GetByUserIDAndLongURL.
+func (mr *MockUserURLsRepositoryMockRecorder) GetByUserIDAndLongURL(ctx, userID, longURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndLongURL", reflect.TypeOf((*MockUserURLsRepository)(nil).GetByUserIDAndLongURL), ctx, userID, longURL)
+}
+
+// GetDeletedByUserID mocks base method.
+func (m *MockUserURLsRepository) GetDeletedByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*model.URLsModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedByUserID indicates an expected call of GetDeletedByUserID.
+func (mr *MockUserURLsRepositoryMockRecorder) GetDeletedByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedByUserID", reflect.TypeOf((*MockUserURLsRepository)(nil).GetDeletedByUserID), ctx, userID)
+}
+
+// RestoreURLsWithUser mocks base method.
+func (m *MockUserURLsRepository) RestoreURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreURLsWithUser", ctx, shortURLs, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreURLsWithUser indicates an expected call of RestoreURLsWithUser.
+func (mr *MockUserURLsRepositoryMockRecorder) RestoreURLsWithUser(ctx, shortURLs, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreURLsWithUser", reflect.TypeOf((*MockUserURLsRepository)(nil).RestoreURLsWithUser), ctx, shortURLs, userID)
+}
+
 // MockUserURLsRepositoryReader is a mock of UserURLsRepositoryReader interface.
 type MockUserURLsRepositoryReader struct {
 	ctrl     *gomock.Controller
@@ -606,6 +826,36 @@ func (mr *MockUserURLsRepositoryReaderMockRecorder) GetByUserID(ctx, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockUserURLsRepositoryReader)(nil).GetByUserID), ctx, userID)
 }
 
+// GetByUserIDAndLongURL mocks base method.
+func (m *MockUserURLsRepositoryReader) GetByUserIDAndLongURL(ctx context.Context, userID, longURL string) (*model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndLongURL", ctx, userID, longURL)
+	ret0, _ := ret[0].(*model.URLsModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndLongURL indicates an expected call of GetByUserIDAndLongURL.
+func (mr *MockUserURLsRepositoryReaderMockRecorder) GetByUserIDAndLongURL(ctx, userID, longURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndLongURL", reflect.TypeOf((*MockUserURLsRepositoryReader)(nil).GetByUserIDAndLongURL), ctx, userID, longURL)
+}
+
+// GetDeletedByUserID mocks base method.
+func (m *MockUserURLsRepositoryReader) GetDeletedByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*model.URLsModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedByUserID indicates an expected call of GetDeletedByUserID.
+func (mr *MockUserURLsRepositoryReaderMockRecorder) GetDeletedByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedByUserID", reflect.TypeOf((*MockUserURLsRepositoryReader)(nil).GetDeletedByUserID), ctx, userID)
+}
+
 // MockUserURLsRepositoryWriter is a mock of UserURLsRepositoryWriter interface.
 type MockUserURLsRepositoryWriter struct {
 	ctrl     *gomock.Controller
@@ -658,16 +908,396 @@ func (mr *MockUserURLsRepositoryWriterMockRecorder) CreateURLWithUser(ctx, url,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateURLWithUser", reflect.TypeOf((*MockUserURLsRepositoryWriter)(nil).CreateURLWithUser), ctx, url, userID)
 }
 
-// DeleteURLsWithUser mocks base method.
-func (m *MockUserURLsRepositoryWriter) DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
+// DeleteURLWithUser mocks base method.
+func (m *MockUserURLsRepositoryWriter) DeleteURLWithUser(ctx context.Context, shortURL, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteURLsWithUser", ctx, shortURLs, userID)
+	ret := m.ctrl.Call(m, "DeleteURLWithUser", ctx, shortURL, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// DeleteURLWithUser indicates an expected call of DeleteURLWithUser.
+func (mr *MockUserURLsRepositoryWriterMockRecorder) DeleteURLWithUser(ctx, shortURL, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURLWithUser", reflect.TypeOf((*MockUserURLsRepositoryWriter)(nil).DeleteURLWithUser), ctx, shortURL, userID)
+}
+
+// DeleteURLsWithUser mocks base method.
+func (m *MockUserURLsRepositoryWriter) DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) (map[string]model.DeleteOutcome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteURLsWithUser", ctx, shortURLs, userID)
+	ret0, _ := ret[0].(map[string]model.DeleteOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DeleteURLsWithUser indicates an expected call of DeleteURLsWithUser.
 func (mr *MockUserURLsRepositoryWriterMockRecorder) DeleteURLsWithUser(ctx, shortURLs, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURLsWithUser", reflect.TypeOf((*MockUserURLsRepositoryWriter)(nil).DeleteURLsWithUser), ctx, shortURLs, userID)
 }
+
+// RestoreURLsWithUser mocks base method.
+func (m *MockUserURLsRepositoryWriter) RestoreURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreURLsWithUser", ctx, shortURLs, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreURLsWithUser indicates an expected call of RestoreURLsWithUser.
+func (mr *MockUserURLsRepositoryWriterMockRecorder) RestoreURLsWithUser(ctx, shortURLs, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreURLsWithUser", reflect.TypeOf((*MockUserURLsRepositoryWriter)(nil).RestoreURLsWithUser), ctx, shortURLs, userID)
+}
+
+// MockDeleteJobRepository is a mock of DeleteJobRepository interface.
+type MockDeleteJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeleteJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDeleteJobRepositoryMockRecorder is the mock recorder for MockDeleteJobRepository.
+type MockDeleteJobRepositoryMockRecorder struct {
+	mock *MockDeleteJobRepository
+}
+
+// NewMockDeleteJobRepository creates a new mock instance.
+func NewMockDeleteJobRepository(ctrl *gomock.Controller) *MockDeleteJobRepository {
+	mock := &MockDeleteJobRepository{ctrl: ctrl}
+	mock.recorder = &MockDeleteJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeleteJobRepository) EXPECT() *MockDeleteJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimBatch mocks base method.
+func (m *MockDeleteJobRepository) ClaimBatch(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*model.DeleteJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimBatch", ctx, now, leaseUntil, limit)
+	ret0, _ := ret[0].([]*model.DeleteJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimBatch indicates an expected call of ClaimBatch.
+func (mr *MockDeleteJobRepositoryMockRecorder) ClaimBatch(ctx, now, leaseUntil, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimBatch", reflect.TypeOf((*MockDeleteJobRepository)(nil).ClaimBatch), ctx, now, leaseUntil, limit)
+}
+
+// Complete mocks base method.
+func (m *MockDeleteJobRepository) Complete(ctx context.Context, jobID string, results map[string]model.DeleteOutcome, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, jobID, results, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockDeleteJobRepositoryMockRecorder) Complete(ctx, jobID, results, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockDeleteJobRepository)(nil).Complete), ctx, jobID, results, now)
+}
+
+// Create mocks base method.
+func (m *MockDeleteJobRepository) Create(ctx context.Context, job *model.DeleteJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeleteJobRepositoryMockRecorder) Create(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeleteJobRepository)(nil).Create), ctx, job)
+}
+
+// DeleteFinished mocks base method.
+func (m *MockDeleteJobRepository) DeleteFinished(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinished", ctx, finishedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinished indicates an expected call of DeleteFinished.
+func (mr *MockDeleteJobRepositoryMockRecorder) DeleteFinished(ctx, finishedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinished", reflect.TypeOf((*MockDeleteJobRepository)(nil).DeleteFinished), ctx, finishedBefore)
+}
+
+// Fail mocks base method.
+func (m *MockDeleteJobRepository) Fail(ctx context.Context, jobID, lastErr string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, jobID, lastErr, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockDeleteJobRepositoryMockRecorder) Fail(ctx, jobID, lastErr, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockDeleteJobRepository)(nil).Fail), ctx, jobID, lastErr, now)
+}
+
+// GetByID mocks base method.
+func (m *MockDeleteJobRepository) GetByID(ctx context.Context, jobID string) (*model.DeleteJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, jobID)
+	ret0, _ := ret[0].(*model.DeleteJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDeleteJobRepositoryMockRecorder) GetByID(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDeleteJobRepository)(nil).GetByID), ctx, jobID)
+}
+
+// Retry mocks base method.
+func (m *MockDeleteJobRepository) Retry(ctx context.Context, jobID, lastErr string, nextRunAt, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, jobID, lastErr, nextRunAt, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockDeleteJobRepositoryMockRecorder) Retry(ctx, jobID, lastErr, nextRunAt, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockDeleteJobRepository)(nil).Retry), ctx, jobID, lastErr, nextRunAt, now)
+}
+
+// MockClickRepository is a mock of ClickRepository interface.
+type MockClickRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockClickRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockClickRepositoryMockRecorder is the mock recorder for MockClickRepository.
+type MockClickRepositoryMockRecorder struct {
+	mock *MockClickRepository
+}
+
+// NewMockClickRepository creates a new mock instance.
+func NewMockClickRepository(ctrl *gomock.Controller) *MockClickRepository {
+	mock := &MockClickRepository{ctrl: ctrl}
+	mock.recorder = &MockClickRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClickRepository) EXPECT() *MockClickRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetClickStats mocks base method.
+func (m *MockClickRepository) GetClickStats(ctx context.Context, shortURL, userID string, since time.Time) (*model.ClickStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClickStats", ctx, shortURL, userID, since)
+	ret0, _ := ret[0].(*model.ClickStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClickStats indicates an expected call of GetClickStats.
+func (mr *MockClickRepositoryMockRecorder) GetClickStats(ctx, shortURL, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClickStats", reflect.TypeOf((*MockClickRepository)(nil).GetClickStats), ctx, shortURL, userID, since)
+}
+
+// IncrementClickCounters mocks base method.
+func (m *MockClickRepository) IncrementClickCounters(ctx context.Context, counters map[string]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementClickCounters", ctx, counters)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementClickCounters indicates an expected call of IncrementClickCounters.
+func (mr *MockClickRepositoryMockRecorder) IncrementClickCounters(ctx, counters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementClickCounters", reflect.TypeOf((*MockClickRepository)(nil).IncrementClickCounters), ctx, counters)
+}
+
+// RecordClicks mocks base method.
+func (m *MockClickRepository) RecordClicks(ctx context.Context, clicks []*model.ClickModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClicks", ctx, clicks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClicks indicates an expected call of RecordClicks.
+func (mr *MockClickRepositoryMockRecorder) RecordClicks(ctx, clicks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClicks", reflect.TypeOf((*MockClickRepository)(nil).RecordClicks), ctx, clicks)
+}
+
+// MockClickRepositoryWriter is a mock of ClickRepositoryWriter interface.
+type MockClickRepositoryWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockClickRepositoryWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockClickRepositoryWriterMockRecorder is the mock recorder for MockClickRepositoryWriter.
+type MockClickRepositoryWriterMockRecorder struct {
+	mock *MockClickRepositoryWriter
+}
+
+// NewMockClickRepositoryWriter creates a new mock instance.
+func NewMockClickRepositoryWriter(ctrl *gomock.Controller) *MockClickRepositoryWriter {
+	mock := &MockClickRepositoryWriter{ctrl: ctrl}
+	mock.recorder = &MockClickRepositoryWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClickRepositoryWriter) EXPECT() *MockClickRepositoryWriterMockRecorder {
+	return m.recorder
+}
+
+// IncrementClickCounters mocks base method.
+func (m *MockClickRepositoryWriter) IncrementClickCounters(ctx context.Context, counters map[string]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementClickCounters", ctx, counters)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementClickCounters indicates an expected call of IncrementClickCounters.
+func (mr *MockClickRepositoryWriterMockRecorder) IncrementClickCounters(ctx, counters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementClickCounters", reflect.TypeOf((*MockClickRepositoryWriter)(nil).IncrementClickCounters), ctx, counters)
+}
+
+// RecordClicks mocks base method.
+func (m *MockClickRepositoryWriter) RecordClicks(ctx context.Context, clicks []*model.ClickModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClicks", ctx, clicks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClicks indicates an expected call of RecordClicks.
+func (mr *MockClickRepositoryWriterMockRecorder) RecordClicks(ctx, clicks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClicks", reflect.TypeOf((*MockClickRepositoryWriter)(nil).RecordClicks), ctx, clicks)
+}
+
+// MockClickRepositoryReader is a mock of ClickRepositoryReader interface.
+type MockClickRepositoryReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockClickRepositoryReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockClickRepositoryReaderMockRecorder is the mock recorder for MockClickRepositoryReader.
+type MockClickRepositoryReaderMockRecorder struct {
+	mock *MockClickRepositoryReader
+}
+
+// NewMockClickRepositoryReader creates a new mock instance.
+func NewMockClickRepositoryReader(ctrl *gomock.Controller) *MockClickRepositoryReader {
+	mock := &MockClickRepositoryReader{ctrl: ctrl}
+	mock.recorder = &MockClickRepositoryReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClickRepositoryReader) EXPECT() *MockClickRepositoryReaderMockRecorder {
+	return m.recorder
+}
+
+// GetClickStats mocks base method.
+func (m *MockClickRepositoryReader) GetClickStats(ctx context.Context, shortURL, userID string, since time.Time) (*model.ClickStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClickStats", ctx, shortURL, userID, since)
+	ret0, _ := ret[0].(*model.ClickStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClickStats indicates an expected call of GetClickStats.
+func (mr *MockClickRepositoryReaderMockRecorder) GetClickStats(ctx, shortURL, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClickStats", reflect.TypeOf((*MockClickRepositoryReader)(nil).GetClickStats), ctx, shortURL, userID, since)
+}
+
+// MockLinkHealthRepository is a mock of LinkHealthRepository interface.
+type MockLinkHealthRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkHealthRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLinkHealthRepositoryMockRecorder is the mock recorder for MockLinkHealthRepository.
+type MockLinkHealthRepositoryMockRecorder struct {
+	mock *MockLinkHealthRepository
+}
+
+// NewMockLinkHealthRepository creates a new mock instance.
+func NewMockLinkHealthRepository(ctrl *gomock.Controller) *MockLinkHealthRepository {
+	mock := &MockLinkHealthRepository{ctrl: ctrl}
+	mock.recorder = &MockLinkHealthRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkHealthRepository) EXPECT() *MockLinkHealthRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBrokenLinks mocks base method.
+func (m *MockLinkHealthRepository) GetBrokenLinks(ctx context.Context, minFailures, limit int) ([]*model.BrokenLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrokenLinks", ctx, minFailures, limit)
+	ret0, _ := ret[0].([]*model.BrokenLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrokenLinks indicates an expected call of GetBrokenLinks.
+func (mr *MockLinkHealthRepositoryMockRecorder) GetBrokenLinks(ctx, minFailures, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrokenLinks", reflect.TypeOf((*MockLinkHealthRepository)(nil).GetBrokenLinks), ctx, minFailures, limit)
+}
+
+// GetCheckTargets mocks base method.
+func (m *MockLinkHealthRepository) GetCheckTargets(ctx context.Context, afterID uint, limit int) ([]*model.LinkCheckTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckTargets", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.LinkCheckTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckTargets indicates an expected call of GetCheckTargets.
+func (mr *MockLinkHealthRepositoryMockRecorder) GetCheckTargets(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckTargets", reflect.TypeOf((*MockLinkHealthRepository)(nil).GetCheckTargets), ctx, afterID, limit)
+}
+
+// SaveResults mocks base method.
+func (m *MockLinkHealthRepository) SaveResults(ctx context.Context, results []*model.LinkCheckResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveResults", ctx, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveResults indicates an expected call of SaveResults.
+func (mr *MockLinkHealthRepositoryMockRecorder) SaveResults(ctx, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResults", reflect.TypeOf((*MockLinkHealthRepository)(nil).SaveResults), ctx, results)
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type clicksRepository struct {
+	pool PoolInterface
+}
+
+// NewClicksRepository создает новый репозиторий журнала переходов в PostgreSQL базе данных.
+// Принимает пул соединений PostgreSQL и возвращает реализацию интерфейса ClickRepository.
+func NewClicksRepository(pool *pgxpool.Pool) repository.ClickRepository {
+	return &clicksRepository{pool: pool}
+}
+
+// RecordClicks сохраняет пачку переходов одним запросом. Ссылки ищутся по короткому коду в том же запросе,
+// переходы по несуществующим ссылкам пропускаются.
+func (r *clicksRepository) RecordClicks(ctx context.Context, clicks []*model.ClickModel) error {
+	if len(clicks) == 0 {
+		return nil
+	}
+
+	var (
+		shortURLs  = make([]string, 0, len(clicks))
+		clickedAt  = make([]time.Time, 0, len(clicks))
+		referrers  = make([]string, 0, len(clicks))
+		userAgents = make([]string, 0, len(clicks))
+		ips        = make([]string, 0, len(clicks))
+		variants   = make([]string, 0, len(clicks))
+	)
+	for _, click := range clicks {
+		if click == nil {
+			continue
+		}
+		shortURLs = append(shortURLs, click.ShortURL)
+		clickedAt = append(clickedAt, click.ClickedAt)
+		referrers = append(referrers, click.Referrer)
+		userAgents = append(userAgents, click.UserAgent)
+		ips = append(ips, click.IP)
+		variants = append(variants, click.Variant)
+	}
+
+	query := `
+		INSERT INTO clicks (url_id, clicked_at, referrer, user_agent, ip, variant)
+		SELECT u.id, v.clicked_at, v.referrer, v.user_agent, v.ip, v.variant
+		FROM unnest($1::text[], $2::timestamptz[], $3::text[], $4::text[], $5::text[], $6::text[])
+			AS v(short_url, clicked_at, referrer, user_agent, ip, variant)
+		INNER JOIN urls u ON u.short_url = v.short_url
+	`
+	if _, err := r.pool.Exec(ctx, query, shortURLs, clickedAt, referrers, userAgents, ips, variants); err != nil {
+		return fmt.Errorf("failed to record clicks: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementClickCounters увеличивает счетчики переходов одним запросом с upsert.
+// Строки обновляются в порядке идентификаторов ссылок, чтобы параллельные сбросы блокировали их в одном порядке.
+func (r *clicksRepository) IncrementClickCounters(ctx context.Context, counters map[string]int64) error {
+	if len(counters) == 0 {
+		return nil
+	}
+
+	shortURLs := slices.Sorted(maps.Keys(counters))
+	deltas := make([]int64, len(shortURLs))
+	for i, shortURL := range shortURLs {
+		deltas[i] = counters[shortURL]
+	}
+
+	query := `
+		INSERT INTO click_counters (url_id, clicks, updated_at)
+		SELECT u.id, v.clicks, now()
+		FROM unnest($1::text[], $2::bigint[]) AS v(short_url, clicks)
+		INNER JOIN urls u ON u.short_url = v.short_url
+		ORDER BY u.id
+		ON CONFLICT (url_id) DO UPDATE
+		SET clicks = click_counters.clicks + EXCLUDED.clicks, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := r.pool.Exec(ctx, query, shortURLs, deltas); err != nil {
+		return fmt.Errorf("failed to increment click counters: %w", err)
+	}
+
+	return nil
+}
+
+// GetClickStats возвращает статистику переходов по ссылке пользователя.
+// Итоги и разбивка по вариантам считаются за все время, разбивка по дням - с since; дни определяются по UTC.
+func (r *clicksRepository) GetClickStats(
+	ctx context.Context,
+	shortURL, userID string,
+	since time.Time,
+) (*model.ClickStats, error) {
+	totalsQuery := `
+		SELECT u.id,
+			EXISTS (SELECT 1 FROM user_urls uu WHERE uu.url_id = u.id AND uu.user_id = $2),
+			(SELECT COUNT(*) FROM clicks c WHERE c.url_id = u.id),
+			(SELECT COUNT(DISTINCT (c.ip, c.user_agent)) FROM clicks c WHERE c.url_id = u.id)
+		FROM urls u
+		WHERE u.short_url = $1
+	`
+
+	var (
+		urlID   int64
+		isOwned bool
+	)
+	stats := &model.ClickStats{ShortURL: shortURL, Daily: []model.DailyClicks{}}
+	err := r.pool.QueryRow(ctx, totalsQuery, shortURL, userID).
+		Scan(&urlID, &isOwned, &stats.TotalClicks, &stats.UniqueVisitors)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrURLNotFound
+		}
+		return nil, fmt.Errorf("failed to get click totals: %w", err)
+	}
+	if !isOwned {
+		return nil, repository.ErrURLNotOwned
+	}
+
+	dailyQuery := `
+		SELECT date_trunc('day', clicked_at AT TIME ZONE 'UTC') AS day, COUNT(*), COUNT(DISTINCT (ip, user_agent))
+		FROM clicks
+		WHERE url_id = $1 AND clicked_at >= $2
+		GROUP BY day
+		ORDER BY day
+	`
+	rows, err := r.pool.Query(ctx, dailyQuery, urlID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily clicks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day model.DailyClicks
+		if err := rows.Scan(&day.Date, &day.Clicks, &day.UniqueVisitors); err != nil {
+			return nil, fmt.Errorf("failed to scan daily clicks: %w", err)
+		}
+		day.Date = time.Date(day.Date.Year(), day.Date.Month(), day.Date.Day(), 0, 0, 0, 0, time.UTC)
+		stats.Daily = append(stats.Daily, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get daily clicks: %w", err)
+	}
+
+	variantsQuery := `
+		SELECT variant, COUNT(*), COUNT(DISTINCT (ip, user_agent))
+		FROM clicks
+		WHERE url_id = $1 AND variant <> ''
+		GROUP BY variant
+		ORDER BY variant
+	`
+	variantRows, err := r.pool.Query(ctx, variantsQuery, urlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variant clicks: %w", err)
+	}
+	defer variantRows.Close()
+
+	for variantRows.Next() {
+		var variant model.VariantClicks
+		if err := variantRows.Scan(&variant.Variant, &variant.Clicks, &variant.UniqueVisitors); err != nil {
+			return nil, fmt.Errorf("failed to scan variant clicks: %w", err)
+		}
+		stats.Variants = append(stats.Variants, variant)
+	}
+	if err := variantRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get variant clicks: %w", err)
+	}
+
+	return stats, nil
+}
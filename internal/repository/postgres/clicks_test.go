@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupClicksMockPool(t *testing.T) (pgxmock.PgxPoolIface, *clicksRepository) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := &clicksRepository{pool: mock}
+	return mock, repo
+}
+
+func TestClicksRepository_RecordClicks(t *testing.T) {
+	mock, repo := setupClicksMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	clicks := []*model.ClickModel{
+		{ShortURL: "abc123", ClickedAt: now, Referrer: "https://news.example.com/", UserAgent: "Mozilla/5.0", IP: "192.168.1.0"},
+		nil,
+		{ShortURL: "xyz789", ClickedAt: now, UserAgent: "curl", IP: "10.0.0.0", Variant: "b"},
+	}
+	insertPattern := "INSERT INTO clicks \\(url_id, clicked_at, referrer, user_agent, ip, variant\\) SELECT u.id, v.clicked_at, v.referrer, v.user_agent, v.ip, v.variant FROM unnest\\("
+
+	mock.ExpectExec(insertPattern).
+		WithArgs(
+			[]string{"abc123", "xyz789"},
+			[]time.Time{now, now},
+			[]string{"https://news.example.com/", ""},
+			[]string{"Mozilla/5.0", "curl"},
+			[]string{"192.168.1.0", "10.0.0.0"},
+			[]string{"", "b"},
+		).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	require.NoError(t, repo.RecordClicks(ctx, clicks))
+
+	// Пустая пачка не отправляется в базу данных
+	require.NoError(t, repo.RecordClicks(ctx, nil))
+
+	mock.ExpectExec(insertPattern).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(errors.New("connection reset"))
+	assert.Error(t, repo.RecordClicks(ctx, clicks))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClicksRepository_IncrementClickCounters(t *testing.T) {
+	mock, repo := setupClicksMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	upsertPattern := "INSERT INTO click_counters \\(url_id, clicks, updated_at\\) SELECT u.id, v.clicks, now\\(\\) " +
+		"FROM unnest\\(\\$1::text\\[\\], \\$2::bigint\\[\\]\\) AS v\\(short_url, clicks\\) " +
+		"INNER JOIN urls u ON u.short_url = v.short_url ORDER BY u.id ON CONFLICT \\(url_id\\) DO UPDATE"
+
+	// Коды передаются отсортированными, чтобы порядок не зависел от обхода map
+	mock.ExpectExec(upsertPattern).
+		WithArgs([]string{"abc123", "xyz789"}, []int64{3, 1}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	require.NoError(t, repo.IncrementClickCounters(ctx, map[string]int64{"xyz789": 1, "abc123": 3}))
+
+	require.NoError(t, repo.IncrementClickCounters(ctx, map[string]int64{}))
+
+	mock.ExpectExec(upsertPattern).
+		WithArgs([]string{"abc123"}, []int64{1}).
+		WillReturnError(errors.New("connection reset"))
+	assert.Error(t, repo.IncrementClickCounters(ctx, map[string]int64{"abc123": 1}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClicksRepository_GetClickStats(t *testing.T) {
+	ctx := context.Background()
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	totalsPattern := "SELECT u.id, EXISTS \\(SELECT 1 FROM user_urls uu WHERE uu.url_id = u.id AND uu.user_id = \\$2\\)"
+	dailyPattern := "SELECT date_trunc\\('day', clicked_at AT TIME ZONE 'UTC'\\) AS day, COUNT\\(\\*\\), COUNT\\(DISTINCT \\(ip, user_agent\\)\\) FROM clicks WHERE url_id = \\$1 AND clicked_at >= \\$2"
+	variantsPattern := "SELECT variant, COUNT\\(\\*\\), COUNT\\(DISTINCT \\(ip, user_agent\\)\\) FROM clicks WHERE url_id = \\$1 AND variant <> ''"
+
+	t.Run("статистика владельца", func(t *testing.T) {
+		mock, repo := setupClicksMockPool(t)
+		defer mock.Close()
+
+		mock.ExpectQuery(totalsPattern).
+			WithArgs("abc123", "owner").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "exists", "total", "unique"}).AddRow(int64(7), true, int64(5), int64(3)))
+		mock.ExpectQuery(dailyPattern).
+			WithArgs(int64(7), since).
+			WillReturnRows(pgxmock.NewRows([]string{"day", "count", "unique"}).
+				AddRow(time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), int64(2), int64(1)).
+				AddRow(time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), int64(3), int64(2)))
+		mock.ExpectQuery(variantsPattern).
+			WithArgs(int64(7)).
+			WillReturnRows(pgxmock.NewRows([]string{"variant", "count", "unique"}).
+				AddRow("a", int64(3), int64(2)).
+				AddRow("b", int64(1), int64(1)))
+
+		stats, err := repo.GetClickStats(ctx, "abc123", "owner", since)
+		require.NoError(t, err)
+		assert.Equal(t, &model.ClickStats{
+			ShortURL:       "abc123",
+			TotalClicks:    5,
+			UniqueVisitors: 3,
+			Daily: []model.DailyClicks{
+				{Date: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), Clicks: 2, UniqueVisitors: 1},
+				{Date: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Clicks: 3, UniqueVisitors: 2},
+			},
+			Variants: []model.VariantClicks{
+				{Variant: "a", Clicks: 3, UniqueVisitors: 2},
+				{Variant: "b", Clicks: 1, UniqueVisitors: 1},
+			},
+		}, stats)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ссылка другого пользователя", func(t *testing.T) {
+		mock, repo := setupClicksMockPool(t)
+		defer mock.Close()
+
+		mock.ExpectQuery(totalsPattern).
+			WithArgs("abc123", "stranger").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "exists", "total", "unique"}).AddRow(int64(7), false, int64(5), int64(3)))
+
+		_, err := repo.GetClickStats(ctx, "abc123", "stranger", since)
+		assert.ErrorIs(t, err, repository.ErrURLNotOwned)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ссылка не найдена", func(t *testing.T) {
+		mock, repo := setupClicksMockPool(t)
+		defer mock.Close()
+
+		mock.ExpectQuery(totalsPattern).
+			WithArgs("missing", "owner").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "exists", "total", "unique"}))
+
+		_, err := repo.GetClickStats(ctx, "missing", "owner", since)
+		assert.ErrorIs(t, err, repository.ErrURLNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
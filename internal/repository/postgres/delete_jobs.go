@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const deleteJobColumns = `id, user_id, action, short_urls, status, results, error, attempts, next_run_at, created_at, updated_at`
+
+type deleteJobsRepository struct {
+	pool PoolInterface
+}
+
+// NewDeleteJobsRepository создает новый репозиторий очереди задач удаления в PostgreSQL базе данных.
+// Принимает пул соединений PostgreSQL и возвращает реализацию интерфейса DeleteJobRepository.
+func NewDeleteJobsRepository(pool *pgxpool.Pool) repository.DeleteJobRepository {
+	return &deleteJobsRepository{pool: pool}
+}
+
+// Create сохраняет новую задачу в очереди.
+func (r *deleteJobsRepository) Create(ctx context.Context, job *model.DeleteJob) error {
+	if job == nil {
+		return errors.New("job cannot be nil")
+	}
+
+	shortURLs, err := json.Marshal(job.ShortURLs)
+	if err != nil {
+		return fmt.Errorf("failed to encode short urls: %w", err)
+	}
+
+	query := `
+		INSERT INTO delete_jobs (id, user_id, action, short_urls, status, attempts, next_run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = r.pool.Exec(ctx, query,
+		job.ID, job.UserID, job.Action, shortURLs, job.Status, job.Attempts, job.NextRunAt, job.CreatedAt, job.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create delete job: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID возвращает задачу по идентификатору или ErrDeleteJobNotFound, если задача не найдена.
+// Идентификатор, не являющийся UUID, тоже приводит к ErrDeleteJobNotFound: такой задачи не может быть в таблице.
+func (r *deleteJobsRepository) GetByID(ctx context.Context, jobID string) (*model.DeleteJob, error) {
+	query := `SELECT ` + deleteJobColumns + ` FROM delete_jobs WHERE id = $1`
+
+	job, err := scanDeleteJob(r.pool.QueryRow(ctx, query, jobID))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "22P02" { // invalid_text_representation
+			return nil, repository.ErrDeleteJobNotFound
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// ClaimBatch захватывает до limit самых ранних готовых к выполнению задач.
+// Строки, захваченные другими воркерами, пропускаются (FOR UPDATE SKIP LOCKED), поэтому одну задачу
+// не могут одновременно получить два воркера. Задача с истекшей арендой считается брошенной и захватывается снова.
+func (r *deleteJobsRepository) ClaimBatch(
+	ctx context.Context,
+	now, leaseUntil time.Time,
+	limit int,
+) ([]*model.DeleteJob, error) {
+	query := `
+		UPDATE delete_jobs
+		SET status = 'running', lease_until = $2, attempts = attempts + 1, updated_at = $1
+		WHERE id IN (
+			SELECT id FROM delete_jobs
+			WHERE (status = 'queued' AND next_run_at <= $1)
+			OR (status = 'running' AND lease_until < $1)
+			ORDER BY next_run_at, created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deleteJobColumns
+
+	rows, err := r.pool.Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim delete jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*model.DeleteJob
+	for rows.Next() {
+		job, err := scanDeleteJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delete job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim delete jobs: %w", err)
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	sortDeleteJobs(jobs)
+	return jobs, nil
+}
+
+// Complete завершает задачу со статусом done и сохраняет результат по каждой ссылке.
+func (r *deleteJobsRepository) Complete(
+	ctx context.Context,
+	jobID string,
+	results map[string]model.DeleteOutcome,
+	now time.Time,
+) error {
+	var encoded []byte
+	if results != nil {
+		var err error
+		if encoded, err = json.Marshal(results); err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE delete_jobs
+		SET status = 'done', results = $2, error = NULL, lease_until = NULL, updated_at = $3
+		WHERE id = $1
+	`
+	return r.finish(ctx, query, jobID, encoded, now)
+}
+
+// Retry возвращает задачу в очередь до nextRunAt и сохраняет ошибку последней попытки.
+func (r *deleteJobsRepository) Retry(ctx context.Context, jobID, lastErr string, nextRunAt, now time.Time) error {
+	query := `
+		UPDATE delete_jobs
+		SET status = 'queued', error = $2, next_run_at = $4, lease_until = NULL, updated_at = $3
+		WHERE id = $1
+	`
+	return r.finish(ctx, query, jobID, lastErr, now, nextRunAt)
+}
+
+// Fail завершает задачу со статусом failed.
+func (r *deleteJobsRepository) Fail(ctx context.Context, jobID, lastErr string, now time.Time) error {
+	query := `
+		UPDATE delete_jobs
+		SET status = 'failed', error = $2, lease_until = NULL, updated_at = $3
+		WHERE id = $1
+	`
+	return r.finish(ctx, query, jobID, lastErr, now)
+}
+
+func (r *deleteJobsRepository) finish(ctx context.Context, query, jobID string, args ...any) error {
+	tag, err := r.pool.Exec(ctx, query, append([]any{jobID}, args...)...)
+	if err != nil {
+		return fmt.Errorf("failed to update delete job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrDeleteJobNotFound
+	}
+	return nil
+}
+
+// DeleteFinished удаляет завершенные задачи, последний раз измененные раньше finishedBefore.
+// Возвращает количество удаленных задач.
+func (r *deleteJobsRepository) DeleteFinished(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	query := `DELETE FROM delete_jobs WHERE status IN ('done', 'failed') AND updated_at < $1`
+
+	tag, err := r.pool.Exec(ctx, query, finishedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func scanDeleteJob(row pgx.Row) (*model.DeleteJob, error) {
+	var (
+		job       model.DeleteJob
+		shortURLs []byte
+		results   []byte
+		lastErr   *string
+	)
+
+	err := row.Scan(
+		&job.ID, &job.UserID, &job.Action, &shortURLs, &job.Status, &results, &lastErr,
+		&job.Attempts, &job.NextRunAt, &job.CreatedAt, &job.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrDeleteJobNotFound
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(shortURLs, &job.ShortURLs); err != nil {
+		return nil, fmt.Errorf("failed to decode short urls: %w", err)
+	}
+	if len(results) > 0 {
+		if err := json.Unmarshal(results, &job.Results); err != nil {
+			return nil, fmt.Errorf("failed to decode results: %w", err)
+		}
+	}
+	if lastErr != nil {
+		job.Error = *lastErr
+	}
+
+	return &job, nil
+}
+
+func sortDeleteJobs(jobs []*model.DeleteJob) {
+	slices.SortStableFunc(jobs, func(a, b *model.DeleteJob) int {
+		if c := a.NextRunAt.Compare(b.NextRunAt); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+}
@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deleteJobColumnsPattern = "id, user_id, action, short_urls, status, results, error, attempts, next_run_at, created_at, updated_at"
+
+func setupDeleteJobsMockPool(t *testing.T) (pgxmock.PgxPoolIface, *deleteJobsRepository) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := &deleteJobsRepository{pool: mock}
+	return mock, repo
+}
+
+func deleteJobRows() *pgxmock.Rows {
+	return pgxmock.NewRows([]string{"id", "user_id", "action", "short_urls", "status", "results", "error", "attempts", "next_run_at", "created_at", "updated_at"})
+}
+
+func TestDeleteJobsRepository_Create(t *testing.T) {
+	mock, repo := setupDeleteJobsMockPool(t)
+	defer mock.Close()
+
+	now := time.Now()
+	job := &model.DeleteJob{
+		ID:        "job-1",
+		UserID:    "test-user-id",
+		Action:    model.DeleteJobActionDelete,
+		Status:    model.DeleteJobQueued,
+		ShortURLs: []string{"abc123", "def456"},
+		NextRunAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	mock.ExpectExec("INSERT INTO delete_jobs \\(id, user_id, action, short_urls, status, attempts, next_run_at, created_at, updated_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9\\)").
+		WithArgs("job-1", "test-user-id", model.DeleteJobActionDelete, []byte(`["abc123","def456"]`), model.DeleteJobQueued, 0, now, now, now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.Create(context.Background(), job))
+	assert.Error(t, repo.Create(context.Background(), nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteJobsRepository_GetByID(t *testing.T) {
+	mock, repo := setupDeleteJobsMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	lastErr := "database connection failed"
+
+	mock.ExpectQuery("SELECT " + deleteJobColumnsPattern + " FROM delete_jobs WHERE id = \\$1").
+		WithArgs("job-1").
+		WillReturnRows(deleteJobRows().AddRow(
+			"job-1", "test-user-id", model.DeleteJobActionDelete, []byte(`["abc123","def456"]`), model.DeleteJobDone,
+			[]byte(`{"abc123":"deleted","def456":"not_found"}`), &lastErr, 2, now, now, now,
+		))
+	mock.ExpectQuery("SELECT " + deleteJobColumnsPattern + " FROM delete_jobs WHERE id = \\$1").
+		WithArgs("unknown").
+		WillReturnRows(deleteJobRows())
+
+	job, err := repo.GetByID(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc123", "def456"}, job.ShortURLs)
+	assert.Equal(t, map[string]model.DeleteOutcome{
+		"abc123": model.DeleteOutcomeDeleted,
+		"def456": model.DeleteOutcomeNotFound,
+	}, job.Results)
+	assert.Equal(t, lastErr, job.Error)
+	assert.Equal(t, 2, job.Attempts)
+
+	_, err = repo.GetByID(ctx, "unknown")
+	assert.ErrorIs(t, err, repository.ErrDeleteJobNotFound)
+
+	// Столбец id имеет тип UUID, поэтому PostgreSQL отклоняет идентификатор другого вида
+	mock.ExpectQuery("SELECT " + deleteJobColumnsPattern + " FROM delete_jobs WHERE id = \\$1").
+		WithArgs("not-a-uuid").
+		WillReturnError(&pgconn.PgError{Code: "22P02", Message: "invalid input syntax for type uuid"})
+
+	_, err = repo.GetByID(ctx, "not-a-uuid")
+	assert.ErrorIs(t, err, repository.ErrDeleteJobNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteJobsRepository_ClaimBatch(t *testing.T) {
+	mock, repo := setupDeleteJobsMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	leaseUntil := now.Add(30 * time.Second)
+	claimQuery := "UPDATE delete_jobs SET status = 'running', lease_until = \\$2, attempts = attempts \\+ 1, updated_at = \\$1 WHERE id IN \\( SELECT id FROM delete_jobs WHERE \\(status = 'queued' AND next_run_at <= \\$1\\) OR \\(status = 'running' AND lease_until < \\$1\\) ORDER BY next_run_at, created_at LIMIT \\$3 FOR UPDATE SKIP LOCKED \\) RETURNING " + deleteJobColumnsPattern
+
+	// RETURNING возвращает строки в произвольном порядке
+	mock.ExpectQuery(claimQuery).
+		WithArgs(now, leaseUntil, 10).
+		WillReturnRows(deleteJobRows().
+			AddRow(
+				"job-2", "test-user-id", model.DeleteJobActionDelete, []byte(`["def456"]`), model.DeleteJobRunning,
+				nil, nil, 1, now, now, now,
+			).
+			AddRow(
+				"job-1", "test-user-id", model.DeleteJobActionRestore, []byte(`["abc123"]`), model.DeleteJobRunning,
+				nil, nil, 1, now.Add(-time.Second), now, now,
+			))
+	mock.ExpectQuery(claimQuery).
+		WithArgs(now, leaseUntil, 10).
+		WillReturnRows(deleteJobRows())
+	mock.ExpectQuery(claimQuery).
+		WithArgs(now, leaseUntil, 10).
+		WillReturnError(errors.New("database connection failed"))
+
+	jobs, err := repo.ClaimBatch(ctx, now, leaseUntil, 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "job-1", jobs[0].ID)
+	assert.Equal(t, model.DeleteJobActionRestore, jobs[0].Action)
+	assert.Equal(t, model.DeleteJobRunning, jobs[0].Status)
+	assert.Nil(t, jobs[0].Results)
+	assert.Empty(t, jobs[0].Error)
+	assert.Equal(t, "job-2", jobs[1].ID)
+
+	// Очередь пуста
+	jobs, err = repo.ClaimBatch(ctx, now, leaseUntil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	_, err = repo.ClaimBatch(ctx, now, leaseUntil, 10)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteJobsRepository_Finish(t *testing.T) {
+	mock, repo := setupDeleteJobsMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	nextRunAt := now.Add(time.Second)
+
+	mock.ExpectExec("UPDATE delete_jobs SET status = 'done', results = \\$2, error = NULL, lease_until = NULL, updated_at = \\$3 WHERE id = \\$1").
+		WithArgs("job-1", []byte(`{"abc123":"deleted"}`), now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE delete_jobs SET status = 'queued', error = \\$2, next_run_at = \\$4, lease_until = NULL, updated_at = \\$3 WHERE id = \\$1").
+		WithArgs("job-2", "timeout", now, nextRunAt).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE delete_jobs SET status = 'failed', error = \\$2, lease_until = NULL, updated_at = \\$3 WHERE id = \\$1").
+		WithArgs("job-3", "timeout", now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE delete_jobs SET status = 'failed', error = \\$2, lease_until = NULL, updated_at = \\$3 WHERE id = \\$1").
+		WithArgs("job-4", "timeout", now).
+		WillReturnError(errors.New("database connection failed"))
+
+	require.NoError(t, repo.Complete(ctx, "job-1", map[string]model.DeleteOutcome{"abc123": model.DeleteOutcomeDeleted}, now))
+	require.NoError(t, repo.Retry(ctx, "job-2", "timeout", nextRunAt, now))
+	assert.ErrorIs(t, repo.Fail(ctx, "job-3", "timeout", now), repository.ErrDeleteJobNotFound)
+	assert.Error(t, repo.Fail(ctx, "job-4", "timeout", now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteJobsRepository_DeleteFinished(t *testing.T) {
+	mock, repo := setupDeleteJobsMockPool(t)
+	defer mock.Close()
+
+	before := time.Now().Add(-time.Hour)
+
+	mock.ExpectExec("DELETE FROM delete_jobs WHERE status IN \\('done', 'failed'\\) AND updated_at < \\$1").
+		WithArgs(before).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+	deleted, err := repo.DeleteFinished(context.Background(), before)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
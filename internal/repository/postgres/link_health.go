@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type linkHealthRepository struct {
+	pool PoolInterface
+}
+
+// NewLinkHealthRepository создает новый репозиторий результатов проверки доступности адресов назначения
+// в PostgreSQL базе данных. Принимает пул соединений PostgreSQL и возвращает реализацию интерфейса LinkHealthRepository.
+func NewLinkHealthRepository(pool *pgxpool.Pool) repository.LinkHealthRepository {
+	return &linkHealthRepository{pool: pool}
+}
+
+// GetCheckTargets возвращает страницу действующих ссылок для проверки в порядке идентификаторов.
+func (r *linkHealthRepository) GetCheckTargets(ctx context.Context, afterID uint, limit int) ([]*model.LinkCheckTarget, error) {
+	query := `
+		SELECT id, short_url, long_url
+		FROM urls
+		WHERE id > $1 AND is_deleted = false AND is_disabled = false AND is_expired = false
+		AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link check targets: %w", err)
+	}
+	defer rows.Close()
+
+	var targets []*model.LinkCheckTarget
+	for rows.Next() {
+		var target model.LinkCheckTarget
+		if err := rows.Scan(&target.ID, &target.ShortURL, &target.LongURL); err != nil {
+			return nil, fmt.Errorf("failed to scan link check target: %w", err)
+		}
+		targets = append(targets, &target)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate link check targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+// SaveResults сохраняет результаты проверок одним запросом с upsert.
+// Строки обновляются в порядке идентификаторов ссылок, чтобы параллельные записи блокировали их в одном порядке.
+func (r *linkHealthRepository) SaveResults(ctx context.Context, results []*model.LinkCheckResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	sorted := slices.SortedFunc(slices.Values(results), func(a, b *model.LinkCheckResult) int {
+		return cmp.Compare(a.URLID, b.URLID)
+	})
+
+	urlIDs := make([]int64, len(sorted))
+	statusCodes := make([]int32, len(sorted))
+	errs := make([]string, len(sorted))
+	chains := make([]string, len(sorted))
+	healthy := make([]bool, len(sorted))
+	checkedAt := make([]time.Time, len(sorted))
+	for i, result := range sorted {
+		chain, err := result.RedirectChain.Value()
+		if err != nil {
+			return fmt.Errorf("failed to encode redirect chain: %w", err)
+		}
+		urlIDs[i] = int64(result.URLID)
+		statusCodes[i] = int32(result.StatusCode)
+		errs[i] = result.Error
+		chains[i] = chain.(string)
+		healthy[i] = result.IsHealthy()
+		checkedAt[i] = result.CheckedAt
+	}
+
+	query := `
+		INSERT INTO link_health (url_id, status_code, error, redirect_chain, consecutive_failures, checked_at)
+		SELECT u.id, v.status_code, v.error, v.redirect_chain, CASE WHEN v.healthy THEN 0 ELSE 1 END, v.checked_at
+		FROM unnest($1::bigint[], $2::int[], $3::text[], $4::text[], $5::bool[], $6::timestamptz[])
+			AS v(url_id, status_code, error, redirect_chain, healthy, checked_at)
+		INNER JOIN urls u ON u.id = v.url_id
+		ORDER BY u.id
+		ON CONFLICT (url_id) DO UPDATE
+		SET status_code = EXCLUDED.status_code, error = EXCLUDED.error, redirect_chain = EXCLUDED.redirect_chain,
+			consecutive_failures = CASE WHEN EXCLUDED.consecutive_failures = 0 THEN 0 ELSE link_health.consecutive_failures + 1 END,
+			checked_at = EXCLUDED.checked_at
+	`
+	if _, err := r.pool.Exec(ctx, query, urlIDs, statusCodes, errs, chains, healthy, checkedAt); err != nil {
+		return fmt.Errorf("failed to save link check results: %w", err)
+	}
+
+	return nil
+}
+
+// GetBrokenLinks возвращает действующие ссылки, недоступные не менее minFailures проверок подряд.
+func (r *linkHealthRepository) GetBrokenLinks(ctx context.Context, minFailures, limit int) ([]*model.BrokenLink, error) {
+	query := `
+		SELECT u.short_url, u.long_url, lh.status_code, lh.error, lh.redirect_chain, lh.consecutive_failures, lh.checked_at
+		FROM link_health lh
+		INNER JOIN urls u ON u.id = lh.url_id
+		WHERE lh.consecutive_failures >= $1 AND u.is_deleted = false AND u.is_disabled = false
+		ORDER BY lh.consecutive_failures DESC, u.id
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, minFailures, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broken links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*model.BrokenLink
+	for rows.Next() {
+		var link model.BrokenLink
+		if err := rows.Scan(
+			&link.ShortURL,
+			&link.LongURL,
+			&link.Health.StatusCode,
+			&link.Health.Error,
+			&link.Health.RedirectChain,
+			&link.Health.ConsecutiveFailures,
+			&link.Health.CheckedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan broken link: %w", err)
+		}
+		links = append(links, &link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate broken links: %w", err)
+	}
+
+	return links, nil
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLinkHealthMockPool(t *testing.T) (pgxmock.PgxPoolIface, *linkHealthRepository) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	repo := &linkHealthRepository{pool: mock}
+	return mock, repo
+}
+
+func TestLinkHealthRepository_GetCheckTargets(t *testing.T) {
+	mock, repo := setupLinkHealthMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	selectPattern := "SELECT id, short_url, long_url FROM urls WHERE id > \\$1 AND is_deleted = false AND is_disabled = false AND is_expired = false"
+
+	mock.ExpectQuery(selectPattern).
+		WithArgs(uint(10), 2).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "short_url", "long_url"}).
+			AddRow(uint(11), "abc123", "https://example.com/1").
+			AddRow(uint(15), "def456", "https://example.com/2"))
+
+	targets, err := repo.GetCheckTargets(ctx, 10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.LinkCheckTarget{
+		{ID: 11, ShortURL: "abc123", LongURL: "https://example.com/1"},
+		{ID: 15, ShortURL: "def456", LongURL: "https://example.com/2"},
+	}, targets)
+
+	mock.ExpectQuery(selectPattern).
+		WithArgs(uint(0), 2).
+		WillReturnError(errors.New("connection reset"))
+	_, err = repo.GetCheckTargets(ctx, 0, 2)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLinkHealthRepository_SaveResults(t *testing.T) {
+	mock, repo := setupLinkHealthMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	results := []*model.LinkCheckResult{
+		{URLID: 7, LinkHealth: model.LinkHealth{Error: "connection refused", CheckedAt: now}},
+		{URLID: 3, LinkHealth: model.LinkHealth{StatusCode: 200, RedirectChain: model.RedirectChain{"https://example.com/new"}, CheckedAt: now}},
+	}
+	insertPattern := "INSERT INTO link_health \\(url_id, status_code, error, redirect_chain, consecutive_failures, checked_at\\) SELECT .+ FROM unnest\\(.+ ON CONFLICT \\(url_id\\) DO UPDATE"
+
+	// Результаты сохраняются в порядке идентификаторов ссылок
+	mock.ExpectExec(insertPattern).
+		WithArgs(
+			[]int64{3, 7},
+			[]int32{200, 0},
+			[]string{"", "connection refused"},
+			[]string{`["https://example.com/new"]`, ""},
+			[]bool{true, false},
+			[]time.Time{now, now},
+		).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	require.NoError(t, repo.SaveResults(ctx, results))
+
+	// Пустая пачка не отправляется в базу данных
+	require.NoError(t, repo.SaveResults(ctx, nil))
+
+	mock.ExpectExec(insertPattern).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(errors.New("connection reset"))
+	assert.Error(t, repo.SaveResults(ctx, results))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLinkHealthRepository_GetBrokenLinks(t *testing.T) {
+	mock, repo := setupLinkHealthMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	checkedAt := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	selectPattern := "SELECT u.short_url, u.long_url, lh.status_code, lh.error, lh.redirect_chain, lh.consecutive_failures, lh.checked_at FROM link_health lh INNER JOIN urls u ON u.id = lh.url_id WHERE lh.consecutive_failures >= \\$1"
+
+	mock.ExpectQuery(selectPattern).
+		WithArgs(3, 100).
+		WillReturnRows(pgxmock.NewRows([]string{"short_url", "long_url", "status_code", "error", "redirect_chain", "consecutive_failures", "checked_at"}).
+			AddRow("abc123", "https://example.com/removed", 404, "", model.RedirectChain{"https://example.com/gone"}, 5, checkedAt))
+
+	links, err := repo.GetBrokenLinks(ctx, 3, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.BrokenLink{{
+		ShortURL: "abc123",
+		LongURL:  "https://example.com/removed",
+		Health: model.LinkHealth{
+			StatusCode:          404,
+			RedirectChain:       model.RedirectChain{"https://example.com/gone"},
+			ConsecutiveFailures: 5,
+			CheckedAt:           checkedAt,
+		},
+	}}, links)
+
+	mock.ExpectQuery(selectPattern).
+		WithArgs(3, 100).
+		WillReturnError(errors.New("connection reset"))
+	_, err = repo.GetBrokenLinks(ctx, 3, 100)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/repository"
 
@@ -43,51 +44,26 @@ func (r *urlsRepository) Ping(ctx context.Context) error {
 }
 
 // GetByLongURL получает URL из базы данных по длинному URL.
-// Возвращает модель URL или ошибку, если URL не найден или был удален.
+// Ссылки со сроком действия, лимитом переходов, паролем, заголовком, страницей предупреждения, особыми настройками перенаправления или переносом пути не участвуют в поиске, так как каждая из них выдается отдельно.
+// Возвращает модель URL или ошибку, если URL не найден, был удален, истек или отключен владельцем.
 func (r *urlsRepository) GetByLongURL(ctx context.Context, longURL string) (*model.URLsModel, error) {
-	var urls model.URLsModel
-
 	query := `
-		SELECT id, short_url, long_url, is_deleted, created_at, updated_at 
+		SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants
 		FROM urls 
-		WHERE long_url = $1 AND is_deleted = false
+		WHERE long_url = $1 AND is_deleted = false AND is_expired = false
+		AND expires_at IS NULL AND max_clicks IS NULL AND password_hash IS NULL AND is_disabled = false
+		AND title = '' AND show_interstitial = false AND redirect_code = 307 AND query_passthrough = '' AND is_prefix = false AND routing_rules = '' AND variants = ''
 		`
 
-	err := r.pool.QueryRow(ctx, query, longURL).Scan(
-		&urls.ID,
-		&urls.ShortURL,
-		&urls.LongURL,
-		&urls.IsDeleted,
-		&urls.CreatedAt,
-		&urls.UpdatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return &urls, nil
+	return scanURL(r.pool.QueryRow(ctx, query, longURL))
 }
 
 // GetByShortURL получает URL из базы данных по короткому идентификатору.
 // Возвращает модель URL или ошибку, если URL не найден.
 func (r *urlsRepository) GetByShortURL(ctx context.Context, shortURL string) (*model.URLsModel, error) {
-	var urls model.URLsModel
-
-	query := `SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE short_url = $1`
+	query := `SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE short_url = $1`
 
-	err := r.pool.QueryRow(ctx, query, shortURL).Scan(
-		&urls.ID,
-		&urls.ShortURL,
-		&urls.LongURL,
-		&urls.IsDeleted,
-		&urls.CreatedAt,
-		&urls.UpdatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return &urls, nil
+	return scanURL(r.pool.QueryRow(ctx, query, shortURL))
 }
 
 // Create создает новую запись URL в базе данных.
@@ -97,10 +73,13 @@ func (r *urlsRepository) Create(ctx context.Context, url *model.URLsModel) error
 		return errors.New("url cannot be nil")
 	}
 
-	query := `INSERT INTO urls (short_url, long_url) VALUES ($1, $2)`
+	query := `INSERT INTO urls (short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants) VALUES ($1, $2, $3, $4, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
-	_, err := r.pool.Exec(ctx, query, url.ShortURL, url.LongURL)
+	_, err := r.pool.Exec(ctx, query, url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants)
 	if err != nil {
+		if repository.IsShortURLExistsError(err) {
+			return repository.ErrShortURLExists
+		}
 		return err
 	}
 
@@ -108,6 +87,8 @@ func (r *urlsRepository) Create(ctx context.Context, url *model.URLsModel) error
 }
 
 // CreateBatch создает несколько записей URL в базе данных в одной транзакции.
+// Повторная вставка того же короткого URL для того же длинного URL игнорируется,
+// а если короткий URL уже занят другим длинным URL, возвращается ErrShortURLExists.
 // Принимает список моделей URL и возвращает ошибку, если создание не удалось.
 func (r *urlsRepository) CreateBatch(ctx context.Context, urls []*model.URLsModel) error {
 	if len(urls) == 0 {
@@ -121,14 +102,15 @@ func (r *urlsRepository) CreateBatch(ctx context.Context, urls []*model.URLsMode
 	}
 
 	// Подготавливаем batch insert запрос
-	query := `INSERT INTO urls (short_url, long_url, created_at, updated_at) VALUES ($1, $2, $3, $4) ON CONFLICT (short_url) DO NOTHING`
+	query := `INSERT INTO urls (short_url, long_url, created_at, updated_at, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants) VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $8, $9, $10, $11, $12, $13, $14) ON CONFLICT (short_url) DO NOTHING`
+	existingQuery := `SELECT long_url FROM urls WHERE short_url = $1`
 
 	// Выполняем вставку каждого URL в транзакции
 	for _, url := range urls {
 		if url == nil {
 			continue
 		}
-		_, err := tx.Exec(ctx, query, url.ShortURL, url.LongURL, url.CreatedAt, url.UpdatedAt)
+		tag, err := tx.Exec(ctx, query, url.ShortURL, url.LongURL, url.CreatedAt, url.UpdatedAt, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants)
 		if err != nil {
 			err := tx.Rollback(ctx)
 			if err != nil {
@@ -136,19 +118,38 @@ func (r *urlsRepository) CreateBatch(ctx context.Context, urls []*model.URLsMode
 			}
 			return err
 		}
+
+		if tag.RowsAffected() > 0 {
+			continue
+		}
+
+		// Короткий URL уже существует: допустимо только если он указывает на тот же длинный URL
+		var existingLongURL string
+		if err := tx.QueryRow(ctx, existingQuery, url.ShortURL).Scan(&existingLongURL); err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				return rollbackErr
+			}
+			return err
+		}
+		if existingLongURL != url.LongURL {
+			if err := tx.Rollback(ctx); err != nil {
+				return err
+			}
+			return repository.ErrShortURLExists
+		}
 	}
 
 	// Подтверждаем транзакцию
 	return tx.Commit(ctx)
 }
 
-// GetAll получает список URL из базы данных с пагинацией.
+// GetAll получает список действующих URL из базы данных с пагинацией.
 // Принимает лимит и смещение для пагинации, возвращает список моделей URL или ошибку.
 func (r *urlsRepository) GetAll(ctx context.Context, limit, offset int) ([]*model.URLsModel, error) {
 	query := `
-		SELECT id, short_url, long_url, is_deleted, created_at, updated_at
+		SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants
 		FROM urls 
-		WHERE is_deleted = false
+		WHERE is_deleted = false AND is_expired = false
 		ORDER BY created_at DESC 
 		LIMIT $1 OFFSET $2
 	`
@@ -161,19 +162,11 @@ func (r *urlsRepository) GetAll(ctx context.Context, limit, offset int) ([]*mode
 
 	var urls []*model.URLsModel
 	for rows.Next() {
-		var url model.URLsModel
-		err := rows.Scan(
-			&url.ID,
-			&url.ShortURL,
-			&url.LongURL,
-			&url.IsDeleted,
-			&url.CreatedAt,
-			&url.UpdatedAt,
-		)
+		url, err := scanURL(rows)
 		if err != nil {
 			return nil, err
 		}
-		urls = append(urls, &url)
+		urls = append(urls, url)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -183,10 +176,10 @@ func (r *urlsRepository) GetAll(ctx context.Context, limit, offset int) ([]*mode
 	return urls, nil
 }
 
-// GetTotalCount получает общее количество URL в базе данных.
+// GetTotalCount получает количество неудаленных и не помеченных как истекшие URL в базе данных.
 // Возвращает количество записей или ошибку, если запрос не удался.
 func (r *urlsRepository) GetTotalCount(ctx context.Context) (int64, error) {
-	query := `SELECT COUNT(*) FROM urls WHERE is_deleted = false`
+	query := `SELECT COUNT(*) FROM urls WHERE is_deleted = false AND is_expired = false`
 
 	var count int64
 	err := r.pool.QueryRow(ctx, query).Scan(&count)
@@ -197,6 +190,192 @@ func (r *urlsRepository) GetTotalCount(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+// MarkExpired помечает как истекшие все неудаленные URL, срок действия которых наступил к моменту now.
+// Возвращает количество помеченных записей или ошибку, если обновление не удалось.
+func (r *urlsRepository) MarkExpired(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		UPDATE urls
+		SET is_expired = true, updated_at = NOW()
+		WHERE is_expired = false AND is_deleted = false AND expires_at IS NOT NULL AND expires_at <= $1
+	`
+
+	tag, err := r.pool.Exec(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ConsumeClick атомарно уменьшает количество оставшихся переходов по ссылке с лимитом.
+// Условие clicks_left > 0 проверяется в том же UPDATE, поэтому параллельные переходы не превышают лимит.
+// Возвращает новое количество оставшихся переходов или ErrClickLimitReached, если переходов не осталось
+// или ссылка не ограничена по переходам.
+func (r *urlsRepository) ConsumeClick(ctx context.Context, shortURL string) (int64, error) {
+	query := `
+		UPDATE urls
+		SET clicks_left = clicks_left - 1
+		WHERE short_url = $1 AND clicks_left > 0
+		RETURNING clicks_left
+	`
+
+	var clicksLeft int64
+	err := r.pool.QueryRow(ctx, query, shortURL).Scan(&clicksLeft)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, repository.ErrClickLimitReached
+		}
+		return 0, err
+	}
+
+	return clicksLeft, nil
+}
+
+// UpdateByUser изменяет ссылку, которой пользователь владеет через user_urls.
+// Запись блокируется до конца транзакции (SELECT ... FOR UPDATE), поэтому параллельные изменения применяются по очереди.
+// Возвращает состояние ссылки до и после изменения или ErrURLNotFound,
+// если ссылка не найдена, удалена или принадлежит другому пользователю.
+func (r *urlsRepository) UpdateByUser(
+	ctx context.Context,
+	shortURL, userID string,
+	update model.URLUpdate,
+) (*model.URLsModel, *model.URLsModel, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback(ctx)
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	selectQuery := `
+		SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants
+		FROM urls
+		WHERE short_url = $1 AND is_deleted = false
+		AND id IN (
+			SELECT uu.url_id
+			FROM user_urls uu
+			WHERE uu.user_id = $2
+		)
+		FOR UPDATE
+	`
+
+	var previous *model.URLsModel
+	previous, err = scanURL(tx.QueryRow(ctx, selectQuery, shortURL, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, repository.ErrURLNotFound
+		}
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	updated := previous.WithUpdate(update, now)
+	updated.UpdatedAt = now
+
+	updateQuery := `
+		UPDATE urls
+		SET long_url = $2, expires_at = $3, is_expired = $4, is_disabled = $5, title = $6, show_interstitial = $7, routing_rules = $8, variants = $9, updated_at = $10
+		WHERE id = $1
+	`
+
+	_, err = tx.Exec(ctx, updateQuery, updated.ID, updated.LongURL, updated.ExpiresAt, updated.IsExpired, updated.IsDisabled, updated.Title, updated.ShowInterstitial, updated.RoutingRules, updated.Variants, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return previous, updated, nil
+}
+
+// PurgeDeleted окончательно удаляет не более limit ссылок, помеченных удаленными раньше deletedBefore,
+// вместе с их связями в user_urls; журнал и счетчики переходов удаляются каскадно по внешнему ключу.
+// Момент удаления определяется по deleted_at, который меняется только при пометке ссылки удаленной.
+// Строки, заблокированные другими транзакциями, пропускаются (FOR UPDATE SKIP LOCKED),
+// поэтому параллельные запуски не мешают друг другу. Возвращает количество удаленных ссылок и связей и короткие коды удаленных ссылок.
+func (r *urlsRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (model.PurgeStats, error) {
+	var stats model.PurgeStats
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return stats, err
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback(ctx)
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	selectQuery := `
+		SELECT id, short_url
+		FROM urls
+		WHERE is_deleted = true AND deleted_at < $1
+		ORDER BY id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.Query(ctx, selectQuery, deletedBefore, limit)
+	if err != nil {
+		return stats, err
+	}
+	var (
+		ids       []int64
+		shortURLs []string
+	)
+	for rows.Next() {
+		var (
+			id       int64
+			shortURL string
+		)
+		if err = rows.Scan(&id, &shortURL); err != nil {
+			rows.Close()
+			return stats, err
+		}
+		ids = append(ids, id)
+		shortURLs = append(shortURLs, shortURL)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return stats, err
+	}
+
+	if len(ids) == 0 {
+		err = tx.Commit(ctx)
+		return stats, err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM user_urls WHERE url_id = ANY($1)`, ids)
+	if err != nil {
+		return stats, err
+	}
+	stats.UserLinks = tag.RowsAffected()
+
+	tag, err = tx.Exec(ctx, `DELETE FROM urls WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return stats, err
+	}
+	stats.URLs = tag.RowsAffected()
+	stats.ShortURLs = shortURLs
+
+	if err = tx.Commit(ctx); err != nil {
+		return model.PurgeStats{}, err
+	}
+
+	return stats, nil
+}
+
 // SoftDeleteByShortURLs помечает указанные URL как удаленные (soft delete) для конкретного пользователя в PostgreSQL.
 // Выполняет мягкое удаление только тех URL, которые принадлежат указанному пользователю.
 // Принимает список коротких URL и идентификатор пользователя, возвращает ошибку, если удаление не удалось.
@@ -222,8 +401,8 @@ func (r *urlsRepository) SoftDeleteByShortURLs(ctx context.Context, shortURLs []
 	// Подготавливаем batch update запрос с проверкой владельца
 	query := `
 		UPDATE urls 
-		SET is_deleted = true, updated_at = NOW() 
-		WHERE short_url = ANY($1) 
+		SET is_deleted = true, deleted_at = NOW(), updated_at = NOW() 
+		WHERE short_url = ANY($1) AND is_deleted = false
 		AND id IN (
 			SELECT uu.url_id 
 			FROM user_urls uu 
@@ -239,3 +418,77 @@ func (r *urlsRepository) SoftDeleteByShortURLs(ctx context.Context, shortURLs []
 	// Подтверждаем транзакцию
 	return tx.Commit(ctx)
 }
+
+// rowScanner обобщает pgx.Row и pgx.Rows для чтения одной записи.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanURL читает запись URL, выбранную в порядке колонок
+// id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants.
+func scanURL(row rowScanner) (*model.URLsModel, error) {
+	var url model.URLsModel
+	if err := row.Scan(urlDest(&url)...); err != nil {
+		return nil, err
+	}
+
+	return &url, nil
+}
+
+// scanURLWithClicks читает запись URL, за колонками которой следует число переходов по ссылке.
+func scanURLWithClicks(row rowScanner) (*model.URLsModel, error) {
+	var url model.URLsModel
+	if err := row.Scan(append(urlDest(&url), &url.Clicks)...); err != nil {
+		return nil, err
+	}
+
+	return &url, nil
+}
+
+// scanURLWithHealth читает запись URL с числом переходов и результатом последней проверки доступности
+// адреса назначения (колонки status_code, error, redirect_chain, consecutive_failures и checked_at таблицы
+// link_health после числа переходов). Если ссылка еще не проверялась, checked_at равен NULL и Health остается nil.
+func scanURLWithHealth(row rowScanner) (*model.URLsModel, error) {
+	var (
+		url       model.URLsModel
+		health    model.LinkHealth
+		checkedAt *time.Time
+	)
+	dest := append(urlDest(&url), &url.Clicks,
+		&health.StatusCode, &health.Error, &health.RedirectChain, &health.ConsecutiveFailures, &checkedAt)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if checkedAt != nil {
+		health.CheckedAt = *checkedAt
+		url.Health = &health
+	}
+
+	return &url, nil
+}
+
+// urlDest возвращает адреса полей URL в порядке колонок, ожидаемом scanURL.
+func urlDest(url *model.URLsModel) []any {
+	return []any{
+		&url.ID,
+		&url.ShortURL,
+		&url.LongURL,
+		&url.IsDeleted,
+		&url.CreatedAt,
+		&url.UpdatedAt,
+		&url.ExpiresAt,
+		&url.IsExpired,
+		&url.MaxClicks,
+		&url.ClicksLeft,
+		&url.PasswordHash,
+		&url.IsDisabled,
+		&url.Title,
+		&url.ShowInterstitial,
+		&url.RedirectCode,
+		&url.QueryPassthrough,
+		&url.IsPrefix,
+		&url.RoutingRules,
+		&url.Variants,
+	}
+}
@@ -78,7 +78,7 @@ func TestURLsRepository_GetByLongURL_Success(t *testing.T) {
 		UpdatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 	}
 
-	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at"}).
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants"}).
 		AddRow(
 			expectedURL.ID,
 			expectedURL.ShortURL,
@@ -86,9 +86,22 @@ func TestURLsRepository_GetByLongURL_Success(t *testing.T) {
 			expectedURL.IsDeleted,
 			expectedURL.CreatedAt,
 			expectedURL.UpdatedAt,
+			expectedURL.ExpiresAt,
+			expectedURL.IsExpired,
+			expectedURL.MaxClicks,
+			expectedURL.ClicksLeft,
+			expectedURL.PasswordHash,
+			expectedURL.IsDisabled,
+			expectedURL.Title,
+			expectedURL.ShowInterstitial,
+			expectedURL.RedirectCode,
+			expectedURL.QueryPassthrough,
+			expectedURL.IsPrefix,
+			expectedURL.RoutingRules,
+			expectedURL.Variants,
 		)
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE long_url = \\$1 AND is_deleted = false").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE long_url = \\$1 AND is_deleted = false AND is_expired = false AND expires_at IS NULL AND").
 		WithArgs(expectedURL.LongURL).
 		WillReturnRows(rows)
 
@@ -111,7 +124,7 @@ func TestURLsRepository_GetByLongURL_NotFound(t *testing.T) {
 	ctx := context.Background()
 	longURL := "https://example.com/not/found"
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE long_url = \\$1 AND is_deleted = false").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE long_url = \\$1 AND is_deleted = false AND is_expired = false").
 		WithArgs(longURL).
 		WillReturnError(pgx.ErrNoRows)
 
@@ -130,7 +143,7 @@ func TestURLsRepository_GetByLongURL_DatabaseError(t *testing.T) {
 	longURL := "https://example.com/error"
 	expectedErr := errors.New("database error")
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE long_url = \\$1 AND is_deleted = false").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE long_url = \\$1 AND is_deleted = false AND is_expired = false").
 		WithArgs(longURL).
 		WillReturnError(expectedErr)
 
@@ -155,10 +168,10 @@ func TestURLsRepository_GetByShortURL_Success(t *testing.T) {
 		UpdatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 	}
 
-	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at"}).
-		AddRow(expectedURL.ID, expectedURL.ShortURL, expectedURL.LongURL, expectedURL.IsDeleted, expectedURL.CreatedAt, expectedURL.UpdatedAt)
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants"}).
+		AddRow(expectedURL.ID, expectedURL.ShortURL, expectedURL.LongURL, expectedURL.IsDeleted, expectedURL.CreatedAt, expectedURL.UpdatedAt, expectedURL.ExpiresAt, expectedURL.IsExpired, expectedURL.MaxClicks, expectedURL.ClicksLeft, expectedURL.PasswordHash, expectedURL.IsDisabled, expectedURL.Title, expectedURL.ShowInterstitial, expectedURL.RedirectCode, expectedURL.QueryPassthrough, expectedURL.IsPrefix, expectedURL.RoutingRules, expectedURL.Variants)
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE short_url = \\$1").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE short_url = \\$1").
 		WithArgs(expectedURL.ShortURL).
 		WillReturnRows(rows)
 
@@ -181,7 +194,7 @@ func TestURLsRepository_GetByShortURL_NotFound(t *testing.T) {
 	ctx := context.Background()
 	shortURL := "notfound"
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE short_url = \\$1").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE short_url = \\$1").
 		WithArgs(shortURL).
 		WillReturnError(pgx.ErrNoRows)
 
@@ -200,7 +213,7 @@ func TestURLsRepository_GetByShortURL_DatabaseError(t *testing.T) {
 	shortURL := "error"
 	expectedErr := errors.New("database error")
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE short_url = \\$1").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE short_url = \\$1").
 		WithArgs(shortURL).
 		WillReturnError(expectedErr)
 
@@ -221,8 +234,8 @@ func TestURLsRepository_Create_Success(t *testing.T) {
 		LongURL:  "https://example.com/very/long/url",
 	}
 
-	mock.ExpectExec("INSERT INTO urls \\(short_url, long_url\\) VALUES \\(\\$1, \\$2\\)").
-		WithArgs(url.ShortURL, url.LongURL).
+	mock.ExpectExec("INSERT INTO urls \\(short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\)").
+		WithArgs(url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	err := repo.Create(ctx, url)
@@ -245,8 +258,8 @@ func TestURLsRepository_Create_DuplicateKeyError(t *testing.T) {
 		Code: "23505", // unique_violation
 	}
 
-	mock.ExpectExec("INSERT INTO urls \\(short_url, long_url\\) VALUES \\(\\$1, \\$2\\)").
-		WithArgs(url.ShortURL, url.LongURL).
+	mock.ExpectExec("INSERT INTO urls \\(short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\)").
+		WithArgs(url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 		WillReturnError(pgErr)
 
 	err := repo.Create(ctx, url)
@@ -255,6 +268,31 @@ func TestURLsRepository_Create_DuplicateKeyError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestURLsRepository_Create_ShortURLConflict(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	url := &model.URLsModel{
+		ShortURL: "q4-report",
+		LongURL:  "https://example.com/very/long/url",
+	}
+
+	// Симулируем нарушение уникальности short_url
+	pgErr := &pgconn.PgError{
+		Code:           "23505",
+		ConstraintName: "urls_short_url_key",
+	}
+
+	mock.ExpectExec("INSERT INTO urls \\(short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\)").
+		WithArgs(url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
+		WillReturnError(pgErr)
+
+	err := repo.Create(ctx, url)
+	assert.ErrorIs(t, err, repository.ErrShortURLExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestURLsRepository_Create_DatabaseError(t *testing.T) {
 	mock, repo := setupMockPool(t)
 	defer mock.Close()
@@ -266,8 +304,8 @@ func TestURLsRepository_Create_DatabaseError(t *testing.T) {
 	}
 	expectedErr := errors.New("database connection error")
 
-	mock.ExpectExec("INSERT INTO urls \\(short_url, long_url\\) VALUES \\(\\$1, \\$2\\)").
-		WithArgs(url.ShortURL, url.LongURL).
+	mock.ExpectExec("INSERT INTO urls \\(short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\)").
+		WithArgs(url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 		WillReturnError(expectedErr)
 
 	err := repo.Create(ctx, url)
@@ -396,12 +434,12 @@ func TestURLsRepository_GetAll_Success(t *testing.T) {
 		},
 	}
 
-	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at"})
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants"})
 	for _, url := range expectedURLs {
-		rows.AddRow(url.ID, url.ShortURL, url.LongURL, url.IsDeleted, url.CreatedAt, url.UpdatedAt)
+		rows.AddRow(url.ID, url.ShortURL, url.LongURL, url.IsDeleted, url.CreatedAt, url.UpdatedAt, url.ExpiresAt, url.IsExpired, url.MaxClicks, url.ClicksLeft, url.PasswordHash, url.IsDisabled, url.Title, url.ShowInterstitial, url.RedirectCode, url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants)
 	}
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE is_deleted = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE is_deleted = false AND is_expired = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
 		WithArgs(limit, offset).
 		WillReturnRows(rows)
 
@@ -427,9 +465,9 @@ func TestURLsRepository_GetAll_EmptyResult(t *testing.T) {
 	ctx := context.Background()
 	limit, offset := 10, 0
 
-	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at"})
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants"})
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE is_deleted = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE is_deleted = false AND is_expired = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
 		WithArgs(limit, offset).
 		WillReturnRows(rows)
 
@@ -457,12 +495,12 @@ func TestURLsRepository_GetAll_WithPagination(t *testing.T) {
 		},
 	}
 
-	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at"})
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants"})
 	for _, url := range expectedURLs {
-		rows.AddRow(url.ID, url.ShortURL, url.LongURL, url.IsDeleted, url.CreatedAt, url.UpdatedAt)
+		rows.AddRow(url.ID, url.ShortURL, url.LongURL, url.IsDeleted, url.CreatedAt, url.UpdatedAt, url.ExpiresAt, url.IsExpired, url.MaxClicks, url.ClicksLeft, url.PasswordHash, url.IsDisabled, url.Title, url.ShowInterstitial, url.RedirectCode, url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants)
 	}
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE is_deleted = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE is_deleted = false AND is_expired = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
 		WithArgs(limit, offset).
 		WillReturnRows(rows)
 
@@ -482,7 +520,7 @@ func TestURLsRepository_GetAll_DatabaseError(t *testing.T) {
 	limit, offset := 10, 0
 	expectedErr := errors.New("database connection error")
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE is_deleted = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE is_deleted = false AND is_expired = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
 		WithArgs(limit, offset).
 		WillReturnError(expectedErr)
 
@@ -501,10 +539,10 @@ func TestURLsRepository_GetAll_ScanError(t *testing.T) {
 	limit, offset := 10, 0
 
 	// Создаем строки с неправильными типами данных для вызова ошибки сканирования
-	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at"}).
-		AddRow("invalid_id", "abc123", "https://example.com", "invalid_bool", "invalid_date", "invalid_date")
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants"}).
+		AddRow("invalid_id", "abc123", "https://example.com", "invalid_bool", "invalid_date", "invalid_date", nil, false, nil, nil, nil, false, "", false, 307, model.QueryPassthroughOff, false, "", "")
 
-	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at FROM urls WHERE is_deleted = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE is_deleted = false AND is_expired = false ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
 		WithArgs(limit, offset).
 		WillReturnRows(rows)
 
@@ -523,7 +561,7 @@ func TestURLsRepository_GetTotalCount_Success(t *testing.T) {
 
 	rows := pgxmock.NewRows([]string{"count"}).AddRow(expectedCount)
 
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM urls WHERE is_deleted = false").
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM urls WHERE is_deleted = false AND is_expired = false").
 		WillReturnRows(rows)
 
 	result, err := repo.GetTotalCount(ctx)
@@ -541,7 +579,7 @@ func TestURLsRepository_GetTotalCount_ZeroCount(t *testing.T) {
 
 	rows := pgxmock.NewRows([]string{"count"}).AddRow(expectedCount)
 
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM urls WHERE is_deleted = false").
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM urls WHERE is_deleted = false AND is_expired = false").
 		WillReturnRows(rows)
 
 	result, err := repo.GetTotalCount(ctx)
@@ -557,7 +595,7 @@ func TestURLsRepository_GetTotalCount_DatabaseError(t *testing.T) {
 	ctx := context.Background()
 	expectedErr := errors.New("database connection error")
 
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM urls WHERE is_deleted = false").
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM urls WHERE is_deleted = false AND is_expired = false").
 		WillReturnError(expectedErr)
 
 	result, err := repo.GetTotalCount(ctx)
@@ -594,8 +632,8 @@ func TestURLsRepository_CreateBatch_Success(t *testing.T) {
 
 	// Ожидаем batch операции - параметры в правильном порядке: short_url, long_url, created_at, updated_at
 	for _, url := range urls {
-		mock.ExpectExec("INSERT INTO urls \\(short_url, long_url, created_at, updated_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) ON CONFLICT \\(short_url\\) DO NOTHING").
-			WithArgs(url.ShortURL, url.LongURL, url.CreatedAt, url.UpdatedAt).
+		mock.ExpectExec("INSERT INTO urls \\(short_url, long_url, created_at, updated_at, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12, \\$13, \\$14\\) ON CONFLICT \\(short_url\\) DO NOTHING").
+			WithArgs(url.ShortURL, url.LongURL, url.CreatedAt, url.UpdatedAt, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	}
 
@@ -647,8 +685,8 @@ func TestURLsRepository_CreateBatch_WithNilURLs(t *testing.T) {
 	// Ожидаем batch операции только для не-nil URL - параметры в правильном порядке
 	validURLs := []*model.URLsModel{urls[0], urls[2]}
 	for _, url := range validURLs {
-		mock.ExpectExec("INSERT INTO urls \\(short_url, long_url, created_at, updated_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) ON CONFLICT \\(short_url\\) DO NOTHING").
-			WithArgs(url.ShortURL, url.LongURL, url.CreatedAt, url.UpdatedAt).
+		mock.ExpectExec("INSERT INTO urls \\(short_url, long_url, created_at, updated_at, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12, \\$13, \\$14\\) ON CONFLICT \\(short_url\\) DO NOTHING").
+			WithArgs(url.ShortURL, url.LongURL, url.CreatedAt, url.UpdatedAt, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	}
 
@@ -697,7 +735,7 @@ func TestURLsRepository_SoftDeleteByShortURLs_Success(t *testing.T) {
 	mock.ExpectBegin()
 
 	// Ожидаем выполнение UPDATE запроса
-	mock.ExpectExec("UPDATE urls SET is_deleted = true, updated_at = NOW\\(\\) WHERE short_url = ANY\\(\\$1\\) AND id IN \\( SELECT uu\\.url_id FROM user_urls uu WHERE uu\\.user_id = \\$2 \\)").
+	mock.ExpectExec("UPDATE urls SET is_deleted = true, deleted_at = NOW\\(\\), updated_at = NOW\\(\\) WHERE short_url = ANY\\(\\$1\\) AND is_deleted = false AND id IN \\( SELECT uu\\.url_id FROM user_urls uu WHERE uu\\.user_id = \\$2 \\)").
 		WithArgs(shortURLs, userID).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
 
@@ -751,7 +789,7 @@ func TestURLsRepository_SoftDeleteByShortURLs_UpdateError(t *testing.T) {
 	mock.ExpectBegin()
 
 	// Ожидаем ошибку при выполнении UPDATE запроса
-	mock.ExpectExec("UPDATE urls SET is_deleted = true, updated_at = NOW\\(\\) WHERE short_url = ANY\\(\\$1\\) AND id IN \\( SELECT uu\\.url_id FROM user_urls uu WHERE uu\\.user_id = \\$2 \\)").
+	mock.ExpectExec("UPDATE urls SET is_deleted = true, deleted_at = NOW\\(\\), updated_at = NOW\\(\\) WHERE short_url = ANY\\(\\$1\\) AND is_deleted = false AND id IN \\( SELECT uu\\.url_id FROM user_urls uu WHERE uu\\.user_id = \\$2 \\)").
 		WithArgs(shortURLs, userID).
 		WillReturnError(expectedErr)
 
@@ -763,3 +801,190 @@ func TestURLsRepository_SoftDeleteByShortURLs_UpdateError(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestURLsRepository_UpdateByUser_Success(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	newLongURL := "https://example.com/new"
+	disabled := true
+
+	mock.ExpectBegin()
+
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants"}).
+		AddRow(uint(1), "abc123", "https://example.com/old", false, createdAt, createdAt, nil, false, nil, nil, nil, false, "", false, 307, model.QueryPassthroughOff, false, "", "")
+	mock.ExpectQuery("SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE short_url = \\$1 AND is_deleted = false AND id IN \\( SELECT uu\\.url_id FROM user_urls uu WHERE uu\\.user_id = \\$2 \\) FOR UPDATE").
+		WithArgs("abc123", "user123").
+		WillReturnRows(rows)
+
+	mock.ExpectExec("UPDATE urls SET long_url = \\$2, expires_at = \\$3, is_expired = \\$4, is_disabled = \\$5, title = \\$6, show_interstitial = \\$7, routing_rules = \\$8, variants = \\$9, updated_at = \\$10 WHERE id = \\$1").
+		WithArgs(uint(1), newLongURL, (*time.Time)(nil), false, true, "", false, model.RoutingRules(nil), model.SplitVariants(nil), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	mock.ExpectCommit()
+
+	previous, updated, err := repo.UpdateByUser(ctx, "abc123", "user123", model.URLUpdate{LongURL: &newLongURL, IsDisabled: &disabled})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/old", previous.LongURL)
+	assert.False(t, previous.IsDisabled)
+	assert.Equal(t, newLongURL, updated.LongURL)
+	assert.True(t, updated.IsDisabled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestURLsRepository_UpdateByUser_NotOwned(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	newLongURL := "https://example.com/new"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, short_url, long_url").
+		WithArgs("abc123", "stranger").
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	previous, updated, err := repo.UpdateByUser(ctx, "abc123", "stranger", model.URLUpdate{LongURL: &newLongURL})
+	assert.ErrorIs(t, err, repository.ErrURLNotFound)
+	assert.Nil(t, previous)
+	assert.Nil(t, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestURLsRepository_PurgeDeleted_Success(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, short_url FROM urls WHERE is_deleted = true AND deleted_at < \\$1 ORDER BY id LIMIT \\$2 FOR UPDATE SKIP LOCKED").
+		WithArgs(cutoff, 100).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "short_url"}).AddRow(int64(1), "old1").AddRow(int64(2), "old2"))
+	mock.ExpectExec("DELETE FROM user_urls WHERE url_id = ANY\\(\\$1\\)").
+		WithArgs([]int64{1, 2}).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	mock.ExpectExec("DELETE FROM urls WHERE id = ANY\\(\\$1\\)").
+		WithArgs([]int64{1, 2}).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	mock.ExpectCommit()
+
+	stats, err := repo.PurgeDeleted(ctx, cutoff, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.URLs)
+	assert.Equal(t, int64(2), stats.UserLinks)
+	assert.Equal(t, []string{"old1", "old2"}, stats.ShortURLs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestURLsRepository_PurgeDeleted_NothingToPurge(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, short_url FROM urls").
+		WithArgs(cutoff, 100).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "short_url"}))
+	mock.ExpectCommit()
+
+	stats, err := repo.PurgeDeleted(ctx, cutoff, 100)
+	require.NoError(t, err)
+	assert.Equal(t, model.PurgeStats{}, stats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestURLsRepository_PurgeDeleted_DeleteError(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, short_url FROM urls").
+		WithArgs(cutoff, 100).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "short_url"}).AddRow(int64(1), "old1"))
+	mock.ExpectExec("DELETE FROM user_urls").
+		WithArgs([]int64{1}).
+		WillReturnError(errors.New("connection lost"))
+	mock.ExpectRollback()
+
+	_, err := repo.PurgeDeleted(ctx, cutoff, 100)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestURLsRepository_MarkExpired_Success(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	mock.ExpectExec("UPDATE urls SET is_expired = true, updated_at = NOW\\(\\) WHERE is_expired = false AND is_deleted = false AND expires_at IS NOT NULL AND expires_at <= \\$1").
+		WithArgs(now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	count, err := repo.MarkExpired(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestURLsRepository_MarkExpired_DatabaseError(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	expectedErr := errors.New("update error")
+
+	mock.ExpectExec("UPDATE urls SET is_expired = true").
+		WithArgs(now).
+		WillReturnError(expectedErr)
+
+	count, err := repo.MarkExpired(ctx, now)
+	assert.Error(t, err)
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, int64(0), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestURLsRepository_ConsumeClick_Success(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectQuery("UPDATE urls SET clicks_left = clicks_left - 1 WHERE short_url = \\$1 AND clicks_left > 0 RETURNING clicks_left").
+		WithArgs("invite1").
+		WillReturnRows(pgxmock.NewRows([]string{"clicks_left"}).AddRow(int64(2)))
+
+	clicksLeft, err := repo.ConsumeClick(ctx, "invite1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), clicksLeft)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestURLsRepository_ConsumeClick_LimitReached(t *testing.T) {
+	mock, repo := setupMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectQuery("UPDATE urls SET clicks_left = clicks_left - 1").
+		WithArgs("invite1").
+		WillReturnError(pgx.ErrNoRows)
+
+	clicksLeft, err := repo.ConsumeClick(ctx, "invite1")
+	assert.ErrorIs(t, err, repository.ErrClickLimitReached)
+	assert.Equal(t, int64(0), clicksLeft)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -7,6 +7,7 @@ import (
 	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/repository"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -22,12 +23,16 @@ func NewUserURLsRepository(pool *pgxpool.Pool) repository.UserURLsRepository {
 }
 
 // GetByUserID получает все URL, принадлежащие указанному пользователю, из базы данных.
+// Ссылки содержат число переходов и результат последней проверки доступности адреса назначения.
 // Возвращает список моделей URL, отсортированных по дате создания (от новых к старым), или ошибку.
 func (r *userURLsRepository) GetByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error) {
 	query := `
-		SELECT u.id, u.short_url, u.long_url, u.is_deleted, u.created_at, u.updated_at
+		SELECT u.id, u.short_url, u.long_url, u.is_deleted, u.created_at, u.updated_at, u.expires_at, u.is_expired, u.max_clicks, u.clicks_left, u.password_hash, u.is_disabled, u.title, u.show_interstitial, u.redirect_code, u.query_passthrough, u.is_prefix, u.routing_rules, u.variants, COALESCE(cc.clicks, 0),
+			COALESCE(lh.status_code, 0), COALESCE(lh.error, ''), COALESCE(lh.redirect_chain, ''), COALESCE(lh.consecutive_failures, 0), lh.checked_at
 		FROM urls u
 		INNER JOIN user_urls uu ON u.id = uu.url_id
+		LEFT JOIN click_counters cc ON cc.url_id = u.id
+		LEFT JOIN link_health lh ON lh.url_id = u.id
 		WHERE uu.user_id = $1
 		ORDER BY uu.created_at DESC
 	`
@@ -40,19 +45,45 @@ func (r *userURLsRepository) GetByUserID(ctx context.Context, userID string) ([]
 
 	var urls []*model.URLsModel
 	for rows.Next() {
-		var url model.URLsModel
-		err := rows.Scan(
-			&url.ID,
-			&url.ShortURL,
-			&url.LongURL,
-			&url.IsDeleted,
-			&url.CreatedAt,
-			&url.UpdatedAt,
-		)
+		url, err := scanURLWithHealth(rows)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, url)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return urls, nil
+}
+
+// GetDeletedByUserID получает удаленные ссылки указанного пользователя (корзину).
+// Возвращает список моделей URL, отсортированных по времени удаления (от новых к старым), или ошибку.
+func (r *userURLsRepository) GetDeletedByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error) {
+	query := `
+		SELECT u.id, u.short_url, u.long_url, u.is_deleted, u.created_at, u.updated_at, u.expires_at, u.is_expired, u.max_clicks, u.clicks_left, u.password_hash, u.is_disabled, u.title, u.show_interstitial, u.redirect_code, u.query_passthrough, u.is_prefix, u.routing_rules, u.variants, COALESCE(cc.clicks, 0)
+		FROM urls u
+		INNER JOIN user_urls uu ON u.id = uu.url_id
+		LEFT JOIN click_counters cc ON cc.url_id = u.id
+		WHERE uu.user_id = $1 AND u.is_deleted = true
+		ORDER BY u.deleted_at DESC, u.id DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var urls []*model.URLsModel
+	for rows.Next() {
+		url, err := scanURLWithClicks(rows)
 		if err != nil {
 			return nil, err
 		}
-		urls = append(urls, &url)
+		urls = append(urls, url)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -62,6 +93,31 @@ func (r *userURLsRepository) GetByUserID(ctx context.Context, userID string) ([]
 	return urls, nil
 }
 
+// GetByUserIDAndLongURL получает действующую (неудаленную, неистекшую, неотключенную, бессрочную, не ограниченную по переходам, не защищенную паролем, без настроек предпросмотра, перенаправления и переноса пути) ссылку пользователя на указанный длинный URL.
+// Возвращает модель URL или ErrURLNotFound, если у пользователя нет такой ссылки.
+func (r *userURLsRepository) GetByUserIDAndLongURL(ctx context.Context, userID, longURL string) (*model.URLsModel, error) {
+	query := `
+		SELECT u.id, u.short_url, u.long_url, u.is_deleted, u.created_at, u.updated_at, u.expires_at, u.is_expired, u.max_clicks, u.clicks_left, u.password_hash, u.is_disabled, u.title, u.show_interstitial, u.redirect_code, u.query_passthrough, u.is_prefix, u.routing_rules, u.variants
+		FROM urls u
+		INNER JOIN user_urls uu ON u.id = uu.url_id
+		WHERE uu.user_id = $1 AND u.long_url = $2 AND u.is_deleted = false AND u.is_expired = false
+		AND u.expires_at IS NULL AND u.max_clicks IS NULL AND u.password_hash IS NULL AND u.is_disabled = false
+		AND u.title = '' AND u.show_interstitial = false AND u.redirect_code = 307 AND u.query_passthrough = '' AND u.is_prefix = false AND u.routing_rules = '' AND u.variants = ''
+		ORDER BY u.id
+		LIMIT 1
+	`
+
+	url, err := scanURL(r.pool.QueryRow(ctx, query, userID, longURL))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrURLNotFound
+		}
+		return nil, err
+	}
+
+	return url, nil
+}
+
 // CreateURLWithUser создает новую запись URL и связывает ее с пользователем в базе данных.
 // Принимает модель URL и идентификатор пользователя, возвращает ошибку, если создание не удалось.
 func (r *userURLsRepository) CreateURLWithUser(ctx context.Context, url *model.URLsModel, userID string) error {
@@ -90,12 +146,15 @@ func (r *userURLsRepository) CreateURLWithUser(ctx context.Context, url *model.U
 	}()
 
 	// 1. Создаем URL
-	urlQuery := `INSERT INTO urls (short_url, long_url) VALUES ($1, $2) RETURNING id`
-	err = tx.QueryRow(ctx, urlQuery, url.ShortURL, url.LongURL).Scan(&url.ID)
+	urlQuery := `INSERT INTO urls (short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants) VALUES ($1, $2, $3, $4, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
+	err = tx.QueryRow(ctx, urlQuery, url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).Scan(&url.ID)
 	if err != nil {
 		// Проверяем на дублирование записи
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			if repository.IsShortURLExistsError(err) {
+				return repository.ErrShortURLExists
+			}
 			return repository.ErrURLExists
 		}
 		return fmt.Errorf("failed to insert url: %w", err)
@@ -149,7 +208,7 @@ func (r *userURLsRepository) CreateMultipleURLsWithUser(ctx context.Context, url
 	}()
 
 	// Подготавливаем batch запросы
-	urlQuery := `INSERT INTO urls (short_url, long_url) VALUES ($1, $2) RETURNING id`
+	urlQuery := `INSERT INTO urls (short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants) VALUES ($1, $2, $3, $4, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
 	userURLQuery := `INSERT INTO user_urls (user_id, url_id) VALUES ($1, $2)`
 
 	// Выполняем batch операцию
@@ -159,11 +218,14 @@ func (r *userURLsRepository) CreateMultipleURLsWithUser(ctx context.Context, url
 		}
 
 		// Создаем URL
-		err = tx.QueryRow(ctx, urlQuery, url.ShortURL, url.LongURL).Scan(&url.ID)
+		err = tx.QueryRow(ctx, urlQuery, url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).Scan(&url.ID)
 		if err != nil {
 			// Проверяем на дублирование записи
 			var pgErr *pgconn.PgError
 			if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+				if repository.IsShortURLExistsError(err) {
+					return repository.ErrShortURLExists
+				}
 				return repository.ErrURLExists
 			}
 			return fmt.Errorf("failed to insert url %s: %w", url.ShortURL, err)
@@ -184,20 +246,94 @@ func (r *userURLsRepository) CreateMultipleURLsWithUser(ctx context.Context, url
 	return tx.Commit(ctx)
 }
 
-// DeleteURLsWithUser помечает указанные URL как удаленные для конкретного пользователя.
-// Принимает список коротких URL и идентификатор пользователя, возвращает ошибку, если удаление не удалось.
-func (r *userURLsRepository) DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
+// DeleteURLWithUser помечает удаленной одну ссылку пользователя.
+// Строка ссылки блокируется на время проверки, поэтому конкурентные удаления одной ссылки
+// не могут обе завершиться успешно. Возвращает ErrURLNotFound, если ссылка не найдена,
+// ErrURLNotOwned, если она принадлежит другому пользователю, и ErrURLAlreadyDeleted, если она уже удалена.
+func (r *userURLsRepository) DeleteURLWithUser(ctx context.Context, shortURL, userID string) error {
 	if userID == "" {
 		return errors.New("userID cannot be empty")
 	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Отложенный rollback
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				fmt.Printf("rollback failed: %v\n", rollbackErr)
+			}
+		}
+	}()
+
+	selectQuery := `
+		SELECT u.id, u.is_deleted, EXISTS (
+			SELECT 1 FROM user_urls uu WHERE uu.url_id = u.id AND uu.user_id = $2
+		)
+		FROM urls u
+		WHERE u.short_url = $1
+		FOR UPDATE
+	`
+
+	var (
+		urlID     int64
+		isDeleted bool
+		isOwned   bool
+	)
+	err = tx.QueryRow(ctx, selectQuery, shortURL, userID).Scan(&urlID, &isDeleted, &isOwned)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrURLNotFound
+		}
+		return fmt.Errorf("failed to get URL: %w", err)
+	}
+
+	switch {
+	case !isOwned:
+		err = repository.ErrURLNotOwned
+		return err
+	case isDeleted:
+		err = repository.ErrURLAlreadyDeleted
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE urls SET is_deleted = true, deleted_at = NOW(), updated_at = NOW() WHERE id = $1`, urlID)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete URL: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteURLsWithUser помечает указанные URL как удаленные для конкретного пользователя.
+// Удаляются только ссылки, которыми пользователь владеет через user_urls; чужие, неизвестные и уже удаленные
+// коды не изменяются. Принимает список коротких URL и идентификатор пользователя, возвращает результат
+// для каждого кода или ошибку, если удаление не удалось.
+func (r *userURLsRepository) DeleteURLsWithUser(
+	ctx context.Context,
+	shortURLs []string,
+	userID string,
+) (map[string]model.DeleteOutcome, error) {
+	if userID == "" {
+		return nil, errors.New("userID cannot be empty")
+	}
 	if len(shortURLs) == 0 {
-		return nil
+		return map[string]model.DeleteOutcome{}, nil
 	}
 
 	// Начинаем транзакцию
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
 	// Отложенный rollback
@@ -210,28 +346,109 @@ func (r *userURLsRepository) DeleteURLsWithUser(ctx context.Context, shortURLs [
 		}
 	}()
 
+	// Определяем, какие коды существуют и какие из них принадлежат пользователю
+	ownershipQuery := `
+		SELECT u.short_url, EXISTS (
+			SELECT 1 FROM user_urls uu WHERE uu.url_id = u.id AND uu.user_id = $2
+		)
+		FROM urls u
+		WHERE u.short_url = ANY($1)
+	`
+	var rows pgx.Rows
+	rows, err = tx.Query(ctx, ownershipQuery, shortURLs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check URLs ownership: %w", err)
+	}
+
+	outcomes := make(map[string]model.DeleteOutcome, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		outcomes[shortURL] = model.DeleteOutcomeNotFound
+	}
+	for rows.Next() {
+		var (
+			shortURL string
+			isOwned  bool
+		)
+		if err = rows.Scan(&shortURL, &isOwned); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan URL ownership: %w", err)
+		}
+		// Ссылка пользователя считается удаленной ранее, пока UPDATE ниже не изменит ее
+		if isOwned {
+			outcomes[shortURL] = model.DeleteOutcomeAlreadyDeleted
+		} else {
+			outcomes[shortURL] = model.DeleteOutcomeNotOwned
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to check URLs ownership: %w", err)
+	}
+
 	// Подготавливаем запрос для мягкого удаления URL-адресов
-	// Обновляем is_deleted на true только для URL-адресов, которые принадлежат указанному пользователю
+	// Обновляем is_deleted на true только для неудаленных URL-адресов, которые принадлежат указанному пользователю,
+	// чтобы повторное удаление не сдвигало deleted_at и срок хранения в корзине
 	query := `
 		UPDATE urls 
-		SET is_deleted = true, updated_at = NOW()
-		WHERE short_url = ANY($1) 
+		SET is_deleted = true, deleted_at = NOW(), updated_at = NOW()
+		WHERE short_url = ANY($1) AND is_deleted = false
 		AND id IN (
 			SELECT uu.url_id 
 			FROM user_urls uu 
 			WHERE uu.user_id = $2
 		)
+		RETURNING short_url
 	`
 	// Выполняем обновление
-	_, err = tx.Exec(ctx, query, shortURLs, userID)
+	rows, err = tx.Query(ctx, query, shortURLs, userID)
 	if err != nil {
-		return fmt.Errorf("failed to soft delete URLs: %w", err)
+		return nil, fmt.Errorf("failed to soft delete URLs: %w", err)
+	}
+	for rows.Next() {
+		var shortURL string
+		if err = rows.Scan(&shortURL); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan deleted URL: %w", err)
+		}
+		outcomes[shortURL] = model.DeleteOutcomeDeleted
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to soft delete URLs: %w", err)
 	}
 
 	// Подтверждаем транзакцию
 	err = tx.Commit(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return outcomes, nil
+}
+
+// RestoreURLsWithUser восстанавливает указанные удаленные URL конкретного пользователя.
+// Восстанавливаются только ссылки, которыми пользователь владеет через user_urls; чужие, неизвестные
+// и неудаленные коды игнорируются. Возвращает ошибку, если восстановление не удалось.
+func (r *userURLsRepository) RestoreURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
+	if userID == "" {
+		return errors.New("userID cannot be empty")
+	}
+	if len(shortURLs) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE urls 
+		SET is_deleted = false, deleted_at = NULL, updated_at = NOW()
+		WHERE short_url = ANY($1) AND is_deleted = true
+		AND id IN (
+			SELECT uu.url_id 
+			FROM user_urls uu 
+			WHERE uu.user_id = $2
+		)
+	`
+	if _, err := r.pool.Exec(ctx, query, shortURLs, userID); err != nil {
+		return fmt.Errorf("failed to restore URLs: %w", err)
 	}
 
 	return nil
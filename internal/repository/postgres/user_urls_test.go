@@ -35,6 +35,13 @@ func TestUserURLsRepository_GetByUserID(t *testing.T) {
 			LongURL:   "https://example.com/1",
 			CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 			UpdatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			Clicks:    5,
+			Health: &model.LinkHealth{
+				StatusCode:          301,
+				RedirectChain:       model.RedirectChain{"https://example.com/moved"},
+				ConsecutiveFailures: 0,
+				CheckedAt:           time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
 		},
 		{
 			ID:        2,
@@ -45,12 +52,20 @@ func TestUserURLsRepository_GetByUserID(t *testing.T) {
 		},
 	}
 
-	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at"})
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants", "clicks", "status_code", "error", "redirect_chain", "consecutive_failures", "checked_at"})
 	for _, url := range expectedURLs {
-		rows.AddRow(url.ID, url.ShortURL, url.LongURL, url.IsDeleted, url.CreatedAt, url.UpdatedAt)
+		var (
+			health    model.LinkHealth
+			checkedAt *time.Time
+		)
+		if url.Health != nil {
+			health, checkedAt = *url.Health, &url.Health.CheckedAt
+		}
+		rows.AddRow(url.ID, url.ShortURL, url.LongURL, url.IsDeleted, url.CreatedAt, url.UpdatedAt, url.ExpiresAt, url.IsExpired, url.MaxClicks, url.ClicksLeft, url.PasswordHash, url.IsDisabled, url.Title, url.ShowInterstitial, url.RedirectCode, url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants, url.Clicks,
+			health.StatusCode, health.Error, health.RedirectChain, health.ConsecutiveFailures, checkedAt)
 	}
 
-	mock.ExpectQuery("SELECT u\\.id, u\\.short_url, u\\.long_url, u\\.is_deleted, u\\.created_at, u\\.updated_at FROM urls u INNER JOIN user_urls uu ON u\\.id = uu\\.url_id WHERE uu\\.user_id = \\$1 ORDER BY uu\\.created_at DESC").
+	mock.ExpectQuery("SELECT u\\.id, u\\.short_url, u\\.long_url, u\\.is_deleted, u\\.created_at, u\\.updated_at, u\\.expires_at, u\\.is_expired, u\\.max_clicks, u\\.clicks_left, u\\.password_hash, u\\.is_disabled, u\\.title, u\\.show_interstitial, u\\.redirect_code, u\\.query_passthrough, u\\.is_prefix, u\\.routing_rules, u\\.variants, COALESCE\\(cc\\.clicks, 0\\), COALESCE\\(lh\\.status_code, 0\\), COALESCE\\(lh\\.error, ''\\), COALESCE\\(lh\\.redirect_chain, ''\\), COALESCE\\(lh\\.consecutive_failures, 0\\), lh\\.checked_at FROM urls u INNER JOIN user_urls uu ON u\\.id = uu\\.url_id LEFT JOIN click_counters cc ON cc\\.url_id = u\\.id LEFT JOIN link_health lh ON lh\\.url_id = u\\.id WHERE uu\\.user_id = \\$1 ORDER BY uu\\.created_at DESC").
 		WithArgs(userID).
 		WillReturnRows(rows)
 
@@ -61,9 +76,13 @@ func TestUserURLsRepository_GetByUserID(t *testing.T) {
 	assert.Equal(t, expectedURLs[0].ID, result[0].ID)
 	assert.Equal(t, expectedURLs[0].ShortURL, result[0].ShortURL)
 	assert.Equal(t, expectedURLs[0].LongURL, result[0].LongURL)
+	assert.Equal(t, int64(5), result[0].Clicks)
+	assert.Equal(t, expectedURLs[0].Health, result[0].Health)
+	assert.Nil(t, result[1].Health)
 	assert.Equal(t, expectedURLs[1].ID, result[1].ID)
 	assert.Equal(t, expectedURLs[1].ShortURL, result[1].ShortURL)
 	assert.Equal(t, expectedURLs[1].LongURL, result[1].LongURL)
+	assert.Zero(t, result[1].Clicks)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -74,9 +93,9 @@ func TestUserURLsRepository_GetByUserID_EmptyResult(t *testing.T) {
 	ctx := context.Background()
 	userID := "test-user-id"
 
-	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at"})
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants", "clicks"})
 
-	mock.ExpectQuery("SELECT u\\.id, u\\.short_url, u\\.long_url, u\\.is_deleted, u\\.created_at, u\\.updated_at FROM urls u INNER JOIN user_urls uu ON u\\.id = uu\\.url_id WHERE uu\\.user_id = \\$1 ORDER BY uu\\.created_at DESC").
+	mock.ExpectQuery("SELECT u\\.id, u\\.short_url, u\\.long_url, u\\.is_deleted, u\\.created_at, u\\.updated_at, u\\.expires_at, u\\.is_expired, u\\.max_clicks, u\\.clicks_left, u\\.password_hash, u\\.is_disabled, u\\.title, u\\.show_interstitial, u\\.redirect_code, u\\.query_passthrough, u\\.is_prefix, u\\.routing_rules, u\\.variants, COALESCE\\(cc\\.clicks, 0\\), COALESCE\\(lh\\.status_code, 0\\), COALESCE\\(lh\\.error, ''\\), COALESCE\\(lh\\.redirect_chain, ''\\), COALESCE\\(lh\\.consecutive_failures, 0\\), lh\\.checked_at FROM urls u INNER JOIN user_urls uu ON u\\.id = uu\\.url_id LEFT JOIN click_counters cc ON cc\\.url_id = u\\.id LEFT JOIN link_health lh ON lh\\.url_id = u\\.id WHERE uu\\.user_id = \\$1 ORDER BY uu\\.created_at DESC").
 		WithArgs(userID).
 		WillReturnRows(rows)
 
@@ -94,7 +113,7 @@ func TestUserURLsRepository_GetByUserID_DatabaseError(t *testing.T) {
 	userID := "test-user-id"
 	expectedErr := repository.ErrURLNotFound
 
-	mock.ExpectQuery("SELECT u\\.id, u\\.short_url, u\\.long_url, u\\.is_deleted, u\\.created_at, u\\.updated_at FROM urls u INNER JOIN user_urls uu ON u\\.id = uu\\.url_id WHERE uu\\.user_id = \\$1 ORDER BY uu\\.created_at DESC").
+	mock.ExpectQuery("SELECT u\\.id, u\\.short_url, u\\.long_url, u\\.is_deleted, u\\.created_at, u\\.updated_at, u\\.expires_at, u\\.is_expired, u\\.max_clicks, u\\.clicks_left, u\\.password_hash, u\\.is_disabled, u\\.title, u\\.show_interstitial, u\\.redirect_code, u\\.query_passthrough, u\\.is_prefix, u\\.routing_rules, u\\.variants, COALESCE\\(cc\\.clicks, 0\\), COALESCE\\(lh\\.status_code, 0\\), COALESCE\\(lh\\.error, ''\\), COALESCE\\(lh\\.redirect_chain, ''\\), COALESCE\\(lh\\.consecutive_failures, 0\\), lh\\.checked_at FROM urls u INNER JOIN user_urls uu ON u\\.id = uu\\.url_id LEFT JOIN click_counters cc ON cc\\.url_id = u\\.id LEFT JOIN link_health lh ON lh\\.url_id = u\\.id WHERE uu\\.user_id = \\$1 ORDER BY uu\\.created_at DESC").
 		WithArgs(userID).
 		WillReturnError(expectedErr)
 
@@ -105,6 +124,180 @@ func TestUserURLsRepository_GetByUserID_DatabaseError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserURLsRepository_GetByUserIDAndLongURL(t *testing.T) {
+	mock, repo := setupUserURLsMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	userID := "test-user-id"
+	longURL := "https://example.com/1"
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := "SELECT u\\.id, u\\.short_url, u\\.long_url, u\\.is_deleted, u\\.created_at, u\\.updated_at, u\\.expires_at, u\\.is_expired, u\\.max_clicks, u\\.clicks_left, u\\.password_hash, u\\.is_disabled, u\\.title, u\\.show_interstitial, u\\.redirect_code, u\\.query_passthrough, u\\.is_prefix, u\\.routing_rules, u\\.variants FROM urls u INNER JOIN user_urls uu ON u\\.id = uu\\.url_id WHERE uu\\.user_id = \\$1 AND u\\.long_url = \\$2 AND u\\.is_deleted = false AND u\\.is_expired = false AND u\\.expires_at IS NULL AND"
+
+	t.Run("found", func(t *testing.T) {
+		rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants"}).
+			AddRow(uint(1), "abc123", longURL, false, createdAt, createdAt, nil, false, nil, nil, nil, false, "", false, 307, model.QueryPassthroughOff, false, "", "")
+
+		mock.ExpectQuery(query).
+			WithArgs(userID, longURL).
+			WillReturnRows(rows)
+
+		result, err := repo.GetByUserIDAndLongURL(ctx, userID, longURL)
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", result.ShortURL)
+		assert.Equal(t, longURL, result.LongURL)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(userID, longURL).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants"}))
+
+		result, err := repo.GetByUserIDAndLongURL(ctx, userID, longURL)
+		assert.ErrorIs(t, err, repository.ErrURLNotFound)
+		assert.Nil(t, result)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserURLsRepository_GetDeletedByUserID(t *testing.T) {
+	mock, repo := setupUserURLsMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	deletedAt := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	rows := pgxmock.NewRows([]string{"id", "short_url", "long_url", "is_deleted", "created_at", "updated_at", "expires_at", "is_expired", "max_clicks", "clicks_left", "password_hash", "is_disabled", "title", "show_interstitial", "redirect_code", "query_passthrough", "is_prefix", "routing_rules", "variants", "clicks"}).
+		AddRow(uint(1), "abc123", "https://example.com/1", true, deletedAt, deletedAt, nil, false, nil, nil, nil, false, "", false, 307, model.QueryPassthroughOff, false, "", "", int64(2))
+
+	mock.ExpectQuery("SELECT u\\.id, u\\.short_url, u\\.long_url, u\\.is_deleted, u\\.created_at, u\\.updated_at, u\\.expires_at, u\\.is_expired, u\\.max_clicks, u\\.clicks_left, u\\.password_hash, u\\.is_disabled, u\\.title, u\\.show_interstitial, u\\.redirect_code, u\\.query_passthrough, u\\.is_prefix, u\\.routing_rules, u\\.variants, COALESCE\\(cc\\.clicks, 0\\) FROM urls u INNER JOIN user_urls uu ON u\\.id = uu\\.url_id LEFT JOIN click_counters cc ON cc\\.url_id = u\\.id WHERE uu\\.user_id = \\$1 AND u\\.is_deleted = true ORDER BY u\\.deleted_at DESC, u\\.id DESC").
+		WithArgs("test-user-id").
+		WillReturnRows(rows)
+
+	result, err := repo.GetDeletedByUserID(ctx, "test-user-id")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "abc123", result[0].ShortURL)
+	assert.True(t, result[0].IsDeleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserURLsRepository_RestoreURLsWithUser(t *testing.T) {
+	mock, repo := setupUserURLsMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	shortURLs := []string{"abc123", "def456"}
+
+	mock.ExpectExec("UPDATE urls SET is_deleted = false, deleted_at = NULL, updated_at = NOW\\(\\) WHERE short_url = ANY\\(\\$1\\) AND is_deleted = true AND id IN \\( SELECT uu\\.url_id FROM user_urls uu WHERE uu\\.user_id = \\$2 \\)").
+		WithArgs(shortURLs, "test-user-id").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	assert.NoError(t, repo.RestoreURLsWithUser(ctx, shortURLs, "test-user-id"))
+	assert.NoError(t, repo.RestoreURLsWithUser(ctx, nil, "test-user-id"))
+	assert.Error(t, repo.RestoreURLsWithUser(ctx, shortURLs, ""))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserURLsRepository_DeleteURLsWithUser(t *testing.T) {
+	mock, repo := setupUserURLsMockPool(t)
+	defer mock.Close()
+
+	ctx := context.Background()
+	shortURLs := []string{"own1", "trashed", "alien", "unknown"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT u\\.short_url, EXISTS \\( SELECT 1 FROM user_urls uu WHERE uu\\.url_id = u\\.id AND uu\\.user_id = \\$2 \\) FROM urls u WHERE u\\.short_url = ANY\\(\\$1\\)").
+		WithArgs(shortURLs, "test-user-id").
+		WillReturnRows(pgxmock.NewRows([]string{"short_url", "exists"}).
+			AddRow("own1", true).
+			AddRow("trashed", true).
+			AddRow("alien", false))
+	mock.ExpectQuery("UPDATE urls SET is_deleted = true, deleted_at = NOW\\(\\), updated_at = NOW\\(\\) WHERE short_url = ANY\\(\\$1\\) AND is_deleted = false AND id IN \\( SELECT uu\\.url_id FROM user_urls uu WHERE uu\\.user_id = \\$2 \\) RETURNING short_url").
+		WithArgs(shortURLs, "test-user-id").
+		WillReturnRows(pgxmock.NewRows([]string{"short_url"}).AddRow("own1"))
+	mock.ExpectCommit()
+
+	outcomes, err := repo.DeleteURLsWithUser(ctx, shortURLs, "test-user-id")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]model.DeleteOutcome{
+		"own1":    model.DeleteOutcomeDeleted,
+		"trashed": model.DeleteOutcomeAlreadyDeleted,
+		"alien":   model.DeleteOutcomeNotOwned,
+		"unknown": model.DeleteOutcomeNotFound,
+	}, outcomes)
+
+	outcomes, err = repo.DeleteURLsWithUser(ctx, nil, "test-user-id")
+	assert.NoError(t, err)
+	assert.Empty(t, outcomes)
+
+	_, err = repo.DeleteURLsWithUser(ctx, shortURLs, "")
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserURLsRepository_DeleteURLWithUser(t *testing.T) {
+	selectQuery := "SELECT u\\.id, u\\.is_deleted, EXISTS \\( SELECT 1 FROM user_urls uu WHERE uu\\.url_id = u\\.id AND uu\\.user_id = \\$2 \\) FROM urls u WHERE u\\.short_url = \\$1 FOR UPDATE"
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mock, repo := setupUserURLsMockPool(t)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectQuery).
+			WithArgs("abc123", "test-user-id").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "is_deleted", "exists"}).AddRow(int64(1), false, true))
+		mock.ExpectExec("UPDATE urls SET is_deleted = true, deleted_at = NOW\\(\\), updated_at = NOW\\(\\) WHERE id = \\$1").
+			WithArgs(int64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.DeleteURLWithUser(ctx, "abc123", "test-user-id"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	tests := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		expectedErr error
+	}{
+		{
+			name:        "not found",
+			rows:        pgxmock.NewRows([]string{"id", "is_deleted", "exists"}),
+			expectedErr: repository.ErrURLNotFound,
+		},
+		{
+			name:        "not owned",
+			rows:        pgxmock.NewRows([]string{"id", "is_deleted", "exists"}).AddRow(int64(1), false, false),
+			expectedErr: repository.ErrURLNotOwned,
+		},
+		{
+			name:        "already deleted",
+			rows:        pgxmock.NewRows([]string{"id", "is_deleted", "exists"}).AddRow(int64(1), true, true),
+			expectedErr: repository.ErrURLAlreadyDeleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, repo := setupUserURLsMockPool(t)
+			defer mock.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(selectQuery).
+				WithArgs("abc123", "test-user-id").
+				WillReturnRows(tt.rows)
+			mock.ExpectRollback()
+
+			err := repo.DeleteURLWithUser(ctx, "abc123", "test-user-id")
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestUserURLsRepository_CreateURLWithUser_Success(t *testing.T) {
 	mock, repo := setupUserURLsMockPool(t)
 	defer mock.Close()
@@ -120,8 +313,8 @@ func TestUserURLsRepository_CreateURLWithUser_Success(t *testing.T) {
 	mock.ExpectBegin()
 
 	// Ожидаем создание URL
-	mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url\\) VALUES \\(\\$1, \\$2\\) RETURNING id").
-		WithArgs(url.ShortURL, url.LongURL).
+	mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\) RETURNING id").
+		WithArgs(url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(1)))
 
 	// Ожидаем связывание с пользователем
@@ -157,8 +350,8 @@ func TestUserURLsRepository_CreateURLWithUser_DuplicateURL(t *testing.T) {
 		Code: "23505", // unique_violation
 	}
 
-	mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url\\) VALUES \\(\\$1, \\$2\\) RETURNING id").
-		WithArgs(url.ShortURL, url.LongURL).
+	mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\) RETURNING id").
+		WithArgs(url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 		WillReturnError(pgErr)
 
 	// Ожидаем откат транзакции
@@ -185,8 +378,8 @@ func TestUserURLsRepository_CreateURLWithUser_DuplicateUserURL(t *testing.T) {
 	mock.ExpectBegin()
 
 	// Ожидаем создание URL
-	mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url\\) VALUES \\(\\$1, \\$2\\) RETURNING id").
-		WithArgs(url.ShortURL, url.LongURL).
+	mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\) RETURNING id").
+		WithArgs(url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(1)))
 
 	// Ожидаем ошибку дублирования при связывании с пользователем
@@ -241,8 +434,8 @@ func TestUserURLsRepository_CreateMultipleURLsWithUser_Success(t *testing.T) {
 
 	// Ожидаем создание каждого URL
 	for i, url := range urls {
-		mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url\\) VALUES \\(\\$1, \\$2\\) RETURNING id").
-			WithArgs(url.ShortURL, url.LongURL).
+		mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\) RETURNING id").
+			WithArgs(url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(i + 1)))
 
 		// Ожидаем связывание с пользователем
@@ -292,8 +485,8 @@ func TestUserURLsRepository_CreateMultipleURLsWithUser_WithNilURLs(t *testing.T)
 	// Ожидаем создание только не-nil URL
 	validURLs := []*model.URLsModel{urls[0], urls[2]}
 	for i, url := range validURLs {
-		mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url\\) VALUES \\(\\$1, \\$2\\) RETURNING id").
-			WithArgs(url.ShortURL, url.LongURL).
+		mock.ExpectQuery("INSERT INTO urls \\(short_url, long_url, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\) RETURNING id").
+			WithArgs(url.ShortURL, url.LongURL, url.ExpiresAt, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants).
 			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(i + 1)))
 
 		// Ожидаем связывание с пользователем
@@ -0,0 +1,224 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+)
+
+const (
+	// maxClicksPerStatement - максимальное количество переходов в одном запросе (по 6 параметров на переход)
+	maxClicksPerStatement = 150
+	// maxCountersPerStatement - максимальное количество счетчиков в одном запросе (по 2 параметра на счетчик)
+	maxCountersPerStatement = 400
+)
+
+type clicksRepository struct {
+	db *sql.DB
+}
+
+// NewClicksRepository создает новый репозиторий журнала переходов в SQLite базе данных.
+// Принимает соединение с SQLite и возвращает реализацию интерфейса ClickRepository.
+// Моменты переходов сохраняются в UTC, поэтому первые 10 символов значения совпадают с датой дня.
+func NewClicksRepository(db *sql.DB) repository.ClickRepository {
+	return &clicksRepository{db: db}
+}
+
+// RecordClicks сохраняет пачку переходов в одной транзакции. Ссылки ищутся по короткому коду в том же запросе,
+// переходы по несуществующим ссылкам пропускаются.
+func (r *clicksRepository) RecordClicks(ctx context.Context, clicks []*model.ClickModel) error {
+	clicks = slices.DeleteFunc(slices.Clone(clicks), func(click *model.ClickModel) bool { return click == nil })
+	if len(clicks) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Отложенный rollback (выполнится только если не будет commit)
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				fmt.Printf("rollback failed: %v\n", rollbackErr)
+			}
+		}
+	}()
+
+	// Длинная пачка записывается частями, чтобы не превысить лимит параметров запроса
+	for chunk := range slices.Chunk(clicks, maxClicksPerStatement) {
+		values := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?),", len(chunk)), ",")
+		query := fmt.Sprintf(`
+			INSERT INTO clicks (url_id, clicked_at, referrer, user_agent, ip, variant)
+			SELECT u.id, v.column2, v.column3, v.column4, v.column5, v.column6
+			FROM (VALUES %s) AS v
+			INNER JOIN urls u ON u.short_url = v.column1
+		`, values)
+
+		args := make([]any, 0, len(chunk)*6)
+		for _, click := range chunk {
+			args = append(args, click.ShortURL, click.ClickedAt.UTC(), click.Referrer, click.UserAgent, click.IP, click.Variant)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to record clicks: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementClickCounters увеличивает счетчики переходов в одной транзакции с upsert.
+func (r *clicksRepository) IncrementClickCounters(ctx context.Context, counters map[string]int64) error {
+	if len(counters) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Отложенный rollback (выполнится только если не будет commit)
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				fmt.Printf("rollback failed: %v\n", rollbackErr)
+			}
+		}
+	}()
+
+	shortURLs := slices.Sorted(maps.Keys(counters))
+	for chunk := range slices.Chunk(shortURLs, maxCountersPerStatement) {
+		values := strings.TrimSuffix(strings.Repeat("(?, ?),", len(chunk)), ",")
+		// WHERE true нужен SQLite, чтобы отличить ON CONFLICT от условия соединения
+		query := fmt.Sprintf(`
+			INSERT INTO click_counters (url_id, clicks, updated_at)
+			SELECT u.id, v.column2, CURRENT_TIMESTAMP
+			FROM (VALUES %s) AS v
+			INNER JOIN urls u ON u.short_url = v.column1
+			WHERE true
+			ON CONFLICT (url_id) DO UPDATE
+			SET clicks = clicks + excluded.clicks, updated_at = excluded.updated_at
+		`, values)
+
+		args := make([]any, 0, len(chunk)*2)
+		for _, shortURL := range chunk {
+			args = append(args, shortURL, counters[shortURL])
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to increment click counters: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetClickStats возвращает статистику переходов по ссылке пользователя.
+// Итоги и разбивка по вариантам считаются за все время, разбивка по дням - с since; дни определяются по UTC.
+func (r *clicksRepository) GetClickStats(
+	ctx context.Context,
+	shortURL, userID string,
+	since time.Time,
+) (*model.ClickStats, error) {
+	totalsQuery := `
+		SELECT u.id,
+			EXISTS (SELECT 1 FROM user_urls uu WHERE uu.url_id = u.id AND uu.user_id = ?),
+			(SELECT COUNT(*) FROM clicks c WHERE c.url_id = u.id),
+			(SELECT COUNT(DISTINCT c.ip || '|' || c.user_agent) FROM clicks c WHERE c.url_id = u.id)
+		FROM urls u
+		WHERE u.short_url = ?
+	`
+
+	var (
+		urlID   int64
+		isOwned bool
+	)
+	stats := &model.ClickStats{ShortURL: shortURL, Daily: []model.DailyClicks{}}
+	err := r.db.QueryRowContext(ctx, totalsQuery, userID, shortURL).
+		Scan(&urlID, &isOwned, &stats.TotalClicks, &stats.UniqueVisitors)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrURLNotFound
+		}
+		return nil, fmt.Errorf("failed to get click totals: %w", err)
+	}
+	if !isOwned {
+		return nil, repository.ErrURLNotOwned
+	}
+
+	dailyQuery := `
+		SELECT substr(clicked_at, 1, 10) AS day, COUNT(*), COUNT(DISTINCT ip || '|' || user_agent)
+		FROM clicks
+		WHERE url_id = ? AND clicked_at >= ?
+		GROUP BY day
+		ORDER BY day
+	`
+	rows, err := r.db.QueryContext(ctx, dailyQuery, urlID, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily clicks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			day  model.DailyClicks
+			date string
+		)
+		if err := rows.Scan(&date, &day.Clicks, &day.UniqueVisitors); err != nil {
+			return nil, fmt.Errorf("failed to scan daily clicks: %w", err)
+		}
+		if day.Date, err = time.Parse(time.DateOnly, date); err != nil {
+			return nil, fmt.Errorf("failed to parse click date: %w", err)
+		}
+		stats.Daily = append(stats.Daily, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get daily clicks: %w", err)
+	}
+
+	variantsQuery := `
+		SELECT variant, COUNT(*), COUNT(DISTINCT ip || '|' || user_agent)
+		FROM clicks
+		WHERE url_id = ? AND variant <> ''
+		GROUP BY variant
+		ORDER BY variant
+	`
+	variantRows, err := r.db.QueryContext(ctx, variantsQuery, urlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variant clicks: %w", err)
+	}
+	defer variantRows.Close()
+
+	for variantRows.Next() {
+		var variant model.VariantClicks
+		if err := variantRows.Scan(&variant.Variant, &variant.Clicks, &variant.UniqueVisitors); err != nil {
+			return nil, fmt.Errorf("failed to scan variant clicks: %w", err)
+		}
+		stats.Variants = append(stats.Variants, variant)
+	}
+	if err := variantRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get variant clicks: %w", err)
+	}
+
+	return stats, nil
+}
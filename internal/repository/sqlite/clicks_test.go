@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClicksRepository_RecordAndStats(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	userURLsRepo := NewUserURLsRepository(db)
+	repo := NewClicksRepository(db)
+	ctx := context.Background()
+
+	for _, userID := range []string{"owner", "stranger"} {
+		_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`, userID, userID, false)
+		require.NoError(t, err)
+	}
+	require.NoError(t, userURLsRepo.CreateMultipleURLsWithUser(ctx, []*model.URLsModel{
+		{ShortURL: "own", LongURL: "https://example.com/own"},
+		{ShortURL: "quiet", LongURL: "https://example.com/quiet"},
+	}, "owner"))
+
+	day1 := time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 3, 4, 23, 30, 0, 0, time.UTC)
+	clicks := []*model.ClickModel{
+		// Переход до начала периода учитывается только в итогах
+		{ShortURL: "own", ClickedAt: time.Date(2025, 2, 20, 12, 0, 0, 0, time.UTC), IP: "10.0.0.0", UserAgent: "curl"},
+		{ShortURL: "own", ClickedAt: day1, IP: "192.168.1.0", UserAgent: "Mozilla/5.0", Variant: "a"},
+		{ShortURL: "own", ClickedAt: day1.Add(time.Hour), IP: "192.168.1.0", UserAgent: "Mozilla/5.0", Variant: "a"},
+		{ShortURL: "own", ClickedAt: day2, IP: "192.168.1.0", UserAgent: "Mozilla/5.0", Referrer: "https://news.example.com/", Variant: "b"},
+		// Время в другом часовом поясе приводится к UTC: это тот же 4 марта
+		{ShortURL: "own", ClickedAt: day2.Add(10 * time.Minute).In(time.FixedZone("UTC+3", 3*60*60)), IP: "2001:db8:1::", UserAgent: "Mozilla/5.0", Variant: "a"},
+	}
+	// Переходы по несуществующей ссылке пропускаются
+	clicks = append(clicks, nil, &model.ClickModel{ShortURL: "missing", ClickedAt: day1})
+	require.NoError(t, repo.RecordClicks(ctx, clicks))
+	require.NoError(t, repo.RecordClicks(ctx, nil))
+
+	stats, err := repo.GetClickStats(ctx, "own", "owner", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, &model.ClickStats{
+		ShortURL:       "own",
+		TotalClicks:    5,
+		UniqueVisitors: 3,
+		Daily: []model.DailyClicks{
+			{Date: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), Clicks: 2, UniqueVisitors: 1},
+			{Date: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Clicks: 2, UniqueVisitors: 2},
+		},
+		// Переходы без варианта в разбивку по вариантам не попадают
+		Variants: []model.VariantClicks{
+			{Variant: "a", Clicks: 3, UniqueVisitors: 2},
+			{Variant: "b", Clicks: 1, UniqueVisitors: 1},
+		},
+	}, stats)
+
+	// Ссылка без переходов
+	stats, err = repo.GetClickStats(ctx, "quiet", "owner", day1)
+	require.NoError(t, err)
+	assert.Zero(t, stats.TotalClicks)
+	assert.Empty(t, stats.Daily)
+
+	_, err = repo.GetClickStats(ctx, "own", "stranger", day1)
+	assert.ErrorIs(t, err, repository.ErrURLNotOwned)
+
+	_, err = repo.GetClickStats(ctx, "missing", "owner", day1)
+	assert.ErrorIs(t, err, repository.ErrURLNotFound)
+}
+
+func TestClicksRepository_IncrementClickCounters(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	userURLsRepo := NewUserURLsRepository(db)
+	repo := NewClicksRepository(db)
+	ctx := context.Background()
+
+	_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`, "owner", "owner", false)
+	require.NoError(t, err)
+
+	links := make([]*model.URLsModel, 0, maxCountersPerStatement+1)
+	for i := range maxCountersPerStatement + 1 {
+		links = append(links, &model.URLsModel{ShortURL: fmt.Sprintf("code%d", i), LongURL: fmt.Sprintf("https://example.com/%d", i)})
+	}
+	require.NoError(t, userURLsRepo.CreateMultipleURLsWithUser(ctx, links, "owner"))
+
+	// Пачка больше лимита одного запроса записывается частями
+	counters := map[string]int64{"missing": 5}
+	for _, link := range links {
+		counters[link.ShortURL] = 1
+	}
+	require.NoError(t, repo.IncrementClickCounters(ctx, counters))
+	require.NoError(t, repo.IncrementClickCounters(ctx, map[string]int64{"code0": 2}))
+	require.NoError(t, repo.IncrementClickCounters(ctx, nil))
+
+	urls, err := userURLsRepo.GetByUserID(ctx, "owner")
+	require.NoError(t, err)
+	require.Len(t, urls, len(links))
+
+	clicksByCode := make(map[string]int64, len(urls))
+	for _, url := range urls {
+		clicksByCode[url.ShortURL] = url.Clicks
+	}
+	assert.Equal(t, int64(3), clicksByCode["code0"])
+	assert.Equal(t, int64(1), clicksByCode[fmt.Sprintf("code%d", maxCountersPerStatement)])
+}
@@ -0,0 +1,218 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+)
+
+const deleteJobColumns = `id, user_id, action, short_urls, status, results, error, attempts, next_run_at, created_at, updated_at`
+
+type deleteJobsRepository struct {
+	db *sql.DB
+}
+
+// NewDeleteJobsRepository создает новый репозиторий очереди задач удаления в SQLite базе данных.
+// Принимает соединение с SQLite и возвращает реализацию интерфейса DeleteJobRepository.
+// Все временные метки сохраняются в UTC, чтобы их можно было сравнивать в запросах.
+func NewDeleteJobsRepository(db *sql.DB) repository.DeleteJobRepository {
+	return &deleteJobsRepository{db: db}
+}
+
+// Create сохраняет новую задачу в очереди.
+func (r *deleteJobsRepository) Create(ctx context.Context, job *model.DeleteJob) error {
+	if job == nil {
+		return errors.New("job cannot be nil")
+	}
+
+	shortURLs, err := json.Marshal(job.ShortURLs)
+	if err != nil {
+		return fmt.Errorf("failed to encode short urls: %w", err)
+	}
+
+	query := `
+		INSERT INTO delete_jobs (id, user_id, action, short_urls, status, attempts, next_run_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		job.ID, job.UserID, job.Action, string(shortURLs), job.Status, job.Attempts,
+		job.NextRunAt.UTC(), job.CreatedAt.UTC(), job.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create delete job: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID возвращает задачу по идентификатору или ErrDeleteJobNotFound, если задача не найдена.
+func (r *deleteJobsRepository) GetByID(ctx context.Context, jobID string) (*model.DeleteJob, error) {
+	query := `SELECT ` + deleteJobColumns + ` FROM delete_jobs WHERE id = ?`
+
+	return scanDeleteJob(r.db.QueryRowContext(ctx, query, jobID))
+}
+
+// ClaimBatch захватывает до limit самых ранних готовых к выполнению задач.
+// SQLite выполняет запись под блокировкой базы, поэтому выбор и захват задач в одном UPDATE атомарны.
+// Задача с истекшей арендой считается брошенной и захватывается снова.
+func (r *deleteJobsRepository) ClaimBatch(
+	ctx context.Context,
+	now, leaseUntil time.Time,
+	limit int,
+) ([]*model.DeleteJob, error) {
+	query := `
+		UPDATE delete_jobs
+		SET status = 'running', lease_until = ?, attempts = attempts + 1, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM delete_jobs
+			WHERE (status = 'queued' AND next_run_at <= ?)
+			OR (status = 'running' AND lease_until < ?)
+			ORDER BY next_run_at, created_at
+			LIMIT ?
+		)
+		RETURNING ` + deleteJobColumns
+
+	now = now.UTC()
+	rows, err := r.db.QueryContext(ctx, query, leaseUntil.UTC(), now, now, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim delete jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*model.DeleteJob
+	for rows.Next() {
+		job, err := scanDeleteJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delete job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim delete jobs: %w", err)
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	sortDeleteJobs(jobs)
+	return jobs, nil
+}
+
+// Complete завершает задачу со статусом done и сохраняет результат по каждой ссылке.
+func (r *deleteJobsRepository) Complete(
+	ctx context.Context,
+	jobID string,
+	results map[string]model.DeleteOutcome,
+	now time.Time,
+) error {
+	var encoded any
+	if results != nil {
+		data, err := json.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+		encoded = string(data)
+	}
+
+	query := `
+		UPDATE delete_jobs
+		SET status = 'done', results = ?, error = NULL, lease_until = NULL, updated_at = ?
+		WHERE id = ?
+	`
+	return r.finish(ctx, query, encoded, now.UTC(), jobID)
+}
+
+// Retry возвращает задачу в очередь до nextRunAt и сохраняет ошибку последней попытки.
+func (r *deleteJobsRepository) Retry(ctx context.Context, jobID, lastErr string, nextRunAt, now time.Time) error {
+	query := `
+		UPDATE delete_jobs
+		SET status = 'queued', error = ?, next_run_at = ?, lease_until = NULL, updated_at = ?
+		WHERE id = ?
+	`
+	return r.finish(ctx, query, lastErr, nextRunAt.UTC(), now.UTC(), jobID)
+}
+
+// Fail завершает задачу со статусом failed.
+func (r *deleteJobsRepository) Fail(ctx context.Context, jobID, lastErr string, now time.Time) error {
+	query := `
+		UPDATE delete_jobs
+		SET status = 'failed', error = ?, lease_until = NULL, updated_at = ?
+		WHERE id = ?
+	`
+	return r.finish(ctx, query, lastErr, now.UTC(), jobID)
+}
+
+func (r *deleteJobsRepository) finish(ctx context.Context, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update delete job: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrDeleteJobNotFound
+	}
+
+	return nil
+}
+
+// DeleteFinished удаляет завершенные задачи, последний раз измененные раньше finishedBefore.
+// Возвращает количество удаленных задач.
+func (r *deleteJobsRepository) DeleteFinished(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	query := `DELETE FROM delete_jobs WHERE status IN ('done', 'failed') AND updated_at < ?`
+
+	result, err := r.db.ExecContext(ctx, query, finishedBefore.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func scanDeleteJob(row rowScanner) (*model.DeleteJob, error) {
+	var (
+		job       model.DeleteJob
+		shortURLs string
+		results   sql.NullString
+		lastErr   sql.NullString
+	)
+
+	err := row.Scan(
+		&job.ID, &job.UserID, &job.Action, &shortURLs, &job.Status, &results, &lastErr,
+		&job.Attempts, &job.NextRunAt, &job.CreatedAt, &job.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrDeleteJobNotFound
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(shortURLs), &job.ShortURLs); err != nil {
+		return nil, fmt.Errorf("failed to decode short urls: %w", err)
+	}
+	if results.Valid {
+		if err := json.Unmarshal([]byte(results.String), &job.Results); err != nil {
+			return nil, fmt.Errorf("failed to decode results: %w", err)
+		}
+	}
+	job.Error = lastErr.String
+
+	return &job, nil
+}
+
+func sortDeleteJobs(jobs []*model.DeleteJob) {
+	slices.SortStableFunc(jobs, func(a, b *model.DeleteJob) int {
+		if c := a.NextRunAt.Compare(b.NextRunAt); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+}
@@ -0,0 +1,151 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDeleteJobsTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	// Каждое соединение с :memory: открывает отдельную базу
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS delete_jobs (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			action TEXT NOT NULL,
+			short_urls TEXT NOT NULL,
+			status TEXT NOT NULL,
+			results TEXT,
+			error TEXT,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_run_at DATETIME NOT NULL,
+			lease_until DATETIME,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		)
+	`)
+	require.NoError(t, err)
+
+	return db
+}
+
+func newQueuedJob(id string, runAt time.Time) *model.DeleteJob {
+	return &model.DeleteJob{
+		ID:        id,
+		UserID:    "owner",
+		Action:    model.DeleteJobActionDelete,
+		Status:    model.DeleteJobQueued,
+		ShortURLs: []string{"own1", "own2"},
+		NextRunAt: runAt,
+		CreatedAt: runAt,
+		UpdatedAt: runAt,
+	}
+}
+
+func TestDeleteJobsRepository_Lifecycle(t *testing.T) {
+	repo := NewDeleteJobsRepository(setupDeleteJobsTestDB(t))
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, repo.Create(ctx, newQueuedJob("job-1", now.Add(-time.Second))))
+	// Отложенная задача еще не готова к выполнению
+	require.NoError(t, repo.Create(ctx, newQueuedJob("job-2", now.Add(time.Minute))))
+
+	jobs, err := repo.ClaimBatch(ctx, now, now.Add(30*time.Second), 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	job := jobs[0]
+	assert.Equal(t, "job-1", job.ID)
+	assert.Equal(t, model.DeleteJobRunning, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.Equal(t, []string{"own1", "own2"}, job.ShortURLs)
+
+	// Захваченная задача с действующей арендой не выдается повторно
+	jobs, err = repo.ClaimBatch(ctx, now, now.Add(30*time.Second), 10)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	// После истечения аренды задача считается брошенной
+	later := now.Add(time.Minute - time.Second)
+	jobs, err = repo.ClaimBatch(ctx, later, later.Add(30*time.Second), 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "job-1", jobs[0].ID)
+	assert.Equal(t, 2, jobs[0].Attempts)
+
+	require.NoError(t, repo.Retry(ctx, "job-1", "database is locked", later.Add(time.Hour), later))
+	job, err = repo.GetByID(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.DeleteJobQueued, job.Status)
+	assert.Equal(t, "database is locked", job.Error)
+
+	// Наступил срок отложенной задачи job-2, job-1 ждет повторной попытки
+	jobs, err = repo.ClaimBatch(ctx, now.Add(2*time.Minute), now.Add(3*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "job-2", jobs[0].ID)
+
+	results := map[string]model.DeleteOutcome{
+		"own1": model.DeleteOutcomeDeleted,
+		"own2": model.DeleteOutcomeNotOwned,
+	}
+	require.NoError(t, repo.Complete(ctx, "job-2", results, now.Add(2*time.Minute)))
+	job, err = repo.GetByID(ctx, "job-2")
+	require.NoError(t, err)
+	assert.Equal(t, model.DeleteJobDone, job.Status)
+	assert.Equal(t, results, job.Results)
+	assert.Empty(t, job.Error)
+
+	require.NoError(t, repo.Fail(ctx, "job-1", "database is locked", now.Add(2*time.Minute)))
+	job, err = repo.GetByID(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.DeleteJobFailed, job.Status)
+	assert.Nil(t, job.Results)
+
+	assert.ErrorIs(t, repo.Fail(ctx, "unknown", "error", now), repository.ErrDeleteJobNotFound)
+	_, err = repo.GetByID(ctx, "unknown")
+	assert.ErrorIs(t, err, repository.ErrDeleteJobNotFound)
+
+	// Завершенные задачи удаляются по сроку
+	deleted, err := repo.DeleteFinished(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	deleted, err = repo.DeleteFinished(ctx, now.Add(3*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
+
+func TestDeleteJobsRepository_ClaimBatchLimit(t *testing.T) {
+	repo := NewDeleteJobsRepository(setupDeleteJobsTestDB(t))
+	ctx := context.Background()
+	now := time.Now()
+
+	for i, id := range []string{"job-3", "job-1", "job-2"} {
+		require.NoError(t, repo.Create(ctx, newQueuedJob(id, now.Add(-time.Duration(3-i)*time.Second))))
+	}
+
+	// Захватываются самые ранние задачи в порядке постановки в очередь
+	jobs, err := repo.ClaimBatch(ctx, now, now.Add(30*time.Second), 2)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "job-3", jobs[0].ID)
+	assert.Equal(t, "job-1", jobs[1].ID)
+
+	jobs, err = repo.ClaimBatch(ctx, now, now.Add(30*time.Second), 2)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "job-2", jobs[0].ID)
+}
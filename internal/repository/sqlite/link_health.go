@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+)
+
+// maxLinkCheckResultsPerStatement - максимальное количество результатов проверки в одном запросе (по 6 параметров на результат)
+const maxLinkCheckResultsPerStatement = 150
+
+type linkHealthRepository struct {
+	db *sql.DB
+}
+
+// NewLinkHealthRepository создает новый репозиторий результатов проверки доступности адресов назначения
+// в SQLite базе данных. Принимает соединение с SQLite и возвращает реализацию интерфейса LinkHealthRepository.
+// Моменты проверок сохраняются в UTC.
+func NewLinkHealthRepository(db *sql.DB) repository.LinkHealthRepository {
+	return &linkHealthRepository{db: db}
+}
+
+// GetCheckTargets возвращает страницу действующих ссылок для проверки в порядке идентификаторов.
+func (r *linkHealthRepository) GetCheckTargets(ctx context.Context, afterID uint, limit int) ([]*model.LinkCheckTarget, error) {
+	query := `
+		SELECT id, short_url, long_url
+		FROM urls
+		WHERE id > ? AND is_deleted = 0 AND is_disabled = 0 AND is_expired = 0
+		AND (expires_at IS NULL OR expires_at > ?)
+		ORDER BY id
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, time.Now().UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link check targets: %w", err)
+	}
+	defer rows.Close()
+
+	var targets []*model.LinkCheckTarget
+	for rows.Next() {
+		var target model.LinkCheckTarget
+		if err := rows.Scan(&target.ID, &target.ShortURL, &target.LongURL); err != nil {
+			return nil, fmt.Errorf("failed to scan link check target: %w", err)
+		}
+		targets = append(targets, &target)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate link check targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+// SaveResults сохраняет результаты проверок в одной транзакции с upsert.
+func (r *linkHealthRepository) SaveResults(ctx context.Context, results []*model.LinkCheckResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Отложенный rollback (выполнится только если не будет commit)
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				fmt.Printf("rollback failed: %v\n", rollbackErr)
+			}
+		}
+	}()
+
+	sorted := slices.SortedFunc(slices.Values(results), func(a, b *model.LinkCheckResult) int {
+		return cmp.Compare(a.URLID, b.URLID)
+	})
+
+	for chunk := range slices.Chunk(sorted, maxLinkCheckResultsPerStatement) {
+		values := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?),", len(chunk)), ",")
+		// WHERE true нужен SQLite, чтобы отличить ON CONFLICT от условия соединения
+		query := fmt.Sprintf(`
+			INSERT INTO link_health (url_id, status_code, error, redirect_chain, consecutive_failures, checked_at)
+			SELECT u.id, v.column2, v.column3, v.column4, v.column5, v.column6
+			FROM (VALUES %s) AS v
+			INNER JOIN urls u ON u.id = v.column1
+			WHERE true
+			ON CONFLICT (url_id) DO UPDATE
+			SET status_code = excluded.status_code, error = excluded.error, redirect_chain = excluded.redirect_chain,
+				consecutive_failures = CASE WHEN excluded.consecutive_failures = 0 THEN 0 ELSE consecutive_failures + 1 END,
+				checked_at = excluded.checked_at
+		`, values)
+
+		args := make([]any, 0, len(chunk)*6)
+		for _, result := range chunk {
+			failures := 1
+			if result.IsHealthy() {
+				failures = 0
+			}
+			args = append(args, result.URLID, result.StatusCode, result.Error, result.RedirectChain, failures, result.CheckedAt.UTC())
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to save link check results: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetBrokenLinks возвращает действующие ссылки, недоступные не менее minFailures проверок подряд.
+func (r *linkHealthRepository) GetBrokenLinks(ctx context.Context, minFailures, limit int) ([]*model.BrokenLink, error) {
+	query := `
+		SELECT u.short_url, u.long_url, lh.status_code, lh.error, lh.redirect_chain, lh.consecutive_failures, lh.checked_at
+		FROM link_health lh
+		INNER JOIN urls u ON u.id = lh.url_id
+		WHERE lh.consecutive_failures >= ? AND u.is_deleted = 0 AND u.is_disabled = 0
+		ORDER BY lh.consecutive_failures DESC, u.id
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, minFailures, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broken links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*model.BrokenLink
+	for rows.Next() {
+		var link model.BrokenLink
+		if err := rows.Scan(
+			&link.ShortURL,
+			&link.LongURL,
+			&link.Health.StatusCode,
+			&link.Health.Error,
+			&link.Health.RedirectChain,
+			&link.Health.ConsecutiveFailures,
+			&link.Health.CheckedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan broken link: %w", err)
+		}
+		links = append(links, &link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate broken links: %w", err)
+	}
+
+	return links, nil
+}
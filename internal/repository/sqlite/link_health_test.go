@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkHealthRepository(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	userURLsRepo := NewUserURLsRepository(db)
+	repo := NewLinkHealthRepository(db)
+	ctx := context.Background()
+
+	_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`, "owner", "owner", false)
+	require.NoError(t, err)
+	require.NoError(t, userURLsRepo.CreateMultipleURLsWithUser(ctx, []*model.URLsModel{
+		{ShortURL: "ok", LongURL: "https://example.com/ok"},
+		{ShortURL: "broken", LongURL: "https://example.com/broken"},
+		{ShortURL: "deleted", LongURL: "https://example.com/deleted"},
+	}, "owner"))
+	_, err = userURLsRepo.DeleteURLsWithUser(ctx, []string{"deleted"}, "owner")
+	require.NoError(t, err)
+
+	var targets []*model.LinkCheckTarget
+	t.Run("targets skip deleted links and are paged by id", func(t *testing.T) {
+		first, err := repo.GetCheckTargets(ctx, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+		assert.Equal(t, "ok", first[0].ShortURL)
+
+		rest, err := repo.GetCheckTargets(ctx, first[0].ID, 10)
+		require.NoError(t, err)
+		require.Len(t, rest, 1)
+		assert.Equal(t, "broken", rest[0].ShortURL)
+		assert.Equal(t, "https://example.com/broken", rest[0].LongURL)
+
+		targets = append(first, rest...)
+	})
+	require.Len(t, targets, 2)
+	okID, brokenID := targets[0].ID, targets[1].ID
+
+	checkedAt := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	save := func(t *testing.T, okStatus int, at time.Time) {
+		t.Helper()
+		require.NoError(t, repo.SaveResults(ctx, []*model.LinkCheckResult{
+			{URLID: brokenID, LinkHealth: model.LinkHealth{StatusCode: 404, RedirectChain: model.RedirectChain{"https://example.com/gone"}, CheckedAt: at}},
+			{URLID: okID, LinkHealth: model.LinkHealth{StatusCode: okStatus, CheckedAt: at}},
+		}))
+	}
+
+	t.Run("consecutive failures are counted and reset", func(t *testing.T) {
+		save(t, 200, checkedAt)
+		save(t, 503, checkedAt.Add(time.Hour))
+		save(t, 200, checkedAt.Add(2*time.Hour))
+
+		links, err := repo.GetBrokenLinks(ctx, 3, 10)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "broken", links[0].ShortURL)
+		assert.Equal(t, "https://example.com/broken", links[0].LongURL)
+		assert.Equal(t, 404, links[0].Health.StatusCode)
+		assert.Equal(t, 3, links[0].Health.ConsecutiveFailures)
+		assert.Equal(t, model.RedirectChain{"https://example.com/gone"}, links[0].Health.RedirectChain)
+		assert.True(t, links[0].Health.CheckedAt.Equal(checkedAt.Add(2*time.Hour)))
+
+		links, err = repo.GetBrokenLinks(ctx, 4, 10)
+		require.NoError(t, err)
+		assert.Empty(t, links)
+	})
+
+	t.Run("results for removed links are skipped", func(t *testing.T) {
+		require.NoError(t, repo.SaveResults(ctx, []*model.LinkCheckResult{
+			{URLID: brokenID + 100, LinkHealth: model.LinkHealth{Error: "connection refused", CheckedAt: checkedAt}},
+		}))
+		require.NoError(t, repo.SaveResults(ctx, nil))
+	})
+
+	t.Run("owner listing includes health", func(t *testing.T) {
+		urls, err := userURLsRepo.GetByUserID(ctx, "owner")
+		require.NoError(t, err)
+
+		health := make(map[string]*model.LinkHealth)
+		for _, url := range urls {
+			health[url.ShortURL] = url.Health
+		}
+		require.NotNil(t, health["ok"])
+		assert.Equal(t, 200, health["ok"].StatusCode)
+		assert.Zero(t, health["ok"].ConsecutiveFailures)
+		assert.True(t, health["ok"].IsHealthy())
+		require.NotNil(t, health["broken"])
+		assert.Equal(t, 3, health["broken"].ConsecutiveFailures)
+		assert.Nil(t, health["deleted"])
+	})
+}
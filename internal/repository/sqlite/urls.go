@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/repository"
 
@@ -37,19 +38,18 @@ func (r *urlsRepository) Ping(ctx context.Context) error {
 }
 
 // GetByLongURL получает URL из базы данных SQLite по длинному URL.
-// Возвращает модель URL или ошибку, если URL не найден.
+// Ссылки со сроком действия, лимитом переходов, паролем, заголовком, страницей предупреждения, особыми настройками перенаправления или переносом пути не участвуют в поиске, так как каждая из них выдается отдельно.
+// Возвращает модель URL или ошибку, если URL не найден, был удален, истек или отключен владельцем.
 func (r *urlsRepository) GetByLongURL(ctx context.Context, longURL string) (*model.URLsModel, error) {
-	var urls model.URLsModel
-
-	query := `SELECT id, short_url, long_url, created_at, updated_at FROM urls WHERE long_url = ?`
-
-	err := r.db.QueryRowContext(ctx, query, longURL).Scan(
-		&urls.ID,
-		&urls.ShortURL,
-		&urls.LongURL,
-		&urls.CreatedAt,
-		&urls.UpdatedAt,
-	)
+	query := `
+		SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants
+		FROM urls
+		WHERE long_url = ? AND is_deleted = 0 AND is_expired = 0
+		AND expires_at IS NULL AND max_clicks IS NULL AND password_hash IS NULL AND is_disabled = 0
+		AND title = '' AND show_interstitial = 0 AND redirect_code = 307 AND query_passthrough = '' AND is_prefix = 0 AND routing_rules = '' AND variants = ''
+	`
+
+	url, err := scanURL(r.db.QueryRowContext(ctx, query, longURL))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrURLNotFound
@@ -57,23 +57,15 @@ func (r *urlsRepository) GetByLongURL(ctx context.Context, longURL string) (*mod
 		return nil, err
 	}
 
-	return &urls, nil
+	return url, nil
 }
 
 // GetByShortURL получает URL из базы данных SQLite по короткому идентификатору.
 // Возвращает модель URL или ошибку, если URL не найден.
 func (r *urlsRepository) GetByShortURL(ctx context.Context, shortURL string) (*model.URLsModel, error) {
-	var urls model.URLsModel
-
-	query := `SELECT id, short_url, long_url, created_at, updated_at FROM urls WHERE short_url = ?`
+	query := `SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants FROM urls WHERE short_url = ?`
 
-	err := r.db.QueryRowContext(ctx, query, shortURL).Scan(
-		&urls.ID,
-		&urls.ShortURL,
-		&urls.LongURL,
-		&urls.CreatedAt,
-		&urls.UpdatedAt,
-	)
+	url, err := scanURL(r.db.QueryRowContext(ctx, query, shortURL))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrURLNotFound
@@ -81,7 +73,7 @@ func (r *urlsRepository) GetByShortURL(ctx context.Context, shortURL string) (*m
 		return nil, err
 	}
 
-	return &urls, nil
+	return url, nil
 }
 
 // Create создает новую запись URL в базе данных SQLite.
@@ -91,10 +83,13 @@ func (r *urlsRepository) Create(ctx context.Context, url *model.URLsModel) error
 		return errors.New("url cannot be nil")
 	}
 
-	query := `INSERT INTO urls (short_url, long_url, created_at, updated_at) VALUES (?, ?, datetime('now'), datetime('now'))`
+	query := `INSERT INTO urls (short_url, long_url, created_at, updated_at, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants) VALUES (?, ?, datetime('now'), datetime('now'), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := r.db.ExecContext(ctx, query, url.ShortURL, url.LongURL)
+	_, err := r.db.ExecContext(ctx, query, url.ShortURL, url.LongURL, utcTime(url.ExpiresAt), url.MaxClicks, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants)
 	if err != nil {
+		if repository.IsShortURLExistsError(err) {
+			return repository.ErrShortURLExists
+		}
 		return err
 	}
 
@@ -102,6 +97,8 @@ func (r *urlsRepository) Create(ctx context.Context, url *model.URLsModel) error
 }
 
 // CreateBatch создает несколько записей URL в базе данных SQLite в одной транзакции.
+// Повторная вставка того же короткого URL для того же длинного URL игнорируется,
+// а если короткий URL уже занят другим длинным URL, возвращается ErrShortURLExists.
 // Принимает список моделей URL и возвращает ошибку, если создание не удалось.
 func (r *urlsRepository) CreateBatch(ctx context.Context, urls []*model.URLsModel) error {
 	if len(urls) == 0 {
@@ -123,7 +120,7 @@ func (r *urlsRepository) CreateBatch(ctx context.Context, urls []*model.URLsMode
 	}()
 
 	// Подготавливаем batch insert запрос
-	query := `INSERT OR IGNORE INTO urls (id, short_url, long_url, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`
+	query := `INSERT OR IGNORE INTO urls (id, short_url, long_url, created_at, updated_at, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	stmt, err := tx.PrepareContext(ctx, query)
 	if err != nil {
@@ -136,26 +133,66 @@ func (r *urlsRepository) CreateBatch(ctx context.Context, urls []*model.URLsMode
 		}
 	}(stmt)
 
+	existingQuery := `SELECT long_url FROM urls WHERE short_url = ?`
+
 	// Выполняем batch вставку
 	for _, url := range urls {
 		if url == nil {
 			continue
 		}
 
-		_, err := stmt.ExecContext(ctx, url.ID, url.ShortURL, url.LongURL, url.CreatedAt, url.UpdatedAt)
+		// Нулевой ID означает, что идентификатор должна назначить база данных
+		var id any
+		if url.ID != 0 {
+			id = url.ID
+		}
+
+		var result sql.Result
+		result, err = stmt.ExecContext(ctx, id, url.ShortURL, url.LongURL, url.CreatedAt, url.UpdatedAt, utcTime(url.ExpiresAt), url.MaxClicks, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants)
+		if err != nil {
+			return err
+		}
+
+		var affected int64
+		affected, err = result.RowsAffected()
 		if err != nil {
 			return err
 		}
+		if affected > 0 {
+			continue
+		}
+
+		// Запись проигнорирована: допустимо, только если короткий URL указывает на тот же длинный URL
+		var existingLongURL string
+		err = tx.QueryRowContext(ctx, existingQuery, url.ShortURL).Scan(&existingLongURL)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Конфликт по идентификатору записи, а не по короткому URL
+			err = nil
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if existingLongURL != url.LongURL {
+			err = repository.ErrShortURLExists
+			return err
+		}
 	}
 
 	// Подтверждаем транзакцию
 	return tx.Commit()
 }
 
-// GetAll получает список URL из базы данных SQLite с пагинацией.
+// GetAll получает список действующих URL из базы данных SQLite с пагинацией.
 // Принимает лимит и смещение для пагинации, возвращает список моделей URL или ошибку.
 func (r *urlsRepository) GetAll(ctx context.Context, limit, offset int) ([]*model.URLsModel, error) {
-	query := `SELECT id, short_url, long_url, created_at, updated_at FROM urls ORDER BY created_at DESC LIMIT ? OFFSET ?`
+	query := `
+		SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants
+		FROM urls
+		WHERE is_deleted = 0 AND is_expired = 0
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`
 
 	rows, err := r.db.QueryContext(ctx, query, limit, offset)
 	if err != nil {
@@ -170,18 +207,11 @@ func (r *urlsRepository) GetAll(ctx context.Context, limit, offset int) ([]*mode
 
 	var urls []*model.URLsModel
 	for rows.Next() {
-		var url model.URLsModel
-		err := rows.Scan(
-			&url.ID,
-			&url.ShortURL,
-			&url.LongURL,
-			&url.CreatedAt,
-			&url.UpdatedAt,
-		)
+		url, err := scanURL(rows)
 		if err != nil {
 			return nil, err
 		}
-		urls = append(urls, &url)
+		urls = append(urls, url)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -191,10 +221,10 @@ func (r *urlsRepository) GetAll(ctx context.Context, limit, offset int) ([]*mode
 	return urls, nil
 }
 
-// GetTotalCount получает общее количество URL в базе данных SQLite.
+// GetTotalCount получает количество неудаленных и не помеченных как истекшие URL в базе данных SQLite.
 // Возвращает количество записей или ошибку, если запрос не удался.
 func (r *urlsRepository) GetTotalCount(ctx context.Context) (int64, error) {
-	query := `SELECT COUNT(*) FROM urls`
+	query := `SELECT COUNT(*) FROM urls WHERE is_deleted = 0 AND is_expired = 0`
 
 	var count int64
 	err := r.db.QueryRowContext(ctx, query).Scan(&count)
@@ -204,3 +234,275 @@ func (r *urlsRepository) GetTotalCount(ctx context.Context) (int64, error) {
 
 	return count, nil
 }
+
+// MarkExpired помечает как истекшие все неудаленные URL, срок действия которых наступил к моменту now.
+// Возвращает количество помеченных записей или ошибку, если обновление не удалось.
+func (r *urlsRepository) MarkExpired(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		UPDATE urls
+		SET is_expired = 1, updated_at = datetime('now')
+		WHERE is_expired = 0 AND is_deleted = 0 AND expires_at IS NOT NULL AND expires_at <= ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, now.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// ConsumeClick атомарно уменьшает количество оставшихся переходов по ссылке с лимитом в SQLite.
+// Условие clicks_left > 0 проверяется в том же UPDATE, поэтому параллельные переходы не превышают лимит.
+// Возвращает новое количество оставшихся переходов или ErrClickLimitReached, если переходов не осталось
+// или ссылка не ограничена по переходам.
+func (r *urlsRepository) ConsumeClick(ctx context.Context, shortURL string) (int64, error) {
+	query := `
+		UPDATE urls
+		SET clicks_left = clicks_left - 1
+		WHERE short_url = ? AND clicks_left > 0
+		RETURNING clicks_left
+	`
+
+	var clicksLeft int64
+	err := r.db.QueryRowContext(ctx, query, shortURL).Scan(&clicksLeft)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repository.ErrClickLimitReached
+		}
+		return 0, err
+	}
+
+	return clicksLeft, nil
+}
+
+// UpdateByUser изменяет ссылку, которой пользователь владеет через user_urls, в базе данных SQLite.
+// Чтение и изменение выполняются в одной транзакции.
+// Возвращает состояние ссылки до и после изменения или ErrURLNotFound,
+// если ссылка не найдена, удалена или принадлежит другому пользователю.
+func (r *urlsRepository) UpdateByUser(
+	ctx context.Context,
+	shortURL, userID string,
+	update model.URLUpdate,
+) (*model.URLsModel, *model.URLsModel, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	selectQuery := `
+		SELECT id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants
+		FROM urls
+		WHERE short_url = ? AND is_deleted = 0
+		AND id IN (
+			SELECT uu.url_id
+			FROM user_urls uu
+			WHERE uu.user_id = ?
+		)
+	`
+
+	var previous *model.URLsModel
+	previous, err = scanURL(tx.QueryRowContext(ctx, selectQuery, shortURL, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, repository.ErrURLNotFound
+		}
+		return nil, nil, err
+	}
+
+	now := time.Now().UTC()
+	updated := previous.WithUpdate(update, now)
+	updated.UpdatedAt = now
+
+	updateQuery := `
+		UPDATE urls
+		SET long_url = ?, expires_at = ?, is_expired = ?, is_disabled = ?, title = ?, show_interstitial = ?, routing_rules = ?, variants = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	_, err = tx.ExecContext(ctx, updateQuery, updated.LongURL, utcTime(updated.ExpiresAt), updated.IsExpired, updated.IsDisabled, updated.Title, updated.ShowInterstitial, updated.RoutingRules, updated.Variants, now, updated.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return previous, updated, nil
+}
+
+// PurgeDeleted окончательно удаляет не более limit ссылок, помеченных удаленными раньше deletedBefore,
+// вместе с их связями в user_urls, журналом и счетчиками переходов и результатами проверки доступности,
+// в базе данных SQLite. Момент удаления определяется по deleted_at, который меняется только при пометке ссылки удаленной.
+// Возвращает количество удаленных ссылок и связей и короткие коды удаленных ссылок.
+func (r *urlsRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (model.PurgeStats, error) {
+	var stats model.PurgeStats
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return stats, err
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// Выбираем пачку в подзапросе, чтобы удалить связи и ссылки одним и тем же набором
+	batchQuery := `
+		SELECT id FROM urls
+		WHERE is_deleted = 1 AND deleted_at < ?
+		ORDER BY id
+		LIMIT ?
+	`
+	before := deletedBefore.UTC().Format(time.DateTime)
+
+	// Коды ссылок пачки возвращаются вызывающему, чтобы сбросить их записи в кэше
+	rows, err := tx.QueryContext(ctx, `SELECT short_url FROM urls WHERE id IN (`+batchQuery+`) ORDER BY id`, before, limit)
+	if err != nil {
+		return stats, err
+	}
+	var shortURLs []string
+	for rows.Next() {
+		var shortURL string
+		if err = rows.Scan(&shortURL); err != nil {
+			_ = rows.Close()
+			return stats, err
+		}
+		shortURLs = append(shortURLs, shortURL)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return stats, err
+	}
+
+	// Внешние ключи в SQLite не включены, поэтому журнал и счетчики переходов и результаты проверки
+	// доступности удаляются явно
+	if _, err = tx.ExecContext(ctx, `DELETE FROM clicks WHERE url_id IN (`+batchQuery+`)`, before, limit); err != nil {
+		return stats, err
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM click_counters WHERE url_id IN (`+batchQuery+`)`, before, limit); err != nil {
+		return stats, err
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM link_health WHERE url_id IN (`+batchQuery+`)`, before, limit); err != nil {
+		return stats, err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM user_urls WHERE url_id IN (`+batchQuery+`)`, before, limit)
+	if err != nil {
+		return stats, err
+	}
+	if stats.UserLinks, err = result.RowsAffected(); err != nil {
+		return stats, err
+	}
+
+	result, err = tx.ExecContext(ctx, `DELETE FROM urls WHERE id IN (`+batchQuery+`)`, before, limit)
+	if err != nil {
+		return stats, err
+	}
+	if stats.URLs, err = result.RowsAffected(); err != nil {
+		return stats, err
+	}
+	stats.ShortURLs = shortURLs
+
+	if err = tx.Commit(); err != nil {
+		return model.PurgeStats{}, err
+	}
+
+	return stats, nil
+}
+
+// rowScanner обобщает *sql.Row и *sql.Rows для чтения одной записи.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanURL читает запись URL, выбранную в порядке колонок
+// id, short_url, long_url, is_deleted, created_at, updated_at, expires_at, is_expired, max_clicks, clicks_left, password_hash, is_disabled, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants.
+func scanURL(row rowScanner) (*model.URLsModel, error) {
+	var url model.URLsModel
+	if err := row.Scan(urlDest(&url)...); err != nil {
+		return nil, err
+	}
+
+	return &url, nil
+}
+
+// scanURLWithClicks читает запись URL, за колонками которой следует число переходов по ссылке.
+func scanURLWithClicks(row rowScanner) (*model.URLsModel, error) {
+	var url model.URLsModel
+	if err := row.Scan(append(urlDest(&url), &url.Clicks)...); err != nil {
+		return nil, err
+	}
+
+	return &url, nil
+}
+
+// scanURLWithHealth читает запись URL с числом переходов и результатом последней проверки доступности
+// адреса назначения (колонки status_code, error, redirect_chain, consecutive_failures и checked_at таблицы
+// link_health после числа переходов). Если ссылка еще не проверялась, checked_at равен NULL и Health остается nil.
+func scanURLWithHealth(row rowScanner) (*model.URLsModel, error) {
+	var (
+		url       model.URLsModel
+		health    model.LinkHealth
+		checkedAt *time.Time
+	)
+	dest := append(urlDest(&url), &url.Clicks,
+		&health.StatusCode, &health.Error, &health.RedirectChain, &health.ConsecutiveFailures, &checkedAt)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if checkedAt != nil {
+		health.CheckedAt = *checkedAt
+		url.Health = &health
+	}
+
+	return &url, nil
+}
+
+// urlDest возвращает адреса полей URL в порядке колонок, ожидаемом scanURL.
+func urlDest(url *model.URLsModel) []any {
+	return []any{
+		&url.ID,
+		&url.ShortURL,
+		&url.LongURL,
+		&url.IsDeleted,
+		&url.CreatedAt,
+		&url.UpdatedAt,
+		&url.ExpiresAt,
+		&url.IsExpired,
+		&url.MaxClicks,
+		&url.ClicksLeft,
+		&url.PasswordHash,
+		&url.IsDisabled,
+		&url.Title,
+		&url.ShowInterstitial,
+		&url.RedirectCode,
+		&url.QueryPassthrough,
+		&url.IsPrefix,
+		&url.RoutingRules,
+		&url.Variants,
+	}
+}
+
+// utcTime приводит необязательное время к UTC перед записью в SQLite.
+// SQLite хранит время строкой, поэтому сравнение значений корректно только в одной временной зоне.
+func utcTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
@@ -3,6 +3,10 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 	"yp-go-short-url-service/internal/model"
@@ -23,6 +27,21 @@ func setupTestDB(t *testing.T) (*sql.DB, func()) {
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		short_url TEXT NOT NULL UNIQUE,
 		long_url TEXT NOT NULL,
+		is_deleted BOOLEAN DEFAULT FALSE,
+		expires_at DATETIME,
+		is_expired BOOLEAN DEFAULT FALSE,
+		max_clicks INTEGER,
+		clicks_left INTEGER,
+		password_hash TEXT,
+		is_disabled BOOLEAN DEFAULT FALSE,
+		title TEXT NOT NULL DEFAULT '',
+		show_interstitial BOOLEAN NOT NULL DEFAULT FALSE,
+		redirect_code INTEGER NOT NULL DEFAULT 307,
+		query_passthrough TEXT NOT NULL DEFAULT '',
+		is_prefix BOOLEAN DEFAULT FALSE,
+		routing_rules TEXT NOT NULL DEFAULT '',
+		variants TEXT NOT NULL DEFAULT '',
+		deleted_at DATETIME,
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 	);`
@@ -117,6 +136,96 @@ func TestURLsRepository_GetByLongURL_NotFound(t *testing.T) {
 	assert.Equal(t, repository.ErrURLNotFound, err)
 }
 
+func TestURLsRepository_PreviewSettings(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewURLsRepository(db)
+	ctx := context.Background()
+
+	err := repo.Create(ctx, &model.URLsModel{
+		ShortURL:         "report1",
+		LongURL:          "https://example.com/report",
+		Title:            "Квартальный отчет",
+		ShowInterstitial: true,
+	})
+	require.NoError(t, err)
+
+	result, err := repo.GetByShortURL(ctx, "report1")
+	require.NoError(t, err)
+	assert.Equal(t, "Квартальный отчет", result.Title)
+	assert.True(t, result.ShowInterstitial)
+
+	// Ссылка с настройками предпросмотра не переиспользуется для того же длинного URL
+	_, err = repo.GetByLongURL(ctx, "https://example.com/report")
+	assert.Equal(t, repository.ErrURLNotFound, err)
+}
+
+func TestURLsRepository_RedirectSettings(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewURLsRepository(db)
+	ctx := context.Background()
+
+	err := repo.Create(ctx, &model.URLsModel{ShortURL: "plain1", LongURL: "https://example.com/plain"})
+	require.NoError(t, err)
+	err = repo.Create(ctx, &model.URLsModel{
+		ShortURL:         "landing1",
+		LongURL:          "https://example.com/landing",
+		RedirectCode:     http.StatusMovedPermanently,
+		QueryPassthrough: model.QueryPassthroughMerge,
+		IsPrefix:         true,
+	})
+	require.NoError(t, err)
+
+	// Ссылка без заданного типа перенаправления сохраняется со статусом по умолчанию
+	result, err := repo.GetByShortURL(ctx, "plain1")
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultRedirectCode, result.RedirectCode)
+	assert.Equal(t, model.QueryPassthroughOff, result.QueryPassthrough)
+	assert.False(t, result.IsPrefix)
+
+	result, err = repo.GetByShortURL(ctx, "landing1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, result.RedirectCode)
+	assert.Equal(t, model.QueryPassthroughMerge, result.QueryPassthrough)
+	assert.True(t, result.IsPrefix)
+
+	// Ссылка с настройками перенаправления не переиспользуется для того же длинного URL
+	_, err = repo.GetByLongURL(ctx, "https://example.com/landing")
+	assert.Equal(t, repository.ErrURLNotFound, err)
+}
+
+func TestURLsRepository_RoutingRules(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewURLsRepository(db)
+	ctx := context.Background()
+
+	rules := model.RoutingRules{
+		{Device: model.DeviceAndroid, Destination: "https://play.google.com/store/apps/details?id=app"},
+		{Languages: []string{"ru"}, CIDRs: []string{"10.0.0.0/8"}, Destination: "https://example.com/ru"},
+	}
+	err := repo.Create(ctx, &model.URLsModel{ShortURL: "app1", LongURL: "https://example.com/app", RoutingRules: rules})
+	require.NoError(t, err)
+	err = repo.Create(ctx, &model.URLsModel{ShortURL: "plain1", LongURL: "https://example.com/plain"})
+	require.NoError(t, err)
+
+	result, err := repo.GetByShortURL(ctx, "app1")
+	require.NoError(t, err)
+	assert.Equal(t, rules, result.RoutingRules)
+
+	result, err = repo.GetByShortURL(ctx, "plain1")
+	require.NoError(t, err)
+	assert.Nil(t, result.RoutingRules)
+
+	// Ссылка с правилами перенаправления не переиспользуется для того же длинного URL
+	_, err = repo.GetByLongURL(ctx, "https://example.com/app")
+	assert.Equal(t, repository.ErrURLNotFound, err)
+}
+
 func TestURLsRepository_GetByShortURL(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -520,7 +629,7 @@ func TestURLsRepository_CreateBatch_DuplicateHandling(t *testing.T) {
 
 	repo := NewURLsRepository(db)
 
-	// Создаем URL с одинаковым short_url
+	// Повтор одной и той же пары short_url/long_url игнорируется
 	urls := []*model.URLsModel{
 		{
 			ID:        1,
@@ -532,7 +641,7 @@ func TestURLsRepository_CreateBatch_DuplicateHandling(t *testing.T) {
 		{
 			ID:        2,
 			ShortURL:  "abc123", // Дубликат
-			LongURL:   "https://example2.com",
+			LongURL:   "https://example1.com",
 			CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
 			UpdatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
 		},
@@ -560,3 +669,194 @@ func TestURLsRepository_CreateBatch_DuplicateHandling(t *testing.T) {
 	assert.Equal(t, "abc123", result.ShortURL)
 	assert.Equal(t, "https://example1.com", result.LongURL)
 }
+
+func TestURLsRepository_CreateBatch_ShortURLConflict(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewURLsRepository(db)
+
+	// Один и тот же short_url для разных длинных URL - конфликт, пакет откатывается целиком
+	urls := []*model.URLsModel{
+		{ShortURL: "q4-report", LongURL: "https://example1.com"},
+		{ShortURL: "q4-report", LongURL: "https://example2.com"},
+	}
+
+	err := repo.CreateBatch(context.Background(), urls)
+	assert.Error(t, err)
+	assert.True(t, repository.IsShortURLExistsError(err))
+
+	count, err := repo.GetTotalCount(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestURLsRepository_Create_ShortURLConflict(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewURLsRepository(db)
+
+	err := repo.Create(context.Background(), &model.URLsModel{ShortURL: "q4-report", LongURL: "https://example1.com"})
+	assert.NoError(t, err)
+
+	err = repo.Create(context.Background(), &model.URLsModel{ShortURL: "q4-report", LongURL: "https://example2.com"})
+	assert.Error(t, err)
+	assert.True(t, repository.IsShortURLExistsError(err))
+	assert.True(t, repository.IsExistsError(err))
+}
+
+func TestURLsRepository_Expiration(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewURLsRepository(db)
+	ctx := context.Background()
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	urls := []*model.URLsModel{
+		{ShortURL: "expired", LongURL: "https://example.com/expired", ExpiresAt: &past},
+		{ShortURL: "active", LongURL: "https://example.com/active", ExpiresAt: &future},
+		{ShortURL: "forever", LongURL: "https://example.com/forever"},
+	}
+	for _, url := range urls {
+		require.NoError(t, repo.Create(ctx, url))
+	}
+
+	// Истекшая ссылка не используется повторно при сокращении того же URL
+	_, err := repo.GetByLongURL(ctx, "https://example.com/expired")
+	assert.ErrorIs(t, err, repository.ErrURLNotFound)
+
+	// Ссылка со сроком действия выдается отдельно, даже пока она действует: иначе повторное сокращение
+	// без срока вернуло бы ссылку, которая скоро перестанет работать
+	_, err = repo.GetByLongURL(ctx, "https://example.com/active")
+	assert.ErrorIs(t, err, repository.ErrURLNotFound)
+
+	forever, err := repo.GetByLongURL(ctx, "https://example.com/forever")
+	require.NoError(t, err)
+	assert.Equal(t, "forever", forever.ShortURL)
+
+	// До пометки истекшая ссылка еще учитывается в статистике
+	count, err := repo.GetTotalCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	// Удаленная ссылка не помечается истекшей, чтобы не сдвигать ее updated_at
+	require.NoError(t, repo.Create(ctx, &model.URLsModel{ShortURL: "deleted", LongURL: "https://example.com/deleted", ExpiresAt: &past}))
+	_, err = db.ExecContext(ctx, `UPDATE urls SET is_deleted = 1, deleted_at = datetime('now') WHERE short_url = 'deleted'`)
+	require.NoError(t, err)
+
+	marked, err := repo.MarkExpired(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked)
+
+	// Повторная пометка не затрагивает уже помеченные записи
+	marked, err = repo.MarkExpired(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), marked)
+
+	count, err = repo.GetTotalCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	expired, err := repo.GetByShortURL(ctx, "expired")
+	require.NoError(t, err)
+	assert.True(t, expired.IsExpired)
+	assert.True(t, expired.ExpiredAt(time.Now()))
+}
+
+func TestURLsRepository_ConsumeClick(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewURLsRepository(db)
+	ctx := context.Background()
+
+	maxClicks := int64(2)
+	require.NoError(t, repo.Create(ctx, &model.URLsModel{ShortURL: "invite1", LongURL: "https://example.com/invite", MaxClicks: &maxClicks}))
+	require.NoError(t, repo.Create(ctx, &model.URLsModel{ShortURL: "plain1", LongURL: "https://example.com/invite"}))
+
+	url, err := repo.GetByShortURL(ctx, "invite1")
+	require.NoError(t, err)
+	require.NotNil(t, url.MaxClicks)
+	require.NotNil(t, url.ClicksLeft)
+	assert.Equal(t, int64(2), *url.MaxClicks)
+	assert.Equal(t, int64(2), *url.ClicksLeft)
+
+	// Ссылка с лимитом не используется повторно при сокращении того же URL
+	existing, err := repo.GetByLongURL(ctx, "https://example.com/invite")
+	require.NoError(t, err)
+	assert.Equal(t, "plain1", existing.ShortURL)
+
+	clicksLeft, err := repo.ConsumeClick(ctx, "invite1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), clicksLeft)
+
+	clicksLeft, err = repo.ConsumeClick(ctx, "invite1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), clicksLeft)
+
+	_, err = repo.ConsumeClick(ctx, "invite1")
+	assert.ErrorIs(t, err, repository.ErrClickLimitReached)
+
+	// Ссылка без лимита не расходует переходы
+	_, err = repo.ConsumeClick(ctx, "plain1")
+	assert.ErrorIs(t, err, repository.ErrClickLimitReached)
+}
+
+func TestURLsRepository_ConsumeClick_Concurrent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "clicks.db")
+	db, err := sql.Open("sqlite3", dbPath+"?_busy_timeout=5000")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+	CREATE TABLE urls (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		short_url TEXT NOT NULL UNIQUE,
+		long_url TEXT NOT NULL,
+		is_deleted BOOLEAN DEFAULT FALSE,
+		expires_at DATETIME,
+		is_expired BOOLEAN DEFAULT FALSE,
+		max_clicks INTEGER,
+		clicks_left INTEGER,
+		password_hash TEXT,
+		is_disabled BOOLEAN DEFAULT FALSE,
+		title TEXT NOT NULL DEFAULT '',
+		show_interstitial BOOLEAN NOT NULL DEFAULT FALSE,
+		redirect_code INTEGER NOT NULL DEFAULT 307,
+		query_passthrough TEXT NOT NULL DEFAULT '',
+		is_prefix BOOLEAN DEFAULT FALSE,
+		routing_rules TEXT NOT NULL DEFAULT '',
+		variants TEXT NOT NULL DEFAULT '',
+		deleted_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`)
+	require.NoError(t, err)
+
+	repo := NewURLsRepository(db)
+	ctx := context.Background()
+
+	maxClicks := int64(5)
+	require.NoError(t, repo.Create(ctx, &model.URLsModel{ShortURL: "invite", LongURL: "https://example.com", MaxClicks: &maxClicks}))
+
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int64
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.ConsumeClick(ctx, "invite"); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Параллельные переходы не превышают лимит
+	assert.Equal(t, maxClicks, succeeded.Load())
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/repository"
@@ -12,17 +13,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// maxShortURLsPerStatement - максимальное количество коротких кодов в одном запросе.
+// Старые сборки SQLite ограничивают число параметров запроса 999 (SQLITE_MAX_VARIABLE_NUMBER).
+const maxShortURLsPerStatement = 500
+
 type userURLsRepository struct {
 	db *sql.DB
 }
 
-// DeleteURLsWithUser помечает указанные URL как удаленные для конкретного пользователя в SQLite.
-// Принимает список коротких URL и идентификатор пользователя, возвращает ошибку, если удаление не удалось.
-func (r *userURLsRepository) DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
-	//TODO implement me
-	panic("implement me")
-}
-
 // NewUserURLsRepository создает новый репозиторий для работы с URL пользователей в SQLite базе данных.
 // Принимает соединение с SQLite и возвращает реализацию интерфейса UserURLsRepository.
 func NewUserURLsRepository(db *sql.DB) repository.UserURLsRepository {
@@ -30,12 +28,16 @@ func NewUserURLsRepository(db *sql.DB) repository.UserURLsRepository {
 }
 
 // GetByUserID получает все URL, принадлежащие указанному пользователю, из базы данных SQLite.
+// Ссылки содержат число переходов и результат последней проверки доступности адреса назначения.
 // Возвращает список моделей URL, отсортированных по дате создания (от новых к старым), или ошибку.
 func (r *userURLsRepository) GetByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error) {
 	query := `
-		SELECT u.id, u.short_url, u.long_url, u.created_at, u.updated_at
+		SELECT u.id, u.short_url, u.long_url, u.is_deleted, u.created_at, u.updated_at, u.expires_at, u.is_expired, u.max_clicks, u.clicks_left, u.password_hash, u.is_disabled, u.title, u.show_interstitial, u.redirect_code, u.query_passthrough, u.is_prefix, u.routing_rules, u.variants, COALESCE(cc.clicks, 0),
+			COALESCE(lh.status_code, 0), COALESCE(lh.error, ''), COALESCE(lh.redirect_chain, ''), COALESCE(lh.consecutive_failures, 0), lh.checked_at
 		FROM urls u
 		INNER JOIN user_urls uu ON u.id = uu.url_id
+		LEFT JOIN click_counters cc ON cc.url_id = u.id
+		LEFT JOIN link_health lh ON lh.url_id = u.id
 		WHERE uu.user_id = ?
 		ORDER BY uu.created_at DESC
 	`
@@ -53,18 +55,11 @@ func (r *userURLsRepository) GetByUserID(ctx context.Context, userID string) ([]
 
 	var urls []*model.URLsModel
 	for rows.Next() {
-		var url model.URLsModel
-		err := rows.Scan(
-			&url.ID,
-			&url.ShortURL,
-			&url.LongURL,
-			&url.CreatedAt,
-			&url.UpdatedAt,
-		)
+		url, err := scanURLWithHealth(rows)
 		if err != nil {
 			return nil, err
 		}
-		urls = append(urls, &url)
+		urls = append(urls, url)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -74,6 +69,70 @@ func (r *userURLsRepository) GetByUserID(ctx context.Context, userID string) ([]
 	return urls, nil
 }
 
+// GetDeletedByUserID получает удаленные ссылки указанного пользователя (корзину).
+// Возвращает список моделей URL, отсортированных по времени удаления (от новых к старым), или ошибку.
+func (r *userURLsRepository) GetDeletedByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error) {
+	query := `
+		SELECT u.id, u.short_url, u.long_url, u.is_deleted, u.created_at, u.updated_at, u.expires_at, u.is_expired, u.max_clicks, u.clicks_left, u.password_hash, u.is_disabled, u.title, u.show_interstitial, u.redirect_code, u.query_passthrough, u.is_prefix, u.routing_rules, u.variants, COALESCE(cc.clicks, 0)
+		FROM urls u
+		INNER JOIN user_urls uu ON u.id = uu.url_id
+		LEFT JOIN click_counters cc ON cc.url_id = u.id
+		WHERE uu.user_id = ? AND u.is_deleted = 1
+		ORDER BY u.deleted_at DESC, u.id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+
+	var urls []*model.URLsModel
+	for rows.Next() {
+		url, err := scanURLWithClicks(rows)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, url)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return urls, nil
+}
+
+// GetByUserIDAndLongURL получает действующую (неудаленную, неистекшую, неотключенную, бессрочную, не ограниченную по переходам, не защищенную паролем, без настроек предпросмотра, перенаправления и переноса пути) ссылку пользователя на указанный длинный URL из базы данных SQLite.
+// Возвращает модель URL или ErrURLNotFound, если у пользователя нет такой ссылки.
+func (r *userURLsRepository) GetByUserIDAndLongURL(ctx context.Context, userID, longURL string) (*model.URLsModel, error) {
+	query := `
+		SELECT u.id, u.short_url, u.long_url, u.is_deleted, u.created_at, u.updated_at, u.expires_at, u.is_expired, u.max_clicks, u.clicks_left, u.password_hash, u.is_disabled, u.title, u.show_interstitial, u.redirect_code, u.query_passthrough, u.is_prefix, u.routing_rules, u.variants
+		FROM urls u
+		INNER JOIN user_urls uu ON u.id = uu.url_id
+		WHERE uu.user_id = ? AND u.long_url = ? AND u.is_deleted = 0 AND u.is_expired = 0
+		AND u.expires_at IS NULL AND u.max_clicks IS NULL AND u.password_hash IS NULL AND u.is_disabled = 0
+		AND u.title = '' AND u.show_interstitial = 0 AND u.redirect_code = 307 AND u.query_passthrough = '' AND u.is_prefix = 0 AND u.routing_rules = '' AND u.variants = ''
+		ORDER BY u.id
+		LIMIT 1
+	`
+
+	url, err := scanURL(r.db.QueryRowContext(ctx, query, userID, longURL))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrURLNotFound
+		}
+		return nil, err
+	}
+
+	return url, nil
+}
+
 // CreateURLWithUser создает новую запись URL и связывает ее с пользователем в базе данных SQLite.
 // Принимает модель URL и идентификатор пользователя, возвращает ошибку, если создание не удалось.
 func (r *userURLsRepository) CreateURLWithUser(ctx context.Context, url *model.URLsModel, userID string) error {
@@ -102,10 +161,13 @@ func (r *userURLsRepository) CreateURLWithUser(ctx context.Context, url *model.U
 	}()
 
 	// 1. Создаем URL
-	urlQuery := `INSERT INTO urls (short_url, long_url, created_at, updated_at) VALUES (?, ?, datetime('now'), datetime('now'))`
-	result, err := tx.ExecContext(ctx, urlQuery, url.ShortURL, url.LongURL)
+	urlQuery := `INSERT INTO urls (short_url, long_url, created_at, updated_at, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants) VALUES (?, ?, datetime('now'), datetime('now'), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := tx.ExecContext(ctx, urlQuery, url.ShortURL, url.LongURL, utcTime(url.ExpiresAt), url.MaxClicks, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants)
 	if err != nil {
 		// Проверяем на дублирование записи в SQLite
+		if repository.IsShortURLExistsError(err) {
+			return repository.ErrShortURLExists
+		}
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
 			return repository.ErrURLExists
 		}
@@ -167,8 +229,8 @@ func (r *userURLsRepository) CreateMultipleURLsWithUser(ctx context.Context, url
 	}()
 
 	// Подготавливаем batch запросы
-	urlQuery := `INSERT INTO urls (short_url, long_url, created_at, updated_at) VALUES (?, ?, datetime('now'), datetime('now'))`
-	userURLQuery := `INSERT INTO user_urls (user_id, url_id) VALUES (?, ?)`
+	urlQuery := `INSERT INTO urls (short_url, long_url, created_at, updated_at, expires_at, max_clicks, clicks_left, password_hash, title, show_interstitial, redirect_code, query_passthrough, is_prefix, routing_rules, variants) VALUES (?, ?, datetime('now'), datetime('now'), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	userURLQuery := `INSERT INTO user_urls (id, user_id, url_id) VALUES (?, ?, ?)`
 
 	// Выполняем batch операцию
 	for _, url := range urls {
@@ -177,9 +239,13 @@ func (r *userURLsRepository) CreateMultipleURLsWithUser(ctx context.Context, url
 		}
 
 		// 1. Создаем URL
-		result, err := tx.ExecContext(ctx, urlQuery, url.ShortURL, url.LongURL)
+		var result sql.Result
+		result, err = tx.ExecContext(ctx, urlQuery, url.ShortURL, url.LongURL, utcTime(url.ExpiresAt), url.MaxClicks, url.MaxClicks, url.PasswordHash, url.Title, url.ShowInterstitial, url.RedirectStatus(), url.QueryPassthrough, url.IsPrefix, url.RoutingRules, url.Variants)
 		if err != nil {
 			// Проверяем на дублирование записи в SQLite
+			if repository.IsShortURLExistsError(err) {
+				return repository.ErrShortURLExists
+			}
 			if strings.Contains(err.Error(), "UNIQUE constraint failed") {
 				return repository.ErrURLExists
 			}
@@ -187,14 +253,15 @@ func (r *userURLsRepository) CreateMultipleURLsWithUser(ctx context.Context, url
 		}
 
 		// Получаем ID созданного URL
-		urlID, err := result.LastInsertId()
+		var urlID int64
+		urlID, err = result.LastInsertId()
 		if err != nil {
 			return fmt.Errorf("failed to get last insert id for url %s: %w", url.ShortURL, err)
 		}
 		url.ID = uint(urlID)
 
 		// 2. Связываем с пользователем
-		_, err = tx.ExecContext(ctx, userURLQuery, userID, url.ID)
+		_, err = tx.ExecContext(ctx, userURLQuery, uuid.New().String(), userID, url.ID)
 		if err != nil {
 			// Проверяем на дублирование записи
 			if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -206,3 +273,258 @@ func (r *userURLsRepository) CreateMultipleURLsWithUser(ctx context.Context, url
 
 	return tx.Commit()
 }
+
+// DeleteURLWithUser помечает удаленной одну ссылку пользователя.
+// Проверка и удаление выполняются в одной транзакции. Возвращает ErrURLNotFound, если ссылка не найдена,
+// ErrURLNotOwned, если она принадлежит другому пользователю, и ErrURLAlreadyDeleted, если она уже удалена.
+func (r *userURLsRepository) DeleteURLWithUser(ctx context.Context, shortURL, userID string) error {
+	if userID == "" {
+		return errors.New("userID cannot be empty")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Отложенный rollback (выполнится только если не будет commit)
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				fmt.Printf("rollback failed: %v\n", rollbackErr)
+			}
+		}
+	}()
+
+	selectQuery := `
+		SELECT u.id, u.is_deleted, EXISTS (
+			SELECT 1 FROM user_urls uu WHERE uu.url_id = u.id AND uu.user_id = ?
+		)
+		FROM urls u
+		WHERE u.short_url = ?
+	`
+
+	var (
+		urlID     int64
+		isDeleted bool
+		isOwned   bool
+	)
+	err = tx.QueryRowContext(ctx, selectQuery, userID, shortURL).Scan(&urlID, &isDeleted, &isOwned)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrURLNotFound
+		}
+		return fmt.Errorf("failed to get URL: %w", err)
+	}
+
+	switch {
+	case !isOwned:
+		err = repository.ErrURLNotOwned
+		return err
+	case isDeleted:
+		err = repository.ErrURLAlreadyDeleted
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE urls SET is_deleted = 1, deleted_at = datetime('now'), updated_at = datetime('now') WHERE id = ?`, urlID)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete URL: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteURLsWithUser помечает указанные URL как удаленные для конкретного пользователя в SQLite.
+// Удаляются только ссылки, которыми пользователь владеет через user_urls; чужие, неизвестные и уже удаленные
+// коды не изменяются. Принимает список коротких URL и идентификатор пользователя, возвращает результат
+// для каждого кода или ошибку, если удаление не удалось.
+func (r *userURLsRepository) DeleteURLsWithUser(
+	ctx context.Context,
+	shortURLs []string,
+	userID string,
+) (map[string]model.DeleteOutcome, error) {
+	if userID == "" {
+		return nil, errors.New("userID cannot be empty")
+	}
+	if len(shortURLs) == 0 {
+		return map[string]model.DeleteOutcome{}, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Отложенный rollback (выполнится только если не будет commit)
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				fmt.Printf("rollback failed: %v\n", rollbackErr)
+			}
+		}
+	}()
+
+	outcomes := make(map[string]model.DeleteOutcome, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		outcomes[shortURL] = model.DeleteOutcomeNotFound
+	}
+
+	// Длинный список кодов обрабатывается частями, чтобы не превысить лимит параметров запроса
+	for chunk := range slices.Chunk(shortURLs, maxShortURLsPerStatement) {
+		if err = deleteURLsChunk(ctx, tx, chunk, userID, outcomes); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return outcomes, nil
+}
+
+// deleteURLsChunk помечает удаленными ссылки пользователя из одной части списка
+// и записывает в outcomes результат для найденных кодов.
+func deleteURLsChunk(
+	ctx context.Context,
+	tx *sql.Tx,
+	shortURLs []string,
+	userID string,
+	outcomes map[string]model.DeleteOutcome,
+) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(shortURLs)), ",")
+	shortURLArgs := make([]any, 0, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		shortURLArgs = append(shortURLArgs, shortURL)
+	}
+
+	// Определяем, какие коды существуют и какие из них принадлежат пользователю
+	ownershipQuery := fmt.Sprintf(`
+		SELECT u.short_url, EXISTS (
+			SELECT 1 FROM user_urls uu WHERE uu.url_id = u.id AND uu.user_id = ?
+		)
+		FROM urls u
+		WHERE u.short_url IN (%s)
+	`, placeholders)
+
+	rows, err := tx.QueryContext(ctx, ownershipQuery, append([]any{userID}, shortURLArgs...)...)
+	if err != nil {
+		return fmt.Errorf("failed to check URLs ownership: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			shortURL string
+			isOwned  bool
+		)
+		if err := rows.Scan(&shortURL, &isOwned); err != nil {
+			return fmt.Errorf("failed to scan URL ownership: %w", err)
+		}
+		// Ссылка пользователя считается удаленной ранее, пока UPDATE ниже не изменит ее
+		if isOwned {
+			outcomes[shortURL] = model.DeleteOutcomeAlreadyDeleted
+		} else {
+			outcomes[shortURL] = model.DeleteOutcomeNotOwned
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to check URLs ownership: %w", err)
+	}
+	_ = rows.Close()
+
+	query := fmt.Sprintf(`
+		UPDATE urls
+		SET is_deleted = 1, deleted_at = datetime('now'), updated_at = datetime('now')
+		WHERE short_url IN (%s) AND is_deleted = 0
+		AND id IN (
+			SELECT uu.url_id
+			FROM user_urls uu
+			WHERE uu.user_id = ?
+		)
+		RETURNING short_url
+	`, placeholders)
+
+	deleted, err := tx.QueryContext(ctx, query, append(shortURLArgs, userID)...)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete URLs: %w", err)
+	}
+	defer deleted.Close()
+
+	for deleted.Next() {
+		var shortURL string
+		if err := deleted.Scan(&shortURL); err != nil {
+			return fmt.Errorf("failed to scan deleted URL: %w", err)
+		}
+		outcomes[shortURL] = model.DeleteOutcomeDeleted
+	}
+	if err := deleted.Err(); err != nil {
+		return fmt.Errorf("failed to soft delete URLs: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreURLsWithUser восстанавливает указанные удаленные URL конкретного пользователя.
+// Восстанавливаются только ссылки, которыми пользователь владеет через user_urls; чужие, неизвестные
+// и неудаленные коды игнорируются. Возвращает ошибку, если восстановление не удалось.
+func (r *userURLsRepository) RestoreURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
+	if userID == "" {
+		return errors.New("userID cannot be empty")
+	}
+	if len(shortURLs) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Отложенный rollback (выполнится только если не будет commit)
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				fmt.Printf("rollback failed: %v\n", rollbackErr)
+			}
+		}
+	}()
+
+	// Длинный список кодов обрабатывается частями, чтобы не превысить лимит параметров запроса
+	for chunk := range slices.Chunk(shortURLs, maxShortURLsPerStatement) {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(chunk)), ",")
+		query := fmt.Sprintf(`
+			UPDATE urls
+			SET is_deleted = 0, deleted_at = NULL, updated_at = datetime('now')
+			WHERE short_url IN (%s) AND is_deleted = 1
+			AND id IN (
+				SELECT uu.url_id
+				FROM user_urls uu
+				WHERE uu.user_id = ?
+			)
+		`, placeholders)
+
+		args := make([]any, 0, len(chunk)+1)
+		for _, shortURL := range chunk {
+			args = append(args, shortURL)
+		}
+		args = append(args, userID)
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to restore URLs: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -3,7 +3,9 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"testing"
+	"time"
 	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/repository"
 
@@ -203,6 +205,425 @@ func TestUserURLsRepository_CreateMultipleURLsWithUser(t *testing.T) {
 	assert.Contains(t, err.Error(), "userID cannot be empty")
 }
 
+func TestUserURLsRepository_GetByUserIDAndLongURL(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	repo := NewUserURLsRepository(db)
+	ctx := context.Background()
+
+	for _, userID := range []string{"user-a", "user-b"} {
+		_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`,
+			userID, userID, false)
+		require.NoError(t, err)
+	}
+
+	// Оба пользователя сокращают один и тот же длинный URL
+	longURL := "https://example.com/shared"
+	require.NoError(t, repo.CreateURLWithUser(ctx, &model.URLsModel{ShortURL: "userA1", LongURL: longURL}, "user-a"))
+	require.NoError(t, repo.CreateURLWithUser(ctx, &model.URLsModel{ShortURL: "userB1", LongURL: longURL}, "user-b"))
+
+	url, err := repo.GetByUserIDAndLongURL(ctx, "user-a", longURL)
+	require.NoError(t, err)
+	assert.Equal(t, "userA1", url.ShortURL)
+
+	url, err = repo.GetByUserIDAndLongURL(ctx, "user-b", longURL)
+	require.NoError(t, err)
+	assert.Equal(t, "userB1", url.ShortURL)
+
+	_, err = repo.GetByUserIDAndLongURL(ctx, "user-c", longURL)
+	assert.ErrorIs(t, err, repository.ErrURLNotFound)
+
+	// Удаленная ссылка не переиспользуется
+	_, err = repo.DeleteURLsWithUser(ctx, []string{"userA1"}, "user-a")
+	require.NoError(t, err)
+	_, err = repo.GetByUserIDAndLongURL(ctx, "user-a", longURL)
+	assert.ErrorIs(t, err, repository.ErrURLNotFound)
+}
+
+func TestUserURLsRepository_DeleteURLsWithUser(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	repo := NewUserURLsRepository(db)
+	ctx := context.Background()
+
+	for _, userID := range []string{"owner", "stranger"} {
+		_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`,
+			userID, userID, false)
+		require.NoError(t, err)
+	}
+
+	urls := []*model.URLsModel{
+		{ShortURL: "own1", LongURL: "https://example.com/own1"},
+		{ShortURL: "own2", LongURL: "https://example.com/own2"},
+	}
+	require.NoError(t, repo.CreateMultipleURLsWithUser(ctx, urls, "owner"))
+	require.NoError(t, repo.CreateURLWithUser(ctx, &model.URLsModel{ShortURL: "alien", LongURL: "https://example.com/alien"}, "stranger"))
+
+	// Чужая ссылка не удаляется, даже если передана в запросе
+	outcomes, err := repo.DeleteURLsWithUser(ctx, []string{"own1", "alien", "unknown"}, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]model.DeleteOutcome{
+		"own1":    model.DeleteOutcomeDeleted,
+		"alien":   model.DeleteOutcomeNotOwned,
+		"unknown": model.DeleteOutcomeNotFound,
+	}, outcomes)
+
+	isDeleted := func(shortURL string) bool {
+		var deleted bool
+		err := db.QueryRowContext(ctx, `SELECT is_deleted FROM urls WHERE short_url = ?`, shortURL).Scan(&deleted)
+		require.NoError(t, err)
+		return deleted
+	}
+
+	assert.True(t, isDeleted("own1"))
+	assert.False(t, isDeleted("own2"))
+	assert.False(t, isDeleted("alien"))
+
+	// Уже удаленная ссылка не изменяется и отличается от удаленной этим запросом
+	outcomes, err = repo.DeleteURLsWithUser(ctx, []string{"own1", "own2"}, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]model.DeleteOutcome{
+		"own1": model.DeleteOutcomeAlreadyDeleted,
+		"own2": model.DeleteOutcomeDeleted,
+	}, outcomes)
+
+	// Пустой список ничего не делает
+	outcomes, err = repo.DeleteURLsWithUser(ctx, nil, "owner")
+	assert.NoError(t, err)
+	assert.Empty(t, outcomes)
+
+	// Валидация
+	_, err = repo.DeleteURLsWithUser(ctx, []string{"own2"}, "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "userID cannot be empty")
+}
+
+func TestUserURLsRepository_DeleteURLsWithUser_Chunked(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	repo := NewUserURLsRepository(db)
+	ctx := context.Background()
+
+	_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`, "owner", "owner", false)
+	require.NoError(t, err)
+
+	// Список длиннее одного запроса обрабатывается частями в одной транзакции
+	total := 2*maxShortURLsPerStatement + 1
+	shortURLs := make([]string, 0, total)
+	urls := make([]*model.URLsModel, 0, total)
+	for i := range total {
+		shortURL := fmt.Sprintf("code%d", i)
+		shortURLs = append(shortURLs, shortURL)
+		urls = append(urls, &model.URLsModel{ShortURL: shortURL, LongURL: "https://example.com/" + shortURL})
+	}
+	require.NoError(t, repo.CreateMultipleURLsWithUser(ctx, urls, "owner"))
+
+	outcomes, err := repo.DeleteURLsWithUser(ctx, append(shortURLs, "unknown"), "owner")
+	require.NoError(t, err)
+	assert.Len(t, outcomes, total+1)
+	assert.Equal(t, model.DeleteOutcomeDeleted, outcomes[shortURLs[total-1]])
+	assert.Equal(t, model.DeleteOutcomeNotFound, outcomes["unknown"])
+
+	countDeleted := func() int {
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM urls WHERE is_deleted = 1`).Scan(&count))
+		return count
+	}
+	assert.Equal(t, total, countDeleted())
+
+	require.NoError(t, repo.RestoreURLsWithUser(ctx, shortURLs, "owner"))
+	assert.Equal(t, 0, countDeleted())
+}
+
+func TestUserURLsRepository_DeleteURLWithUser(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	repo := NewUserURLsRepository(db)
+	ctx := context.Background()
+
+	for _, userID := range []string{"owner", "stranger"} {
+		_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`,
+			userID, userID, false)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, repo.CreateURLWithUser(ctx, &model.URLsModel{ShortURL: "own1", LongURL: "https://example.com/own1"}, "owner"))
+
+	// Чужая ссылка не удаляется
+	err := repo.DeleteURLWithUser(ctx, "own1", "stranger")
+	assert.ErrorIs(t, err, repository.ErrURLNotOwned)
+
+	require.NoError(t, repo.DeleteURLWithUser(ctx, "own1", "owner"))
+
+	trash, err := repo.GetDeletedByUserID(ctx, "owner")
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, "own1", trash[0].ShortURL)
+
+	// Повторное удаление
+	err = repo.DeleteURLWithUser(ctx, "own1", "owner")
+	assert.ErrorIs(t, err, repository.ErrURLAlreadyDeleted)
+
+	err = repo.DeleteURLWithUser(ctx, "unknown", "owner")
+	assert.ErrorIs(t, err, repository.ErrURLNotFound)
+
+	// Валидация
+	err = repo.DeleteURLWithUser(ctx, "own1", "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "userID cannot be empty")
+}
+
+func TestUserURLsRepository_TrashAndRestore(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	repo := NewUserURLsRepository(db)
+	ctx := context.Background()
+
+	for _, userID := range []string{"owner", "stranger"} {
+		_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`,
+			userID, userID, false)
+		require.NoError(t, err)
+	}
+
+	urls := []*model.URLsModel{
+		{ShortURL: "own1", LongURL: "https://example.com/own1"},
+		{ShortURL: "own2", LongURL: "https://example.com/own2"},
+	}
+	require.NoError(t, repo.CreateMultipleURLsWithUser(ctx, urls, "owner"))
+	require.NoError(t, repo.CreateURLWithUser(ctx, &model.URLsModel{ShortURL: "alien", LongURL: "https://example.com/alien"}, "stranger"))
+	_, err := repo.DeleteURLsWithUser(ctx, []string{"own1", "own2"}, "owner")
+	require.NoError(t, err)
+	_, err = repo.DeleteURLsWithUser(ctx, []string{"alien"}, "stranger")
+	require.NoError(t, err)
+
+	// В корзине только удаленные ссылки самого пользователя
+	trash, err := repo.GetDeletedByUserID(ctx, "owner")
+	require.NoError(t, err)
+	require.Len(t, trash, 2)
+	for _, url := range trash {
+		assert.True(t, url.IsDeleted)
+		assert.NotEqual(t, "alien", url.ShortURL)
+	}
+
+	// Чужая ссылка не восстанавливается, даже если передана в запросе
+	require.NoError(t, repo.RestoreURLsWithUser(ctx, []string{"own1", "alien", "unknown"}, "owner"))
+
+	trash, err = repo.GetDeletedByUserID(ctx, "owner")
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, "own2", trash[0].ShortURL)
+
+	restored, err := repo.GetByUserIDAndLongURL(ctx, "owner", "https://example.com/own1")
+	require.NoError(t, err)
+	assert.Equal(t, "own1", restored.ShortURL)
+
+	strangerTrash, err := repo.GetDeletedByUserID(ctx, "stranger")
+	require.NoError(t, err)
+	require.Len(t, strangerTrash, 1)
+	assert.Equal(t, "alien", strangerTrash[0].ShortURL)
+
+	// Пустой список ничего не делает
+	assert.NoError(t, repo.RestoreURLsWithUser(ctx, nil, "owner"))
+
+	// Валидация
+	err = repo.RestoreURLsWithUser(ctx, []string{"own2"}, "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "userID cannot be empty")
+}
+
+func TestURLsRepository_PurgeDeleted(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	repo := NewUserURLsRepository(db)
+	urlsRepo := NewURLsRepository(db)
+	ctx := context.Background()
+
+	_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`, "owner", "owner", false)
+	require.NoError(t, err)
+
+	urls := []*model.URLsModel{
+		{ShortURL: "old1", LongURL: "https://example.com/old1"},
+		{ShortURL: "old2", LongURL: "https://example.com/old2"},
+		{ShortURL: "old3", LongURL: "https://example.com/old3"},
+		{ShortURL: "fresh", LongURL: "https://example.com/fresh"},
+		{ShortURL: "alive", LongURL: "https://example.com/alive"},
+	}
+	require.NoError(t, repo.CreateMultipleURLsWithUser(ctx, urls, "owner"))
+	_, err = repo.DeleteURLsWithUser(ctx, []string{"old1", "old2", "old3", "fresh"}, "owner")
+	require.NoError(t, err)
+
+	clicksRepo := NewClicksRepository(db)
+	require.NoError(t, clicksRepo.RecordClicks(ctx, []*model.ClickModel{
+		{ShortURL: "old1", ClickedAt: time.Now()},
+		{ShortURL: "alive", ClickedAt: time.Now()},
+	}))
+	require.NoError(t, clicksRepo.IncrementClickCounters(ctx, map[string]int64{"old1": 1, "alive": 1}))
+
+	var healthResults []*model.LinkCheckResult
+	for _, shortURL := range []string{"old1", "alive"} {
+		url, err := urlsRepo.GetByShortURL(ctx, shortURL)
+		require.NoError(t, err)
+		healthResults = append(healthResults, &model.LinkCheckResult{
+			URLID:      url.ID,
+			LinkHealth: model.LinkHealth{StatusCode: 404, CheckedAt: time.Now()},
+		})
+	}
+	require.NoError(t, NewLinkHealthRepository(db).SaveResults(ctx, healthResults))
+
+	// Старые ссылки удалены давно, свежая - только что
+	_, err = db.ExecContext(ctx, `UPDATE urls SET deleted_at = datetime('now', '-10 days') WHERE short_url LIKE 'old%'`)
+	require.NoError(t, err)
+
+	// Последующие изменения и повторное удаление не сдвигают момент удаления
+	_, err = db.ExecContext(ctx, `UPDATE urls SET updated_at = datetime('now') WHERE short_url = 'old2'`)
+	require.NoError(t, err)
+	_, err = repo.DeleteURLsWithUser(ctx, []string{"old3"}, "owner")
+	require.NoError(t, err)
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+
+	// Пачка ограничена размером limit
+	stats, err := urlsRepo.PurgeDeleted(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.URLs)
+	assert.Equal(t, int64(2), stats.UserLinks)
+	assert.Equal(t, []string{"old1", "old2"}, stats.ShortURLs)
+
+	stats, err = urlsRepo.PurgeDeleted(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.URLs)
+	assert.Equal(t, int64(1), stats.UserLinks)
+	assert.Equal(t, []string{"old3"}, stats.ShortURLs)
+
+	stats, err = urlsRepo.PurgeDeleted(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, model.PurgeStats{}, stats)
+
+	// Свежеудаленная ссылка остается в корзине, активная не затрагивается
+	trash, err := repo.GetDeletedByUserID(ctx, "owner")
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, "fresh", trash[0].ShortURL)
+
+	all, err := repo.GetByUserID(ctx, "owner")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	var orphanLinks int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_urls WHERE url_id NOT IN (SELECT id FROM urls)`).Scan(&orphanLinks))
+	assert.Zero(t, orphanLinks)
+
+	// Переходы и счетчик окончательно удаленной ссылки удаляются вместе с ней
+	var clicks, counters int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM clicks`).Scan(&clicks))
+	assert.Equal(t, 1, clicks)
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM click_counters`).Scan(&counters))
+	assert.Equal(t, 1, counters)
+
+	// Результаты проверки доступности тоже не остаются без ссылки
+	var health int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM link_health`).Scan(&health))
+	assert.Equal(t, 1, health)
+}
+
+func TestURLsRepository_UpdateByUser(t *testing.T) {
+	db, cleanup := setupUserURLsTestDB(t)
+	defer cleanup()
+
+	repo := NewUserURLsRepository(db)
+	urlsRepo := NewURLsRepository(db)
+	ctx := context.Background()
+
+	for _, userID := range []string{"owner", "stranger"} {
+		_, err := db.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`,
+			userID, userID, false)
+		require.NoError(t, err)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, repo.CreateURLWithUser(ctx, &model.URLsModel{ShortURL: "own1", LongURL: "https://example.com/old", ExpiresAt: &past}, "owner"))
+	_, err := urlsRepo.MarkExpired(ctx, time.Now())
+	require.NoError(t, err)
+
+	newLongURL := "https://example.com/new"
+	future := time.Now().Add(time.Hour)
+	disabled := true
+
+	t.Run("owner retargets and disables link", func(t *testing.T) {
+		previous, updated, err := urlsRepo.UpdateByUser(ctx, "own1", "owner", model.URLUpdate{
+			LongURL:          &newLongURL,
+			UpdateExpiration: true,
+			ExpiresAt:        &future,
+			IsDisabled:       &disabled,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/old", previous.LongURL)
+		assert.True(t, previous.IsExpired)
+		assert.Equal(t, newLongURL, updated.LongURL)
+		assert.False(t, updated.IsExpired)
+		assert.True(t, updated.IsDisabled)
+
+		stored, err := urlsRepo.GetByShortURL(ctx, "own1")
+		require.NoError(t, err)
+		assert.Equal(t, newLongURL, stored.LongURL)
+		assert.False(t, stored.IsExpired)
+		assert.True(t, stored.IsDisabled)
+		require.NotNil(t, stored.ExpiresAt)
+		assert.WithinDuration(t, future, *stored.ExpiresAt, time.Second)
+
+		// Отключенная ссылка не переиспользуется при повторном сокращении
+		_, err = repo.GetByUserIDAndLongURL(ctx, "owner", newLongURL)
+		assert.ErrorIs(t, err, repository.ErrURLNotFound)
+	})
+
+	t.Run("only given fields change", func(t *testing.T) {
+		enabled := false
+		_, updated, err := urlsRepo.UpdateByUser(ctx, "own1", "owner", model.URLUpdate{IsDisabled: &enabled})
+		require.NoError(t, err)
+		assert.Equal(t, newLongURL, updated.LongURL)
+		assert.False(t, updated.IsDisabled)
+		require.NotNil(t, updated.ExpiresAt)
+	})
+
+	t.Run("routing rules are replaced and cleared", func(t *testing.T) {
+		rules := model.RoutingRules{{Device: model.DeviceIOS, Destination: "https://apps.apple.com/app/id1"}}
+		_, updated, err := urlsRepo.UpdateByUser(ctx, "own1", "owner", model.URLUpdate{RoutingRules: &rules})
+		require.NoError(t, err)
+		assert.Equal(t, rules, updated.RoutingRules)
+
+		stored, err := urlsRepo.GetByShortURL(ctx, "own1")
+		require.NoError(t, err)
+		assert.Equal(t, rules, stored.RoutingRules)
+
+		empty := model.RoutingRules{}
+		_, _, err = urlsRepo.UpdateByUser(ctx, "own1", "owner", model.URLUpdate{RoutingRules: &empty})
+		require.NoError(t, err)
+
+		stored, err = urlsRepo.GetByShortURL(ctx, "own1")
+		require.NoError(t, err)
+		assert.Empty(t, stored.RoutingRules)
+	})
+
+	t.Run("stranger cannot update link", func(t *testing.T) {
+		_, _, err := urlsRepo.UpdateByUser(ctx, "own1", "stranger", model.URLUpdate{LongURL: &newLongURL})
+		assert.ErrorIs(t, err, repository.ErrURLNotFound)
+	})
+
+	t.Run("deleted link cannot be updated", func(t *testing.T) {
+		_, err := repo.DeleteURLsWithUser(ctx, []string{"own1"}, "owner")
+		require.NoError(t, err)
+
+		_, _, err = urlsRepo.UpdateByUser(ctx, "own1", "owner", model.URLUpdate{LongURL: &newLongURL})
+		assert.ErrorIs(t, err, repository.ErrURLNotFound)
+	})
+}
+
 // setupUserURLsTestDB создает тестовую базу данных и возвращает соединение
 func setupUserURLsTestDB(t *testing.T) (*sql.DB, func()) {
 	// Создаем временную базу данных в памяти
@@ -228,6 +649,21 @@ func setupUserURLsTestDB(t *testing.T) (*sql.DB, func()) {
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			short_url TEXT NOT NULL UNIQUE,
 			long_url TEXT NOT NULL,
+			is_deleted BOOLEAN DEFAULT FALSE,
+			expires_at DATETIME,
+			is_expired BOOLEAN DEFAULT FALSE,
+		max_clicks INTEGER,
+		clicks_left INTEGER,
+		password_hash TEXT,
+		is_disabled BOOLEAN DEFAULT FALSE,
+		title TEXT NOT NULL DEFAULT '',
+		show_interstitial BOOLEAN NOT NULL DEFAULT FALSE,
+		redirect_code INTEGER NOT NULL DEFAULT 307,
+		query_passthrough TEXT NOT NULL DEFAULT '',
+		is_prefix BOOLEAN DEFAULT FALSE,
+		routing_rules TEXT NOT NULL DEFAULT '',
+		variants TEXT NOT NULL DEFAULT '',
+		deleted_at DATETIME,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)
@@ -247,6 +683,43 @@ func setupUserURLsTestDB(t *testing.T) (*sql.DB, func()) {
 	`)
 	require.NoError(t, err)
 
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS clicks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url_id INTEGER NOT NULL,
+			clicked_at DATETIME NOT NULL,
+			referrer TEXT NOT NULL DEFAULT '',
+			user_agent TEXT NOT NULL DEFAULT '',
+			ip TEXT NOT NULL DEFAULT '',
+			variant TEXT NOT NULL DEFAULT '',
+			FOREIGN KEY (url_id) REFERENCES urls(id) ON DELETE CASCADE
+		)
+	`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS click_counters (
+			url_id INTEGER PRIMARY KEY,
+			clicks INTEGER NOT NULL DEFAULT 0,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (url_id) REFERENCES urls(id) ON DELETE CASCADE
+		)
+	`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS link_health (
+			url_id INTEGER PRIMARY KEY,
+			status_code INTEGER NOT NULL DEFAULT 0,
+			error TEXT NOT NULL DEFAULT '',
+			redirect_chain TEXT NOT NULL DEFAULT '',
+			consecutive_failures INTEGER NOT NULL DEFAULT 0,
+			checked_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (url_id) REFERENCES urls(id) ON DELETE CASCADE
+		)
+	`)
+	require.NoError(t, err)
+
 	// Создаем индексы для улучшения производительности
 	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_urls_short_url ON urls(short_url)`)
 	require.NoError(t, err)
@@ -6,8 +6,57 @@ import "errors"
 var (
 	// ErrURLWasDeleted возвращается, когда запрашиваемый URL был удален.
 	ErrURLWasDeleted = errors.New("url was deleted")
+	// ErrURLNotFound возвращается, когда ссылка не найдена или не принадлежит текущему пользователю.
+	ErrURLNotFound = errors.New("url not found")
+	// ErrURLNotOwned возвращается, когда ссылка принадлежит другому пользователю.
+	ErrURLNotOwned = errors.New("url belongs to another user")
+	// ErrURLDisabled возвращается, когда ссылка отключена владельцем.
+	ErrURLDisabled = errors.New("url is disabled")
+	// ErrInvalidURLUpdate возвращается, когда изменения ссылки заданы некорректно.
+	ErrInvalidURLUpdate = errors.New("invalid url update")
+	// ErrURLExpired возвращается, когда срок действия запрашиваемого URL истек.
+	ErrURLExpired = errors.New("url has expired")
+	// ErrInvalidExpiration возвращается, когда срок действия ссылки задан некорректно.
+	ErrInvalidExpiration = errors.New("invalid expiration")
+	// ErrClickLimitReached возвращается, когда лимит переходов по ссылке исчерпан.
+	ErrClickLimitReached = errors.New("url click limit reached")
+	// ErrInvalidMaxClicks возвращается, когда лимит переходов по ссылке задан некорректно.
+	ErrInvalidMaxClicks = errors.New("invalid max clicks")
+	// ErrPasswordRequired возвращается, когда для перехода по ссылке требуется пароль.
+	ErrPasswordRequired = errors.New("url is password protected")
+	// ErrWrongPassword возвращается, когда указан неверный пароль ссылки.
+	ErrWrongPassword = errors.New("wrong url password")
+	// ErrTooManyPasswordAttempts возвращается, когда исчерпан лимит неудачных попыток ввода пароля ссылки.
+	ErrTooManyPasswordAttempts = errors.New("too many password attempts")
+	// ErrInvalidPassword возвращается, когда пароль для новой ссылки не прошел валидацию.
+	ErrInvalidPassword = errors.New("invalid url password")
+	// ErrInterstitialRequired возвращается, когда перед переходом по ссылке нужно показать страницу предупреждения.
+	ErrInterstitialRequired = errors.New("url requires confirmation before redirect")
+	// ErrInvalidTitle возвращается, когда заголовок ссылки не прошел валидацию.
+	ErrInvalidTitle = errors.New("invalid url title")
+	// ErrInvalidRedirect возвращается, когда тип перенаправления или режим переноса параметров запроса заданы некорректно.
+	ErrInvalidRedirect = errors.New("invalid redirect settings")
+	// ErrInvalidPathSuffix возвращается, когда путь после короткого кода ссылки-префикса нельзя перенести в адрес назначения.
+	ErrInvalidPathSuffix = errors.New("invalid path suffix")
+	// ErrInvalidRoutingRules возвращается, когда правила условного перенаправления ссылки заданы некорректно.
+	ErrInvalidRoutingRules = errors.New("invalid routing rules")
+	// ErrInvalidVariants возвращается, когда варианты адреса назначения ссылки заданы некорректно.
+	ErrInvalidVariants = errors.New("invalid split variants")
+	// ErrURLRejectedByPolicy возвращается, когда адрес назначения запрещен политикой адресов назначения.
+	// Причина отказа передается в PolicyViolationError, которая оборачивает эту ошибку.
+	ErrURLRejectedByPolicy = errors.New("url rejected by destination policy")
 	// ErrURLAlreadyExists возвращается, когда пытаются создать короткий URL для уже существующего длинного URL.
 	ErrURLAlreadyExists = errors.New("url already exists")
+	// ErrInvalidAlias возвращается, когда пользовательский короткий код не прошел валидацию.
+	ErrInvalidAlias = errors.New("invalid alias")
+	// ErrAliasAlreadyExists возвращается, когда пользовательский короткий код уже занят другой ссылкой.
+	ErrAliasAlreadyExists = errors.New("alias already exists")
+	// ErrDeleteJobNotFound возвращается, когда задача удаления не найдена или принадлежит другому пользователю.
+	ErrDeleteJobNotFound = errors.New("delete job not found")
+	// ErrInvalidStatsPeriod возвращается, когда период статистики переходов задан некорректно.
+	ErrInvalidStatsPeriod = errors.New("invalid stats period")
+	// ErrCodeGenerationFailed возвращается, когда не удалось подобрать свободный короткий код за допустимое число попыток.
+	ErrCodeGenerationFailed = errors.New("failed to generate unique short code")
 )
 
 // IsAlreadyExistsError проверяет, является ли ошибка ошибкой "URL уже существует".
@@ -21,3 +70,141 @@ func IsAlreadyExistsError(err error) bool {
 func IsDeletedError(err error) bool {
 	return errors.Is(err, ErrURLWasDeleted)
 }
+
+// IsNotFoundError проверяет, является ли ошибка ошибкой "URL не найден".
+// Возвращает true, если ошибка равна или оборачивает ErrURLNotFound.
+func IsNotFoundError(err error) bool {
+	return errors.Is(err, ErrURLNotFound)
+}
+
+// IsNotOwnedError проверяет, является ли ошибка ошибкой "ссылка принадлежит другому пользователю".
+// Возвращает true, если ошибка равна или оборачивает ErrURLNotOwned.
+func IsNotOwnedError(err error) bool {
+	return errors.Is(err, ErrURLNotOwned)
+}
+
+// IsDisabledError проверяет, является ли ошибка ошибкой "ссылка отключена владельцем".
+// Возвращает true, если ошибка равна или оборачивает ErrURLDisabled.
+func IsDisabledError(err error) bool {
+	return errors.Is(err, ErrURLDisabled)
+}
+
+// IsInvalidURLUpdateError проверяет, является ли ошибка ошибкой некорректного изменения ссылки.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidURLUpdate.
+func IsInvalidURLUpdateError(err error) bool {
+	return errors.Is(err, ErrInvalidURLUpdate)
+}
+
+// IsExpiredError проверяет, является ли ошибка ошибкой "срок действия URL истек".
+// Возвращает true, если ошибка равна или оборачивает ErrURLExpired.
+func IsExpiredError(err error) bool {
+	return errors.Is(err, ErrURLExpired)
+}
+
+// IsInvalidExpirationError проверяет, является ли ошибка ошибкой некорректного срока действия ссылки.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidExpiration.
+func IsInvalidExpirationError(err error) bool {
+	return errors.Is(err, ErrInvalidExpiration)
+}
+
+// IsClickLimitReachedError проверяет, является ли ошибка ошибкой "лимит переходов по ссылке исчерпан".
+// Возвращает true, если ошибка равна или оборачивает ErrClickLimitReached.
+func IsClickLimitReachedError(err error) bool {
+	return errors.Is(err, ErrClickLimitReached)
+}
+
+// IsInvalidMaxClicksError проверяет, является ли ошибка ошибкой некорректного лимита переходов.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidMaxClicks.
+func IsInvalidMaxClicksError(err error) bool {
+	return errors.Is(err, ErrInvalidMaxClicks)
+}
+
+// IsPasswordRequiredError проверяет, является ли ошибка ошибкой "для ссылки требуется пароль".
+// Возвращает true, если ошибка равна или оборачивает ErrPasswordRequired.
+func IsPasswordRequiredError(err error) bool {
+	return errors.Is(err, ErrPasswordRequired)
+}
+
+// IsWrongPasswordError проверяет, является ли ошибка ошибкой "неверный пароль ссылки".
+// Возвращает true, если ошибка равна или оборачивает ErrWrongPassword.
+func IsWrongPasswordError(err error) bool {
+	return errors.Is(err, ErrWrongPassword)
+}
+
+// IsTooManyPasswordAttemptsError проверяет, является ли ошибка ошибкой превышения лимита попыток ввода пароля.
+// Возвращает true, если ошибка равна или оборачивает ErrTooManyPasswordAttempts.
+func IsTooManyPasswordAttemptsError(err error) bool {
+	return errors.Is(err, ErrTooManyPasswordAttempts)
+}
+
+// IsInvalidPasswordError проверяет, является ли ошибка ошибкой валидации пароля новой ссылки.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidPassword.
+func IsInvalidPasswordError(err error) bool {
+	return errors.Is(err, ErrInvalidPassword)
+}
+
+// IsInterstitialRequiredError проверяет, является ли ошибка ошибкой "перед переходом требуется подтверждение".
+// Возвращает true, если ошибка равна или оборачивает ErrInterstitialRequired.
+func IsInterstitialRequiredError(err error) bool {
+	return errors.Is(err, ErrInterstitialRequired)
+}
+
+// IsInvalidTitleError проверяет, является ли ошибка ошибкой валидации заголовка ссылки.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidTitle.
+func IsInvalidTitleError(err error) bool {
+	return errors.Is(err, ErrInvalidTitle)
+}
+
+// IsInvalidRedirectError проверяет, является ли ошибка ошибкой валидации настроек перенаправления ссылки.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidRedirect.
+func IsInvalidRedirectError(err error) bool {
+	return errors.Is(err, ErrInvalidRedirect)
+}
+
+// IsInvalidPathSuffixError проверяет, является ли ошибка ошибкой "путь после короткого кода нельзя перенести".
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidPathSuffix.
+func IsInvalidPathSuffixError(err error) bool {
+	return errors.Is(err, ErrInvalidPathSuffix)
+}
+
+// IsInvalidRoutingRulesError проверяет, является ли ошибка ошибкой валидации правил условного перенаправления.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidRoutingRules.
+func IsInvalidRoutingRulesError(err error) bool {
+	return errors.Is(err, ErrInvalidRoutingRules)
+}
+
+// IsInvalidVariantsError проверяет, является ли ошибка ошибкой валидации вариантов адреса назначения.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidVariants.
+func IsInvalidVariantsError(err error) bool {
+	return errors.Is(err, ErrInvalidVariants)
+}
+
+// IsPolicyViolationError проверяет, является ли ошибка отказом политики адресов назначения.
+// Возвращает true, если ошибка равна или оборачивает ErrURLRejectedByPolicy.
+func IsPolicyViolationError(err error) bool {
+	return errors.Is(err, ErrURLRejectedByPolicy)
+}
+
+// IsInvalidAliasError проверяет, является ли ошибка ошибкой валидации пользовательского короткого кода.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidAlias.
+func IsInvalidAliasError(err error) bool {
+	return errors.Is(err, ErrInvalidAlias)
+}
+
+// IsAliasAlreadyExistsError проверяет, является ли ошибка ошибкой "короткий код уже занят".
+// Возвращает true, если ошибка равна или оборачивает ErrAliasAlreadyExists.
+func IsAliasAlreadyExistsError(err error) bool {
+	return errors.Is(err, ErrAliasAlreadyExists)
+}
+
+// IsDeleteJobNotFoundError проверяет, является ли ошибка ошибкой "задача удаления не найдена".
+// Возвращает true, если ошибка равна или оборачивает ErrDeleteJobNotFound.
+func IsDeleteJobNotFoundError(err error) bool {
+	return errors.Is(err, ErrDeleteJobNotFound)
+}
+
+// IsInvalidStatsPeriodError проверяет, является ли ошибка ошибкой некорректного периода статистики переходов.
+// Возвращает true, если ошибка равна или оборачивает ErrInvalidStatsPeriod.
+func IsInvalidStatsPeriodError(err error) bool {
+	return errors.Is(err, ErrInvalidStatsPeriod)
+}
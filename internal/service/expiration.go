@@ -0,0 +1,35 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResolveExpiration вычисляет момент истечения ссылки по абсолютному сроку expiresAt или времени жизни ttl,
+// отсчитываемому от now. Возвращает момент в UTC или nil, если срок действия не задан, и ошибку
+// ErrInvalidExpiration, если заданы одновременно expiresAt и ttl, ttl отрицателен или момент истечения не в будущем.
+func ResolveExpiration(expiresAt *time.Time, ttl time.Duration, now time.Time) (*time.Time, error) {
+	if expiresAt != nil && ttl != 0 {
+		return nil, fmt.Errorf("%w: expires_at and ttl are mutually exclusive", ErrInvalidExpiration)
+	}
+	if ttl < 0 {
+		return nil, fmt.Errorf("%w: ttl must be positive", ErrInvalidExpiration)
+	}
+
+	var result time.Time
+	switch {
+	case ttl > 0:
+		result = now.Add(ttl)
+	case expiresAt != nil:
+		result = *expiresAt
+	default:
+		return nil, nil
+	}
+
+	if !result.After(now) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidExpiration)
+	}
+
+	result = result.UTC()
+	return &result, nil
+}
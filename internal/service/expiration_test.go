@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveExpiration(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(24 * time.Hour)
+	past := now.Add(-time.Hour)
+	inHour := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		ttl       time.Duration
+		want      *time.Time
+		wantErr   bool
+	}{
+		{name: "no expiration", want: nil},
+		{name: "ttl", ttl: time.Hour, want: &inHour},
+		{name: "absolute expiration", expiresAt: &future, want: &future},
+		{name: "both ttl and expires_at", ttl: time.Hour, expiresAt: &future, wantErr: true},
+		{name: "negative ttl", ttl: -time.Second, wantErr: true},
+		{name: "expires_at in the past", expiresAt: &past, wantErr: true},
+		{name: "expires_at equals now", expiresAt: &now, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveExpiration(tt.expiresAt, tt.ttl, now)
+			if tt.wantErr {
+				assert.True(t, IsInvalidExpirationError(err))
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.True(t, tt.want.Equal(*got))
+			assert.Equal(t, time.UTC, got.Location())
+		})
+	}
+}
@@ -12,21 +12,102 @@ import (
 // Предоставляет методы для создания коротких ссылок из длинных URL.
 type URLShortenerService interface {
 	ShortURL(ctx context.Context, longURL string) (string, error)
+	ShortURLWithOptions(ctx context.Context, longURL string, opts ShortenOptions) (string, error)
 	ShortURLsByBatch(ctx context.Context, longURLs []map[string]string) ([]map[string]string, error)
 }
 
 // URLExtractorService определяет интерфейс для сервиса извлечения URL.
-// Предоставляет методы для получения длинных URL по коротким и для получения всех URL пользователя.
+// Предоставляет методы для получения длинных URL по коротким (в том числе защищенным паролем
+// и со страницей предупреждения),
+// проверки ссылки без учета перехода и для получения всех URL пользователя, в том числе удаленных (корзины).
 type URLExtractorService interface {
-	ExtractLongURL(ctx context.Context, shortURL string) (string, error)
+	ExtractLongURL(ctx context.Context, shortURL string, req model.RedirectRequest) (*model.Redirect, error)
+	ConfirmLongURL(ctx context.Context, shortURL string, req model.RedirectRequest) (*model.Redirect, error)
+	UnlockLongURL(ctx context.Context, shortURL, password string, req model.RedirectRequest) (*model.Redirect, error)
+	ResolveURL(ctx context.Context, shortURL string) (*model.URLsModel, error)
 	ExtractUserURLs(ctx context.Context, userID string) ([]*model.URLsModel, error)
+	ExtractUserDeletedURLs(ctx context.Context, userID string) ([]*model.URLsModel, error)
+}
+
+// URLEditorService определяет интерфейс для сервиса изменения URL.
+// Предоставляет метод для изменения адреса назначения, срока действия и активности ссылки ее владельцем.
+type URLEditorService interface {
+	UpdateUserURL(ctx context.Context, shortURL string, opts UpdateOptions) (*model.URLsModel, error)
+}
+
+// URLStatsService определяет интерфейс для сервиса статистики переходов по ссылкам.
+// Предоставляет метод для получения владельцем ссылки числа переходов, уникальных посетителей
+// и разбивки по дням за последние days дней.
+type URLStatsService interface {
+	GetURLStats(ctx context.Context, shortURL string, days int) (*model.ClickStats, error)
+}
+
+// DestinationPolicy определяет интерфейс политики адресов назначения создаваемых и изменяемых ссылок.
+// Check возвращает PolicyViolationError, если URL запрещен; Reload перечитывает списки правил из файла,
+// сохраняя прежние правила при ошибке; Stop прекращает перечитывание правил по сигналу.
+type DestinationPolicy interface {
+	Check(rawURL string) error
+	Reload() error
+	Stop()
+}
+
+// ClickAggregator определяет интерфейс буфера переходов с отложенной записью.
+// Переходы накапливаются в памяти и сбрасываются в базу данных пачками; Record не обращается к базе данных.
+// Предоставляет методы для учета перехода, немедленного сброса буфера и остановки с финальным сбросом.
+type ClickAggregator interface {
+	Record(click *model.ClickModel)
+	Flush(ctx context.Context) error
+	Stop()
 }
 
 // URLDestructorService определяет интерфейс для сервиса удаления URL.
-// Предоставляет методы для асинхронного удаления URL пользователя.
+// Предоставляет методы для асинхронного удаления URL пользователя с отслеживанием задач удаления
+// и восстановления ссылок из корзины.
 type URLDestructorService interface {
 	DeleteURL(ctx context.Context, shortURL string) error
-	DeleteURLsByBatch(ctx context.Context, shortURLs []string) error
+	DeleteURLsByBatch(ctx context.Context, shortURLs []string) (*model.DeleteJob, error)
+	GetDeleteJob(ctx context.Context, jobID string) (*model.DeleteJob, error)
+	RestoreURLsByBatch(ctx context.Context, shortURLs []string) error
+	Stop()
+}
+
+// ExpiredURLsSweeper определяет интерфейс фонового процесса, помечающего ссылки с истекшим сроком действия.
+// Предоставляет метод для немедленной пометки и остановки фонового процесса.
+type ExpiredURLsSweeper interface {
+	Sweep(ctx context.Context) (int64, error)
+	Stop()
+}
+
+// LinkChecker определяет интерфейс фоновой проверки доступности адресов назначения ссылок.
+// Предоставляет методы для немедленной проверки всех действующих ссылок (возвращает число проверенных),
+// получения ссылок, недоступных не менее minFailures проверок подряд, и остановки фонового процесса.
+type LinkChecker interface {
+	CheckAll(ctx context.Context) (int, error)
+	GetBrokenLinks(ctx context.Context, minFailures int) ([]*model.BrokenLink, error)
+	Stop()
+}
+
+// Cache определяет интерфейс кэша ссылок по короткому коду.
+// GetOrLoad возвращает ссылку из кэша, а при промахе загружает ее функцией load и сохраняет в кэш;
+// для несуществующего короткого кода возвращает nil без ошибки. Invalidate сбрасывает записи для коротких кодов,
+// в том числе у других экземпляров сервиса, если кэш общий. Ошибки хранилища кэша не возвращаются:
+// при его недоступности ссылки читаются через load. Close освобождает ресурсы кэша.
+type Cache interface {
+	GetOrLoad(ctx context.Context, shortURL string, load CacheLoader) (*model.URLsModel, error)
+	Invalidate(ctx context.Context, shortURLs ...string)
+	Stats() model.CacheStats
+	Close() error
+}
+
+// CacheLoader загружает ссылку по короткому коду при промахе кэша.
+type CacheLoader func(ctx context.Context, shortURL string) (*model.URLsModel, error)
+
+// DeletedURLsPurger определяет интерфейс фонового процесса, окончательно удаляющего ссылки из корзины
+// после срока хранения. Предоставляет методы для немедленного удаления, получения накопленной статистики
+// и остановки фонового процесса.
+type DeletedURLsPurger interface {
+	Purge(ctx context.Context) (model.PurgeStats, error)
+	Stats() model.PurgeStats
 	Stop()
 }
 
@@ -66,8 +147,11 @@ type JWTService interface {
 }
 
 // StatsService определяет интерфейс для сервиса статистики.
-// Предоставляет методы для получения общей статистики по URL и пользователям.
+// Предоставляет методы для получения общей статистики по URL и пользователям
+// статистики окончательного удаления ссылок из корзины и счетчиков кэша ссылок по короткому коду.
 type StatsService interface {
 	GetTotalURLsCount(ctx context.Context) (int64, error)
 	GetTotalUsersCount(ctx context.Context) (int64, error)
+	GetPurgeStats(ctx context.Context) model.PurgeStats
+	GetURLCacheStats(ctx context.Context) model.CacheStats
 }
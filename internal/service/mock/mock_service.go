@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 	model "yp-go-short-url-service/internal/model"
+	service "yp-go-short-url-service/internal/service"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -57,6 +58,21 @@ func (mr *MockURLShortenerServiceMockRecorder) ShortURL(ctx, longURL any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortURL", reflect.TypeOf((*MockURLShortenerService)(nil).ShortURL), ctx, longURL)
 }
 
+// ShortURLWithOptions mocks base method.
+func (m *MockURLShortenerService) ShortURLWithOptions(ctx context.Context, longURL string, opts service.ShortenOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShortURLWithOptions", ctx, longURL, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShortURLWithOptions indicates an expected call of ShortURLWithOptions.
+func (mr *MockURLShortenerServiceMockRecorder) ShortURLWithOptions(ctx, longURL, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortURLWithOptions", reflect.TypeOf((*MockURLShortenerService)(nil).ShortURLWithOptions), ctx, longURL, opts)
+}
+
 // ShortURLsByBatch mocks base method.
 func (m *MockURLShortenerService) ShortURLsByBatch(ctx context.Context, longURLs []map[string]string) ([]map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -96,19 +112,49 @@ func (m *MockURLExtractorService) EXPECT() *MockURLExtractorServiceMockRecorder
 	return m.recorder
 }
 
+// ConfirmLongURL mocks base method.
+func (m *MockURLExtractorService) ConfirmLongURL(ctx context.Context, shortURL string, req model.RedirectRequest) (*model.Redirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmLongURL", ctx, shortURL, req)
+	ret0, _ := ret[0].(*model.Redirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmLongURL indicates an expected call of ConfirmLongURL.
+func (mr *MockURLExtractorServiceMockRecorder) ConfirmLongURL(ctx, shortURL, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmLongURL", reflect.TypeOf((*MockURLExtractorService)(nil).ConfirmLongURL), ctx, shortURL, req)
+}
+
 // ExtractLongURL mocks base method.
-func (m *MockURLExtractorService) ExtractLongURL(ctx context.Context, shortURL string) (string, error) {
+func (m *MockURLExtractorService) ExtractLongURL(ctx context.Context, shortURL string, req model.RedirectRequest) (*model.Redirect, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExtractLongURL", ctx, shortURL)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ExtractLongURL", ctx, shortURL, req)
+	ret0, _ := ret[0].(*model.Redirect)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ExtractLongURL indicates an expected call of ExtractLongURL.
-func (mr *MockURLExtractorServiceMockRecorder) ExtractLongURL(ctx, shortURL any) *gomock.Call {
+func (mr *MockURLExtractorServiceMockRecorder) ExtractLongURL(ctx, shortURL, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractLongURL", reflect.TypeOf((*MockURLExtractorService)(nil).ExtractLongURL), ctx, shortURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractLongURL", reflect.TypeOf((*MockURLExtractorService)(nil).ExtractLongURL), ctx, shortURL, req)
+}
+
+// ExtractUserDeletedURLs mocks base method.
+func (m *MockURLExtractorService) ExtractUserDeletedURLs(ctx context.Context, userID string) ([]*model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtractUserDeletedURLs", ctx, userID)
+	ret0, _ := ret[0].([]*model.URLsModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtractUserDeletedURLs indicates an expected call of ExtractUserDeletedURLs.
+func (mr *MockURLExtractorServiceMockRecorder) ExtractUserDeletedURLs(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractUserDeletedURLs", reflect.TypeOf((*MockURLExtractorService)(nil).ExtractUserDeletedURLs), ctx, userID)
 }
 
 // ExtractUserURLs mocks base method.
@@ -126,6 +172,240 @@ func (mr *MockURLExtractorServiceMockRecorder) ExtractUserURLs(ctx, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractUserURLs", reflect.TypeOf((*MockURLExtractorService)(nil).ExtractUserURLs), ctx, userID)
 }
 
+// ResolveURL mocks base method.
+func (m *MockURLExtractorService) ResolveURL(ctx context.Context, shortURL string) (*model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveURL", ctx, shortURL)
+	ret0, _ := ret[0].(*model.URLsModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveURL indicates an expected call of ResolveURL.
+func (mr *MockURLExtractorServiceMockRecorder) ResolveURL(ctx, shortURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveURL", reflect.TypeOf((*MockURLExtractorService)(nil).ResolveURL), ctx, shortURL)
+}
+
+// UnlockLongURL mocks base method.
+func (m *MockURLExtractorService) UnlockLongURL(ctx context.Context, shortURL, password string, req model.RedirectRequest) (*model.Redirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockLongURL", ctx, shortURL, password, req)
+	ret0, _ := ret[0].(*model.Redirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockLongURL indicates an expected call of UnlockLongURL.
+func (mr *MockURLExtractorServiceMockRecorder) UnlockLongURL(ctx, shortURL, password, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockLongURL", reflect.TypeOf((*MockURLExtractorService)(nil).UnlockLongURL), ctx, shortURL, password, req)
+}
+
+// MockURLEditorService is a mock of URLEditorService interface.
+type MockURLEditorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLEditorServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockURLEditorServiceMockRecorder is the mock recorder for MockURLEditorService.
+type MockURLEditorServiceMockRecorder struct {
+	mock *MockURLEditorService
+}
+
+// NewMockURLEditorService creates a new mock instance.
+func NewMockURLEditorService(ctrl *gomock.Controller) *MockURLEditorService {
+	mock := &MockURLEditorService{ctrl: ctrl}
+	mock.recorder = &MockURLEditorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLEditorService) EXPECT() *MockURLEditorServiceMockRecorder {
+	return m.recorder
+}
+
+// UpdateUserURL mocks base method.
+func (m *MockURLEditorService) UpdateUserURL(ctx context.Context, shortURL string, opts service.UpdateOptions) (*model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserURL", ctx, shortURL, opts)
+	ret0, _ := ret[0].(*model.URLsModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserURL indicates an expected call of UpdateUserURL.
+func (mr *MockURLEditorServiceMockRecorder) UpdateUserURL(ctx, shortURL, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserURL", reflect.TypeOf((*MockURLEditorService)(nil).UpdateUserURL), ctx, shortURL, opts)
+}
+
+// MockURLStatsService is a mock of URLStatsService interface.
+type MockURLStatsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLStatsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockURLStatsServiceMockRecorder is the mock recorder for MockURLStatsService.
+type MockURLStatsServiceMockRecorder struct {
+	mock *MockURLStatsService
+}
+
+// NewMockURLStatsService creates a new mock instance.
+func NewMockURLStatsService(ctrl *gomock.Controller) *MockURLStatsService {
+	mock := &MockURLStatsService{ctrl: ctrl}
+	mock.recorder = &MockURLStatsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLStatsService) EXPECT() *MockURLStatsServiceMockRecorder {
+	return m.recorder
+}
+
+// GetURLStats mocks base method.
+func (m *MockURLStatsService) GetURLStats(ctx context.Context, shortURL string, days int) (*model.ClickStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLStats", ctx, shortURL, days)
+	ret0, _ := ret[0].(*model.ClickStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLStats indicates an expected call of GetURLStats.
+func (mr *MockURLStatsServiceMockRecorder) GetURLStats(ctx, shortURL, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLStats", reflect.TypeOf((*MockURLStatsService)(nil).GetURLStats), ctx, shortURL, days)
+}
+
+// MockDestinationPolicy is a mock of DestinationPolicy interface.
+type MockDestinationPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockDestinationPolicyMockRecorder
+	isgomock struct{}
+}
+
+// MockDestinationPolicyMockRecorder is the mock recorder for MockDestinationPolicy.
+type MockDestinationPolicyMockRecorder struct {
+	mock *MockDestinationPolicy
+}
+
+// NewMockDestinationPolicy creates a new mock instance.
+func NewMockDestinationPolicy(ctrl *gomock.Controller) *MockDestinationPolicy {
+	mock := &MockDestinationPolicy{ctrl: ctrl}
+	mock.recorder = &MockDestinationPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDestinationPolicy) EXPECT() *MockDestinationPolicyMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockDestinationPolicy) Check(rawURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", rawURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockDestinationPolicyMockRecorder) Check(rawURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockDestinationPolicy)(nil).Check), rawURL)
+}
+
+// Reload mocks base method.
+func (m *MockDestinationPolicy) Reload() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockDestinationPolicyMockRecorder) Reload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockDestinationPolicy)(nil).Reload))
+}
+
+// Stop mocks base method.
+func (m *MockDestinationPolicy) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockDestinationPolicyMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockDestinationPolicy)(nil).Stop))
+}
+
+// MockClickAggregator is a mock of ClickAggregator interface.
+type MockClickAggregator struct {
+	ctrl     *gomock.Controller
+	recorder *MockClickAggregatorMockRecorder
+	isgomock struct{}
+}
+
+// MockClickAggregatorMockRecorder is the mock recorder for MockClickAggregator.
+type MockClickAggregatorMockRecorder struct {
+	mock *MockClickAggregator
+}
+
+// NewMockClickAggregator creates a new mock instance.
+func NewMockClickAggregator(ctrl *gomock.Controller) *MockClickAggregator {
+	mock := &MockClickAggregator{ctrl: ctrl}
+	mock.recorder = &MockClickAggregatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClickAggregator) EXPECT() *MockClickAggregatorMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockClickAggregator) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockClickAggregatorMockRecorder) Flush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockClickAggregator)(nil).Flush), ctx)
+}
+
+// Record mocks base method.
+func (m *MockClickAggregator) Record(click *model.ClickModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", click)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockClickAggregatorMockRecorder) Record(click any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockClickAggregator)(nil).Record), click)
+}
+
+// Stop mocks base method.
+func (m *MockClickAggregator) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockClickAggregatorMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockClickAggregator)(nil).Stop))
+}
+
 // MockURLDestructorService is a mock of URLDestructorService interface.
 type MockURLDestructorService struct {
 	ctrl     *gomock.Controller
@@ -165,11 +445,12 @@ func (mr *MockURLDestructorServiceMockRecorder) DeleteURL(ctx, shortURL any) *go
 }
 
 // DeleteURLsByBatch mocks base method.
-func (m *MockURLDestructorService) DeleteURLsByBatch(ctx context.Context, shortURLs []string) error {
+func (m *MockURLDestructorService) DeleteURLsByBatch(ctx context.Context, shortURLs []string) (*model.DeleteJob, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteURLsByBatch", ctx, shortURLs)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*model.DeleteJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteURLsByBatch indicates an expected call of DeleteURLsByBatch.
@@ -178,6 +459,35 @@ func (mr *MockURLDestructorServiceMockRecorder) DeleteURLsByBatch(ctx, shortURLs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURLsByBatch", reflect.TypeOf((*MockURLDestructorService)(nil).DeleteURLsByBatch), ctx, shortURLs)
 }
 
+// GetDeleteJob mocks base method.
+func (m *MockURLDestructorService) GetDeleteJob(ctx context.Context, jobID string) (*model.DeleteJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleteJob", ctx, jobID)
+	ret0, _ := ret[0].(*model.DeleteJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleteJob indicates an expected call of GetDeleteJob.
+func (mr *MockURLDestructorServiceMockRecorder) GetDeleteJob(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleteJob", reflect.TypeOf((*MockURLDestructorService)(nil).GetDeleteJob), ctx, jobID)
+}
+
+// RestoreURLsByBatch mocks base method.
+func (m *MockURLDestructorService) RestoreURLsByBatch(ctx context.Context, shortURLs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreURLsByBatch", ctx, shortURLs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreURLsByBatch indicates an expected call of RestoreURLsByBatch.
+func (mr *MockURLDestructorServiceMockRecorder) RestoreURLsByBatch(ctx, shortURLs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreURLsByBatch", reflect.TypeOf((*MockURLDestructorService)(nil).RestoreURLsByBatch), ctx, shortURLs)
+}
+
 // Stop mocks base method.
 func (m *MockURLDestructorService) Stop() {
 	m.ctrl.T.Helper()
@@ -190,6 +500,272 @@ func (mr *MockURLDestructorServiceMockRecorder) Stop() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockURLDestructorService)(nil).Stop))
 }
 
+// MockExpiredURLsSweeper is a mock of ExpiredURLsSweeper interface.
+type MockExpiredURLsSweeper struct {
+	ctrl     *gomock.Controller
+	recorder *MockExpiredURLsSweeperMockRecorder
+	isgomock struct{}
+}
+
+// MockExpiredURLsSweeperMockRecorder is the mock recorder for MockExpiredURLsSweeper.
+type MockExpiredURLsSweeperMockRecorder struct {
+	mock *MockExpiredURLsSweeper
+}
+
+// NewMockExpiredURLsSweeper creates a new mock instance.
+func NewMockExpiredURLsSweeper(ctrl *gomock.Controller) *MockExpiredURLsSweeper {
+	mock := &MockExpiredURLsSweeper{ctrl: ctrl}
+	mock.recorder = &MockExpiredURLsSweeperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExpiredURLsSweeper) EXPECT() *MockExpiredURLsSweeperMockRecorder {
+	return m.recorder
+}
+
+// Stop mocks base method.
+func (m *MockExpiredURLsSweeper) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockExpiredURLsSweeperMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockExpiredURLsSweeper)(nil).Stop))
+}
+
+// Sweep mocks base method.
+func (m *MockExpiredURLsSweeper) Sweep(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sweep", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sweep indicates an expected call of Sweep.
+func (mr *MockExpiredURLsSweeperMockRecorder) Sweep(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sweep", reflect.TypeOf((*MockExpiredURLsSweeper)(nil).Sweep), ctx)
+}
+
+// MockLinkChecker is a mock of LinkChecker interface.
+type MockLinkChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockLinkCheckerMockRecorder is the mock recorder for MockLinkChecker.
+type MockLinkCheckerMockRecorder struct {
+	mock *MockLinkChecker
+}
+
+// NewMockLinkChecker creates a new mock instance.
+func NewMockLinkChecker(ctrl *gomock.Controller) *MockLinkChecker {
+	mock := &MockLinkChecker{ctrl: ctrl}
+	mock.recorder = &MockLinkCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkChecker) EXPECT() *MockLinkCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckAll mocks base method.
+func (m *MockLinkChecker) CheckAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAll indicates an expected call of CheckAll.
+func (mr *MockLinkCheckerMockRecorder) CheckAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAll", reflect.TypeOf((*MockLinkChecker)(nil).CheckAll), ctx)
+}
+
+// GetBrokenLinks mocks base method.
+func (m *MockLinkChecker) GetBrokenLinks(ctx context.Context, minFailures int) ([]*model.BrokenLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrokenLinks", ctx, minFailures)
+	ret0, _ := ret[0].([]*model.BrokenLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrokenLinks indicates an expected call of GetBrokenLinks.
+func (mr *MockLinkCheckerMockRecorder) GetBrokenLinks(ctx, minFailures any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrokenLinks", reflect.TypeOf((*MockLinkChecker)(nil).GetBrokenLinks), ctx, minFailures)
+}
+
+// Stop mocks base method.
+func (m *MockLinkChecker) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockLinkCheckerMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockLinkChecker)(nil).Stop))
+}
+
+// MockCache is a mock of Cache interface.
+type MockCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockCacheMockRecorder is the mock recorder for MockCache.
+type MockCacheMockRecorder struct {
+	mock *MockCache
+}
+
+// NewMockCache creates a new mock instance.
+func NewMockCache(ctrl *gomock.Controller) *MockCache {
+	mock := &MockCache{ctrl: ctrl}
+	mock.recorder = &MockCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache) EXPECT() *MockCacheMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockCache) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockCacheMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCache)(nil).Close))
+}
+
+// GetOrLoad mocks base method.
+func (m *MockCache) GetOrLoad(ctx context.Context, shortURL string, load service.CacheLoader) (*model.URLsModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrLoad", ctx, shortURL, load)
+	ret0, _ := ret[0].(*model.URLsModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrLoad indicates an expected call of GetOrLoad.
+func (mr *MockCacheMockRecorder) GetOrLoad(ctx, shortURL, load any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrLoad", reflect.TypeOf((*MockCache)(nil).GetOrLoad), ctx, shortURL, load)
+}
+
+// Invalidate mocks base method.
+func (m *MockCache) Invalidate(ctx context.Context, shortURLs ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range shortURLs {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Invalidate", varargs...)
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockCacheMockRecorder) Invalidate(ctx any, shortURLs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, shortURLs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockCache)(nil).Invalidate), varargs...)
+}
+
+// Stats mocks base method.
+func (m *MockCache) Stats() model.CacheStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(model.CacheStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCacheMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCache)(nil).Stats))
+}
+
+// MockDeletedURLsPurger is a mock of DeletedURLsPurger interface.
+type MockDeletedURLsPurger struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeletedURLsPurgerMockRecorder
+	isgomock struct{}
+}
+
+// MockDeletedURLsPurgerMockRecorder is the mock recorder for MockDeletedURLsPurger.
+type MockDeletedURLsPurgerMockRecorder struct {
+	mock *MockDeletedURLsPurger
+}
+
+// NewMockDeletedURLsPurger creates a new mock instance.
+func NewMockDeletedURLsPurger(ctrl *gomock.Controller) *MockDeletedURLsPurger {
+	mock := &MockDeletedURLsPurger{ctrl: ctrl}
+	mock.recorder = &MockDeletedURLsPurgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeletedURLsPurger) EXPECT() *MockDeletedURLsPurgerMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *MockDeletedURLsPurger) Purge(ctx context.Context) (model.PurgeStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx)
+	ret0, _ := ret[0].(model.PurgeStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockDeletedURLsPurgerMockRecorder) Purge(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockDeletedURLsPurger)(nil).Purge), ctx)
+}
+
+// Stats mocks base method.
+func (m *MockDeletedURLsPurger) Stats() model.PurgeStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(model.PurgeStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockDeletedURLsPurgerMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockDeletedURLsPurger)(nil).Stats))
+}
+
+// Stop mocks base method.
+func (m *MockDeletedURLsPurger) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockDeletedURLsPurgerMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockDeletedURLsPurger)(nil).Stop))
+}
+
 // MockHealthCheckService is a mock of HealthCheckService interface.
 type MockHealthCheckService struct {
 	ctrl     *gomock.Controller
@@ -532,6 +1108,20 @@ func (m *MockStatsService) EXPECT() *MockStatsServiceMockRecorder {
 	return m.recorder
 }
 
+// GetPurgeStats mocks base method.
+func (m *MockStatsService) GetPurgeStats(ctx context.Context) model.PurgeStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurgeStats", ctx)
+	ret0, _ := ret[0].(model.PurgeStats)
+	return ret0
+}
+
+// GetPurgeStats indicates an expected call of GetPurgeStats.
+func (mr *MockStatsServiceMockRecorder) GetPurgeStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurgeStats", reflect.TypeOf((*MockStatsService)(nil).GetPurgeStats), ctx)
+}
+
 // GetTotalURLsCount mocks base method.
 func (m *MockStatsService) GetTotalURLsCount(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -561,3 +1151,17 @@ func (mr *MockStatsServiceMockRecorder) GetTotalUsersCount(ctx any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalUsersCount", reflect.TypeOf((*MockStatsService)(nil).GetTotalUsersCount), ctx)
 }
+
+// GetURLCacheStats mocks base method.
+func (m *MockStatsService) GetURLCacheStats(ctx context.Context) model.CacheStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLCacheStats", ctx)
+	ret0, _ := ret[0].(model.CacheStats)
+	return ret0
+}
+
+// GetURLCacheStats indicates an expected call of GetURLCacheStats.
+func (mr *MockStatsServiceMockRecorder) GetURLCacheStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLCacheStats", reflect.TypeOf((*MockStatsService)(nil).GetURLCacheStats), ctx)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"time"
+	"yp-go-short-url-service/internal/model"
+)
+
+// MaxTitleLength - максимальная длина заголовка ссылки в символах.
+const MaxTitleLength = 200
+
+// ShortenOptions содержит необязательные параметры создания короткой ссылки.
+// Нулевое значение соответствует поведению по умолчанию: короткий код генерируется автоматически,
+// а ссылка действует бессрочно, без ограничения числа переходов, без пароля, без заголовка и без страницы предупреждения,
+// перенаправляет со статусом 307, отбрасывает параметры запроса, не принимает сегменты пути после короткого кода
+// и не имеет правил условного перенаправления.
+type ShortenOptions struct {
+	// Alias - пользовательский короткий код (vanity URL). Если пуст, код генерируется автоматически.
+	Alias string
+	// ExpiresAt - момент, после которого ссылка перестает работать. Не может быть задан вместе с TTL.
+	ExpiresAt *time.Time
+	// TTL - время жизни ссылки, отсчитываемое от момента создания. Не может быть задан вместе с ExpiresAt.
+	TTL time.Duration
+	// MaxClicks - количество переходов, после которого ссылка перестает работать. Ноль означает отсутствие лимита.
+	MaxClicks int64
+	// Password - пароль, который нужно ввести перед переходом по ссылке. Пустая строка означает ссылку без пароля.
+	Password string
+	// Title - заголовок ссылки, который показывается на странице предпросмотра. Не длиннее MaxTitleLength символов.
+	Title string
+	// ShowInterstitial включает страницу предупреждения, которая показывается перед каждым переходом по ссылке.
+	ShowInterstitial bool
+	// RedirectCode - HTTP статус перенаправления: 301, 302, 307 или 308. Ноль означает 307.
+	RedirectCode int
+	// QueryPassthrough - режим переноса параметров запроса к короткой ссылке в адрес назначения.
+	QueryPassthrough model.QueryPassthrough
+	// Prefix делает ссылку ссылкой-префиксом: сегменты пути после короткого кода переносятся в конец адреса назначения.
+	Prefix bool
+	// RoutingRules - упорядоченный список правил условного перенаправления, не длиннее MaxRoutingRules.
+	RoutingRules model.RoutingRules
+	// Variants - варианты адреса назначения для разделения трафика: от 2 до MaxSplitVariants вариантов.
+	// Если заданы, адрес длинного URL используется только для отображения.
+	Variants model.SplitVariants
+}
+
+// UpdateOptions содержит изменения существующей короткой ссылки.
+// Поля, оставленные нулевыми, не изменяются; должно быть задано хотя бы одно изменение.
+type UpdateOptions struct {
+	// LongURL - новый адрес назначения ссылки.
+	LongURL *string
+	// ExpiresAt - новый момент истечения ссылки. Не может быть задан вместе с TTL или ClearExpiration.
+	ExpiresAt *time.Time
+	// TTL - новое время жизни ссылки, отсчитываемое от момента изменения.
+	TTL time.Duration
+	// ClearExpiration делает ссылку бессрочной.
+	ClearExpiration bool
+	// Active включает (true) или отключает (false) переходы по ссылке.
+	Active *bool
+	// Title - новый заголовок ссылки. Пустая строка удаляет заголовок.
+	Title *string
+	// ShowInterstitial включает (true) или отключает (false) страницу предупреждения перед переходом.
+	ShowInterstitial *bool
+	// RoutingRules - новый список правил условного перенаправления. Пустой список удаляет все правила.
+	RoutingRules *model.RoutingRules
+	// Variants - новый список вариантов адреса назначения. Пустой список отключает разделение трафика.
+	Variants *model.SplitVariants
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PolicyReason - код причины, по которой политика адресов назначения отклонила URL.
+type PolicyReason string
+
+const (
+	// PolicyReasonInvalidURL - URL не разбирается или не содержит хоста.
+	PolicyReasonInvalidURL PolicyReason = "invalid_url"
+	// PolicyReasonSchemeNotAllowed - схема URL не входит в список разрешенных.
+	PolicyReasonSchemeNotAllowed PolicyReason = "scheme_not_allowed"
+	// PolicyReasonSelfReference - URL указывает на хост самого сервиса и привел бы к циклу перенаправлений.
+	PolicyReasonSelfReference PolicyReason = "self_reference"
+	// PolicyReasonDomainDenied - хост URL входит в список запрещенных доменов.
+	PolicyReasonDomainDenied PolicyReason = "domain_denied"
+	// PolicyReasonSuffixDenied - хост URL оканчивается запрещенным суффиксом домена.
+	PolicyReasonSuffixDenied PolicyReason = "suffix_denied"
+	// PolicyReasonPatternDenied - URL подходит под запрещающее регулярное выражение.
+	PolicyReasonPatternDenied PolicyReason = "pattern_denied"
+	// PolicyReasonNotAllowed - список разрешенных адресов задан, но URL не подходит ни под одно его правило.
+	PolicyReasonNotAllowed PolicyReason = "not_allowed"
+)
+
+// PolicyViolationError описывает отказ политики адресов назначения: код причины, отклоненный URL и пояснение.
+// Оборачивает ErrURLRejectedByPolicy, поэтому распознается IsPolicyViolationError.
+type PolicyViolationError struct {
+	Reason PolicyReason
+	URL    string
+	Detail string
+}
+
+// Error возвращает текст ошибки с кодом причины и пояснением.
+func (e *PolicyViolationError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("%s: %s", ErrURLRejectedByPolicy, e.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", ErrURLRejectedByPolicy, e.Reason, e.Detail)
+}
+
+// Unwrap возвращает ErrURLRejectedByPolicy.
+func (e *PolicyViolationError) Unwrap() error {
+	return ErrURLRejectedByPolicy
+}
+
+// PolicyViolationReason возвращает код причины отказа политики адресов назначения,
+// если ошибка равна или оборачивает PolicyViolationError, и пустую строку в остальных случаях.
+func PolicyViolationReason(err error) PolicyReason {
+	var violation *PolicyViolationError
+	if errors.As(err, &violation) {
+		return violation.Reason
+	}
+	return ""
+}
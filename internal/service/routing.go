@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"yp-go-short-url-service/internal/model"
+)
+
+const (
+	// MaxRoutingRules - максимальное число правил условного перенаправления у одной ссылки.
+	MaxRoutingRules = 20
+	// maxRoutingRuleValues - максимальное число языков или диапазонов IP-адресов в одном правиле.
+	maxRoutingRuleValues = 50
+	// maxLanguageSubtagLength - максимальная длина части языкового тега по BCP 47.
+	maxLanguageSubtagLength = 8
+)
+
+// NormalizeRoutingRules проверяет правила условного перенаправления и приводит их к каноническому виду:
+// семейство устройства и языковые теги переводятся в нижний регистр, отдельный IP-адрес заменяется
+// диапазоном из одного адреса, у диапазонов обнуляются биты после префикса.
+// Пустой список возвращается как nil. Возвращает ошибку ErrInvalidRoutingRules, если правил больше
+// MaxRoutingRules, в правиле нет ни одного условия, условие задано некорректно или адрес назначения
+// не является абсолютным HTTP(S) URL.
+func NormalizeRoutingRules(rules model.RoutingRules) (model.RoutingRules, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if len(rules) > MaxRoutingRules {
+		return nil, fmt.Errorf("%w: at most %d rules are allowed", ErrInvalidRoutingRules, MaxRoutingRules)
+	}
+
+	normalized := make(model.RoutingRules, 0, len(rules))
+	for i, rule := range rules {
+		result, err := normalizeRoutingRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rule %d: %s", ErrInvalidRoutingRules, i+1, err)
+		}
+		normalized = append(normalized, result)
+	}
+
+	return normalized, nil
+}
+
+func normalizeRoutingRule(rule model.RoutingRule) (model.RoutingRule, error) {
+	var result model.RoutingRule
+
+	result.Device = model.DeviceFamily(strings.ToLower(strings.TrimSpace(string(rule.Device))))
+	if result.Device != "" && !result.Device.IsValid() {
+		return result, fmt.Errorf("unknown device %q", rule.Device)
+	}
+
+	if len(rule.Languages) > maxRoutingRuleValues || len(rule.CIDRs) > maxRoutingRuleValues {
+		return result, fmt.Errorf("at most %d languages and cidrs are allowed", maxRoutingRuleValues)
+	}
+
+	for _, language := range rule.Languages {
+		language = strings.ToLower(strings.TrimSpace(language))
+		if !isLanguageTag(language) {
+			return result, fmt.Errorf("invalid language %q", language)
+		}
+		result.Languages = append(result.Languages, language)
+	}
+
+	for _, cidr := range rule.CIDRs {
+		prefix, err := parseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return result, fmt.Errorf("invalid cidr %q", cidr)
+		}
+		result.CIDRs = append(result.CIDRs, prefix.String())
+	}
+
+	if result.Device == "" && len(result.Languages) == 0 && len(result.CIDRs) == 0 {
+		return result, fmt.Errorf("at least one of device, languages or cidrs is required")
+	}
+
+	result.Destination = strings.TrimSpace(rule.Destination)
+	if !IsHTTPURL(result.Destination) {
+		return result, fmt.Errorf("destination must be an absolute http or https url")
+	}
+
+	return result, nil
+}
+
+// IsHTTPURL сообщает, является ли строка абсолютным HTTP(S) URL с указанным хостом.
+// Так проверяются адреса назначения правил, вариантов и новый адрес назначения изменяемой ссылки.
+func IsHTTPURL(value string) bool {
+	destination, err := url.Parse(value)
+	return err == nil && (destination.Scheme == "http" || destination.Scheme == "https") && destination.Host != ""
+}
+
+// isLanguageTag сообщает, похожа ли строка на языковой тег BCP 47: части из латинских букв и цифр длиной
+// от 1 до 8 символов, разделенные "-", причем первая часть состоит только из букв.
+func isLanguageTag(tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	for i, subtag := range strings.Split(tag, "-") {
+		if subtag == "" || len(subtag) > maxLanguageSubtagLength {
+			return false
+		}
+		for _, r := range subtag {
+			isLetter := r >= 'a' && r <= 'z'
+			isDigit := r >= '0' && r <= '9'
+			if !isLetter && (i == 0 || !isDigit) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parseCIDR разбирает диапазон IP-адресов в нотации CIDR или отдельный IP-адрес.
+// IPv4-адреса, отображенные в IPv6, приводятся к IPv4.
+func parseCIDR(value string) (netip.Prefix, error) {
+	if !strings.Contains(value, "/") {
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"yp-go-short-url-service/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeRoutingRules(t *testing.T) {
+	t.Run("empty list", func(t *testing.T) {
+		rules, err := NormalizeRoutingRules(model.RoutingRules{})
+		require.NoError(t, err)
+		assert.Nil(t, rules)
+	})
+
+	t.Run("rules are normalized", func(t *testing.T) {
+		rules, err := NormalizeRoutingRules(model.RoutingRules{{
+			Device:      " Android ",
+			Languages:   []string{" RU ", "en-GB"},
+			CIDRs:       []string{"10.1.2.3/8", "192.0.2.7", "2001:db8::1/32"},
+			Destination: " https://example.com/app ",
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, model.RoutingRules{{
+			Device:      model.DeviceAndroid,
+			Languages:   []string{"ru", "en-gb"},
+			CIDRs:       []string{"10.0.0.0/8", "192.0.2.7/32", "2001:db8::/32"},
+			Destination: "https://example.com/app",
+		}}, rules)
+	})
+
+	tooMany := make(model.RoutingRules, MaxRoutingRules+1)
+	for i := range tooMany {
+		tooMany[i] = model.RoutingRule{Device: model.DeviceIOS, Destination: "https://example.com"}
+	}
+
+	tests := []struct {
+		name  string
+		rules model.RoutingRules
+	}{
+		{name: "too many rules", rules: tooMany},
+		{name: "rule without matcher", rules: model.RoutingRules{{Destination: "https://example.com"}}},
+		{name: "unknown device", rules: model.RoutingRules{{Device: "tv", Destination: "https://example.com"}}},
+		{name: "invalid language", rules: model.RoutingRules{{Languages: []string{"en_US"}, Destination: "https://example.com"}}},
+		{name: "language subtag too long", rules: model.RoutingRules{{Languages: []string{strings.Repeat("a", 9)}, Destination: "https://example.com"}}},
+		{name: "invalid cidr", rules: model.RoutingRules{{CIDRs: []string{"10.0.0.0/33"}, Destination: "https://example.com"}}},
+		{name: "relative destination", rules: model.RoutingRules{{Device: model.DeviceIOS, Destination: "/app"}}},
+		{name: "unsupported destination scheme", rules: model.RoutingRules{{Device: model.DeviceIOS, Destination: "javascript:alert(1)"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeRoutingRules(tt.rules)
+			assert.True(t, IsInvalidRoutingRulesError(err))
+		})
+	}
+}
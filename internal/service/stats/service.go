@@ -2,6 +2,7 @@ package stats
 
 import (
 	"context"
+	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/repository"
 	"yp-go-short-url-service/internal/service"
 )
@@ -9,16 +10,22 @@ import (
 func New(
 	userRepo repository.UserRepositoryReader,
 	urlsRepo repository.URLRepositoryReader,
+	purger service.DeletedURLsPurger,
+	urlCache service.Cache,
 ) service.StatsService {
 	return &serviceImpl{
 		userRepo: userRepo,
 		urlsRepo: urlsRepo,
+		purger:   purger,
+		urlCache: urlCache,
 	}
 }
 
 type serviceImpl struct {
 	userRepo repository.UserRepositoryReader
 	urlsRepo repository.URLRepositoryReader
+	purger   service.DeletedURLsPurger
+	urlCache service.Cache
 }
 
 func (s *serviceImpl) GetTotalURLsCount(ctx context.Context) (int64, error) {
@@ -28,3 +35,17 @@ func (s *serviceImpl) GetTotalURLsCount(ctx context.Context) (int64, error) {
 func (s *serviceImpl) GetTotalUsersCount(ctx context.Context) (int64, error) {
 	return s.userRepo.GetUsersCount(ctx)
 }
+
+func (s *serviceImpl) GetPurgeStats(_ context.Context) model.PurgeStats {
+	if s.purger == nil {
+		return model.PurgeStats{}
+	}
+	return s.purger.Stats()
+}
+
+func (s *serviceImpl) GetURLCacheStats(_ context.Context) model.CacheStats {
+	if s.urlCache == nil {
+		return model.CacheStats{}
+	}
+	return s.urlCache.Stats()
+}
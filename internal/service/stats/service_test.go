@@ -5,7 +5,9 @@ import (
 	"errors"
 	"testing"
 
+	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/repository/mock"
+	serviceMock "yp-go-short-url-service/internal/service/mock"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -21,7 +23,7 @@ func TestNew(t *testing.T) {
 	mockURLsRepo := mock.NewMockURLRepositoryReader(ctrl)
 
 	// Создаем сервис через тестовый конструктор
-	service := New(mockUserRepo, mockURLsRepo)
+	service := New(mockUserRepo, mockURLsRepo, nil, nil)
 
 	// Проверяем, что сервис создан корректно
 	assert.NotNil(t, service)
@@ -161,3 +163,49 @@ func Test_serviceImpl_GetTotalUsersCount(t *testing.T) {
 		assert.Equal(t, expectedCount, count)
 	})
 }
+
+func Test_serviceImpl_GetPurgeStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	t.Run("without purger", func(t *testing.T) {
+		service := New(mock.NewMockUserRepositoryReader(ctrl), mock.NewMockURLRepositoryReader(ctrl), nil, nil)
+
+		assert.Equal(t, model.PurgeStats{}, service.GetPurgeStats(ctx))
+	})
+
+	t.Run("with purger", func(t *testing.T) {
+		purger := serviceMock.NewMockDeletedURLsPurger(ctrl)
+		expected := model.PurgeStats{URLs: 10, UserLinks: 10}
+		purger.EXPECT().Stats().Return(expected)
+
+		service := New(mock.NewMockUserRepositoryReader(ctrl), mock.NewMockURLRepositoryReader(ctrl), purger, nil)
+
+		assert.Equal(t, expected, service.GetPurgeStats(ctx))
+	})
+}
+
+func Test_serviceImpl_GetURLCacheStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	t.Run("without cache", func(t *testing.T) {
+		service := New(mock.NewMockUserRepositoryReader(ctrl), mock.NewMockURLRepositoryReader(ctrl), nil, nil)
+
+		assert.Equal(t, model.CacheStats{}, service.GetURLCacheStats(ctx))
+	})
+
+	t.Run("with cache", func(t *testing.T) {
+		cache := serviceMock.NewMockCache(ctrl)
+		expected := model.CacheStats{Backend: "memory", Hits: 12, NegativeHits: 2, Misses: 3, Size: 3}
+		cache.EXPECT().Stats().Return(expected)
+
+		service := New(mock.NewMockUserRepositoryReader(ctrl), mock.NewMockURLRepositoryReader(ctrl), nil, cache)
+
+		assert.Equal(t, expected, service.GetURLCacheStats(ctx))
+	})
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ResolveTitle проверяет заголовок ссылки и возвращает его без пробелов по краям.
+// Возвращает ошибку ErrInvalidTitle, если заголовок длиннее MaxTitleLength символов или содержит управляющие символы.
+func ResolveTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return "", fmt.Errorf("%w: title must be at most %d characters", ErrInvalidTitle, MaxTitleLength)
+	}
+	if strings.ContainsFunc(title, unicode.IsControl) {
+		return "", fmt.Errorf("%w: title must not contain control characters", ErrInvalidTitle)
+	}
+
+	return title, nil
+}
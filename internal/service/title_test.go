@@ -0,0 +1,33 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTitle(t *testing.T) {
+	t.Run("title is trimmed", func(t *testing.T) {
+		title, err := ResolveTitle("  Квартальный отчет ")
+		require.NoError(t, err)
+		assert.Equal(t, "Квартальный отчет", title)
+	})
+
+	t.Run("length is counted in characters", func(t *testing.T) {
+		title, err := ResolveTitle(strings.Repeat("я", MaxTitleLength))
+		require.NoError(t, err)
+		assert.Len(t, []rune(title), MaxTitleLength)
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		_, err := ResolveTitle(strings.Repeat("я", MaxTitleLength+1))
+		assert.True(t, IsInvalidTitleError(err))
+	})
+
+	t.Run("control characters", func(t *testing.T) {
+		_, err := ResolveTitle("line\nbreak")
+		assert.True(t, IsInvalidTitleError(err))
+	})
+}
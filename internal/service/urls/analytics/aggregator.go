@@ -0,0 +1,208 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/service"
+
+	"go.uber.org/zap"
+)
+
+// flushTimeout - максимальная длительность одного сброса буфера переходов в базу данных
+const flushTimeout = 10 * time.Second
+
+// NewClickAggregator создает буфер переходов с отложенной записью. Переходы накапливаются в памяти:
+// счетчики суммируются по короткому коду, записи журнала складываются в очередь. С периодом flushInterval
+// буфер сбрасывается в базу данных пачкой, а при заполнении - досрочно. maxBuffered ограничивает и число
+// различных кодов, и число записей журнала; переходы сверх лимита отбрасываются до следующего сброса,
+// а число отброшенных счетчиков и записей журнала сообщается в логе при сбросе.
+// Если flushInterval не положителен, фоновый сброс отключен и выполняется только явным вызовом Flush
+// или при остановке. Возвращает реализацию интерфейса ClickAggregator.
+func NewClickAggregator(
+	clickRepository repository.ClickRepositoryWriter,
+	flushInterval time.Duration,
+	maxBuffered int,
+	logger *zap.SugaredLogger,
+) service.ClickAggregator {
+	aggregator := &clickAggregator{
+		clickRepository: clickRepository,
+		maxBuffered:     maxBuffered,
+		logger:          logger,
+		counters:        make(map[string]int64),
+		flushChan:       make(chan struct{}, 1),
+		stopChan:        make(chan struct{}),
+		wg:              &sync.WaitGroup{},
+	}
+
+	if flushInterval > 0 {
+		aggregator.wg.Add(1)
+		go aggregator.run(flushInterval)
+	}
+
+	return aggregator
+}
+
+type clickAggregator struct {
+	clickRepository repository.ClickRepositoryWriter
+	maxBuffered     int
+	logger          *zap.SugaredLogger
+
+	// mu защищает буфер: счетчики, записи журнала и число отброшенных переходов
+	mu              sync.Mutex
+	counters        map[string]int64
+	clicks          []*model.ClickModel
+	droppedCounters int64
+	droppedClicks   int64
+
+	// flushMu не дает двум сбросам выполняться одновременно, чтобы при ошибке буфер возвращался целиком
+	flushMu   sync.Mutex
+	flushChan chan struct{}
+	stopChan  chan struct{}
+	stopOnce  sync.Once
+	wg        *sync.WaitGroup
+}
+
+// run периодически сбрасывает буфер до получения сигнала остановки.
+// Заполненный буфер сбрасывается досрочно, не дожидаясь очередного тика.
+func (a *clickAggregator) run(interval time.Duration) {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.flushInBackground()
+		case <-a.flushChan:
+			a.flushInBackground()
+		case <-a.stopChan:
+			return
+		}
+	}
+}
+
+// flushInBackground сбрасывает буфер с ограничением по времени и логирует ошибку.
+func (a *clickAggregator) flushInBackground() {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := a.Flush(ctx); err != nil {
+		a.logger.Errorw("Failed to flush click buffer", "error", err)
+	}
+}
+
+// Record учитывает переход в буфере без обращения к базе данных.
+// Если в буфере нет места для счетчика кода или для записи журнала, соответствующая часть перехода
+// отбрасывается и учитывается в числе отброшенных, а фоновому процессу отправляется сигнал досрочного сброса.
+func (a *clickAggregator) Record(click *model.ClickModel) {
+	if click == nil {
+		return
+	}
+
+	a.mu.Lock()
+	if _, ok := a.counters[click.ShortURL]; ok || len(a.counters) < a.maxBuffered {
+		a.counters[click.ShortURL]++
+	} else {
+		a.droppedCounters++
+	}
+	if len(a.clicks) < a.maxBuffered {
+		a.clicks = append(a.clicks, click)
+	} else {
+		a.droppedClicks++
+	}
+	isFull := len(a.counters) >= a.maxBuffered || len(a.clicks) >= a.maxBuffered
+	a.mu.Unlock()
+
+	if isFull {
+		select {
+		case a.flushChan <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush сбрасывает накопленные счетчики и записи журнала в базу данных. Счетчики увеличиваются одним
+// пакетным upsert, записи журнала сохраняются одной пачкой. Если запись не удалась, несохраненная часть
+// возвращается в буфер в пределах лимита и будет записана при следующем сбросе.
+func (a *clickAggregator) Flush(ctx context.Context) error {
+	a.flushMu.Lock()
+	defer a.flushMu.Unlock()
+
+	a.mu.Lock()
+	counters, clicks := a.counters, a.clicks
+	droppedCounters, droppedClicks := a.droppedCounters, a.droppedClicks
+	a.counters, a.clicks = make(map[string]int64, len(counters)), nil
+	a.droppedCounters, a.droppedClicks = 0, 0
+	a.mu.Unlock()
+
+	if droppedCounters > 0 || droppedClicks > 0 {
+		a.logger.Warnw("Click buffer overflowed, clicks were dropped",
+			"dropped_counters", droppedCounters,
+			"dropped_clicks", droppedClicks,
+		)
+	}
+	if len(counters) == 0 && len(clicks) == 0 {
+		return nil
+	}
+
+	countersErr := a.clickRepository.IncrementClickCounters(ctx, counters)
+	clicksErr := a.clickRepository.RecordClicks(ctx, clicks)
+	if countersErr != nil || clicksErr != nil {
+		a.restore(countersErr, counters, clicksErr, clicks)
+	}
+
+	return errors.Join(countersErr, clicksErr)
+}
+
+// restore возвращает в буфер то, что не удалось записать, не превышая лимит; не поместившееся учитывается
+// в числе отброшенных.
+// Более ранние записи журнала ставятся перед накопленными за время сброса.
+func (a *clickAggregator) restore(
+	countersErr error,
+	counters map[string]int64,
+	clicksErr error,
+	clicks []*model.ClickModel,
+) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if countersErr != nil {
+		for shortURL, count := range counters {
+			if _, ok := a.counters[shortURL]; ok || len(a.counters) < a.maxBuffered {
+				a.counters[shortURL] += count
+			} else {
+				a.droppedCounters += count
+			}
+		}
+	}
+
+	if clicksErr != nil {
+		clicks = append(clicks, a.clicks...)
+		if len(clicks) > a.maxBuffered {
+			a.droppedClicks += int64(len(clicks) - a.maxBuffered)
+			clicks = clicks[len(clicks)-a.maxBuffered:]
+		}
+		a.clicks = clicks
+	}
+}
+
+// Stop останавливает фоновый процесс и выполняет финальный сброс буфера.
+// Переходы, учтенные после остановки, в базу данных не попадают. Повторные вызовы безопасны.
+func (a *clickAggregator) Stop() {
+	a.stopOnce.Do(func() {
+		close(a.stopChan)
+		a.wg.Wait()
+
+		ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+		defer cancel()
+
+		if err := a.Flush(ctx); err != nil {
+			a.logger.Errorw("Failed to flush click buffer on shutdown", "error", err)
+		}
+	})
+}
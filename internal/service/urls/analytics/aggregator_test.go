@@ -0,0 +1,152 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestClickAggregator_Flush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockClickRepositoryWriter(ctrl)
+	aggregator := NewClickAggregator(mockRepo, 0, 3, zap.NewNop().Sugar())
+	defer aggregator.Stop()
+
+	ctx := context.Background()
+	click := func(shortURL string) *model.ClickModel {
+		return &model.ClickModel{ShortURL: shortURL, ClickedAt: time.Now()}
+	}
+
+	t.Run("counters are aggregated per code", func(t *testing.T) {
+		first, second, third := click("abc123"), click("abc123"), click("xyz789")
+		aggregator.Record(first)
+		aggregator.Record(second)
+		aggregator.Record(third)
+
+		mockRepo.EXPECT().IncrementClickCounters(ctx, map[string]int64{"abc123": 2, "xyz789": 1}).Return(nil)
+		mockRepo.EXPECT().RecordClicks(ctx, []*model.ClickModel{first, second, third}).Return(nil)
+
+		require.NoError(t, aggregator.Flush(ctx))
+	})
+
+	t.Run("empty buffer is not flushed", func(t *testing.T) {
+		require.NoError(t, aggregator.Flush(ctx))
+	})
+
+	t.Run("buffer is bounded", func(t *testing.T) {
+		for _, shortURL := range []string{"a", "b", "c", "d", "a"} {
+			aggregator.Record(click(shortURL))
+		}
+
+		mockRepo.EXPECT().IncrementClickCounters(ctx, map[string]int64{"a": 2, "b": 1, "c": 1}).Return(nil)
+		mockRepo.EXPECT().RecordClicks(ctx, gomock.Len(3)).Return(nil)
+
+		require.NoError(t, aggregator.Flush(ctx))
+	})
+
+	t.Run("failed flush is retried", func(t *testing.T) {
+		first := click("abc123")
+		aggregator.Record(first)
+
+		dbErr := errors.New("database is locked")
+		mockRepo.EXPECT().IncrementClickCounters(ctx, map[string]int64{"abc123": 1}).Return(dbErr)
+		mockRepo.EXPECT().RecordClicks(ctx, []*model.ClickModel{first}).Return(nil)
+		assert.ErrorIs(t, aggregator.Flush(ctx), dbErr)
+
+		second := click("abc123")
+		aggregator.Record(second)
+
+		// Счетчик возвращен в буфер, а записи журнала повторно не сохраняются
+		mockRepo.EXPECT().IncrementClickCounters(ctx, map[string]int64{"abc123": 2}).Return(nil)
+		mockRepo.EXPECT().RecordClicks(ctx, []*model.ClickModel{second}).Return(nil)
+		require.NoError(t, aggregator.Flush(ctx))
+	})
+}
+
+func TestClickAggregator_DroppedClicks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockClickRepositoryWriter(ctrl)
+	core, logs := observer.New(zap.WarnLevel)
+	aggregator := NewClickAggregator(mockRepo, 0, 2, zap.New(core).Sugar())
+	defer aggregator.Stop()
+
+	ctx := context.Background()
+	for _, shortURL := range []string{"a", "a", "a", "b", "c"} {
+		aggregator.Record(&model.ClickModel{ShortURL: shortURL, ClickedAt: time.Now()})
+	}
+
+	mockRepo.EXPECT().IncrementClickCounters(ctx, map[string]int64{"a": 3, "b": 1}).Return(nil)
+	mockRepo.EXPECT().RecordClicks(ctx, gomock.Len(2)).Return(nil)
+	require.NoError(t, aggregator.Flush(ctx))
+
+	entries := logs.FilterMessage("Click buffer overflowed, clicks were dropped").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(1), entries[0].ContextMap()["dropped_counters"])
+	assert.Equal(t, int64(3), entries[0].ContextMap()["dropped_clicks"])
+
+	t.Run("counts are reset after flush", func(t *testing.T) {
+		aggregator.Record(&model.ClickModel{ShortURL: "a", ClickedAt: time.Now()})
+
+		mockRepo.EXPECT().IncrementClickCounters(ctx, map[string]int64{"a": 1}).Return(nil)
+		mockRepo.EXPECT().RecordClicks(ctx, gomock.Len(1)).Return(nil)
+		require.NoError(t, aggregator.Flush(ctx))
+
+		assert.Equal(t, 1, logs.FilterMessage("Click buffer overflowed, clicks were dropped").Len())
+	})
+}
+
+func TestClickAggregator_Stop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockClickRepositoryWriter(ctrl)
+	aggregator := NewClickAggregator(mockRepo, time.Hour, 100, zap.NewNop().Sugar())
+
+	aggregator.Record(&model.ClickModel{ShortURL: "abc123"})
+
+	mockRepo.EXPECT().IncrementClickCounters(gomock.Any(), map[string]int64{"abc123": 1}).Return(nil)
+	mockRepo.EXPECT().RecordClicks(gomock.Any(), gomock.Len(1)).Return(nil)
+
+	aggregator.Stop()
+	// Повторная остановка не выполняет сброс еще раз
+	aggregator.Stop()
+}
+
+func TestClickAggregator_FlushesFullBuffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockClickRepositoryWriter(ctrl)
+	aggregator := NewClickAggregator(mockRepo, time.Hour, 2, zap.NewNop().Sugar())
+	defer aggregator.Stop()
+
+	flushed := make(chan struct{})
+	mockRepo.EXPECT().IncrementClickCounters(gomock.Any(), map[string]int64{"abc123": 2}).Return(nil)
+	mockRepo.EXPECT().RecordClicks(gomock.Any(), gomock.Len(2)).
+		DoAndReturn(func(context.Context, []*model.ClickModel) error {
+			close(flushed)
+			return nil
+		})
+
+	aggregator.Record(&model.ClickModel{ShortURL: "abc123"})
+	aggregator.Record(&model.ClickModel{ShortURL: "abc123"})
+
+	select {
+	case <-flushed:
+	case <-time.After(time.Second):
+		t.Fatal("full buffer was not flushed")
+	}
+}
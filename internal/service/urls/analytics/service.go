@@ -0,0 +1,100 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/service"
+)
+
+const (
+	// DefaultStatsDays - период разбивки статистики по дням, если он не указан
+	DefaultStatsDays = 30
+	// MaxStatsDays - максимальный период разбивки статистики по дням
+	MaxStatsDays = 365
+)
+
+// NewURLStatsService создает новый сервис статистики переходов по ссылкам.
+// Принимает репозиторий для чтения журнала переходов и возвращает реализацию интерфейса URLStatsService.
+func NewURLStatsService(clickRepository repository.ClickRepositoryReader) service.URLStatsService {
+	return &urlStatsService{
+		clickRepository: clickRepository,
+		now:             time.Now,
+	}
+}
+
+type urlStatsService struct {
+	clickRepository repository.ClickRepositoryReader
+	now             func() time.Time
+}
+
+// GetURLStats возвращает статистику переходов по ссылке текущего пользователя.
+// Разбивка по дням охватывает последние days дней (UTC) включая текущий, дни без переходов заполняются нулями.
+// Если days равен 0, используется DefaultStatsDays. Возвращает ErrInvalidStatsPeriod для days вне диапазона,
+// ErrURLNotFound, если ссылка не найдена, и ErrURLNotOwned, если ссылка принадлежит другому пользователю.
+func (s *urlStatsService) GetURLStats(ctx context.Context, shortURL string, days int) (*model.ClickStats, error) {
+	logger := middleware.GetLogger(ctx)
+	requestID := middleware.ExtractRequestID(ctx)
+
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return nil, errors.New("user is not authenticated")
+	}
+
+	if days == 0 {
+		days = DefaultStatsDays
+	}
+	if days < 0 || days > MaxStatsDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", service.ErrInvalidStatsPeriod, MaxStatsDays)
+	}
+
+	today := s.now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, 1-days)
+
+	stats, err := s.clickRepository.GetClickStats(ctx, shortURL, user.ID, since)
+	if err != nil {
+		switch {
+		case repository.IsNotFoundError(err):
+			return nil, service.ErrURLNotFound
+		case repository.IsNotOwnedError(err):
+			logger.Warnw("Stats requested for short URL of another user",
+				"short_url", shortURL,
+				"user_id", user.ID,
+				"request_id", requestID,
+			)
+			return nil, service.ErrURLNotOwned
+		}
+		logger.Errorw("Failed to get short URL stats from storage",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		return nil, err
+	}
+
+	stats.Daily = fillDays(stats.Daily, since, days)
+	return stats, nil
+}
+
+// fillDays возвращает разбивку по дням длиной days начиная с since, в которой дни без переходов заполнены нулями.
+func fillDays(daily []model.DailyClicks, since time.Time, days int) []model.DailyClicks {
+	byDate := make(map[string]model.DailyClicks, len(daily))
+	for _, day := range daily {
+		byDate[day.Date.Format(time.DateOnly)] = day
+	}
+
+	filled := make([]model.DailyClicks, days)
+	for i := range filled {
+		date := since.AddDate(0, 0, i)
+		day, ok := byDate[date.Format(time.DateOnly)]
+		if !ok {
+			day = model.DailyClicks{Date: date}
+		}
+		filled[i] = day
+	}
+	return filled
+}
@@ -0,0 +1,93 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/mock"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestURLStatsService_GetURLStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockClickRepositoryReader(ctrl)
+	now := time.Date(2025, 3, 4, 15, 30, 0, 0, time.UTC)
+	statsService := &urlStatsService{
+		clickRepository: mockRepo,
+		now:             func() time.Time { return now },
+	}
+
+	ctx := context.WithValue(context.Background(), middleware.JWTTokenContextKey, &model.UserModel{ID: "owner"})
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("days without clicks are filled with zeros", func(t *testing.T) {
+		mockRepo.EXPECT().GetClickStats(ctx, "abc123", "owner", day(1)).Return(&model.ClickStats{
+			ShortURL:       "abc123",
+			TotalClicks:    7,
+			UniqueVisitors: 4,
+			Daily: []model.DailyClicks{
+				{Date: day(2), Clicks: 3, UniqueVisitors: 2},
+				{Date: day(4), Clicks: 1, UniqueVisitors: 1},
+			},
+		}, nil)
+
+		stats, err := statsService.GetURLStats(ctx, "abc123", 4)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), stats.TotalClicks)
+		assert.Equal(t, int64(4), stats.UniqueVisitors)
+		assert.Equal(t, []model.DailyClicks{
+			{Date: day(1)},
+			{Date: day(2), Clicks: 3, UniqueVisitors: 2},
+			{Date: day(3)},
+			{Date: day(4), Clicks: 1, UniqueVisitors: 1},
+		}, stats.Daily)
+	})
+
+	t.Run("default period", func(t *testing.T) {
+		since := day(4).AddDate(0, 0, 1-DefaultStatsDays)
+		mockRepo.EXPECT().GetClickStats(ctx, "abc123", "owner", since).
+			Return(&model.ClickStats{ShortURL: "abc123", Daily: []model.DailyClicks{}}, nil)
+
+		stats, err := statsService.GetURLStats(ctx, "abc123", 0)
+		require.NoError(t, err)
+		assert.Len(t, stats.Daily, DefaultStatsDays)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		_, err := statsService.GetURLStats(ctx, "abc123", -1)
+		assert.ErrorIs(t, err, service.ErrInvalidStatsPeriod)
+
+		_, err = statsService.GetURLStats(ctx, "abc123", MaxStatsDays+1)
+		assert.ErrorIs(t, err, service.ErrInvalidStatsPeriod)
+	})
+
+	t.Run("repository errors are mapped", func(t *testing.T) {
+		mockRepo.EXPECT().GetClickStats(ctx, "missing", "owner", gomock.Any()).Return(nil, repository.ErrURLNotFound)
+		_, err := statsService.GetURLStats(ctx, "missing", 7)
+		assert.ErrorIs(t, err, service.ErrURLNotFound)
+
+		mockRepo.EXPECT().GetClickStats(ctx, "foreign", "owner", gomock.Any()).Return(nil, repository.ErrURLNotOwned)
+		_, err = statsService.GetURLStats(ctx, "foreign", 7)
+		assert.ErrorIs(t, err, service.ErrURLNotOwned)
+
+		dbErr := errors.New("database connection failed")
+		mockRepo.EXPECT().GetClickStats(ctx, "abc123", "owner", gomock.Any()).Return(nil, dbErr)
+		_, err = statsService.GetURLStats(ctx, "abc123", 7)
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("user is required", func(t *testing.T) {
+		_, err := statsService.GetURLStats(context.Background(), "abc123", 7)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/config/db"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/sqlite"
+	"yp-go-short-url-service/internal/service"
+	"yp-go-short-url-service/internal/service/urls/extractor"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// benchmarkLinks - количество ссылок в базе данных бенчмарков.
+const benchmarkLinks = 1000
+
+// benchmarkOptions - параметры кэша в бенчмарках; все ссылки помещаются в кэш.
+var benchmarkOptions = Options{Size: benchmarkLinks, TTL: time.Minute, NegativeTTL: time.Minute}
+
+func benchmarkCode(i int) string {
+	return fmt.Sprintf("code%04d", i)
+}
+
+// setupBenchmarkRepository создает репозиторий SQLite во временном файле с benchmarkLinks ссылками.
+func setupBenchmarkRepository(b *testing.B) repository.URLRepository {
+	sqliteDB, err := db.SetupSQLiteDB(filepath.Join(b.TempDir(), "bench.db"), zap.NewNop().Sugar())
+	require.NoError(b, err)
+	b.Cleanup(func() { _ = sqliteDB.Close() })
+
+	repo := sqlite.NewURLsRepository(sqliteDB)
+	urls := make([]*model.URLsModel, benchmarkLinks)
+	for i := range urls {
+		urls[i] = &model.URLsModel{ShortURL: benchmarkCode(i), LongURL: fmt.Sprintf("https://example.com/%d", i)}
+	}
+	require.NoError(b, repo.CreateBatch(context.Background(), urls))
+	return repo
+}
+
+// benchmarkCaches возвращает варианты кэша для сравнения: без кэша, в памяти процесса и в Redis.
+func benchmarkCaches() map[string]func(b *testing.B) service.Cache {
+	return map[string]func(b *testing.B) service.Cache{
+		"without cache": func(b *testing.B) service.Cache { return nil },
+		"memory":        func(b *testing.B) service.Cache { return NewMemoryCache(benchmarkOptions) },
+		"redis": func(b *testing.B) service.Cache {
+			return newTestRedisCache(b, miniredis.RunT(b))
+		},
+	}
+}
+
+// benchmarkExtract выполняет переходы по кодам code(i) через сервис извлечения URL с кэшем urlCache.
+func benchmarkExtract(b *testing.B, urlCache service.Cache, code func(i int) string) {
+	repo := setupBenchmarkRepository(b)
+	service := extractor.NewLinkExtractorService(repo, repo, nil, nil, nil, urlCache)
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := service.ExtractLongURL(ctx, code(i), model.RedirectRequest{}); err != nil {
+			b.Fatalf("redirect failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkRedirect сравнивает переход по ссылке с чтением из SQLite и через кэш поиска по короткому коду.
+func BenchmarkRedirect(b *testing.B) {
+	for name, newCache := range benchmarkCaches() {
+		b.Run(name, func(b *testing.B) {
+			benchmarkExtract(b, newCache(b), func(i int) string { return benchmarkCode(i % benchmarkLinks) })
+		})
+	}
+}
+
+// BenchmarkRedirect_UnknownCode сравнивает переход по несуществующему коду без кэша и с кэшированием ненайденных кодов.
+func BenchmarkRedirect_UnknownCode(b *testing.B) {
+	for name, newCache := range benchmarkCaches() {
+		b.Run(name, func(b *testing.B) {
+			benchmarkExtract(b, newCache(b), func(int) string { return "missing" })
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// BackendMemory - кэш в памяти процесса.
+	BackendMemory = "memory"
+	// BackendRedis - кэш в Redis, общий для экземпляров сервиса.
+	BackendRedis = "redis"
+)
+
+// Options содержит параметры кэша ссылок по короткому коду.
+type Options struct {
+	// Size - максимальное количество записей в памяти процесса; не меньше 1.
+	Size int
+	// TTL - срок жизни записи о найденной ссылке.
+	TTL time.Duration
+	// NegativeTTL - срок жизни записи о ненайденном коротком коде; неположительное значение отключает
+	// кэширование ненайденных кодов.
+	NegativeTTL time.Duration
+}
+
+// NewCache создает кэш ссылок в хранилище backend: BackendMemory или BackendRedis; пустое значение
+// соответствует BackendMemory. Для BackendRedis подключается к серверу по адресу redisURL
+// (например, redis://localhost:6379/0); созданный клиент закрывается методом Close кэша.
+// Возвращает ошибку для неизвестного хранилища, некорректного адреса или недоступного сервера Redis.
+func NewCache(ctx context.Context, backend, redisURL string, opts Options, logger *zap.SugaredLogger) (service.Cache, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case BackendMemory, "":
+		return NewMemoryCache(opts), nil
+	case BackendRedis:
+		if redisURL == "" {
+			return nil, errors.New("redis url is required for redis cache backend")
+		}
+		redisOpts, err := redis.ParseURL(redisURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis url: %w", err)
+		}
+		client := redis.NewClient(redisOpts)
+		c, err := newRedisCache(ctx, client, RedisOptions{Options: opts}, logger)
+		if err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+		c.ownsClient = true
+		return c, nil
+	default:
+		return nil, fmt.Errorf("unknown url cache backend %q", backend)
+	}
+}
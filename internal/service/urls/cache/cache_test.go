@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewCache(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+	opts := Options{Size: 10, TTL: time.Minute}
+
+	t.Run("memory", func(t *testing.T) {
+		for _, backend := range []string{"", "memory", " Memory "} {
+			cache, err := NewCache(ctx, backend, "", opts, logger)
+			require.NoError(t, err)
+			assert.Equal(t, BackendMemory, cache.Stats().Backend)
+		}
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		cache, err := NewCache(ctx, "redis", "redis://"+server.Addr()+"/0", opts, logger)
+		require.NoError(t, err)
+		assert.Equal(t, BackendRedis, cache.Stats().Backend)
+		assert.NoError(t, cache.Close())
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		_, err := NewCache(ctx, "memcached", "", opts, logger)
+		assert.ErrorContains(t, err, "unknown url cache backend")
+
+		_, err = NewCache(ctx, "redis", "", opts, logger)
+		assert.Error(t, err)
+
+		_, err = NewCache(ctx, "redis", "http://localhost:6379", opts, logger)
+		assert.ErrorContains(t, err, "invalid redis url")
+	})
+}
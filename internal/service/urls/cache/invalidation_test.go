@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/config/db"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/sqlite"
+	"yp-go-short-url-service/internal/service"
+	"yp-go-short-url-service/internal/service/urls/destructor"
+	"yp-go-short-url-service/internal/service/urls/editor"
+	"yp-go-short-url-service/internal/service/urls/expiration"
+	"yp-go-short-url-service/internal/service/urls/extractor"
+	"yp-go-short-url-service/internal/service/urls/shortener"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestCache_Invalidation проверяет, что переход по ссылке через кэш видит изменения, внесенные сервисами
+// создания, изменения, удаления, восстановления, пометки истекших и окончательного удаления ссылок.
+func TestCache_Invalidation(t *testing.T) {
+	caches := map[string]func(t *testing.T) service.Cache{
+		"memory": func(t *testing.T) service.Cache { return NewMemoryCache(benchmarkOptions) },
+		"redis":  func(t *testing.T) service.Cache { return newTestRedisCache(t, miniredis.RunT(t)) },
+	}
+	for name, newCache := range caches {
+		t.Run(name, func(t *testing.T) {
+			testCacheInvalidation(t, newCache(t))
+		})
+	}
+}
+
+func testCacheInvalidation(t *testing.T, urlCache service.Cache) {
+	logger := zap.NewNop().Sugar()
+	sqliteDB, err := db.SetupSQLiteDB(filepath.Join(t.TempDir(), "cache.db"), logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqliteDB.Close() })
+	// Запись в SQLite выполняется по одной, лишние соединения приводят к ошибкам блокировки
+	sqliteDB.SetMaxOpenConns(1)
+
+	ctx := middleware.WithLogger(context.Background(), logger)
+	ctx = context.WithValue(ctx, middleware.JWTTokenContextKey, &model.UserModel{ID: "owner"})
+	_, err = sqliteDB.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`, "owner", "owner", false)
+	require.NoError(t, err)
+
+	urlsRepo := sqlite.NewURLsRepository(sqliteDB)
+	userURLsRepo := sqlite.NewUserURLsRepository(sqliteDB)
+
+	shortenerService := shortener.NewURLShortenerService(
+		urlsRepo, userURLsRepo, nil, shortener.NewHashCodeGenerator(8), shortener.DedupPerUser, urlCache,
+	)
+	extractorService := extractor.NewLinkExtractorService(urlsRepo, urlsRepo, userURLsRepo, nil, nil, urlCache)
+	editorService := editor.NewURLEditorService(urlsRepo, nil, nil, urlCache)
+	destructorService := destructor.NewURLDestructorService(
+		urlsRepo, userURLsRepo, sqlite.NewDeleteJobsRepository(sqliteDB), 0, 0, urlCache, logger,
+	)
+	t.Cleanup(destructorService.Stop)
+	sweeper := expiration.NewExpiredURLsSweeper(urlsRepo, 0, logger)
+	t.Cleanup(sweeper.Stop)
+	purger := destructor.NewDeletedURLsPurger(urlsRepo, time.Hour, 0, urlCache, logger)
+	t.Cleanup(purger.Stop)
+
+	resolve := func(shortURL string) (string, error) {
+		result, err := extractorService.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		if result == nil {
+			return "", err
+		}
+		return result.Location, err
+	}
+	eventuallyResolves := func(shortURL, want string) {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			location, err := resolve(shortURL)
+			return err == nil && location == want
+		}, time.Second, 5*time.Millisecond)
+	}
+
+	t.Run("create replaces cached not found", func(t *testing.T) {
+		location, err := resolve("landing")
+		require.NoError(t, err)
+		assert.Empty(t, location)
+
+		_, err = shortenerService.ShortURLWithOptions(ctx, "https://example.com/landing", service.ShortenOptions{Alias: "landing"})
+		require.NoError(t, err)
+
+		location, err = resolve("landing")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/landing", location)
+	})
+
+	t.Run("edit", func(t *testing.T) {
+		newLongURL := "https://example.com/landing-v2"
+		_, err := editorService.UpdateUserURL(ctx, "landing", service.UpdateOptions{LongURL: &newLongURL})
+		require.NoError(t, err)
+
+		location, err := resolve("landing")
+		require.NoError(t, err)
+		assert.Equal(t, newLongURL, location)
+	})
+
+	t.Run("delete and restore", func(t *testing.T) {
+		require.NoError(t, destructorService.DeleteURL(ctx, "landing"))
+		_, err := resolve("landing")
+		assert.ErrorIs(t, err, service.ErrURLWasDeleted)
+
+		require.NoError(t, destructorService.RestoreURLsByBatch(ctx, []string{"landing"}))
+		eventuallyResolves("landing", "https://example.com/landing-v2")
+	})
+
+	t.Run("batch delete", func(t *testing.T) {
+		_, err := destructorService.DeleteURLsByBatch(ctx, []string{"landing"})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			_, err := resolve("landing")
+			return errors.Is(err, service.ErrURLWasDeleted)
+		}, time.Second, 5*time.Millisecond)
+
+		require.NoError(t, destructorService.RestoreURLsByBatch(ctx, []string{"landing"}))
+		eventuallyResolves("landing", "https://example.com/landing-v2")
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		_, err := shortenerService.ShortURLWithOptions(ctx, "https://example.com/promo",
+			service.ShortenOptions{Alias: "promo", TTL: 200 * time.Millisecond})
+		require.NoError(t, err)
+		location, err := resolve("promo")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/promo", location)
+
+		// Срок действия проверяется по записи кэша: ссылка истекает без сброса записи
+		require.Eventually(t, func() bool {
+			_, err := resolve("promo")
+			return errors.Is(err, service.ErrURLExpired)
+		}, 2*time.Second, 10*time.Millisecond)
+
+		marked, err := sweeper.Sweep(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), marked)
+		_, err = resolve("promo")
+		assert.ErrorIs(t, err, service.ErrURLExpired)
+
+		// Продление срока владельцем сбрасывает запись
+		_, err = editorService.UpdateUserURL(ctx, "promo", service.UpdateOptions{ClearExpiration: true})
+		require.NoError(t, err)
+		location, err = resolve("promo")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/promo", location)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		require.NoError(t, destructorService.DeleteURL(ctx, "landing"))
+		_, err := resolve("landing")
+		assert.ErrorIs(t, err, service.ErrURLWasDeleted)
+
+		_, err = sqliteDB.ExecContext(ctx, `UPDATE urls SET deleted_at = datetime('now', '-1 day') WHERE short_url = ?`, "landing")
+		require.NoError(t, err)
+		purged, err := purger.Purge(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged.URLs)
+
+		// Окончательно удаленная ссылка больше не считается удаленной
+		location, err := resolve("landing")
+		require.NoError(t, err)
+		assert.Empty(t, location)
+	})
+}
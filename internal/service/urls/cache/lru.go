@@ -0,0 +1,178 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+	"yp-go-short-url-service/internal/model"
+)
+
+// lruCache - ограниченный по размеру кэш ссылок по короткому коду с вытеснением давно не используемых записей
+// и сроком жизни каждой записи. Запись со ссылкой nil означает, что короткий код не найден.
+// Для кодов, которые сейчас загружаются, хранится поколение: удаление записи увеличивает поколение
+// ее кода, и загрузка, начатая до удаления, не сохраняет в кэш прочитанное до изменения состояние.
+// Удаление записи не мешает загрузкам других кодов.
+type lruCache struct {
+	capacity int
+
+	mu        sync.Mutex
+	items     map[string]*list.Element
+	order     *list.List
+	loads     map[string]*lruLoad
+	evictions int64
+}
+
+// lruLoad - поколение кода и число его незавершенных загрузок.
+// Запись удаляется после завершения последней загрузки.
+type lruLoad struct {
+	generation uint64
+	pending    int
+}
+
+type lruEntry struct {
+	key       string
+	url       *model.URLsModel
+	expiresAt time.Time
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: max(capacity, 1),
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		loads:    make(map[string]*lruLoad),
+	}
+}
+
+// get возвращает ссылку из записи для key и признак наличия действующей записи.
+// Просроченная запись удаляется.
+func (c *lruCache) get(key string, now time.Time) (*model.URLsModel, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !now.Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.url, true
+}
+
+// beginLoad регистрирует загрузку key и возвращает поколение кода; его нужно получить до чтения
+// из базы данных. После загрузки нужно вызвать endLoad.
+func (c *lruCache) beginLoad(key string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	load, ok := c.loads[key]
+	if !ok {
+		load = &lruLoad{}
+		c.loads[key] = load
+	}
+	load.pending++
+	return load.generation
+}
+
+// endLoad завершает загрузку key, зарегистрированную beginLoad.
+func (c *lruCache) endLoad(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if load, ok := c.loads[key]; ok {
+		load.pending--
+		if load.pending <= 0 {
+			delete(c.loads, key)
+		}
+	}
+}
+
+// isCurrent сообщает, что запись для key не удалялась с момента получения generation.
+func (c *lruCache) isCurrent(key string, generation uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.isCurrentLocked(key, generation)
+}
+
+func (c *lruCache) isCurrentLocked(key string, generation uint64) bool {
+	load, ok := c.loads[key]
+	if !ok {
+		return generation == 0
+	}
+	return load.generation == generation
+}
+
+// add сохраняет запись для key до expiresAt, если с момента получения generation запись для key
+// не удалялась. При превышении размера вытесняется давно не используемая запись.
+func (c *lruCache) add(key string, url *model.URLsModel, expiresAt time.Time, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.isCurrentLocked(key, generation) {
+		return
+	}
+
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.url, entry.expiresAt = url, expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, url: url, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+		c.evictions++
+	}
+}
+
+// remove удаляет записи для keys и возвращает число удаленных записей.
+func (c *lruCache) remove(keys ...string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for _, key := range keys {
+		if load, ok := c.loads[key]; ok {
+			load.generation++
+		}
+		if element, ok := c.items[key]; ok {
+			c.order.Remove(element)
+			delete(c.items, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// clear удаляет все записи и возвращает их число.
+func (c *lruCache) clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, load := range c.loads {
+		load.generation++
+	}
+	removed := c.order.Len()
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+	return removed
+}
+
+// stats возвращает число записей и число вытесненных записей.
+func (c *lruCache) stats() (size int, evictions int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len(), c.evictions
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Minute)
+
+	t.Run("evicts least recently used entry", func(t *testing.T) {
+		cache := newLRUCache(2)
+		loadInto(cache, "a", &model.URLsModel{ShortURL: "a"}, later)
+		loadInto(cache, "b", &model.URLsModel{ShortURL: "b"}, later)
+
+		// Обращение к "a" делает вытесняемой запись "b"
+		_, ok := cache.get("a", now)
+		require.True(t, ok)
+		loadInto(cache, "c", nil, later)
+
+		_, ok = cache.get("b", now)
+		assert.False(t, ok)
+		url, ok := cache.get("a", now)
+		assert.True(t, ok)
+		assert.Equal(t, "a", url.ShortURL)
+		url, ok = cache.get("c", now)
+		assert.True(t, ok)
+		assert.Nil(t, url)
+
+		size, evictions := cache.stats()
+		assert.Equal(t, 2, size)
+		assert.Equal(t, int64(1), evictions)
+	})
+
+	t.Run("expired entries are dropped", func(t *testing.T) {
+		cache := newLRUCache(2)
+		loadInto(cache, "a", &model.URLsModel{ShortURL: "a"}, now.Add(time.Second))
+
+		_, ok := cache.get("a", now)
+		assert.True(t, ok)
+		_, ok = cache.get("a", now.Add(time.Second))
+		assert.False(t, ok)
+
+		size, _ := cache.stats()
+		assert.Zero(t, size)
+	})
+
+	t.Run("existing entry is replaced", func(t *testing.T) {
+		cache := newLRUCache(2)
+		loadInto(cache, "a", nil, later)
+		loadInto(cache, "a", &model.URLsModel{ShortURL: "a"}, later)
+
+		url, ok := cache.get("a", now)
+		require.True(t, ok)
+		assert.NotNil(t, url)
+		size, _ := cache.stats()
+		assert.Equal(t, 1, size)
+	})
+
+	t.Run("load started before removal is not stored", func(t *testing.T) {
+		cache := newLRUCache(2)
+		generation := cache.beginLoad("a")
+		assert.Zero(t, cache.remove("a"))
+
+		cache.add("a", &model.URLsModel{ShortURL: "a"}, later, generation)
+		cache.endLoad("a")
+		_, ok := cache.get("a", now)
+		assert.False(t, ok)
+
+		// Следующая загрузка сохраняется
+		loadInto(cache, "a", &model.URLsModel{ShortURL: "a"}, later)
+		_, ok = cache.get("a", now)
+		assert.True(t, ok)
+	})
+
+	t.Run("removal of another key does not discard load", func(t *testing.T) {
+		cache := newLRUCache(2)
+		generation := cache.beginLoad("a")
+		cache.remove("b")
+
+		cache.add("a", &model.URLsModel{ShortURL: "a"}, later, generation)
+		cache.endLoad("a")
+		_, ok := cache.get("a", now)
+		assert.True(t, ok)
+	})
+
+	t.Run("clear discards every load in progress", func(t *testing.T) {
+		cache := newLRUCache(2)
+		generationA, generationB := cache.beginLoad("a"), cache.beginLoad("b")
+		cache.clear()
+
+		cache.add("a", nil, later, generationA)
+		cache.add("b", nil, later, generationB)
+		cache.endLoad("a")
+		cache.endLoad("b")
+		size, _ := cache.stats()
+		assert.Zero(t, size)
+		assert.Empty(t, cache.loads)
+	})
+
+	t.Run("remove and clear", func(t *testing.T) {
+		cache := newLRUCache(3)
+		for _, key := range []string{"a", "b", "c"} {
+			loadInto(cache, key, nil, later)
+		}
+
+		assert.Equal(t, 1, cache.remove("a", "missing"))
+		assert.Equal(t, 2, cache.clear())
+
+		size, _ := cache.stats()
+		assert.Zero(t, size)
+	})
+}
+
+// loadInto сохраняет запись так же, как загрузка без параллельных удалений.
+func loadInto(cache *lruCache, key string, url *model.URLsModel, expiresAt time.Time) {
+	generation := cache.beginLoad(key)
+	defer cache.endLoad(key)
+	cache.add(key, url, expiresAt, generation)
+}
@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/service"
+)
+
+// NewMemoryCache создает кэш ссылок в памяти процесса размером opts.Size записей.
+// Найденные ссылки хранятся opts.TTL, ненайденные короткие коды - opts.NegativeTTL; при переполнении
+// вытесняются давно не используемые записи. Invalidate сбрасывает записи только в этом экземпляре сервиса.
+// Возвращает реализацию интерфейса Cache.
+func NewMemoryCache(opts Options) service.Cache {
+	return newMemoryCache(opts)
+}
+
+type memoryCache struct {
+	lru         *lruCache
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	hits          atomic.Int64
+	negativeHits  atomic.Int64
+	sharedHits    atomic.Int64
+	misses        atomic.Int64
+	invalidations atomic.Int64
+}
+
+func newMemoryCache(opts Options) *memoryCache {
+	return &memoryCache{
+		lru:         newLRUCache(opts.Size),
+		ttl:         opts.TTL,
+		negativeTTL: opts.NegativeTTL,
+	}
+}
+
+// GetOrLoad возвращает ссылку из кэша, а при промахе загружает ее функцией load и сохраняет в кэш.
+// Ошибки load не кэшируются. Возвращаемая модель - копия записи кэша.
+func (c *memoryCache) GetOrLoad(ctx context.Context, shortURL string, load service.CacheLoader) (*model.URLsModel, error) {
+	if url, ok := c.lookup(shortURL); ok {
+		return url, nil
+	}
+
+	generation := c.lru.beginLoad(shortURL)
+	defer c.lru.endLoad(shortURL)
+	url, err := c.load(ctx, shortURL, load)
+	if err != nil {
+		return nil, err
+	}
+	c.store(shortURL, url, generation)
+	return url, nil
+}
+
+// Invalidate удаляет из кэша записи для указанных коротких кодов.
+func (c *memoryCache) Invalidate(_ context.Context, shortURLs ...string) {
+	c.invalidations.Add(int64(c.lru.remove(shortURLs...)))
+}
+
+// Stats возвращает счетчики кэша.
+func (c *memoryCache) Stats() model.CacheStats {
+	return c.stats(BackendMemory)
+}
+
+// Close ничего не делает: кэш в памяти не держит внешних ресурсов.
+func (c *memoryCache) Close() error {
+	return nil
+}
+
+// lookup возвращает копию ссылки из записи для shortURL и признак попадания в кэш.
+// Для записи о ненайденном коротком коде возвращает nil и true.
+func (c *memoryCache) lookup(shortURL string) (*model.URLsModel, bool) {
+	url, ok := c.lru.get(shortURL, time.Now())
+	if !ok {
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	if url == nil {
+		c.negativeHits.Add(1)
+		return nil, true
+	}
+	return cloneURL(url), true
+}
+
+// load загружает ссылку функцией load при промахе кэша. ErrURLNotFound приводится к nil без ошибки.
+func (c *memoryCache) load(ctx context.Context, shortURL string, load service.CacheLoader) (*model.URLsModel, error) {
+	c.misses.Add(1)
+	url, err := load(ctx, shortURL)
+	if repository.IsNotFoundError(err) {
+		return nil, nil
+	}
+	return url, err
+}
+
+// store сохраняет копию ссылки url или запись о ненайденном коде, если url равен nil,
+// при условии, что с момента получения generation запись для shortURL не сбрасывалась.
+func (c *memoryCache) store(shortURL string, url *model.URLsModel, generation uint64) {
+	if url == nil {
+		if c.negativeTTL > 0 {
+			c.lru.add(shortURL, nil, time.Now().Add(c.negativeTTL), generation)
+		}
+		return
+	}
+	c.lru.add(shortURL, cloneURL(url), time.Now().Add(c.ttl), generation)
+}
+
+func (c *memoryCache) stats(backend string) model.CacheStats {
+	size, evictions := c.lru.stats()
+	return model.CacheStats{
+		Backend:       backend,
+		Hits:          c.hits.Load(),
+		NegativeHits:  c.negativeHits.Load(),
+		SharedHits:    c.sharedHits.Load(),
+		Misses:        c.misses.Load(),
+		Evictions:     evictions,
+		Invalidations: c.invalidations.Load(),
+		Size:          size,
+	}
+}
+
+func cloneURL(url *model.URLsModel) *model.URLsModel {
+	clone := *url
+	return &clone
+}
@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLoader - загрузчик ссылок для тестов, считающий обращения к хранилищу по коротким кодам.
+type testLoader struct {
+	urls  map[string]*model.URLsModel
+	errs  map[string]error
+	calls map[string]int
+}
+
+func newTestLoader(urls ...*model.URLsModel) *testLoader {
+	loader := &testLoader{
+		urls:  make(map[string]*model.URLsModel),
+		errs:  make(map[string]error),
+		calls: make(map[string]int),
+	}
+	for _, url := range urls {
+		loader.urls[url.ShortURL] = url
+	}
+	return loader
+}
+
+func (l *testLoader) load(_ context.Context, shortURL string) (*model.URLsModel, error) {
+	l.calls[shortURL]++
+	if err, ok := l.errs[shortURL]; ok {
+		return nil, err
+	}
+	url, ok := l.urls[shortURL]
+	if !ok {
+		return nil, repository.ErrURLNotFound
+	}
+	clone := *url
+	return &clone, nil
+}
+
+func TestMemoryCache_GetOrLoad(t *testing.T) {
+	loader := newTestLoader(&model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com"})
+	loader.errs["broken"] = errors.New("database connection failed")
+	cache := NewMemoryCache(Options{Size: 10, TTL: time.Minute, NegativeTTL: time.Minute})
+	ctx := context.Background()
+
+	t.Run("found link is loaded once", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			url, err := cache.GetOrLoad(ctx, "abc123", loader.load)
+			require.NoError(t, err)
+			assert.Equal(t, "https://example.com", url.LongURL)
+		}
+		assert.Equal(t, 1, loader.calls["abc123"])
+
+		// Изменение возвращенной модели не затрагивает кэш
+		url, err := cache.GetOrLoad(ctx, "abc123", loader.load)
+		require.NoError(t, err)
+		url.LongURL = "https://changed.example.com"
+		url, err = cache.GetOrLoad(ctx, "abc123", loader.load)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", url.LongURL)
+	})
+
+	t.Run("unknown code is cached as not found", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			url, err := cache.GetOrLoad(ctx, "missing", loader.load)
+			assert.NoError(t, err)
+			assert.Nil(t, url)
+		}
+		assert.Equal(t, 1, loader.calls["missing"])
+	})
+
+	t.Run("load errors are not cached", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := cache.GetOrLoad(ctx, "broken", loader.load)
+			assert.EqualError(t, err, "database connection failed")
+		}
+		assert.Equal(t, 2, loader.calls["broken"])
+	})
+
+	assert.Equal(t, model.CacheStats{Backend: BackendMemory, Hits: 5, NegativeHits: 1, Misses: 4, Size: 2}, cache.Stats())
+	assert.NoError(t, cache.Close())
+}
+
+func TestMemoryCache_NegativeCachingDisabled(t *testing.T) {
+	loader := newTestLoader()
+	cache := NewMemoryCache(Options{Size: 10, TTL: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		url, err := cache.GetOrLoad(context.Background(), "missing", loader.load)
+		assert.NoError(t, err)
+		assert.Nil(t, url)
+	}
+	assert.Equal(t, 2, loader.calls["missing"])
+}
+
+func TestMemoryCache_Invalidate(t *testing.T) {
+	loader := newTestLoader(&model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com"})
+	cache := NewMemoryCache(Options{Size: 10, TTL: time.Minute, NegativeTTL: time.Minute})
+	ctx := context.Background()
+
+	_, err := cache.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	url, err := cache.GetOrLoad(ctx, "new1", loader.load)
+	require.NoError(t, err)
+	assert.Nil(t, url)
+
+	// Ссылка изменена и создан новый код: записи сбрасываются, следующее чтение идет в хранилище
+	loader.urls["abc123"] = &model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com/changed"}
+	loader.urls["new1"] = &model.URLsModel{ShortURL: "new1", LongURL: "https://example.com/new"}
+	cache.Invalidate(ctx, "abc123", "new1", "unknown")
+
+	url, err = cache.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/changed", url.LongURL)
+	url, err = cache.GetOrLoad(ctx, "new1", loader.load)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/new", url.LongURL)
+
+	assert.Equal(t, int64(2), cache.Stats().Invalidations)
+}
+
+func TestMemoryCache_InvalidateDuringLoad(t *testing.T) {
+	cache := NewMemoryCache(Options{Size: 10, TTL: time.Minute})
+	ctx := context.Background()
+
+	// Ссылка изменилась, пока загружалось ее прежнее состояние: прежнее состояние не попадает в кэш
+	url, err := cache.GetOrLoad(ctx, "abc123", func(ctx context.Context, shortURL string) (*model.URLsModel, error) {
+		cache.Invalidate(ctx, shortURL)
+		return &model.URLsModel{ShortURL: shortURL, LongURL: "https://example.com/old"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/old", url.LongURL)
+
+	loader := newTestLoader(&model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com/new"})
+	url, err = cache.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/new", url.LongURL)
+}
+
+func TestMemoryCache_InvalidateOtherCodeDuringLoad(t *testing.T) {
+	cache := NewMemoryCache(Options{Size: 10, TTL: time.Minute})
+	ctx := context.Background()
+
+	// Сброс другого кода во время загрузки не мешает сохранить загруженную ссылку
+	_, err := cache.GetOrLoad(ctx, "abc123", func(ctx context.Context, shortURL string) (*model.URLsModel, error) {
+		cache.Invalidate(ctx, "other")
+		return &model.URLsModel{ShortURL: shortURL, LongURL: "https://example.com"}, nil
+	})
+	require.NoError(t, err)
+
+	loader := newTestLoader()
+	url, err := cache.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", url.LongURL)
+	assert.Zero(t, loader.calls["abc123"])
+}
@@ -0,0 +1,332 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultKeyPrefix - префикс ключей записей кэша в Redis по умолчанию.
+	defaultKeyPrefix = "short-url:link:"
+	// defaultChannel - канал Redis для уведомлений о сброшенных записях по умолчанию.
+	defaultChannel = "short-url:cache-invalidation"
+	// versionKeySuffix - суффикс ключа версии записи; версия увеличивается при каждом сбросе записи.
+	versionKeySuffix = ":version"
+	// versionTTL - срок хранения версии записи в Redis. Должен превышать длительность загрузки ссылки
+	// из базы данных, иначе загрузка, начатая до сброса, сможет сохранить устаревшее значение.
+	versionTTL = time.Hour
+)
+
+// saveScript сохраняет запись KEYS[1] со значением ARGV[1] и сроком жизни ARGV[2] миллисекунд, только если
+// версия KEYS[2] не изменилась с момента чтения (ARGV[3], пустая строка для отсутствующей версии).
+var saveScript = redis.NewScript(`
+if (redis.call('GET', KEYS[2]) or '') ~= ARGV[3] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 1
+`)
+
+// RedisOptions содержит параметры кэша ссылок в Redis.
+type RedisOptions struct {
+	Options
+	// KeyPrefix - префикс ключей записей в Redis; по умолчанию "short-url:link:".
+	KeyPrefix string
+	// Channel - канал Redis для уведомлений о сброшенных записях; по умолчанию "short-url:cache-invalidation".
+	Channel string
+}
+
+// NewRedisCache создает кэш ссылок, общий для всех экземпляров сервиса, подключенных к одному серверу Redis.
+// Записи хранятся в Redis со сроком жизни opts.TTL (opts.NegativeTTL для ненайденных кодов), а последние
+// прочитанные дополнительно в памяти процесса размером opts.Size записей, чтобы не обращаться к Redis
+// при каждом переходе. Invalidate удаляет записи из Redis и публикует сброшенные коды в канал opts.Channel;
+// каждый экземпляр подписан на канал и сбрасывает у себя записи, сброшенные другими экземплярами.
+// Уведомления, опубликованные во время разрыва соединения с Redis, теряются: такие записи в памяти процесса
+// устаревают до истечения их срока жизни. Сброс увеличивает версию записи в Redis, а загруженная ссылка
+// сохраняется в Redis, только если версия не изменилась с начала загрузки, поэтому экземпляр, еще не
+// получивший уведомление, не может вернуть в Redis устаревшее значение. При недоступности Redis ссылки читаются через load.
+// Возвращает ошибку, если не удалось подписаться на канал. Клиент client не закрывается методом Close.
+func NewRedisCache(
+	ctx context.Context,
+	client redis.UniversalClient,
+	opts RedisOptions,
+	logger *zap.SugaredLogger,
+) (service.Cache, error) {
+	c, err := newRedisCache(ctx, client, opts, logger)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func newRedisCache(
+	ctx context.Context,
+	client redis.UniversalClient,
+	opts RedisOptions,
+	logger *zap.SugaredLogger,
+) (*redisCache, error) {
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = defaultKeyPrefix
+	}
+	if opts.Channel == "" {
+		opts.Channel = defaultChannel
+	}
+
+	instanceID, err := newInstanceID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate cache instance id: %w", err)
+	}
+
+	subscription := client.Subscribe(ctx, opts.Channel)
+	if _, err := subscription.Receive(ctx); err != nil {
+		_ = subscription.Close()
+		return nil, fmt.Errorf("failed to subscribe to cache invalidation channel: %w", err)
+	}
+
+	c := &redisCache{
+		local:        newMemoryCache(opts.Options),
+		client:       client,
+		subscription: subscription,
+		keyPrefix:    opts.KeyPrefix,
+		channel:      opts.Channel,
+		instanceID:   instanceID,
+		logger:       logger,
+	}
+	c.wg.Add(1)
+	go c.listen(subscription.Channel())
+
+	return c, nil
+}
+
+type redisCache struct {
+	local        *memoryCache
+	client       redis.UniversalClient
+	subscription *redis.PubSub
+	keyPrefix    string
+	channel      string
+	instanceID   string
+	logger       *zap.SugaredLogger
+	wg           sync.WaitGroup
+	// ownsClient - признак того, что клиент создан кэшем и закрывается вместе с ним
+	ownsClient bool
+}
+
+// redisEntry - запись кэша в Redis. URL равен nil для ненайденного короткого кода.
+// Хэш пароля хранится отдельно, так как он не сериализуется вместе с моделью ссылки.
+type redisEntry struct {
+	URL          *model.URLsModel `json:"url,omitempty"`
+	PasswordHash *string          `json:"password_hash,omitempty"`
+}
+
+// invalidationMessage - уведомление о сброшенных записях, публикуемое в канал Redis.
+// Origin - идентификатор экземпляра, сбросившего записи; свои уведомления экземпляр пропускает.
+type invalidationMessage struct {
+	Origin    string   `json:"origin"`
+	ShortURLs []string `json:"short_urls"`
+}
+
+// GetOrLoad возвращает ссылку из памяти процесса, затем из Redis, а при промахе загружает ее функцией load
+// и сохраняет в Redis и в память процесса. Ошибки load не кэшируются. Вместе с записью из Redis читается
+// ее версия: если запись сбросили во время загрузки, в Redis она не сохраняется.
+func (c *redisCache) GetOrLoad(ctx context.Context, shortURL string, load service.CacheLoader) (*model.URLsModel, error) {
+	if url, ok := c.local.lookup(shortURL); ok {
+		return url, nil
+	}
+
+	generation := c.local.lru.beginLoad(shortURL)
+	defer c.local.lru.endLoad(shortURL)
+	url, ok, version := c.fetch(ctx, shortURL)
+	if ok {
+		c.local.hits.Add(1)
+		c.local.sharedHits.Add(1)
+		if url == nil {
+			c.local.negativeHits.Add(1)
+		}
+		c.local.store(shortURL, url, generation)
+		return url, nil
+	}
+
+	url, err := c.local.load(ctx, shortURL, load)
+	if err != nil {
+		return nil, err
+	}
+	c.local.store(shortURL, url, generation)
+	if version != nil {
+		c.save(ctx, shortURL, url, generation, *version)
+	}
+	return url, nil
+}
+
+// Invalidate удаляет записи для указанных коротких кодов из памяти процесса и из Redis, увеличивает
+// их версии и уведомляет остальные экземпляры сервиса. Ошибки Redis записываются в журнал.
+func (c *redisCache) Invalidate(ctx context.Context, shortURLs ...string) {
+	if len(shortURLs) == 0 {
+		return
+	}
+
+	// Записи в памяти процесса сбрасываются после удаления ключей из Redis: иначе чтение, начатое между
+	// этими шагами, получило бы из Redis устаревшую запись и сохранило бы ее в памяти с новым поколением
+	defer c.local.Invalidate(ctx, shortURLs...)
+
+	payload, err := json.Marshal(invalidationMessage{Origin: c.instanceID, ShortURLs: shortURLs})
+	if err != nil {
+		return
+	}
+	keys := make([]string, len(shortURLs))
+	for i, shortURL := range shortURLs {
+		keys[i] = c.key(shortURL)
+	}
+
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		for _, shortURL := range shortURLs {
+			pipe.Incr(ctx, c.versionKey(shortURL))
+			pipe.PExpire(ctx, c.versionKey(shortURL), versionTTL)
+		}
+		pipe.Publish(ctx, c.channel, payload)
+		return nil
+	})
+	if err != nil {
+		middleware.GetLogger(ctx).Warnw("Failed to invalidate short URLs in shared cache",
+			"error", err,
+			"short_urls", shortURLs,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+	}
+}
+
+// Stats возвращает счетчики кэша этого экземпляра сервиса.
+func (c *redisCache) Stats() model.CacheStats {
+	return c.local.stats(BackendRedis)
+}
+
+// Close отписывается от уведомлений о сброшенных записях и дожидается завершения их обработки.
+func (c *redisCache) Close() error {
+	err := c.subscription.Close()
+	c.wg.Wait()
+	if c.ownsClient {
+		err = errors.Join(err, c.client.Close())
+	}
+	return err
+}
+
+// fetch читает запись для shortURL и ее версию из Redis. Возвращает признак попадания и версию записи
+// (пустую строку, если запись не сбрасывалась). При ошибке Redis считает, что записи нет, и возвращает
+// nil вместо версии; поврежденная запись тоже считается отсутствующей.
+func (c *redisCache) fetch(ctx context.Context, shortURL string) (*model.URLsModel, bool, *string) {
+	var dataCmd, versionCmd *redis.StringCmd
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		dataCmd = pipe.Get(ctx, c.key(shortURL))
+		versionCmd = pipe.Get(ctx, c.versionKey(shortURL))
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		middleware.GetLogger(ctx).Warnw("Failed to read short URL from shared cache",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+		return nil, false, nil
+	}
+
+	version := versionCmd.Val()
+	data, err := dataCmd.Bytes()
+	if err != nil {
+		return nil, false, &version
+	}
+
+	var entry redisEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		middleware.GetLogger(ctx).Warnw("Skipping malformed shared cache entry",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+		return nil, false, &version
+	}
+	if entry.URL != nil {
+		entry.URL.PasswordHash = entry.PasswordHash
+	}
+	return entry.URL, true, &version
+}
+
+// save сохраняет в Redis ссылку url или запись о ненайденном коде, если url равен nil.
+// Запись не сохраняется, если с момента получения generation этот экземпляр сбрасывал запись или если
+// версия записи в Redis отличается от version, прочитанной до загрузки: загруженное значение могло устареть.
+func (c *redisCache) save(ctx context.Context, shortURL string, url *model.URLsModel, generation uint64, version string) {
+	ttl, entry := c.local.ttl, redisEntry{URL: url}
+	if url == nil {
+		ttl = c.local.negativeTTL
+	} else {
+		entry.PasswordHash = url.PasswordHash
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if !c.local.lru.isCurrent(shortURL, generation) {
+		return
+	}
+
+	keys := []string{c.key(shortURL), c.versionKey(shortURL)}
+	if err := saveScript.Run(ctx, c.client, keys, data, ttl.Milliseconds(), version).Err(); err != nil {
+		middleware.GetLogger(ctx).Warnw("Failed to write short URL to shared cache",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+	}
+}
+
+// listen сбрасывает записи в памяти процесса по уведомлениям других экземпляров до закрытия подписки.
+func (c *redisCache) listen(messages <-chan *redis.Message) {
+	defer c.wg.Done()
+
+	for message := range messages {
+		var event invalidationMessage
+		if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+			c.logger.Warnw("Skipping malformed cache invalidation message",
+				"error", err,
+				"channel", message.Channel,
+			)
+			continue
+		}
+		if event.Origin == c.instanceID {
+			continue
+		}
+		c.local.Invalidate(context.Background(), event.ShortURLs...)
+	}
+}
+
+func (c *redisCache) key(shortURL string) string {
+	return c.keyPrefix + shortURL
+}
+
+func (c *redisCache) versionKey(shortURL string) string {
+	return c.keyPrefix + shortURL + versionKeySuffix
+}
+
+// newInstanceID возвращает случайный идентификатор экземпляра для уведомлений о сброшенных записях.
+func newInstanceID() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,192 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testRedisOptions - параметры кэша в Redis для тестов.
+var testRedisOptions = RedisOptions{Options: Options{Size: 10, TTL: time.Minute, NegativeTTL: 10 * time.Second}}
+
+// newTestRedisCache создает кэш поверх встроенного сервера server; каждый вызов соответствует
+// отдельному экземпляру сервиса со своим кэшем в памяти процесса.
+func newTestRedisCache(tb testing.TB, server *miniredis.Miniredis) service.Cache {
+	tb.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	tb.Cleanup(func() { _ = client.Close() })
+
+	cache, err := NewRedisCache(context.Background(), client, testRedisOptions, zap.NewNop().Sugar())
+	require.NoError(tb, err)
+	tb.Cleanup(func() { _ = cache.Close() })
+	return cache
+}
+
+func TestRedisCache_SharedEntries(t *testing.T) {
+	server := miniredis.RunT(t)
+	first, second := newTestRedisCache(t, server), newTestRedisCache(t, server)
+	ctx := context.Background()
+
+	passwordHash := "$2a$10$hash"
+	loader := newTestLoader(&model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com", PasswordHash: &passwordHash})
+
+	url, err := first.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", url.LongURL)
+	assert.Equal(t, time.Minute, server.TTL(defaultKeyPrefix+"abc123"))
+
+	// Второй экземпляр читает ссылку из Redis, не обращаясь к хранилищу
+	url, err = second.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", url.LongURL)
+	require.NotNil(t, url.PasswordHash)
+	assert.Equal(t, passwordHash, *url.PasswordHash)
+	assert.Equal(t, 1, loader.calls["abc123"])
+
+	// Ненайденный код тоже общий, но хранится NegativeTTL
+	url, err = first.GetOrLoad(ctx, "missing", loader.load)
+	require.NoError(t, err)
+	assert.Nil(t, url)
+	assert.Equal(t, 10*time.Second, server.TTL(defaultKeyPrefix+"missing"))
+	url, err = second.GetOrLoad(ctx, "missing", loader.load)
+	require.NoError(t, err)
+	assert.Nil(t, url)
+	assert.Equal(t, 1, loader.calls["missing"])
+
+	assert.Equal(t, model.CacheStats{Backend: BackendRedis, Misses: 2, Size: 2}, first.Stats())
+	assert.Equal(t, model.CacheStats{Backend: BackendRedis, Hits: 2, NegativeHits: 1, SharedHits: 2, Size: 2}, second.Stats())
+}
+
+func TestRedisCache_InvalidationBetweenInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	first, second := newTestRedisCache(t, server), newTestRedisCache(t, server)
+	ctx := context.Background()
+
+	loader := newTestLoader(&model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com"})
+	for _, cache := range []service.Cache{first, second} {
+		_, err := cache.GetOrLoad(ctx, "abc123", loader.load)
+		require.NoError(t, err)
+	}
+
+	// Первый экземпляр удаляет ссылку: запись сбрасывается в Redis и, по уведомлению, во втором экземпляре
+	loader.urls["abc123"] = &model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com", IsDeleted: true}
+	first.Invalidate(ctx, "abc123")
+	assert.False(t, server.Exists(defaultKeyPrefix+"abc123"))
+	require.Eventually(t, func() bool {
+		return second.Stats().Invalidations == 1
+	}, time.Second, 5*time.Millisecond)
+
+	url, err := second.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	assert.True(t, url.IsDeleted)
+	assert.Equal(t, 2, loader.calls["abc123"])
+
+	// Свое уведомление экземпляр не обрабатывает повторно
+	assert.Equal(t, int64(1), first.Stats().Invalidations)
+}
+
+func TestRedisCache_StaleLoadBetweenInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx := context.Background()
+
+	// Первый экземпляр подписан на другой канал и не получает уведомление второго, как если бы
+	// оно задержалось: поколение его кэша в памяти процесса при сбросе не меняется
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	opts := testRedisOptions
+	opts.Channel = "delayed-invalidation"
+	first, err := NewRedisCache(ctx, client, opts, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = first.Close() })
+	second := newTestRedisCache(t, server)
+
+	active := &model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com"}
+	deleted := &model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com", IsDeleted: true}
+
+	// Первый экземпляр прочитал ссылку из базы данных, после чего второй удалил ее и сбросил запись
+	url, err := first.GetOrLoad(ctx, "abc123", func(ctx context.Context, shortURL string) (*model.URLsModel, error) {
+		second.Invalidate(ctx, "abc123")
+		return active, nil
+	})
+	require.NoError(t, err)
+	assert.False(t, url.IsDeleted)
+	assert.False(t, server.Exists(defaultKeyPrefix+"abc123"), "stale entry must not be saved to Redis")
+
+	loader := newTestLoader(deleted)
+	url, err = second.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	assert.True(t, url.IsDeleted)
+	assert.Equal(t, 1, loader.calls["abc123"])
+
+	// Загрузка, начатая после сброса, сохраняет запись как обычно
+	assert.True(t, server.Exists(defaultKeyPrefix+"abc123"))
+}
+
+func TestRedisCache_MalformedMessages(t *testing.T) {
+	server := miniredis.RunT(t)
+	cache := newTestRedisCache(t, server)
+	ctx := context.Background()
+
+	loader := newTestLoader(&model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com"})
+	_, err := cache.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+
+	server.Publish(defaultChannel, "not a json")
+	server.Publish(defaultChannel, `{"origin":"other","short_urls":["abc123"]}`)
+	require.Eventually(t, func() bool {
+		return cache.Stats().Invalidations == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// Поврежденная запись в Redis считается отсутствующей
+	require.NoError(t, server.Set(defaultKeyPrefix+"abc123", "{broken"))
+	url, err := cache.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", url.LongURL)
+	assert.Equal(t, 2, loader.calls["abc123"])
+}
+
+func TestRedisCache_RedisUnavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	cache := newTestRedisCache(t, server)
+	ctx := context.Background()
+
+	loader := newTestLoader(&model.URLsModel{ShortURL: "abc123", LongURL: "https://example.com"})
+	server.SetError("LOADING Redis is loading the dataset in memory")
+
+	// Ссылки читаются из хранилища, а в памяти процесса кэш продолжает работать
+	for i := 0; i < 2; i++ {
+		url, err := cache.GetOrLoad(ctx, "abc123", loader.load)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", url.LongURL)
+	}
+	assert.Equal(t, 1, loader.calls["abc123"])
+
+	cache.Invalidate(ctx, "abc123")
+	url, err := cache.GetOrLoad(ctx, "abc123", loader.load)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", url.LongURL)
+	assert.Equal(t, 2, loader.calls["abc123"])
+}
+
+func TestNewRedisCache_SubscribeError(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	defer func() { _ = client.Close() }()
+	server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cache, err := NewRedisCache(ctx, client, testRedisOptions, zap.NewNop().Sugar())
+	assert.Error(t, err)
+	assert.Nil(t, cache)
+}
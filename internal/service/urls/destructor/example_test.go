@@ -3,12 +3,24 @@ package destructor_test
 import (
 	"context"
 	"fmt"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/repository/mock"
 	"yp-go-short-url-service/internal/service/urls/destructor"
 
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
 )
 
+// newIdleJobRepository возвращает мок очереди задач удаления, в которой нет готовых задач.
+func newIdleJobRepository(ctrl *gomock.Controller) *mock.MockDeleteJobRepository {
+	mockJobRepo := mock.NewMockDeleteJobRepository(ctrl)
+	mockJobRepo.EXPECT().ClaimBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockJobRepo.EXPECT().DeleteFinished(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+	return mockJobRepo
+}
+
 // ExampleNewURLDestructorService демонстрирует создание нового сервиса для удаления URL.
 func ExampleNewURLDestructorService() {
 	ctrl := gomock.NewController(nil)
@@ -17,10 +29,11 @@ func ExampleNewURLDestructorService() {
 	// Создаем моки репозиториев
 	mockURLRepo := mock.NewMockURLRepository(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
+	mockJobRepo := newIdleJobRepository(ctrl)
 
 	// Создаем сервис для удаления URL
-	// Сервис автоматически запускает воркеры для асинхронной обработки удалений
-	service := destructor.NewURLDestructorService(mockURLRepo, mockUserURLsRepo)
+	// Сервис автоматически запускает воркеры, разбирающие очередь задач удаления
+	service := destructor.NewURLDestructorService(mockURLRepo, mockUserURLsRepo, mockJobRepo, 20*time.Millisecond, 100, nil, zap.NewNop().Sugar())
 
 	// Важно: не забудьте остановить сервис при завершении работы приложения
 	defer service.Stop()
@@ -39,11 +52,16 @@ func ExampleNewURLDestructorService_deleteURLsByBatch() {
 
 	mockURLRepo := mock.NewMockURLRepository(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
+	mockJobRepo := newIdleJobRepository(ctrl)
 
-	service := destructor.NewURLDestructorService(mockURLRepo, mockUserURLsRepo)
+	// Задача сохраняется в очереди, откуда ее заберет воркер
+	mockJobRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	service := destructor.NewURLDestructorService(mockURLRepo, mockUserURLsRepo, mockJobRepo, 20*time.Millisecond, 100, nil, zap.NewNop().Sugar())
 	defer service.Stop()
 
-	ctx := context.Background()
+	// Удалять ссылки может только аутентифицированный пользователь
+	ctx := context.WithValue(context.Background(), middleware.JWTTokenContextKey, &model.UserModel{ID: "user-123"})
 
 	// Подготавливаем список коротких URL для удаления
 	shortURLs := []string{
@@ -52,15 +70,16 @@ func ExampleNewURLDestructorService_deleteURLsByBatch() {
 		"ghi789",
 	}
 
-	// В реальном приложении здесь будет вызов:
-	// err := service.DeleteURLsByBatch(ctx, shortURLs)
-	// Метод возвращает управление сразу после отправки запроса в очередь
-	// Для примера демонстрируем только подготовку данных
-	_ = ctx
-	_ = shortURLs
+	// Метод возвращает задачу сразу после сохранения в очереди,
+	// ее состояние можно запросить через GetDeleteJob
+	job, err := service.DeleteURLsByBatch(ctx, shortURLs)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
 
-	fmt.Printf("Service is ready to delete %d URLs asynchronously\n", len(shortURLs))
-	// Output: Service is ready to delete 3 URLs asynchronously
+	fmt.Printf("Job status: %s, URLs: %d\n", job.Status, len(job.ShortURLs))
+	// Output: Job status: queued, URLs: 3
 }
 
 // ExampleNewURLDestructorService_stop демонстрирует корректную остановку сервиса.
@@ -70,14 +89,16 @@ func ExampleNewURLDestructorService_stop() {
 
 	mockURLRepo := mock.NewMockURLRepository(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
+	mockJobRepo := newIdleJobRepository(ctrl)
 
-	service := destructor.NewURLDestructorService(mockURLRepo, mockUserURLsRepo)
+	service := destructor.NewURLDestructorService(mockURLRepo, mockUserURLsRepo, mockJobRepo, 20*time.Millisecond, 100, nil, zap.NewNop().Sugar())
 
 	// Выполняем работу с сервисом...
 	// ...
 
 	// Корректно останавливаем сервис
-	// Метод Stop() дожидается завершения всех воркеров
+	// Метод Stop() дожидается, пока воркеры разберут готовые задачи, но не дольше таймаута.
+	// Незавершенные задачи остаются в очереди и будут выполнены после перезапуска
 	service.Stop()
 
 	fmt.Println("Service stopped gracefully")
@@ -0,0 +1,158 @@
+package destructor
+
+import (
+	"context"
+	"sync"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/service"
+
+	"go.uber.org/zap"
+)
+
+// purgeBatchSize - максимальное количество ссылок, удаляемых окончательно за одну транзакцию
+const purgeBatchSize = 500
+
+// NewDeletedURLsPurger создает фоновый процесс, который с периодом interval окончательно удаляет ссылки,
+// пролежавшие в корзине дольше retention, вместе с их связями в user_urls. Удаление выполняется пачками
+// по purgeBatchSize ссылок. Если interval не положителен, фоновый запуск отключен и удаление выполняется
+// только явным вызовом Purge. Если urlCache задан, записи окончательно удаленных ссылок сбрасываются в нем,
+// чтобы их коды перестали считаться удаленными ссылками. Возвращает реализацию интерфейса DeletedURLsPurger.
+func NewDeletedURLsPurger(
+	urlRepository repository.URLRepositoryWriter,
+	retention time.Duration,
+	interval time.Duration,
+	urlCache service.Cache,
+	logger *zap.SugaredLogger,
+) service.DeletedURLsPurger {
+	purger := &deletedURLsPurger{
+		urlRepository: urlRepository,
+		retention:     retention,
+		batchSize:     purgeBatchSize,
+		urlCache:      urlCache,
+		logger:        logger,
+		stopChan:      make(chan struct{}),
+		wg:            &sync.WaitGroup{},
+	}
+
+	if interval > 0 {
+		purger.wg.Add(1)
+		go purger.run(interval)
+	}
+
+	return purger
+}
+
+type deletedURLsPurger struct {
+	urlRepository repository.URLRepositoryWriter
+	retention     time.Duration
+	batchSize     int
+	urlCache      service.Cache
+	logger        *zap.SugaredLogger
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+	wg            *sync.WaitGroup
+
+	mu    sync.Mutex
+	total model.PurgeStats
+}
+
+// run периодически вызывает Purge до получения сигнала остановки.
+func (p *deletedURLsPurger) run(interval time.Duration) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if _, err := p.Purge(ctx); err != nil {
+				p.logger.Errorw("Failed to purge deleted URLs", "error", err)
+			}
+			cancel()
+		case <-p.stopChan:
+			return
+		}
+	}
+}
+
+// Purge окончательно удаляет все ссылки, пролежавшие в корзине дольше срока хранения.
+// Пачки удаляются до тех пор, пока очередная пачка не окажется неполной, контекст не будет отменен
+// или не будет получен сигнал остановки. Возвращает количество удаленных за этот вызов ссылок и связей;
+// при ошибке возвращает то, что успело удалиться, вместе с ошибкой.
+func (p *deletedURLsPurger) Purge(ctx context.Context) (model.PurgeStats, error) {
+	deletedBefore := time.Now().Add(-p.retention)
+
+	var purged model.PurgeStats
+	defer func() {
+		p.record(purged)
+	}()
+
+	for {
+		batch, err := p.urlRepository.PurgeDeleted(ctx, deletedBefore, p.batchSize)
+		if err != nil {
+			return purged, err
+		}
+		if p.urlCache != nil && len(batch.ShortURLs) > 0 {
+			p.urlCache.Invalidate(ctx, batch.ShortURLs...)
+		}
+
+		purged.URLs += batch.URLs
+		purged.UserLinks += batch.UserLinks
+
+		if batch.URLs < int64(p.batchSize) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return purged, ctx.Err()
+		case <-p.stopChan:
+			return purged, nil
+		default:
+		}
+	}
+
+	if purged.URLs > 0 {
+		p.logger.Infow("Purged deleted URLs",
+			"urls", purged.URLs,
+			"user_links", purged.UserLinks,
+			"retention", p.retention,
+		)
+	}
+
+	return purged, nil
+}
+
+// record добавляет результат прохода к накопленной статистике.
+func (p *deletedURLsPurger) record(purged model.PurgeStats) {
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.total.URLs += purged.URLs
+	p.total.UserLinks += purged.UserLinks
+	p.total.LastRunAt = &now
+}
+
+// Stats возвращает количество ссылок и связей, удаленных окончательно с момента запуска,
+// и время последнего прохода. LastRunAt равен nil, если проходов еще не было.
+func (p *deletedURLsPurger) Stats() model.PurgeStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.total
+}
+
+// Stop останавливает фоновый процесс и дожидается завершения текущего прохода.
+// Повторные вызовы безопасны.
+func (p *deletedURLsPurger) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopChan)
+	})
+	p.wg.Wait()
+}
@@ -0,0 +1,159 @@
+package destructor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/mock"
+	serviceMock "yp-go-short-url-service/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestDeletedURLsPurger_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryWriter(ctrl)
+	logger, _ := zap.NewDevelopment()
+
+	// Нулевой интервал отключает фоновый запуск
+	purger := NewDeletedURLsPurger(mockRepo, 24*time.Hour, 0, nil, logger.Sugar()).(*deletedURLsPurger)
+	defer purger.Stop()
+	purger.batchSize = 2
+
+	ctx := context.Background()
+
+	assert.Nil(t, purger.Stats().LastRunAt)
+
+	t.Run("purges in batches until a partial batch", func(t *testing.T) {
+		before := time.Now().Add(-24 * time.Hour)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				PurgeDeleted(ctx, gomock.Any(), 2).
+				DoAndReturn(func(ctx context.Context, deletedBefore time.Time, limit int) (model.PurgeStats, error) {
+					assert.False(t, deletedBefore.Before(before))
+					assert.True(t, deletedBefore.Before(time.Now().Add(-23*time.Hour)))
+					return model.PurgeStats{URLs: 2, UserLinks: 2}, nil
+				}),
+			mockRepo.EXPECT().
+				PurgeDeleted(ctx, gomock.Any(), 2).
+				Return(model.PurgeStats{URLs: 1, UserLinks: 1}, nil),
+		)
+
+		purged, err := purger.Purge(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), purged.URLs)
+		assert.Equal(t, int64(3), purged.UserLinks)
+
+		stats := purger.Stats()
+		assert.Equal(t, int64(3), stats.URLs)
+		assert.NotNil(t, stats.LastRunAt)
+	})
+
+	t.Run("repository error keeps purged counts", func(t *testing.T) {
+		dbErr := errors.New("database connection failed")
+		gomock.InOrder(
+			mockRepo.EXPECT().PurgeDeleted(ctx, gomock.Any(), 2).Return(model.PurgeStats{URLs: 2, UserLinks: 2}, nil),
+			mockRepo.EXPECT().PurgeDeleted(ctx, gomock.Any(), 2).Return(model.PurgeStats{}, dbErr),
+		)
+
+		purged, err := purger.Purge(ctx)
+		assert.ErrorIs(t, err, dbErr)
+		assert.Equal(t, int64(2), purged.URLs)
+
+		// Накопленная статистика учитывает все проходы
+		assert.Equal(t, int64(5), purger.Stats().URLs)
+	})
+
+	t.Run("stops on context cancellation", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		mockRepo.EXPECT().
+			PurgeDeleted(cancelCtx, gomock.Any(), 2).
+			DoAndReturn(func(context.Context, time.Time, int) (model.PurgeStats, error) {
+				cancel()
+				return model.PurgeStats{URLs: 2, UserLinks: 2}, nil
+			})
+
+		purged, err := purger.Purge(cancelCtx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int64(2), purged.URLs)
+	})
+}
+
+func TestDeletedURLsPurger_Background(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryWriter(ctrl)
+	logger, _ := zap.NewDevelopment()
+
+	called := make(chan struct{}, 1)
+	mockRepo.EXPECT().
+		PurgeDeleted(gomock.Any(), gomock.Any(), purgeBatchSize).
+		DoAndReturn(func(context.Context, time.Time, int) (model.PurgeStats, error) {
+			select {
+			case called <- struct{}{}:
+			default:
+			}
+			return model.PurgeStats{}, nil
+		}).
+		MinTimes(1)
+
+	purger := NewDeletedURLsPurger(mockRepo, time.Hour, 10*time.Millisecond, nil, logger.Sugar())
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("purger did not run")
+	}
+
+	purger.Stop()
+	// Повторная остановка безопасна
+	purger.Stop()
+}
+
+func TestDeletedURLsPurger_CacheInvalidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryWriter(ctrl)
+	mockCache := serviceMock.NewMockCache(ctrl)
+
+	purger := NewDeletedURLsPurger(mockRepo, time.Hour, 0, mockCache, zap.NewNop().Sugar()).(*deletedURLsPurger)
+	defer purger.Stop()
+	purger.batchSize = 2
+
+	ctx := context.Background()
+
+	t.Run("purged links are invalidated batch by batch", func(t *testing.T) {
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				PurgeDeleted(ctx, gomock.Any(), 2).
+				Return(model.PurgeStats{URLs: 2, UserLinks: 2, ShortURLs: []string{"old1", "old2"}}, nil),
+			mockCache.EXPECT().Invalidate(ctx, "old1", "old2"),
+			mockRepo.EXPECT().
+				PurgeDeleted(ctx, gomock.Any(), 2).
+				Return(model.PurgeStats{URLs: 1, UserLinks: 1, ShortURLs: []string{"old3"}}, nil),
+			mockCache.EXPECT().Invalidate(ctx, "old3"),
+		)
+
+		_, err := purger.Purge(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("empty pass and failed batch keep the cache", func(t *testing.T) {
+		mockRepo.EXPECT().PurgeDeleted(ctx, gomock.Any(), 2).Return(model.PurgeStats{}, nil)
+		_, err := purger.Purge(ctx)
+		require.NoError(t, err)
+
+		mockRepo.EXPECT().PurgeDeleted(ctx, gomock.Any(), 2).Return(model.PurgeStats{}, errors.New("connection lost"))
+		_, err = purger.Purge(ctx)
+		assert.Error(t, err)
+	})
+}
@@ -3,37 +3,121 @@ package destructor
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"time"
 	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
 	"yp-go-short-url-service/internal/repository"
 	"yp-go-short-url-service/internal/service"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 const (
 	// Количество воркеров для обработки удалений
 	numWorkers = 3
-	// Размер буфера канала
-	channelBufferSize = 100
+	// Срок аренды задачи воркером. Если воркер не завершил задачу за это время, ее захватит другой
+	jobLeaseDuration = 30 * time.Second
+	// Период опроса очереди, если новых задач не поступало
+	pollInterval = time.Second
+	// Максимальное количество попыток выполнить задачу
+	maxJobAttempts = 5
+	// Задержка перед первой повторной попыткой, далее удваивается
+	retryBaseDelay = time.Second
+	// Максимальная задержка между попытками
+	retryMaxDelay = time.Minute
+	// Время, которое Stop ждет завершения задач в работе
+	drainTimeout = 10 * time.Second
+	// Время, в течение которого завершенная задача доступна для запроса статуса
+	deleteJobTTL = time.Hour
+	// Период удаления устаревших завершенных задач
+	pruneInterval = time.Minute
+	// Таймаут записи результата задачи после отмены рабочего контекста
+	statusUpdateTimeout = 5 * time.Second
 )
 
+// queueConfig задает параметры обработки очереди задач удаления.
+// batchWindow и batchSize задаются при создании сервиса.
+type queueConfig struct {
+	numWorkers     int
+	leaseDuration  time.Duration
+	pollInterval   time.Duration
+	maxAttempts    int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	drainTimeout   time.Duration
+	jobTTL         time.Duration
+	pruneInterval  time.Duration
+	batchWindow    time.Duration
+	batchSize      int
+}
+
+var defaultQueueConfig = queueConfig{
+	numWorkers:     numWorkers,
+	leaseDuration:  jobLeaseDuration,
+	pollInterval:   pollInterval,
+	maxAttempts:    maxJobAttempts,
+	retryBaseDelay: retryBaseDelay,
+	retryMaxDelay:  retryMaxDelay,
+	drainTimeout:   drainTimeout,
+	jobTTL:         deleteJobTTL,
+	pruneInterval:  pruneInterval,
+	batchSize:      1,
+}
+
 // NewURLDestructorService создает новый сервис для асинхронного удаления URL.
-// Запускает пул воркеров для обработки запросов на удаление. Возвращает реализацию интерфейса URLDestructorService.
+// Задачи пакетного удаления и восстановления сохраняются в таблице delete_jobs и обрабатываются пулом воркеров,
+// которые захватывают задачи под арендой. Ошибки хранилища повторяются с экспоненциальной задержкой,
+// задачи, не завершенные до перезапуска, подхватываются при старте.
+// Захватив задачу, воркер в течение batchWindow добирает готовые задачи, пока их не станет batchSize,
+// и выполняет задачи одного пользователя одним запросом к хранилищу. Если batchWindow не положителен,
+// воркер не ждет новых задач и объединяет только уже готовые; если batchSize не положителен, задачи
+// не объединяются. Удаленные и восстановленные ссылки сбрасываются в кэше urlCache, если он задан.
+// Возвращает реализацию интерфейса URLDestructorService.
 func NewURLDestructorService(
 	urlRepository repository.URLRepository,
 	userURLsRepository repository.UserURLsRepository,
+	jobRepository repository.DeleteJobRepository,
+	batchWindow time.Duration,
+	batchSize int,
+	urlCache service.Cache,
+	logger *zap.SugaredLogger,
 ) service.URLDestructorService {
+	config := defaultQueueConfig
+	config.batchWindow = batchWindow
+	config.batchSize = max(batchSize, 1)
+
+	return newURLDestructorService(urlRepository, userURLsRepository, jobRepository, urlCache, logger, config)
+}
+
+func newURLDestructorService(
+	urlRepository repository.URLRepository,
+	userURLsRepository repository.UserURLsRepository,
+	jobRepository repository.DeleteJobRepository,
+	urlCache service.Cache,
+	logger *zap.SugaredLogger,
+	config queueConfig,
+) *urlDestructorService {
+	workCtx, cancelWork := context.WithCancel(context.Background())
 	destructorService := &urlDestructorService{
 		urlRepository:      urlRepository,
 		userURLsRepository: userURLsRepository,
-		deleteChan:         make(chan deleteRequest, channelBufferSize),
+		jobRepository:      jobRepository,
+		urlCache:           urlCache,
+		logger:             logger,
+		config:             config,
+		wakeChan:           make(chan struct{}, config.numWorkers),
 		stopChan:           make(chan struct{}),
+		workCtx:            workCtx,
+		cancelWork:         cancelWork,
 		wg:                 &sync.WaitGroup{},
 	}
 
-	// Запускаем несколько горутин для обработки удалений (паттерн fan-in)
-	for i := 0; i < numWorkers; i++ {
+	// Запускаем несколько горутин, разбирающих общую очередь
+	for i := 0; i < config.numWorkers; i++ {
 		destructorService.wg.Add(1)
 		go destructorService.deleteWorker(i)
 	}
@@ -41,79 +125,306 @@ func NewURLDestructorService(
 	return destructorService
 }
 
-type deleteRequest struct {
-	shortURLs []string
-	userID    string
-	requestID string
-	logger    *zap.SugaredLogger
-}
-
 type urlDestructorService struct {
 	urlRepository      repository.URLRepository
 	userURLsRepository repository.UserURLsRepository
-	deleteChan         chan deleteRequest
-	stopChan           chan struct{}
-	wg                 *sync.WaitGroup
+	jobRepository      repository.DeleteJobRepository
+	urlCache           service.Cache
+	logger             *zap.SugaredLogger
+	config             queueConfig
+	// wakeChan будит воркеров при постановке новой задачи, не дожидаясь опроса очереди
+	wakeChan chan struct{}
+	stopChan chan struct{}
+	stopOnce sync.Once
+	// workCtx отменяется, если воркеры не завершили работу за drainTimeout после Stop
+	workCtx    context.Context
+	cancelWork context.CancelFunc
+	wg         *sync.WaitGroup
 }
 
-// deleteWorker - горутина для асинхронной обработки удалений (паттерн fan-in)
+// deleteWorker - горутина, захватывающая готовые задачи из очереди.
+// После сигнала остановки воркер дорабатывает готовые задачи и завершается, когда очередь опустеет.
 func (s *urlDestructorService) deleteWorker(workerID int) {
 	defer s.wg.Done()
 
+	ticker := time.NewTicker(s.config.pollInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
 	for {
+		for s.processBatch(workerID) {
+		}
+
 		select {
-		case req := <-s.deleteChan:
-			// Создаем новый контекст для асинхронной операции
-			ctx := context.Background()
+		case <-s.stopChan:
+			return
+		case <-s.wakeChan:
+		case <-ticker.C:
+			// Устаревшие задачи удаляет только один воркер
+			if workerID == 0 && time.Since(lastPrune) >= s.config.pruneInterval {
+				lastPrune = time.Now()
+				s.pruneFinished()
+			}
+		}
+	}
+}
+
+// processBatch захватывает пачку готовых задач и выполняет ее.
+// Возвращает false, если готовых задач нет или работа прервана.
+func (s *urlDestructorService) processBatch(workerID int) bool {
+	jobs := s.claimBatch(workerID)
+	if len(jobs) == 0 {
+		return false
+	}
+
+	for _, group := range groupJobs(jobs) {
+		s.runGroup(s.workCtx, workerID, group)
+	}
+	return true
+}
+
+// claimBatch захватывает готовые задачи и, если пачка не заполнена, в течение batchWindow добирает
+// задачи, поставленные в очередь за это время. После сигнала остановки новых задач не ждет.
+func (s *urlDestructorService) claimBatch(workerID int) []*model.DeleteJob {
+	jobs := s.claim(workerID, s.config.batchSize)
+	if len(jobs) == 0 || s.config.batchWindow <= 0 {
+		return jobs
+	}
+
+	timer := time.NewTimer(s.config.batchWindow)
+	defer timer.Stop()
+
+	for len(jobs) < s.config.batchSize {
+		select {
+		case <-s.wakeChan:
+		case <-timer.C:
+			return jobs
+		case <-s.stopChan:
+			return jobs
+		}
+		jobs = append(jobs, s.claim(workerID, s.config.batchSize-len(jobs))...)
+	}
+
+	return jobs
+}
+
+// claim захватывает до limit готовых задач. Возвращает пустой список, если готовых задач нет или работа прервана.
+func (s *urlDestructorService) claim(workerID, limit int) []*model.DeleteJob {
+	ctx := s.workCtx
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	now := time.Now()
+	jobs, err := s.jobRepository.ClaimBatch(ctx, now, now.Add(s.config.leaseDuration), limit)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Errorw("Failed to claim delete jobs", "worker_id", workerID, "error", err)
+		}
+		return nil
+	}
+
+	return jobs
+}
+
+// jobGroup - последовательные задачи одного пользователя с одинаковым действием,
+// которые выполняются одним запросом к хранилищу.
+type jobGroup struct {
+	userID string
+	action model.DeleteJobAction
+	jobs   []*model.DeleteJob
+}
+
+// groupJobs объединяет задачи пачки по пользователю. Задачи одного пользователя с разными действиями
+// попадают в разные группы в порядке захвата, чтобы удаление и восстановление не поменялись местами.
+func groupJobs(jobs []*model.DeleteJob) []*jobGroup {
+	var groups []*jobGroup
+	lastByUser := make(map[string]*jobGroup)
+
+	for _, job := range jobs {
+		group := lastByUser[job.UserID]
+		if group == nil || group.action != job.Action {
+			group = &jobGroup{userID: job.UserID, action: job.Action}
+			groups = append(groups, group)
+			lastByUser[job.UserID] = group
+		}
+		group.jobs = append(group.jobs, job)
+	}
+
+	return groups
+}
+
+// runGroup выполняет группу задач одним запросом к хранилищу и записывает результат каждой задачи.
+func (s *urlDestructorService) runGroup(ctx context.Context, workerID int, group *jobGroup) {
+	var shortURLs []string
+	seen := make(map[string]struct{})
+	for _, job := range group.jobs {
+		for _, shortURL := range job.ShortURLs {
+			if _, ok := seen[shortURL]; !ok {
+				seen[shortURL] = struct{}{}
+				shortURLs = append(shortURLs, shortURL)
+			}
+		}
+	}
+
+	s.logger.Debugw("Worker started processing delete jobs",
+		"worker_id", workerID,
+		"action", group.action,
+		"user_id", group.userID,
+		"jobs_count", len(group.jobs),
+		"short_urls_count", len(shortURLs),
+	)
+
+	var (
+		results map[string]model.DeleteOutcome
+		err     error
+	)
+	switch group.action {
+	case model.DeleteJobActionRestore:
+		err = s.userURLsRepository.RestoreURLsWithUser(ctx, shortURLs, group.userID)
+	default:
+		results, err = s.userURLsRepository.DeleteURLsWithUser(ctx, shortURLs, group.userID)
+	}
+	if err == nil {
+		s.invalidate(ctx, shortURLs...)
+	}
+
+	for _, job := range group.jobs {
+		var jobResults map[string]model.DeleteOutcome
+		if results != nil {
+			jobResults = make(map[string]model.DeleteOutcome, len(job.ShortURLs))
+			for _, shortURL := range job.ShortURLs {
+				jobResults[shortURL] = results[shortURL]
+			}
+		}
+		s.finishJob(ctx, workerID, job, jobResults, err)
+	}
+}
+
+// finishJob записывает результат выполнения задачи.
+// При ошибке хранилища задача возвращается в очередь с экспоненциальной задержкой,
+// после maxAttempts попыток - завершается со статусом failed. Задача, прерванная остановкой сервиса,
+// возвращается в очередь без задержки и будет выполнена после перезапуска.
+func (s *urlDestructorService) finishJob(
+	ctx context.Context,
+	workerID int,
+	job *model.DeleteJob,
+	results map[string]model.DeleteOutcome,
+	err error,
+) {
+	// Результат записываем и после отмены рабочего контекста, чтобы задача не ждала истечения аренды
+	statusCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statusUpdateTimeout)
+	defer cancel()
 
-			req.logger.Debugw("Worker started processing delete request",
+	now := time.Now()
+	switch {
+	case err == nil:
+		err = s.jobRepository.Complete(statusCtx, job.ID, results, now)
+		if err == nil {
+			s.logger.Debugw("Successfully processed delete job",
 				"worker_id", workerID,
-				"request_id", req.requestID,
-				"short_urls_count", len(req.shortURLs),
+				"job_id", job.ID,
+				"action", job.Action,
 			)
+		}
+	case ctx.Err() != nil:
+		s.logger.Warnw("Delete job interrupted by shutdown, returning it to the queue",
+			"worker_id", workerID,
+			"job_id", job.ID,
+		)
+		err = s.jobRepository.Retry(statusCtx, job.ID, err.Error(), now, now)
+	case job.Attempts >= s.config.maxAttempts:
+		s.logger.Errorw("Delete job failed, no attempts left",
+			"worker_id", workerID,
+			"job_id", job.ID,
+			"action", job.Action,
+			"attempts", job.Attempts,
+			"user_id", job.UserID,
+			"error", err,
+		)
+		err = s.jobRepository.Fail(statusCtx, job.ID, err.Error(), now)
+	default:
+		delay := s.retryDelay(job.Attempts)
+		s.logger.Warnw("Delete job failed, scheduling retry",
+			"worker_id", workerID,
+			"job_id", job.ID,
+			"action", job.Action,
+			"attempts", job.Attempts,
+			"retry_in", delay,
+			"error", err,
+		)
+		err = s.jobRepository.Retry(statusCtx, job.ID, err.Error(), now.Add(delay), now)
+	}
 
-			// Выполняем удаление в отдельной горутине
-			err := s.userURLsRepository.DeleteURLsWithUser(ctx, req.shortURLs, req.userID)
-			if err != nil {
-				req.logger.Errorw("Failed to delete URLs from storage in async worker",
-					"error", err,
-					"worker_id", workerID,
-					"request_id", req.requestID,
-					"short_urls", req.shortURLs,
-					"user_id", req.userID,
-				)
-			} else {
-				req.logger.Debugw("Successfully deleted URLs in worker",
-					"worker_id", workerID,
-					"request_id", req.requestID,
-					"short_urls_count", len(req.shortURLs),
-				)
-			}
-		case <-s.stopChan:
-			// Получаем сигнал остановки
-			return
+	if err != nil {
+		// Задача останется захваченной и будет подхвачена повторно после истечения аренды
+		s.logger.Errorw("Failed to save delete job result",
+			"worker_id", workerID,
+			"job_id", job.ID,
+			"error", err,
+		)
+	}
+}
+
+// retryDelay возвращает задержку перед следующей попыткой: retryBaseDelay, удваиваемая
+// с каждой неудачной попыткой, но не больше retryMaxDelay.
+func (s *urlDestructorService) retryDelay(attempts int) time.Duration {
+	delay := s.config.retryBaseDelay
+	for i := 1; i < attempts && delay < s.config.retryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, s.config.retryMaxDelay)
+}
+
+// pruneFinished удаляет завершенные задачи старше jobTTL.
+func (s *urlDestructorService) pruneFinished() {
+	ctx, cancel := context.WithTimeout(s.workCtx, s.config.pruneInterval)
+	defer cancel()
+
+	deleted, err := s.jobRepository.DeleteFinished(ctx, time.Now().Add(-s.config.jobTTL))
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Errorw("Failed to delete finished delete jobs", "error", err)
 		}
+		return
+	}
+	if deleted > 0 {
+		s.logger.Debugw("Finished delete jobs removed", "count", deleted)
 	}
 }
 
 // Stop корректно останавливает сервис удаления URL.
-// Останавливает всех воркеров и дожидается завершения обработки всех запросов в очереди.
+// Воркеры дорабатывают задачи, готовые к выполнению, пока очередь не опустеет. Если это не удалось
+// за drainTimeout, задачи в работе прерываются и возвращаются в очередь, где их подхватит следующий запуск.
+// Повторный вызов безопасен.
 func (s *urlDestructorService) Stop() {
-	close(s.stopChan)
-	s.wg.Wait()
-}
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
 
-// DeleteURL удаляет один URL по его короткому идентификатору.
-// В текущей реализации не реализован и вызывает panic.
-func (s *urlDestructorService) DeleteURL(ctx context.Context, shortURL string) error {
-	//TODO implement me
-	panic("implement me")
+		done := make(chan struct{})
+		go func() {
+			s.wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(s.config.drainTimeout):
+			s.logger.Warnw("Delete queue was not drained in time, interrupting jobs in progress",
+				"drain_timeout", s.config.drainTimeout,
+			)
+			s.cancelWork()
+			<-done
+		}
+		s.cancelWork()
+	})
 }
 
-// DeleteURLsByBatch асинхронно удаляет список URL пользователя.
-// Отправляет запрос на удаление в очередь воркеров и возвращает управление сразу.
-// Возвращает ошибку, если очередь переполнена или пользователь не аутентифицирован.
-func (s *urlDestructorService) DeleteURLsByBatch(ctx context.Context, shortURLs []string) error {
+// DeleteURL синхронно удаляет одну ссылку текущего пользователя по ее короткому идентификатору.
+// В отличие от пакетного удаления, результат известен сразу: возвращает ErrURLNotFound, если ссылка
+// не найдена, ErrURLNotOwned, если она принадлежит другому пользователю, и ErrURLWasDeleted,
+// если она уже удалена. Возвращает ошибку, если пользователь не аутентифицирован.
+func (s *urlDestructorService) DeleteURL(ctx context.Context, shortURL string) error {
 	logger := middleware.GetLogger(ctx)
 	requestID := middleware.ExtractRequestID(ctx)
 
@@ -122,29 +433,122 @@ func (s *urlDestructorService) DeleteURLsByBatch(ctx context.Context, shortURLs
 		return errors.New("user is not authenticated")
 	}
 
-	logger.Debugw("Starting async URL deletion process",
+	err := s.userURLsRepository.DeleteURLWithUser(ctx, shortURL, user.ID)
+	switch {
+	case err == nil:
+		s.invalidate(ctx, shortURL)
+	case repository.IsNotFoundError(err):
+		return service.ErrURLNotFound
+	case repository.IsNotOwnedError(err):
+		return service.ErrURLNotOwned
+	case repository.IsAlreadyDeletedError(err):
+		return service.ErrURLWasDeleted
+	default:
+		return err
+	}
+
+	logger.Debugw("URL deleted",
 		"request_id", requestID,
-		"short_urls_count", len(shortURLs),
-		"num_workers", numWorkers,
+		"short_url", shortURL,
+		"user_id", user.ID,
 	)
 
-	// Отправляем запрос на удаление в канал для асинхронной обработки
-	select {
-	case s.deleteChan <- deleteRequest{
-		shortURLs: shortURLs,
-		userID:    user.ID,
-		requestID: requestID,
-		logger:    logger,
-	}:
-		logger.Debugw("URL deletion request sent to async worker pool",
+	return nil
+}
+
+// invalidate сбрасывает записи удаленных или восстановленных ссылок в кэше, если он задан.
+func (s *urlDestructorService) invalidate(ctx context.Context, shortURLs ...string) {
+	if s.urlCache != nil {
+		s.urlCache.Invalidate(ctx, shortURLs...)
+	}
+}
+
+// DeleteURLsByBatch асинхронно удаляет список URL пользователя.
+// Сохраняет задачу удаления в очереди и возвращает ее сразу, не дожидаясь удаления.
+// Ход выполнения и результат по каждой ссылке доступны через GetDeleteJob.
+// Возвращает ошибку, если задачу не удалось сохранить или пользователь не аутентифицирован.
+func (s *urlDestructorService) DeleteURLsByBatch(ctx context.Context, shortURLs []string) (*model.DeleteJob, error) {
+	return s.enqueue(ctx, model.DeleteJobActionDelete, shortURLs)
+}
+
+// GetDeleteJob возвращает состояние задачи пакетного удаления текущего пользователя.
+// Возвращает ErrDeleteJobNotFound, если задача не найдена, устарела или принадлежит другому пользователю.
+func (s *urlDestructorService) GetDeleteJob(ctx context.Context, jobID string) (*model.DeleteJob, error) {
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return nil, errors.New("user is not authenticated")
+	}
+
+	job, err := s.jobRepository.GetByID(ctx, jobID)
+	if err != nil {
+		if repository.IsDeleteJobNotFoundError(err) {
+			return nil, service.ErrDeleteJobNotFound
+		}
+		return nil, err
+	}
+	if job.UserID != user.ID {
+		return nil, service.ErrDeleteJobNotFound
+	}
+
+	return job, nil
+}
+
+// RestoreURLsByBatch асинхронно восстанавливает список удаленных URL пользователя.
+// Задача восстановления попадает в ту же очередь, что и удаление, но задачи разбираются несколькими
+// воркерами параллельно, поэтому порядок выполнения удаления и восстановления одних и тех же ссылок
+// не гарантируется.
+// Возвращает ошибку, если задачу не удалось сохранить или пользователь не аутентифицирован.
+func (s *urlDestructorService) RestoreURLsByBatch(ctx context.Context, shortURLs []string) error {
+	_, err := s.enqueue(ctx, model.DeleteJobActionRestore, shortURLs)
+	return err
+}
+
+// enqueue сохраняет задачу текущего пользователя в очереди и будит свободного воркера.
+func (s *urlDestructorService) enqueue(
+	ctx context.Context,
+	action model.DeleteJobAction,
+	shortURLs []string,
+) (*model.DeleteJob, error) {
+	logger := middleware.GetLogger(ctx)
+	requestID := middleware.ExtractRequestID(ctx)
+
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return nil, errors.New("user is not authenticated")
+	}
+
+	now := time.Now()
+	job := &model.DeleteJob{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		Action:    action,
+		Status:    model.DeleteJobQueued,
+		ShortURLs: slices.Clone(shortURLs),
+		NextRunAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.jobRepository.Create(ctx, job); err != nil {
+		logger.Errorw("Failed to enqueue delete job",
+			"action", action,
 			"request_id", requestID,
+			"error", err,
 		)
-		return nil
+		return nil, fmt.Errorf("failed to enqueue delete job: %w", err)
+	}
+
+	// Будим воркера без ожидания: если все воркеры заняты, они заберут задачу, разобрав текущие
+	select {
+	case s.wakeChan <- struct{}{}:
 	default:
-		// Если канал переполнен, возвращаем ошибку
-		logger.Errorw("Delete channel is full, cannot process deletion request",
-			"request_id", requestID,
-		)
-		return errors.New("delete service is overloaded, try again later")
 	}
+
+	logger.Debugw("Delete job enqueued",
+		"action", action,
+		"request_id", requestID,
+		"job_id", job.ID,
+		"short_urls_count", len(shortURLs),
+	)
+
+	return job, nil
 }
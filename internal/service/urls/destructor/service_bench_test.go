@@ -0,0 +1,76 @@
+package destructor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/config/db"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/sqlite"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// BenchmarkDeleteURLsByBatch сравнивает пропускную способность воркеров удаления, когда клиент отправил
+// много запросов на удаление одной ссылки подряд. batch_size=1 соответствует выполнению каждой задачи
+// отдельной транзакцией, остальные варианты объединяют задачи пользователя в один запрос.
+// Задачи ставятся в очередь до запуска таймера, измеряется время ее разбора воркерами.
+// Используется SQLite в файле, поэтому в результат входит стоимость фиксации транзакций.
+func BenchmarkDeleteURLsByBatch(b *testing.B) {
+	for _, batchSize := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("batch_size=%d", batchSize), func(b *testing.B) {
+			benchmarkDeleteQueue(b, batchSize)
+		})
+	}
+}
+
+func benchmarkDeleteQueue(b *testing.B, batchSize int) {
+	sqliteDB, err := db.SetupSQLiteDB(filepath.Join(b.TempDir(), "bench.db"), zap.NewNop().Sugar())
+	require.NoError(b, err)
+	defer sqliteDB.Close()
+	// Запись в SQLite выполняется по одной, лишние соединения приводят к ошибкам блокировки
+	sqliteDB.SetMaxOpenConns(1)
+
+	ctx := context.WithValue(context.Background(), middleware.JWTTokenContextKey, &model.UserModel{ID: "bench-user"})
+	_, err = sqliteDB.ExecContext(ctx, `INSERT INTO users (id, name, is_anonymous) VALUES (?, ?, ?)`, "bench-user", "bench-user", false)
+	require.NoError(b, err)
+
+	userURLsRepo := sqlite.NewUserURLsRepository(sqliteDB)
+	shortURLs := make([]string, b.N)
+	urls := make([]*model.URLsModel, b.N)
+	for i := range b.N {
+		shortURLs[i] = fmt.Sprintf("bench%d", i)
+		urls[i] = &model.URLsModel{ShortURL: shortURLs[i], LongURL: "https://example.com/" + shortURLs[i]}
+	}
+	require.NoError(b, userURLsRepo.CreateMultipleURLsWithUser(ctx, urls, "bench-user"))
+
+	jobRepo := sqlite.NewDeleteJobsRepository(sqliteDB)
+	now := time.Now()
+	for i := range b.N {
+		require.NoError(b, jobRepo.Create(ctx, &model.DeleteJob{
+			ID:        uuid.NewString(),
+			UserID:    "bench-user",
+			Action:    model.DeleteJobActionDelete,
+			Status:    model.DeleteJobQueued,
+			ShortURLs: shortURLs[i : i+1],
+			NextRunAt: now.Add(time.Duration(i-b.N) * time.Microsecond),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}))
+	}
+
+	b.ResetTimer()
+	service := NewURLDestructorService(nil, userURLsRepo, jobRepo, 5*time.Millisecond, batchSize, nil, zap.NewNop().Sugar())
+	// Stop дожидается, пока воркеры разберут очередь
+	service.Stop()
+	b.StopTimer()
+
+	var deleted int
+	require.NoError(b, sqliteDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM urls WHERE is_deleted = 1`).Scan(&deleted))
+	require.Equal(b, b.N, deleted)
+}
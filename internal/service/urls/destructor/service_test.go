@@ -2,12 +2,21 @@ package destructor
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 	"yp-go-short-url-service/internal/middleware"
 	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	services "yp-go-short-url-service/internal/service"
+	serviceMock "yp-go-short-url-service/internal/service/mock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 )
 
@@ -26,8 +35,8 @@ func TestURLDestructorService_DeleteURLsByBatch_Async(t *testing.T) {
 	}
 
 	// Создаем сервис
-	service := NewURLDestructorService(nil, testRepo)
-	defer service.Stop() // Важно остановить сервис после теста
+	service := newTestService(testRepo, newTestDeleteJobRepository())
+	defer service.Stop() // Важно остановить сервис после теста, повторный вызов безопасен
 
 	// Создаем контекст с логгером и пользователем
 	logger, _ := zap.NewDevelopment()
@@ -42,28 +51,619 @@ func TestURLDestructorService_DeleteURLsByBatch_Async(t *testing.T) {
 	ctx = context.WithValue(ctx, middleware.JWTTokenContextKey, user)
 
 	// Вызываем метод
-	err := service.DeleteURLsByBatch(ctx, []string{"url1", "url2"})
+	job, err := service.DeleteURLsByBatch(ctx, []string{"url1", "url2"})
 
 	// Проверяем, что ошибки нет (запрос отправлен в канал)
 	assert.NoError(t, err)
+	assert.NotEmpty(t, job.ID)
 
-	// Ждем немного, чтобы горутина успела обработать запрос
-	time.Sleep(100 * time.Millisecond)
+	// Stop дожидается, пока воркеры разберут очередь
+	service.Stop()
 
 	// Проверяем, что URLs были удалены
 	assert.Contains(t, testRepo.deletedURLs, "test-user-id")
 	assert.Equal(t, []string{"url1", "url2"}, testRepo.deletedURLs["test-user-id"])
 }
 
+func TestURLDestructorService_RestoreURLsByBatch_Async(t *testing.T) {
+	testRepo := &testUserURLsRepository{
+		deletedURLs:  make(map[string][]string),
+		restoredURLs: make(map[string][]string),
+	}
+
+	service := newTestService(testRepo, newTestDeleteJobRepository())
+	defer service.Stop()
+
+	logger, _ := zap.NewDevelopment()
+	ctx := context.WithValue(context.Background(), loggerKey, logger.Sugar())
+	ctx = context.WithValue(ctx, middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+
+	err := service.RestoreURLsByBatch(ctx, []string{"url1", "url2"})
+	assert.NoError(t, err)
+
+	// Stop дожидается, пока воркеры разберут очередь
+	service.Stop()
+
+	assert.Equal(t, []string{"url1", "url2"}, testRepo.restoredURLs["test-user-id"])
+	assert.NotContains(t, testRepo.deletedURLs, "test-user-id")
+
+	// Без пользователя запрос не принимается
+	assert.Error(t, service.RestoreURLsByBatch(context.Background(), []string{"url1"}))
+}
+
+func TestURLDestructorService_DeleteURL(t *testing.T) {
+	testRepo := &testUserURLsRepository{
+		deletedURLs: make(map[string][]string),
+		deleteErrors: map[string]error{
+			"unknown": repository.ErrURLNotFound,
+			"alien":   repository.ErrURLNotOwned,
+			"deleted": repository.ErrURLAlreadyDeleted,
+			"broken":  errors.New("database connection failed"),
+		},
+	}
+
+	service := newTestService(testRepo, newTestDeleteJobRepository())
+	defer service.Stop()
+
+	logger, _ := zap.NewDevelopment()
+	ctx := context.WithValue(context.Background(), loggerKey, logger.Sugar())
+	ctx = context.WithValue(ctx, middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+
+	// Удаление синхронное - результат виден сразу
+	require.NoError(t, service.DeleteURL(ctx, "url1"))
+	assert.Equal(t, []string{"url1"}, testRepo.deletedURLs["test-user-id"])
+
+	assert.ErrorIs(t, service.DeleteURL(ctx, "unknown"), services.ErrURLNotFound)
+	assert.ErrorIs(t, service.DeleteURL(ctx, "alien"), services.ErrURLNotOwned)
+	assert.ErrorIs(t, service.DeleteURL(ctx, "deleted"), services.ErrURLWasDeleted)
+	assert.EqualError(t, service.DeleteURL(ctx, "broken"), "database connection failed")
+
+	// Без пользователя запрос не принимается
+	assert.Error(t, service.DeleteURL(context.Background(), "url1"))
+}
+
+func TestURLDestructorService_CacheInvalidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testRepo := &testUserURLsRepository{
+		deletedURLs:  make(map[string][]string),
+		restoredURLs: make(map[string][]string),
+		deleteErrors: map[string]error{"alien": repository.ErrURLNotOwned},
+	}
+	mockCache := serviceMock.NewMockCache(ctrl)
+	service := newURLDestructorService(nil, testRepo, newTestDeleteJobRepository(), mockCache, zap.NewNop().Sugar(), testQueueConfig)
+	defer service.Stop()
+
+	ctx := context.WithValue(context.Background(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+
+	// Синхронное удаление сбрасывает запись сразу, неудачное - не сбрасывает
+	mockCache.EXPECT().Invalidate(ctx, "url1")
+	require.NoError(t, service.DeleteURL(ctx, "url1"))
+	assert.ErrorIs(t, service.DeleteURL(ctx, "alien"), services.ErrURLNotOwned)
+
+	// Пакетные удаление и восстановление сбрасывают записи после выполнения задачи
+	mockCache.EXPECT().Invalidate(gomock.Any(), "url2", "url3")
+	_, err := service.DeleteURLsByBatch(ctx, []string{"url2", "url3"})
+	require.NoError(t, err)
+
+	mockCache.EXPECT().Invalidate(gomock.Any(), "url2")
+	require.NoError(t, service.RestoreURLsByBatch(ctx, []string{"url2"}))
+
+	service.Stop()
+	assert.Equal(t, []string{"url2"}, testRepo.restoredURLs["test-user-id"])
+}
+
+func TestURLDestructorService_DeleteJobs(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ownerCtx := context.WithValue(context.Background(), loggerKey, logger.Sugar())
+	ownerCtx = context.WithValue(ownerCtx, middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+	strangerCtx := context.WithValue(context.Background(), middleware.JWTTokenContextKey, &model.UserModel{ID: "stranger"})
+
+	waitFinished := func(t *testing.T, service services.URLDestructorService, jobID string) *model.DeleteJob {
+		var job *model.DeleteJob
+		require.Eventually(t, func() bool {
+			var err error
+			job, err = service.GetDeleteJob(ownerCtx, jobID)
+			require.NoError(t, err)
+			return job.IsFinished()
+		}, time.Second, 10*time.Millisecond)
+		return job
+	}
+
+	t.Run("job reports outcome for each code", func(t *testing.T) {
+		testRepo := &testUserURLsRepository{
+			deletedURLs: make(map[string][]string),
+			outcomes: map[string]model.DeleteOutcome{
+				"alien":   model.DeleteOutcomeNotOwned,
+				"unknown": model.DeleteOutcomeNotFound,
+			},
+		}
+		service := newTestService(testRepo, newTestDeleteJobRepository())
+		defer service.Stop()
+
+		job, err := service.DeleteURLsByBatch(ownerCtx, []string{"url1", "alien", "unknown"})
+		require.NoError(t, err)
+		assert.Equal(t, model.DeleteJobQueued, job.Status)
+
+		job = waitFinished(t, service, job.ID)
+		assert.Equal(t, model.DeleteJobDone, job.Status)
+		assert.Equal(t, map[string]model.DeleteOutcome{
+			"url1":    model.DeleteOutcomeDeleted,
+			"alien":   model.DeleteOutcomeNotOwned,
+			"unknown": model.DeleteOutcomeNotFound,
+		}, job.Results)
+
+		// Повторное удаление сообщает, что ссылка уже была удалена
+		job, err = service.DeleteURLsByBatch(ownerCtx, []string{"url1"})
+		require.NoError(t, err)
+		job = waitFinished(t, service, job.ID)
+		assert.Equal(t, map[string]model.DeleteOutcome{
+			"url1": model.DeleteOutcomeAlreadyDeleted,
+		}, job.Results)
+
+		// Чужая задача не видна
+		_, err = service.GetDeleteJob(strangerCtx, job.ID)
+		assert.ErrorIs(t, err, services.ErrDeleteJobNotFound)
+
+		_, err = service.GetDeleteJob(ownerCtx, "unknown-job")
+		assert.ErrorIs(t, err, services.ErrDeleteJobNotFound)
+	})
+
+	t.Run("transient error is retried", func(t *testing.T) {
+		testRepo := &testUserURLsRepository{
+			deletedURLs:     make(map[string][]string),
+			batchErr:        errors.New("database connection failed"),
+			batchErrRepeats: 1,
+		}
+		service := newTestService(testRepo, newTestDeleteJobRepository())
+		defer service.Stop()
+
+		job, err := service.DeleteURLsByBatch(ownerCtx, []string{"url1"})
+		require.NoError(t, err)
+
+		job = waitFinished(t, service, job.ID)
+		assert.Equal(t, model.DeleteJobDone, job.Status)
+		assert.Equal(t, 2, job.Attempts)
+		assert.Empty(t, job.Error)
+		assert.Equal(t, []string{"url1"}, testRepo.deletedURLs["test-user-id"])
+	})
+
+	t.Run("job fails when attempts are exhausted", func(t *testing.T) {
+		testRepo := &testUserURLsRepository{
+			deletedURLs: make(map[string][]string),
+			batchErr:    errors.New("database connection failed"),
+		}
+		service := newTestService(testRepo, newTestDeleteJobRepository())
+		defer service.Stop()
+
+		job, err := service.DeleteURLsByBatch(ownerCtx, []string{"url1"})
+		require.NoError(t, err)
+
+		job = waitFinished(t, service, job.ID)
+		assert.Equal(t, model.DeleteJobFailed, job.Status)
+		assert.Equal(t, testQueueConfig.maxAttempts, job.Attempts)
+		assert.Equal(t, "database connection failed", job.Error)
+		assert.Empty(t, job.Results)
+	})
+
+	t.Run("enqueue error is returned", func(t *testing.T) {
+		jobRepo := newTestDeleteJobRepository()
+		jobRepo.createErr = errors.New("database connection failed")
+		service := newTestService(&testUserURLsRepository{}, jobRepo)
+		defer service.Stop()
+
+		_, err := service.DeleteURLsByBatch(ownerCtx, []string{"url1"})
+		assert.ErrorContains(t, err, "database connection failed")
+		assert.Error(t, service.RestoreURLsByBatch(ownerCtx, []string{"url1"}))
+	})
+
+	t.Run("finished jobs expire", func(t *testing.T) {
+		jobRepo := newTestDeleteJobRepository()
+		old := time.Now().Add(-2 * time.Hour)
+		jobRepo.jobs["old"] = &model.DeleteJob{ID: "old", UserID: "test-user-id", Status: model.DeleteJobDone, UpdatedAt: old}
+		jobRepo.jobs["recent"] = &model.DeleteJob{ID: "recent", UserID: "test-user-id", Status: model.DeleteJobDone, UpdatedAt: time.Now()}
+
+		service := newTestService(&testUserURLsRepository{}, jobRepo)
+		defer service.Stop()
+
+		require.Eventually(t, func() bool {
+			_, err := service.GetDeleteJob(ownerCtx, "old")
+			return services.IsDeleteJobNotFoundError(err)
+		}, time.Second, 10*time.Millisecond)
+
+		_, err := service.GetDeleteJob(ownerCtx, "recent")
+		assert.NoError(t, err)
+	})
+}
+
+func TestURLDestructorService_ResumesPendingJobs(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+	now := time.Now()
+
+	// Задачи, оставшиеся в очереди после предыдущего запуска
+	jobRepo := newTestDeleteJobRepository()
+	jobRepo.jobs["queued"] = &model.DeleteJob{
+		ID:        "queued",
+		UserID:    "test-user-id",
+		Action:    model.DeleteJobActionRestore,
+		Status:    model.DeleteJobQueued,
+		ShortURLs: []string{"url1"},
+		NextRunAt: now.Add(-time.Minute),
+	}
+	jobRepo.jobs["abandoned"] = &model.DeleteJob{
+		ID:        "abandoned",
+		UserID:    "test-user-id",
+		Action:    model.DeleteJobActionDelete,
+		Status:    model.DeleteJobRunning,
+		ShortURLs: []string{"url2"},
+		Attempts:  1,
+		NextRunAt: now.Add(-time.Minute),
+	}
+	jobRepo.leases["abandoned"] = now.Add(-time.Second)
+
+	testRepo := &testUserURLsRepository{
+		deletedURLs:  make(map[string][]string),
+		restoredURLs: make(map[string][]string),
+	}
+	service := newTestService(testRepo, jobRepo)
+	defer service.Stop()
+
+	for _, jobID := range []string{"queued", "abandoned"} {
+		require.Eventually(t, func() bool {
+			job, err := service.GetDeleteJob(ctx, jobID)
+			require.NoError(t, err)
+			return job.Status == model.DeleteJobDone
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	job, err := service.GetDeleteJob(ctx, "abandoned")
+	require.NoError(t, err)
+	assert.Equal(t, 2, job.Attempts)
+	assert.Equal(t, []string{"url1"}, testRepo.restoredURLs["test-user-id"])
+	assert.Equal(t, []string{"url2"}, testRepo.deletedURLs["test-user-id"])
+}
+
+func TestURLDestructorService_StopDrainsQueue(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middleware.JWTTokenContextKey, &model.UserModel{ID: "test-user-id"})
+
+	t.Run("in-flight job completes before stop returns", func(t *testing.T) {
+		testRepo := &testUserURLsRepository{
+			deletedURLs: make(map[string][]string),
+			started:     make(chan struct{}, 1),
+			release:     make(chan struct{}),
+		}
+		service := newTestService(testRepo, newTestDeleteJobRepository())
+
+		job, err := service.DeleteURLsByBatch(ctx, []string{"url1"})
+		require.NoError(t, err)
+		<-testRepo.started
+
+		stopped := make(chan struct{})
+		go func() {
+			service.Stop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			t.Fatal("Stop returned before the in-flight job finished")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(testRepo.release)
+		<-stopped
+
+		job, err = service.GetDeleteJob(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeleteJobDone, job.Status)
+	})
+
+	t.Run("job is returned to the queue after drain timeout", func(t *testing.T) {
+		testRepo := &testUserURLsRepository{
+			deletedURLs: make(map[string][]string),
+			started:     make(chan struct{}, 1),
+			release:     make(chan struct{}),
+		}
+		config := testQueueConfig
+		config.drainTimeout = 50 * time.Millisecond
+		service := newURLDestructorService(nil, testRepo, newTestDeleteJobRepository(), nil, zap.NewNop().Sugar(), config)
+
+		job, err := service.DeleteURLsByBatch(ctx, []string{"url1"})
+		require.NoError(t, err)
+		<-testRepo.started
+
+		service.Stop()
+
+		job, err = service.GetDeleteJob(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeleteJobQueued, job.Status)
+		assert.Equal(t, context.Canceled.Error(), job.Error)
+		assert.False(t, job.NextRunAt.After(time.Now()))
+	})
+}
+
+func TestURLDestructorService_CoalescesJobs(t *testing.T) {
+	ownerCtx := context.WithValue(context.Background(), middleware.JWTTokenContextKey, &model.UserModel{ID: "owner"})
+	now := time.Now()
+
+	jobRepo := newTestDeleteJobRepository()
+	queue := []struct {
+		id        string
+		userID    string
+		action    model.DeleteJobAction
+		shortURLs []string
+	}{
+		{"job-1", "owner", model.DeleteJobActionDelete, []string{"own1", "alien"}},
+		{"job-2", "stranger", model.DeleteJobActionDelete, []string{"str1"}},
+		{"job-3", "owner", model.DeleteJobActionDelete, []string{"own2", "own1"}},
+		{"job-4", "owner", model.DeleteJobActionRestore, []string{"own1"}},
+		{"job-5", "owner", model.DeleteJobActionDelete, []string{"own3"}},
+	}
+	for i, item := range queue {
+		runAt := now.Add(time.Duration(i-len(queue)) * time.Millisecond)
+		jobRepo.jobs[item.id] = &model.DeleteJob{
+			ID:        item.id,
+			UserID:    item.userID,
+			Action:    item.action,
+			Status:    model.DeleteJobQueued,
+			ShortURLs: item.shortURLs,
+			NextRunAt: runAt,
+		}
+	}
+
+	testRepo := &testUserURLsRepository{
+		deletedURLs:  make(map[string][]string),
+		restoredURLs: make(map[string][]string),
+		outcomes:     map[string]model.DeleteOutcome{"alien": model.DeleteOutcomeNotOwned},
+	}
+	config := testQueueConfig
+	config.numWorkers = 1
+	service := newURLDestructorService(nil, testRepo, jobRepo, nil, zap.NewNop().Sugar(), config)
+	service.Stop()
+
+	// Подряд идущие удаления одного пользователя выполнены одним запросом без повторов кодов,
+	// восстановление разделяет удаления, чтобы не нарушить порядок
+	assert.Equal(t, []testRepoCall{
+		{action: model.DeleteJobActionDelete, userID: "owner", shortURLs: []string{"own1", "alien", "own2"}},
+		{action: model.DeleteJobActionDelete, userID: "stranger", shortURLs: []string{"str1"}},
+		{action: model.DeleteJobActionRestore, userID: "owner", shortURLs: []string{"own1"}},
+		{action: model.DeleteJobActionDelete, userID: "owner", shortURLs: []string{"own3"}},
+	}, testRepo.calls)
+
+	// Каждая задача получает результат только по своим кодам
+	job, err := service.GetDeleteJob(ownerCtx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]model.DeleteOutcome{
+		"own1":  model.DeleteOutcomeDeleted,
+		"alien": model.DeleteOutcomeNotOwned,
+	}, job.Results)
+
+	job, err = service.GetDeleteJob(ownerCtx, "job-3")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]model.DeleteOutcome{
+		"own2": model.DeleteOutcomeDeleted,
+		"own1": model.DeleteOutcomeDeleted,
+	}, job.Results)
+
+	job, err = service.GetDeleteJob(ownerCtx, "job-4")
+	require.NoError(t, err)
+	assert.Equal(t, model.DeleteJobDone, job.Status)
+	assert.Nil(t, job.Results)
+}
+
+func TestURLDestructorService_BatchSizeLimit(t *testing.T) {
+	jobRepo := newTestDeleteJobRepository()
+	now := time.Now()
+	for i := range 5 {
+		id := fmt.Sprintf("job-%d", i)
+		jobRepo.jobs[id] = &model.DeleteJob{
+			ID:        id,
+			UserID:    "owner",
+			Action:    model.DeleteJobActionDelete,
+			Status:    model.DeleteJobQueued,
+			ShortURLs: []string{id},
+			NextRunAt: now.Add(time.Duration(i-5) * time.Millisecond),
+		}
+	}
+
+	testRepo := &testUserURLsRepository{deletedURLs: make(map[string][]string)}
+	config := testQueueConfig
+	config.numWorkers = 1
+	config.batchSize = 2
+	service := newURLDestructorService(nil, testRepo, jobRepo, nil, zap.NewNop().Sugar(), config)
+	service.Stop()
+
+	require.Len(t, testRepo.calls, 3)
+	assert.Equal(t, []string{"job-0", "job-1"}, testRepo.calls[0].shortURLs)
+	assert.Equal(t, []string{"job-4"}, testRepo.calls[2].shortURLs)
+}
+
+func TestURLDestructorService_RetryDelay(t *testing.T) {
+	service := &urlDestructorService{config: defaultQueueConfig}
+
+	assert.Equal(t, time.Second, service.retryDelay(1))
+	assert.Equal(t, 2*time.Second, service.retryDelay(2))
+	assert.Equal(t, 16*time.Second, service.retryDelay(5))
+	assert.Equal(t, time.Minute, service.retryDelay(10))
+}
+
+// testQueueConfig ускоряет опрос очереди и повторные попытки в тестах
+var testQueueConfig = queueConfig{
+	numWorkers:     numWorkers,
+	leaseDuration:  time.Minute,
+	pollInterval:   10 * time.Millisecond,
+	maxAttempts:    3,
+	retryBaseDelay: 10 * time.Millisecond,
+	retryMaxDelay:  40 * time.Millisecond,
+	drainTimeout:   time.Second,
+	jobTTL:         time.Hour,
+	pruneInterval:  10 * time.Millisecond,
+	batchWindow:    5 * time.Millisecond,
+	batchSize:      10,
+}
+
+func newTestService(testRepo repository.UserURLsRepository, jobRepo repository.DeleteJobRepository) services.URLDestructorService {
+	return newURLDestructorService(nil, testRepo, jobRepo, nil, zap.NewNop().Sugar(), testQueueConfig)
+}
+
+// testDeleteJobRepository - очередь задач в памяти с той же семантикой захвата, что и в базе данных
+type testDeleteJobRepository struct {
+	mu        sync.Mutex
+	jobs      map[string]*model.DeleteJob
+	leases    map[string]time.Time
+	createErr error
+}
+
+func newTestDeleteJobRepository() *testDeleteJobRepository {
+	return &testDeleteJobRepository{
+		jobs:   make(map[string]*model.DeleteJob),
+		leases: make(map[string]time.Time),
+	}
+}
+
+func (r *testDeleteJobRepository) Create(ctx context.Context, job *model.DeleteJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.createErr != nil {
+		return r.createErr
+	}
+	clone := *job
+	r.jobs[job.ID] = &clone
+	return nil
+}
+
+func (r *testDeleteJobRepository) GetByID(ctx context.Context, jobID string) (*model.DeleteJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[jobID]
+	if !ok {
+		return nil, repository.ErrDeleteJobNotFound
+	}
+	clone := *job
+	return &clone, nil
+}
+
+func (r *testDeleteJobRepository) ClaimBatch(
+	ctx context.Context,
+	now, leaseUntil time.Time,
+	limit int,
+) ([]*model.DeleteJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ready []*model.DeleteJob
+	for _, job := range r.jobs {
+		if (job.Status == model.DeleteJobQueued && !job.NextRunAt.After(now)) ||
+			(job.Status == model.DeleteJobRunning && r.leases[job.ID].Before(now)) {
+			ready = append(ready, job)
+		}
+	}
+	slices.SortFunc(ready, func(a, b *model.DeleteJob) int {
+		return a.NextRunAt.Compare(b.NextRunAt)
+	})
+
+	claimed := make([]*model.DeleteJob, 0, min(limit, len(ready)))
+	for _, job := range ready[:min(limit, len(ready))] {
+		job.Status = model.DeleteJobRunning
+		job.Attempts++
+		job.UpdatedAt = now
+		r.leases[job.ID] = leaseUntil
+
+		clone := *job
+		claimed = append(claimed, &clone)
+	}
+	return claimed, nil
+}
+
+func (r *testDeleteJobRepository) Complete(ctx context.Context, jobID string, results map[string]model.DeleteOutcome, now time.Time) error {
+	return r.update(jobID, func(job *model.DeleteJob) {
+		job.Status = model.DeleteJobDone
+		job.Results = results
+		job.Error = ""
+		job.UpdatedAt = now
+	})
+}
+
+func (r *testDeleteJobRepository) Retry(ctx context.Context, jobID, lastErr string, nextRunAt, now time.Time) error {
+	return r.update(jobID, func(job *model.DeleteJob) {
+		job.Status = model.DeleteJobQueued
+		job.Error = lastErr
+		job.NextRunAt = nextRunAt
+		job.UpdatedAt = now
+	})
+}
+
+func (r *testDeleteJobRepository) Fail(ctx context.Context, jobID, lastErr string, now time.Time) error {
+	return r.update(jobID, func(job *model.DeleteJob) {
+		job.Status = model.DeleteJobFailed
+		job.Error = lastErr
+		job.UpdatedAt = now
+	})
+}
+
+func (r *testDeleteJobRepository) update(jobID string, apply func(job *model.DeleteJob)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[jobID]
+	if !ok {
+		return repository.ErrDeleteJobNotFound
+	}
+	apply(job)
+	delete(r.leases, jobID)
+	return nil
+}
+
+func (r *testDeleteJobRepository) DeleteFinished(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for id, job := range r.jobs {
+		if job.IsFinished() && job.UpdatedAt.Before(finishedBefore) {
+			delete(r.jobs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
 // testUserURLsRepository - простая реализация для тестирования
 type testUserURLsRepository struct {
-	deletedURLs map[string][]string
+	deletedURLs  map[string][]string
+	restoredURLs map[string][]string
+	deleteErrors map[string]error
+	outcomes     map[string]model.DeleteOutcome
+	// trashed хранит коды, которые сейчас помечены удаленными
+	trashed  map[string]bool
+	batchErr error
+	// batchErrRepeats ограничивает количество вызовов, возвращающих batchErr; 0 - без ограничения
+	batchErrRepeats int
+	batchErrCalls   int
+	// started и release позволяют задержать удаление, чтобы проверить остановку сервиса
+	started chan struct{}
+	release chan struct{}
+	// calls хранит пакетные вызовы удаления и восстановления в порядке выполнения
+	calls []testRepoCall
+}
+
+type testRepoCall struct {
+	action    model.DeleteJobAction
+	userID    string
+	shortURLs []string
 }
 
 func (t *testUserURLsRepository) GetByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error) {
 	return nil, nil
 }
 
+func (t *testUserURLsRepository) GetByUserIDAndLongURL(ctx context.Context, userID, longURL string) (*model.URLsModel, error) {
+	return nil, nil
+}
+
 func (t *testUserURLsRepository) CreateURLWithUser(ctx context.Context, url *model.URLsModel, userID string) error {
 	return nil
 }
@@ -72,11 +672,62 @@ func (t *testUserURLsRepository) CreateMultipleURLsWithUser(ctx context.Context,
 	return nil
 }
 
-func (t *testUserURLsRepository) DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
-	t.deletedURLs[userID] = shortURLs
+func (t *testUserURLsRepository) GetDeletedByUserID(ctx context.Context, userID string) ([]*model.URLsModel, error) {
+	return nil, nil
+}
+
+func (t *testUserURLsRepository) RestoreURLsWithUser(ctx context.Context, shortURLs []string, userID string) error {
+	t.calls = append(t.calls, testRepoCall{action: model.DeleteJobActionRestore, userID: userID, shortURLs: shortURLs})
+	t.restoredURLs[userID] = shortURLs
+	for _, shortURL := range shortURLs {
+		delete(t.trashed, shortURL)
+	}
 	return nil
 }
 
+func (t *testUserURLsRepository) DeleteURLWithUser(ctx context.Context, shortURL, userID string) error {
+	if err, ok := t.deleteErrors[shortURL]; ok {
+		return err
+	}
+	t.deletedURLs[userID] = append(t.deletedURLs[userID], shortURL)
+	return nil
+}
+
+func (t *testUserURLsRepository) DeleteURLsWithUser(ctx context.Context, shortURLs []string, userID string) (map[string]model.DeleteOutcome, error) {
+	if t.started != nil {
+		t.started <- struct{}{}
+		select {
+		case <-t.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if t.batchErr != nil && (t.batchErrRepeats == 0 || t.batchErrCalls < t.batchErrRepeats) {
+		t.batchErrCalls++
+		return nil, t.batchErr
+	}
+	t.deletedURLs[userID] = shortURLs
+	t.calls = append(t.calls, testRepoCall{action: model.DeleteJobActionDelete, userID: userID, shortURLs: shortURLs})
+
+	if t.trashed == nil {
+		t.trashed = make(map[string]bool)
+	}
+	outcomes := make(map[string]model.DeleteOutcome, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		if outcome, ok := t.outcomes[shortURL]; ok {
+			outcomes[shortURL] = outcome
+			continue
+		}
+		if t.trashed[shortURL] {
+			outcomes[shortURL] = model.DeleteOutcomeAlreadyDeleted
+			continue
+		}
+		outcomes[shortURL] = model.DeleteOutcomeDeleted
+		t.trashed[shortURL] = true
+	}
+	return outcomes, nil
+}
+
 func TestURLDestructorService_Stop(t *testing.T) {
 	// Создаем простую реализацию репозитория для тестирования
 	testRepo := &testUserURLsRepository{
@@ -84,7 +735,7 @@ func TestURLDestructorService_Stop(t *testing.T) {
 	}
 
 	// Создаем сервис
-	service := NewURLDestructorService(nil, testRepo)
+	service := newTestService(testRepo, newTestDeleteJobRepository())
 
 	// Проверяем, что сервис можно остановить без ошибок
 	assert.NotPanics(t, func() {
@@ -0,0 +1,234 @@
+package editor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/observer/audit"
+	baseObserver "yp-go-short-url-service/internal/observer/base"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/service"
+)
+
+// NewURLEditorService создает новый сервис для изменения URL их владельцами.
+// Принимает репозиторий для записи URL, шину событий для уведомлений, политику адресов назначения
+// и кэш ссылок по короткому коду, возвращает реализацию интерфейса URLEditorService.
+// Если policy задана, новые адреса назначения проверяются ею так же, как при создании ссылки.
+// Если urlCache задан, изменение ссылки сбрасывает ее запись.
+func NewURLEditorService(
+	urlRepository repository.URLRepositoryWriter,
+	eventBus baseObserver.Subject[audit.Event],
+	policy service.DestinationPolicy,
+	urlCache service.Cache,
+) service.URLEditorService {
+	return &urlEditorService{
+		urlRepository: urlRepository,
+		eventBus:      eventBus,
+		policy:        policy,
+		urlCache:      urlCache,
+	}
+}
+
+type urlEditorService struct {
+	urlRepository repository.URLRepositoryWriter
+	eventBus      baseObserver.Subject[audit.Event]
+	policy        service.DestinationPolicy
+	urlCache      service.Cache
+}
+
+// UpdateUserURL изменяет адрес назначения, срок действия, активность, заголовок, страницу предупреждения,
+// правила условного перенаправления и варианты адреса назначения ссылки текущего пользователя.
+// Возвращает измененную ссылку, ErrURLNotFound, если ссылка не найдена, удалена или принадлежит другому пользователю,
+// ErrInvalidURLUpdate, ErrInvalidExpiration, ErrInvalidTitle, ErrInvalidRoutingRules или ErrInvalidVariants,
+// если изменения заданы некорректно, в том числе если новый адрес назначения не является абсолютным HTTP(S) URL,
+// и PolicyViolationError, если новый адрес назначения запрещен политикой.
+// При успешном изменении отправляет событие аудита "update" со старым и новым адресом назначения.
+func (s *urlEditorService) UpdateUserURL(ctx context.Context, shortURL string, opts service.UpdateOptions) (*model.URLsModel, error) {
+	logger := middleware.GetLogger(ctx)
+	requestID := middleware.ExtractRequestID(ctx)
+
+	user := middleware.GetJWTUserFromContext(ctx)
+	if user == nil {
+		return nil, errors.New("user is not authenticated")
+	}
+
+	update, err := buildUpdate(opts, time.Now())
+	if err != nil {
+		logger.Warnw("Invalid URL update",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		return nil, err
+	}
+	if err := s.checkPolicy(ctx, update); err != nil {
+		return nil, err
+	}
+
+	logger.Infow("Starting URL update process",
+		"short_url", shortURL,
+		"user_id", user.ID,
+		"request_id", requestID,
+	)
+
+	previous, updated, err := s.urlRepository.UpdateByUser(ctx, shortURL, user.ID, update)
+	if err != nil {
+		if repository.IsNotFoundError(err) {
+			logger.Warnw("Short URL not found for update",
+				"short_url", shortURL,
+				"user_id", user.ID,
+				"request_id", requestID,
+			)
+			return nil, service.ErrURLNotFound
+		}
+		logger.Errorw("Failed to update URL in storage",
+			"error", err,
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		return nil, err
+	}
+	if s.urlCache != nil {
+		s.urlCache.Invalidate(ctx, shortURL)
+	}
+
+	logger.Infow("Successfully updated URL in storage",
+		"short_url", shortURL,
+		"previous_long_url", previous.LongURL,
+		"long_url", updated.LongURL,
+		"request_id", requestID,
+	)
+
+	s.notify(ctx, user.ID, previous.LongURL, updated.LongURL)
+	return updated, nil
+}
+
+// buildUpdate проверяет параметры изменения ссылки и преобразует их в изменение на уровне репозитория.
+// Срок действия, заданный через TTL, отсчитывается от now.
+func buildUpdate(opts service.UpdateOptions, now time.Time) (model.URLUpdate, error) {
+	var update model.URLUpdate
+
+	if opts.LongURL != nil {
+		longURL := strings.TrimSpace(*opts.LongURL)
+		if longURL == "" {
+			return update, fmt.Errorf("%w: url must not be empty", service.ErrInvalidURLUpdate)
+		}
+		if !service.IsHTTPURL(longURL) {
+			return update, fmt.Errorf("%w: url must be an absolute http or https url", service.ErrInvalidURLUpdate)
+		}
+		update.LongURL = &longURL
+	}
+
+	if opts.ClearExpiration {
+		if opts.ExpiresAt != nil || opts.TTL != 0 {
+			return update, fmt.Errorf("%w: expiration cannot be set and cleared at the same time", service.ErrInvalidExpiration)
+		}
+		update.UpdateExpiration = true
+	} else {
+		expiresAt, err := service.ResolveExpiration(opts.ExpiresAt, opts.TTL, now)
+		if err != nil {
+			return update, err
+		}
+		if expiresAt != nil {
+			update.UpdateExpiration = true
+			update.ExpiresAt = expiresAt
+		}
+	}
+
+	if opts.Active != nil {
+		disabled := !*opts.Active
+		update.IsDisabled = &disabled
+	}
+
+	if opts.Title != nil {
+		title, err := service.ResolveTitle(*opts.Title)
+		if err != nil {
+			return update, err
+		}
+		update.Title = &title
+	}
+
+	update.ShowInterstitial = opts.ShowInterstitial
+
+	if opts.RoutingRules != nil {
+		rules, err := service.NormalizeRoutingRules(*opts.RoutingRules)
+		if err != nil {
+			return update, err
+		}
+		update.RoutingRules = &rules
+	}
+
+	if opts.Variants != nil {
+		variants, err := service.NormalizeVariants(*opts.Variants)
+		if err != nil {
+			return update, err
+		}
+		update.Variants = &variants
+	}
+
+	if update.IsEmpty() {
+		return update, fmt.Errorf("%w: nothing to update", service.ErrInvalidURLUpdate)
+	}
+
+	return update, nil
+}
+
+// checkPolicy проверяет политикой адресов назначения новый адрес ссылки, а также адреса назначения
+// новых правил условного перенаправления и вариантов.
+func (s *urlEditorService) checkPolicy(ctx context.Context, update model.URLUpdate) error {
+	if s.policy == nil {
+		return nil
+	}
+
+	var destinations []string
+	if update.LongURL != nil {
+		destinations = append(destinations, *update.LongURL)
+	}
+	if update.RoutingRules != nil {
+		for _, rule := range *update.RoutingRules {
+			destinations = append(destinations, rule.Destination)
+		}
+	}
+	if update.Variants != nil {
+		for _, variant := range *update.Variants {
+			destinations = append(destinations, variant.Destination)
+		}
+	}
+
+	for _, destination := range destinations {
+		if err := s.policy.Check(destination); err != nil {
+			middleware.GetLogger(ctx).Warnw("URL rejected by destination policy",
+				"reason", service.PolicyViolationReason(err),
+				"long_url", destination,
+				"request_id", middleware.ExtractRequestID(ctx),
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *urlEditorService) notify(ctx context.Context, userID, previousURL, longURL string) {
+	// Если eventBus не инициализирован, пропускаем отправку события
+	if s.eventBus == nil {
+		return
+	}
+
+	logger := middleware.GetLogger(ctx)
+	event := audit.Event{
+		Timestamp:   int(time.Now().Unix()),
+		Action:      audit.EventUpdated,
+		UserID:      userID,
+		URL:         longURL,
+		PreviousURL: previousURL,
+	}
+	go func() {
+		if err := s.eventBus.NotifyAll(ctx, event); err != nil {
+			logger.Errorw("Failed to send URL notification event", "action", audit.EventUpdated, "error", err)
+		}
+	}()
+}
@@ -0,0 +1,342 @@
+package editor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/observer/audit"
+	mockObserver "yp-go-short-url-service/internal/observer/mock"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+	serviceMock "yp-go-short-url-service/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestNewURLEditorService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewURLEditorService(mock.NewMockURLRepositoryWriter(ctrl), mockObserver.NewMockSubject[audit.Event](ctrl), nil, nil)
+
+	assert.NotNil(t, service)
+	assert.IsType(t, &urlEditorService{}, service)
+}
+
+func Test_urlEditorService_UpdateUserURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryWriter(ctrl)
+	auditEventBus := mockObserver.NewMockSubject[audit.Event](ctrl)
+	service := NewURLEditorService(mockRepo, auditEventBus, nil, nil)
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+	userCtx := context.WithValue(ctx, middleware.JWTTokenContextKey, &model.UserModel{ID: "owner"})
+
+	events := make(chan audit.Event, 10)
+	auditEventBus.EXPECT().
+		NotifyAll(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, event audit.Event) error {
+			events <- event
+			return nil
+		}).
+		AnyTimes()
+
+	oldLongURL := "https://example.com/old"
+	newLongURL := "https://example.com/new"
+
+	t.Run("retarget and disable link", func(t *testing.T) {
+		active := false
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, update model.URLUpdate) (*model.URLsModel, *model.URLsModel, error) {
+				require.NotNil(t, update.LongURL)
+				assert.Equal(t, newLongURL, *update.LongURL)
+				require.NotNil(t, update.IsDisabled)
+				assert.True(t, *update.IsDisabled)
+				assert.False(t, update.UpdateExpiration)
+
+				return &model.URLsModel{ShortURL: "abc123", LongURL: oldLongURL},
+					&model.URLsModel{ShortURL: "abc123", LongURL: newLongURL, IsDisabled: true}, nil
+			})
+
+		url, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{LongURL: &newLongURL, Active: &active})
+		require.NoError(t, err)
+		assert.Equal(t, newLongURL, url.LongURL)
+		assert.True(t, url.IsDisabled)
+
+		event := <-events
+		assert.Equal(t, audit.EventActionType(audit.EventUpdated), event.Action)
+		assert.Equal(t, "owner", event.UserID)
+		assert.Equal(t, newLongURL, event.URL)
+		assert.Equal(t, oldLongURL, event.PreviousURL)
+	})
+
+	t.Run("ttl sets expiration", func(t *testing.T) {
+		before := time.Now()
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, update model.URLUpdate) (*model.URLsModel, *model.URLsModel, error) {
+				assert.True(t, update.UpdateExpiration)
+				require.NotNil(t, update.ExpiresAt)
+				assert.WithinDuration(t, before.Add(time.Hour), *update.ExpiresAt, time.Second)
+				return &model.URLsModel{LongURL: oldLongURL}, &model.URLsModel{LongURL: oldLongURL, ExpiresAt: update.ExpiresAt}, nil
+			})
+
+		_, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{TTL: time.Hour})
+		require.NoError(t, err)
+		<-events
+	})
+
+	t.Run("clear expiration", func(t *testing.T) {
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", model.URLUpdate{UpdateExpiration: true}).
+			Return(&model.URLsModel{LongURL: oldLongURL}, &model.URLsModel{LongURL: oldLongURL}, nil)
+
+		_, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{ClearExpiration: true})
+		require.NoError(t, err)
+		<-events
+	})
+
+	t.Run("title is trimmed and interstitial enabled", func(t *testing.T) {
+		title := "  Квартальный отчет  "
+		interstitial := true
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, update model.URLUpdate) (*model.URLsModel, *model.URLsModel, error) {
+				require.NotNil(t, update.Title)
+				assert.Equal(t, "Квартальный отчет", *update.Title)
+				require.NotNil(t, update.ShowInterstitial)
+				assert.True(t, *update.ShowInterstitial)
+				return &model.URLsModel{LongURL: oldLongURL}, &model.URLsModel{LongURL: oldLongURL, Title: *update.Title, ShowInterstitial: true}, nil
+			})
+
+		url, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{Title: &title, ShowInterstitial: &interstitial})
+		require.NoError(t, err)
+		assert.Equal(t, "Квартальный отчет", url.Title)
+		assert.True(t, url.ShowInterstitial)
+		<-events
+	})
+
+	t.Run("routing rules are normalized and can be cleared", func(t *testing.T) {
+		rules := model.RoutingRules{{Device: " iOS ", CIDRs: []string{"10.1.2.3/8"}, Destination: "https://apps.apple.com/app/id1"}}
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, update model.URLUpdate) (*model.URLsModel, *model.URLsModel, error) {
+				require.NotNil(t, update.RoutingRules)
+				assert.Equal(t, model.RoutingRules{{Device: model.DeviceIOS, CIDRs: []string{"10.0.0.0/8"}, Destination: "https://apps.apple.com/app/id1"}}, *update.RoutingRules)
+				return &model.URLsModel{LongURL: oldLongURL}, &model.URLsModel{LongURL: oldLongURL, RoutingRules: *update.RoutingRules}, nil
+			})
+
+		url, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{RoutingRules: &rules})
+		require.NoError(t, err)
+		assert.Len(t, url.RoutingRules, 1)
+		<-events
+
+		// Пустой список удаляет все правила
+		empty := model.RoutingRules{}
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, update model.URLUpdate) (*model.URLsModel, *model.URLsModel, error) {
+				require.NotNil(t, update.RoutingRules)
+				assert.Empty(t, *update.RoutingRules)
+				return &model.URLsModel{LongURL: oldLongURL}, &model.URLsModel{LongURL: oldLongURL}, nil
+			})
+
+		_, err = service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{RoutingRules: &empty})
+		require.NoError(t, err)
+		<-events
+	})
+
+	t.Run("split variants are normalized", func(t *testing.T) {
+		variants := model.SplitVariants{
+			{Name: " A ", Destination: "https://example.com/a", Weight: 70},
+			{Name: "B", Destination: "https://example.com/b", Weight: 30},
+		}
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, update model.URLUpdate) (*model.URLsModel, *model.URLsModel, error) {
+				require.NotNil(t, update.Variants)
+				assert.Equal(t, model.SplitVariants{
+					{Name: "a", Destination: "https://example.com/a", Weight: 70},
+					{Name: "b", Destination: "https://example.com/b", Weight: 30},
+				}, *update.Variants)
+				return &model.URLsModel{LongURL: oldLongURL}, &model.URLsModel{LongURL: oldLongURL, Variants: *update.Variants}, nil
+			})
+
+		url, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{Variants: &variants})
+		require.NoError(t, err)
+		assert.Len(t, url.Variants, 2)
+		<-events
+	})
+
+	t.Run("link not owned", func(t *testing.T) {
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "alien1", "owner", gomock.Any()).
+			Return(nil, nil, repository.ErrURLNotFound)
+
+		url, err := service.UpdateUserURL(userCtx, "alien1", services.UpdateOptions{LongURL: &newLongURL})
+		assert.ErrorIs(t, err, services.ErrURLNotFound)
+		assert.Nil(t, url)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		storageErr := errors.New("database connection failed")
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			Return(nil, nil, storageErr)
+
+		_, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{LongURL: &newLongURL})
+		assert.Equal(t, storageErr, err)
+	})
+
+	t.Run("invalid updates are rejected before storage", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+		blank := "  "
+		scriptURL := "javascript:alert(1)"
+		bareWord := "example"
+		longTitle := strings.Repeat("я", services.MaxTitleLength+1)
+		controlTitle := "line\nbreak"
+		ruleWithoutMatcher := model.RoutingRules{{Destination: "https://example.com/any"}}
+		singleVariant := model.SplitVariants{{Name: "a", Destination: "https://example.com/a", Weight: 1}}
+
+		tests := []struct {
+			name    string
+			opts    services.UpdateOptions
+			wantErr error
+		}{
+			{name: "nothing to update", opts: services.UpdateOptions{}, wantErr: services.ErrInvalidURLUpdate},
+			{name: "blank url", opts: services.UpdateOptions{LongURL: &blank}, wantErr: services.ErrInvalidURLUpdate},
+			{name: "javascript url", opts: services.UpdateOptions{LongURL: &scriptURL}, wantErr: services.ErrInvalidURLUpdate},
+			{name: "relative url", opts: services.UpdateOptions{LongURL: &bareWord}, wantErr: services.ErrInvalidURLUpdate},
+			{name: "expiration in the past", opts: services.UpdateOptions{ExpiresAt: &past}, wantErr: services.ErrInvalidExpiration},
+			{name: "negative ttl", opts: services.UpdateOptions{TTL: -time.Minute}, wantErr: services.ErrInvalidExpiration},
+			{name: "set and clear expiration", opts: services.UpdateOptions{TTL: time.Hour, ClearExpiration: true}, wantErr: services.ErrInvalidExpiration},
+			{name: "title too long", opts: services.UpdateOptions{Title: &longTitle}, wantErr: services.ErrInvalidTitle},
+			{name: "title with control characters", opts: services.UpdateOptions{Title: &controlTitle}, wantErr: services.ErrInvalidTitle},
+			{name: "routing rule without matcher", opts: services.UpdateOptions{RoutingRules: &ruleWithoutMatcher}, wantErr: services.ErrInvalidRoutingRules},
+			{name: "single split variant", opts: services.UpdateOptions{Variants: &singleVariant}, wantErr: services.ErrInvalidVariants},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				url, err := service.UpdateUserURL(userCtx, "abc123", tt.opts)
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, url)
+			})
+		}
+	})
+
+	t.Run("anonymous request", func(t *testing.T) {
+		_, err := service.UpdateUserURL(ctx, "abc123", services.UpdateOptions{LongURL: &newLongURL})
+		assert.Error(t, err)
+	})
+}
+
+func Test_urlEditorService_UpdateUserURL_CacheInvalidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryWriter(ctrl)
+	mockCache := serviceMock.NewMockCache(ctrl)
+	service := NewURLEditorService(mockRepo, nil, nil, mockCache)
+
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	userCtx := context.WithValue(ctx, middleware.JWTTokenContextKey, &model.UserModel{ID: "owner"})
+	newLongURL := "https://example.com/new"
+
+	t.Run("updated link is invalidated", func(t *testing.T) {
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			Return(&model.URLsModel{LongURL: "https://example.com/old"}, &model.URLsModel{LongURL: newLongURL}, nil)
+		mockCache.EXPECT().Invalidate(userCtx, "abc123")
+
+		_, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{LongURL: &newLongURL})
+		require.NoError(t, err)
+	})
+
+	t.Run("failed update keeps the cache", func(t *testing.T) {
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "alien1", "owner", gomock.Any()).
+			Return(nil, nil, repository.ErrURLNotFound)
+
+		_, err := service.UpdateUserURL(userCtx, "alien1", services.UpdateOptions{LongURL: &newLongURL})
+		assert.ErrorIs(t, err, services.ErrURLNotFound)
+	})
+}
+
+func Test_urlEditorService_UpdateUserURL_DestinationPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryWriter(ctrl)
+	mockPolicy := serviceMock.NewMockDestinationPolicy(ctrl)
+	service := NewURLEditorService(mockRepo, nil, mockPolicy, nil)
+
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	userCtx := context.WithValue(ctx, middleware.JWTTokenContextKey, &model.UserModel{ID: "owner"})
+
+	deniedURL := "https://blocked.example/page"
+	rejection := &services.PolicyViolationError{Reason: services.PolicyReasonDomainDenied, URL: deniedURL}
+
+	t.Run("denied long url is rejected", func(t *testing.T) {
+		mockPolicy.EXPECT().Check(deniedURL).Return(rejection)
+
+		url, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{LongURL: &deniedURL})
+		assert.True(t, services.IsPolicyViolationError(err))
+		assert.Equal(t, services.PolicyReasonDomainDenied, services.PolicyViolationReason(err))
+		assert.Nil(t, url)
+	})
+
+	t.Run("denied routing rule destination is rejected", func(t *testing.T) {
+		rules := model.RoutingRules{{Languages: []string{"de"}, Destination: deniedURL}}
+		mockPolicy.EXPECT().Check(deniedURL).Return(rejection)
+
+		_, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{RoutingRules: &rules})
+		assert.True(t, services.IsPolicyViolationError(err))
+	})
+
+	t.Run("denied variant destination is rejected", func(t *testing.T) {
+		variants := model.SplitVariants{
+			{Name: "a", Destination: "https://example.com/a", Weight: 1},
+			{Name: "b", Destination: deniedURL, Weight: 1},
+		}
+		mockPolicy.EXPECT().Check("https://example.com/a").Return(nil)
+		mockPolicy.EXPECT().Check(deniedURL).Return(rejection)
+
+		_, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{Variants: &variants})
+		assert.True(t, services.IsPolicyViolationError(err))
+	})
+
+	t.Run("allowed destination is updated", func(t *testing.T) {
+		allowedURL := "https://example.com/new"
+		mockPolicy.EXPECT().Check(allowedURL).Return(nil)
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			Return(&model.URLsModel{LongURL: "https://example.com/old"}, &model.URLsModel{LongURL: allowedURL}, nil)
+
+		url, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{LongURL: &allowedURL})
+		require.NoError(t, err)
+		assert.Equal(t, allowedURL, url.LongURL)
+	})
+
+	t.Run("changes without destinations skip the policy", func(t *testing.T) {
+		active := false
+		mockRepo.EXPECT().
+			UpdateByUser(userCtx, "abc123", "owner", gomock.Any()).
+			Return(&model.URLsModel{}, &model.URLsModel{IsDisabled: true}, nil)
+
+		_, err := service.UpdateUserURL(userCtx, "abc123", services.UpdateOptions{Active: &active})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,87 @@
+package expiration
+
+import (
+	"context"
+	"sync"
+	"time"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/service"
+
+	"go.uber.org/zap"
+)
+
+// NewExpiredURLsSweeper создает фоновый процесс, который с периодом interval помечает истекшие ссылки,
+// чтобы они не учитывались в статистике. Если interval не положителен, фоновый запуск отключен
+// и пометка выполняется только явным вызовом Sweep. Возвращает реализацию интерфейса ExpiredURLsSweeper.
+func NewExpiredURLsSweeper(
+	urlRepository repository.URLRepositoryWriter,
+	interval time.Duration,
+	logger *zap.SugaredLogger,
+) service.ExpiredURLsSweeper {
+	sweeper := &expiredURLsSweeper{
+		urlRepository: urlRepository,
+		logger:        logger,
+		stopChan:      make(chan struct{}),
+		wg:            &sync.WaitGroup{},
+	}
+
+	if interval > 0 {
+		sweeper.wg.Add(1)
+		go sweeper.run(interval)
+	}
+
+	return sweeper
+}
+
+type expiredURLsSweeper struct {
+	urlRepository repository.URLRepositoryWriter
+	logger        *zap.SugaredLogger
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+	wg            *sync.WaitGroup
+}
+
+// run периодически вызывает Sweep до получения сигнала остановки.
+func (s *expiredURLsSweeper) run(interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if _, err := s.Sweep(ctx); err != nil {
+				s.logger.Errorw("Failed to mark expired URLs", "error", err)
+			}
+			cancel()
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// Sweep помечает как истекшие все ссылки, срок действия которых уже наступил.
+// Возвращает количество помеченных ссылок или ошибку, если обновление не удалось.
+func (s *expiredURLsSweeper) Sweep(ctx context.Context) (int64, error) {
+	count, err := s.urlRepository.MarkExpired(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if count > 0 {
+		s.logger.Infow("Marked expired URLs", "count", count)
+	}
+
+	return count, nil
+}
+
+// Stop останавливает фоновый процесс и дожидается завершения текущего прохода.
+// Повторные вызовы безопасны.
+func (s *expiredURLsSweeper) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+	s.wg.Wait()
+}
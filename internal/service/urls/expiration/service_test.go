@@ -0,0 +1,82 @@
+package expiration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/repository/mock"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestExpiredURLsSweeper_Sweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryWriter(ctrl)
+	logger, _ := zap.NewDevelopment()
+
+	// Нулевой интервал отключает фоновый запуск
+	sweeper := NewExpiredURLsSweeper(mockRepo, 0, logger.Sugar())
+	defer sweeper.Stop()
+
+	ctx := context.Background()
+
+	t.Run("marks expired URLs", func(t *testing.T) {
+		before := time.Now()
+		mockRepo.EXPECT().
+			MarkExpired(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, now time.Time) (int64, error) {
+				assert.False(t, now.Before(before))
+				return 3, nil
+			})
+
+		count, err := sweeper.Sweep(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		dbErr := errors.New("database connection failed")
+		mockRepo.EXPECT().MarkExpired(ctx, gomock.Any()).Return(int64(0), dbErr)
+
+		count, err := sweeper.Sweep(ctx)
+		assert.ErrorIs(t, err, dbErr)
+		assert.Zero(t, count)
+	})
+}
+
+func TestExpiredURLsSweeper_Background(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryWriter(ctrl)
+	logger, _ := zap.NewDevelopment()
+
+	called := make(chan struct{}, 1)
+	mockRepo.EXPECT().
+		MarkExpired(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, now time.Time) (int64, error) {
+			select {
+			case called <- struct{}{}:
+			default:
+			}
+			return 0, nil
+		}).
+		MinTimes(1)
+
+	sweeper := NewExpiredURLsSweeper(mockRepo, 10*time.Millisecond, logger.Sugar())
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("sweeper did not run")
+	}
+
+	sweeper.Stop()
+	// Повторная остановка безопасна
+	sweeper.Stop()
+}
@@ -0,0 +1,97 @@
+package extractor
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// maxPasswordAttempts - число неудачных попыток ввода пароля ссылки, после которого ввод блокируется
+	maxPasswordAttempts = 5
+	// passwordAttemptsWindow - окно, в течение которого учитываются неудачные попытки ввода пароля
+	passwordAttemptsWindow = 15 * time.Minute
+	// maxTrackedLinks - максимальное число отслеживаемых ссылок; при его достижении удаляются устаревшие
+	// записи, а если их не хватило - записи с самым давним началом окна
+	maxTrackedLinks = 10000
+)
+
+// passwordAttempts ограничивает число неудачных попыток ввода пароля для каждой ссылки.
+// Счетчик ссылки сбрасывается после успешного ввода или по истечении окна с момента первой неудачи.
+type passwordAttempts struct {
+	mu       sync.Mutex
+	limit    int
+	window   time.Duration
+	failures map[string]*attemptsWindow
+	now      func() time.Time
+}
+
+type attemptsWindow struct {
+	count     int
+	startedAt time.Time
+}
+
+func newPasswordAttempts(limit int, window time.Duration) *passwordAttempts {
+	return &passwordAttempts{
+		limit:    limit,
+		window:   window,
+		failures: make(map[string]*attemptsWindow),
+		now:      time.Now,
+	}
+}
+
+// reserve атомарно резервирует попытку ввода пароля ссылки и сообщает, удалось ли это. Попытка
+// учитывается как неудачная до проверки пароля, поэтому одновременные запросы не могут превысить лимит;
+// после успешного ввода счетчик сбрасывается вызовом reset. Возвращает false, если лимит исчерпан.
+func (a *passwordAttempts) reserve(shortURL string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	w, ok := a.failures[shortURL]
+	if !ok || a.expired(w) {
+		if len(a.failures) >= maxTrackedLinks {
+			a.prune()
+		}
+		a.failures[shortURL] = &attemptsWindow{count: 1, startedAt: a.now()}
+		return true
+	}
+	if w.count >= a.limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// reset сбрасывает счетчик неудачных попыток ссылки, в том числе зарезервированную попытку.
+func (a *passwordAttempts) reset(shortURL string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.failures, shortURL)
+}
+
+func (a *passwordAttempts) expired(w *attemptsWindow) bool {
+	return a.now().Sub(w.startedAt) >= a.window
+}
+
+// prune удаляет записи с истекшим окном, а если после этого ссылок не меньше maxTrackedLinks -
+// записи с самым давним началом окна, пока для новой ссылки не освободится место. Вызывается под блокировкой.
+func (a *passwordAttempts) prune() {
+	for shortURL, w := range a.failures {
+		if a.expired(w) {
+			delete(a.failures, shortURL)
+		}
+	}
+
+	for len(a.failures) >= maxTrackedLinks {
+		var (
+			oldestURL string
+			oldest    *attemptsWindow
+		)
+		for shortURL, w := range a.failures {
+			if oldest == nil || w.startedAt.Before(oldest.startedAt) {
+				oldestURL, oldest = shortURL, w
+			}
+		}
+		delete(a.failures, oldestURL)
+	}
+}
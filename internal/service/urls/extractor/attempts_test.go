@@ -0,0 +1,79 @@
+package extractor
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_passwordAttempts(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	attempts := newPasswordAttempts(2, time.Minute)
+	attempts.now = func() time.Time { return now }
+
+	t.Run("blocks after limit", func(t *testing.T) {
+		assert.True(t, attempts.reserve("a"))
+		assert.True(t, attempts.reserve("a"))
+		assert.False(t, attempts.reserve("a"))
+
+		// Счетчики ссылок независимы
+		assert.True(t, attempts.reserve("b"))
+	})
+
+	t.Run("window expires", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		assert.True(t, attempts.reserve("a"))
+	})
+
+	t.Run("reset clears failures", func(t *testing.T) {
+		assert.True(t, attempts.reserve("c"))
+		assert.True(t, attempts.reserve("c"))
+		attempts.reset("c")
+		assert.True(t, attempts.reserve("c"))
+	})
+}
+
+func Test_passwordAttempts_Concurrent(t *testing.T) {
+	attempts := newPasswordAttempts(maxPasswordAttempts, passwordAttemptsWindow)
+
+	var reserved atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if attempts.reserve("a") {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(maxPasswordAttempts), reserved.Load())
+}
+
+func Test_passwordAttempts_Bounded(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	attempts := newPasswordAttempts(1, time.Hour)
+	attempts.now = func() time.Time { return now }
+
+	// Ни одно окно не истекло, но число отслеживаемых ссылок не превышает предел
+	for i := 0; i < maxTrackedLinks; i++ {
+		now = now.Add(time.Millisecond)
+		assert.True(t, attempts.reserve(strconv.Itoa(i)))
+	}
+	assert.Len(t, attempts.failures, maxTrackedLinks)
+
+	now = now.Add(time.Millisecond)
+	assert.True(t, attempts.reserve("new"))
+	assert.Len(t, attempts.failures, maxTrackedLinks)
+
+	// Вытеснена запись с самым давним началом окна, остальные ссылки по-прежнему заблокированы
+	assert.NotContains(t, attempts.failures, "0")
+	assert.False(t, attempts.reserve("1"))
+	assert.False(t, attempts.reserve("new"))
+}
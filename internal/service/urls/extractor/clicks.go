@@ -0,0 +1,50 @@
+package extractor
+
+import (
+	"context"
+	"net"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+)
+
+const (
+	// ipv4PrefixBits - длина сохраняемого префикса IPv4-адреса посетителя
+	ipv4PrefixBits = 24
+	// ipv6PrefixBits - длина сохраняемого префикса IPv6-адреса посетителя
+	ipv6PrefixBits = 48
+)
+
+// recordClick учитывает переход по ссылке в буфере переходов, не обращаясь к базе данных.
+// Сведения о посетителе берутся из контекста запроса, IP-адрес сохраняется обезличенным.
+// variant - имя варианта адреса назначения, на который выполнен переход.
+func (s *linkExtractorService) recordClick(ctx context.Context, url *model.URLsModel, variant string) {
+	// Если буфер переходов не подключен, пропускаем учет
+	if s.clickAggregator == nil {
+		return
+	}
+
+	client := middleware.GetClientInfo(ctx)
+	s.clickAggregator.Record(&model.ClickModel{
+		ShortURL:  url.ShortURL,
+		ClickedAt: time.Now(),
+		Referrer:  client.Referrer,
+		UserAgent: client.UserAgent,
+		IP:        anonymizeIP(client.IP),
+		Variant:   variant,
+	})
+}
+
+// anonymizeIP обезличивает IP-адрес: у IPv4 обнуляется последний октет, у IPv6 - все биты после префикса /48.
+// Для пустого или некорректного адреса возвращает пустую строку.
+func anonymizeIP(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+
+	if ipv4 := parsed.To4(); ipv4 != nil {
+		return ipv4.Mask(net.CIDRMask(ipv4PrefixBits, 32)).String()
+	}
+	return parsed.Mask(net.CIDRMask(ipv6PrefixBits, 128)).String()
+}
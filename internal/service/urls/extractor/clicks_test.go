@@ -0,0 +1,112 @@
+package extractor
+
+import (
+	"context"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/mock"
+	mockService "yp-go-short-url-service/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func Test_linkExtractorService_RecordsClick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryReader(ctrl)
+	mockClickAggregator := mockService.NewMockClickAggregator(ctrl)
+
+	service := &linkExtractorService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepositoryReader(ctrl),
+		clickAggregator:    mockClickAggregator,
+	}
+
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	ctx = middleware.WithClientInfo(ctx, middleware.ClientInfo{
+		IP:        "203.0.113.42",
+		UserAgent: "Mozilla/5.0",
+		Referrer:  "https://news.example.com/",
+	})
+
+	shortURL := "abc123"
+	longURL := "https://example.com/very/long/url"
+
+	t.Run("click is buffered with anonymized IP", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL}, nil)
+
+		var recorded *model.ClickModel
+		mockClickAggregator.EXPECT().Record(gomock.Any()).
+			Do(func(click *model.ClickModel) {
+				recorded = click
+			})
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, longURL, result.Location)
+
+		require.NotNil(t, recorded)
+		assert.Equal(t, shortURL, recorded.ShortURL)
+		assert.Equal(t, "203.0.113.0", recorded.IP)
+		assert.Equal(t, "Mozilla/5.0", recorded.UserAgent)
+		assert.Equal(t, "https://news.example.com/", recorded.Referrer)
+		assert.False(t, recorded.ClickedAt.IsZero())
+	})
+
+	t.Run("click is attributed to the sticky variant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(&model.URLsModel{
+			ShortURL: shortURL,
+			LongURL:  longURL,
+			Variants: model.SplitVariants{
+				{Name: "a", Destination: "https://example.com/a", Weight: 70},
+				{Name: "b", Destination: "https://example.com/b", Weight: 30},
+			},
+		}, nil)
+
+		var recorded *model.ClickModel
+		mockClickAggregator.EXPECT().Record(gomock.Any()).
+			Do(func(click *model.ClickModel) {
+				recorded = click
+			})
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{Variant: "b"})
+		require.NoError(t, err)
+		assert.Equal(t, &model.Redirect{Location: "https://example.com/b", StatusCode: model.DefaultRedirectCode, Variant: "b"}, result)
+
+		require.NotNil(t, recorded)
+		assert.Equal(t, "b", recorded.Variant)
+	})
+
+	t.Run("rejected link is not recorded", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).
+			Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL, IsDisabled: true}, nil)
+
+		_, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.Error(t, err)
+	})
+}
+
+func Test_anonymizeIP(t *testing.T) {
+	tests := []struct {
+		ip       string
+		expected string
+	}{
+		{ip: "203.0.113.42", expected: "203.0.113.0"},
+		{ip: "::ffff:203.0.113.42", expected: "203.0.113.0"},
+		{ip: "2001:db8:85a3:8d3:1319:8a2e:370:7348", expected: "2001:db8:85a3::"},
+		{ip: "", expected: ""},
+		{ip: "not-an-ip", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.expected, anonymizeIP(tt.ip))
+		})
+	}
+}
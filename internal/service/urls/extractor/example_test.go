@@ -18,11 +18,12 @@ func ExampleNewLinkExtractorService() {
 
 	// Создаем моки репозиториев
 	mockURLRepo := mock.NewMockURLRepositoryReader(ctrl)
+	mockClickConsumer := mock.NewMockURLClickConsumer(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepositoryReader(ctrl)
 	auditEventBus := observerMock.NewMockSubject[audit.Event](ctrl)
 
 	// Создаем сервис для извлечения URL
-	service := extractor.NewLinkExtractorService(mockURLRepo, mockUserURLsRepo, auditEventBus)
+	service := extractor.NewLinkExtractorService(mockURLRepo, mockClickConsumer, mockUserURLsRepo, nil, auditEventBus, nil)
 
 	// Сервис готов к использованию
 	_ = service
@@ -37,16 +38,17 @@ func ExampleNewLinkExtractorService_extractLongURL() {
 	defer ctrl.Finish()
 
 	mockURLRepo := mock.NewMockURLRepositoryReader(ctrl)
+	mockClickConsumer := mock.NewMockURLClickConsumer(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepositoryReader(ctrl)
 	auditEventBus := observerMock.NewMockSubject[audit.Event](ctrl)
 
-	service := extractor.NewLinkExtractorService(mockURLRepo, mockUserURLsRepo, auditEventBus)
+	service := extractor.NewLinkExtractorService(mockURLRepo, mockClickConsumer, mockUserURLsRepo, nil, auditEventBus, nil)
 
 	ctx := context.Background()
 	shortURL := "abc123"
 
 	// В реальном приложении здесь будет вызов:
-	// longURL, err := service.ExtractLongURL(ctx, shortURL)
+	// redirect, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{Query: r.URL.Query()})
 	// Для примера демонстрируем только создание сервиса
 	_ = service
 	_ = ctx
@@ -62,10 +64,11 @@ func ExampleNewLinkExtractorService_extractUserURLs() {
 	defer ctrl.Finish()
 
 	mockURLRepo := mock.NewMockURLRepositoryReader(ctrl)
+	mockClickConsumer := mock.NewMockURLClickConsumer(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepositoryReader(ctrl)
 	auditEventBus := observerMock.NewMockSubject[audit.Event](ctrl)
 
-	service := extractor.NewLinkExtractorService(mockURLRepo, mockUserURLsRepo, auditEventBus)
+	service := extractor.NewLinkExtractorService(mockURLRepo, mockClickConsumer, mockUserURLsRepo, nil, auditEventBus, nil)
 
 	ctx := context.Background()
 	userID := "user-123"
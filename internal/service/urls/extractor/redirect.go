@@ -0,0 +1,135 @@
+package extractor
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+)
+
+// buildRedirect формирует результат перехода по ссылке: адрес назначения с перенесенными параметрами запроса
+// и HTTP статус перенаправления, заданный для ссылки.
+// Если запрос удовлетворяет одному из правил условного перенаправления, адресом назначения становится
+// адрес из первого подходящего правила. Иначе для ссылки с вариантами адрес назначения берется из варианта,
+// закрепленного за посетителем (req.Variant) или выбранного по весам, а имя варианта возвращается в Redirect.Variant;
+// для остальных ссылок используется адрес назначения ссылки.
+// Для ссылки-префикса путь req.PathSuffix добавляется к пути адреса назначения, а параметры запроса
+// переносятся в режиме QueryPassthroughMerge, если для ссылки не задан другой режим.
+// Возвращает ErrURLNotFound, если путь после короткого кода передан для обычной ссылки,
+// и ErrInvalidPathSuffix, если путь нельзя безопасно перенести.
+func buildRedirect(link *model.URLsModel, req model.RedirectRequest) (*model.Redirect, error) {
+	location, variantName := link.LongURL, ""
+	if rule := matchRoutingRule(link.RoutingRules, req); rule != nil {
+		location = rule.Destination
+	} else if variant := randomVariant(link.Variants, req.Variant); variant != nil {
+		location, variantName = variant.Destination, variant.Name
+	}
+	passthrough := link.QueryPassthrough
+	if link.IsPrefix {
+		var err error
+		location, err = appendPath(location, req.PathSuffix)
+		if err != nil {
+			return nil, err
+		}
+		if passthrough == model.QueryPassthroughOff {
+			passthrough = model.QueryPassthroughMerge
+		}
+	} else if strings.Trim(req.PathSuffix, "/") != "" {
+		return nil, service.ErrURLNotFound
+	}
+
+	return &model.Redirect{
+		Location:   mergeQuery(location, req.Query, passthrough),
+		StatusCode: link.RedirectStatus(),
+		Variant:    variantName,
+	}, nil
+}
+
+// appendPath добавляет к пути адреса назначения destination экранированный путь suffix.
+// Каждый сегмент декодируется и экранируется заново, поэтому закодированные символы, в том числе "%2F",
+// остаются внутри своего сегмента. Пустые сегменты и "." отбрасываются, сегмент ".." приводит
+// к ErrInvalidPathSuffix, чтобы путь не выходил за пределы адреса назначения. Завершающий "/" сохраняется.
+func appendPath(destination, suffix string) (string, error) {
+	if suffix == "" {
+		return destination, nil
+	}
+
+	target, err := url.Parse(destination)
+	if err != nil {
+		return "", fmt.Errorf("%w: destination is not a valid url", service.ErrInvalidPathSuffix)
+	}
+
+	var segments, escaped []string
+	for _, segment := range strings.Split(suffix, "/") {
+		decoded, err := url.PathUnescape(segment)
+		if err != nil {
+			return "", fmt.Errorf("%w: malformed segment %q", service.ErrInvalidPathSuffix, segment)
+		}
+		switch decoded {
+		case "", ".":
+			continue
+		case "..":
+			return "", fmt.Errorf("%w: parent segment is not allowed", service.ErrInvalidPathSuffix)
+		}
+		segments = append(segments, decoded)
+		escaped = append(escaped, url.PathEscape(decoded))
+	}
+
+	trailing := ""
+	if len(segments) > 0 && strings.HasSuffix(suffix, "/") {
+		trailing = "/"
+	}
+	basePath := strings.TrimRight(target.Path, "/")
+	baseRawPath := strings.TrimRight(target.EscapedPath(), "/")
+	target.Path = basePath + "/" + strings.Join(segments, "/") + trailing
+	target.RawPath = baseRawPath + "/" + strings.Join(escaped, "/") + trailing
+
+	return target.String(), nil
+}
+
+// mergeQuery переносит параметры запроса incoming в адрес назначения destination согласно режиму mode.
+// Параметры адреса назначения сохраняют исходный порядок и кодирование, пришедшие параметры добавляются
+// в конец в порядке имен. При QueryPassthroughMerge совпадающие по имени пришедшие параметры отбрасываются,
+// при QueryPassthroughOverride из адреса назначения удаляются все значения параметров с такими именами.
+// Если адрес назначения не разбирается как URL, он возвращается без изменений.
+func mergeQuery(destination string, incoming url.Values, mode model.QueryPassthrough) string {
+	if mode == model.QueryPassthroughOff || len(incoming) == 0 {
+		return destination
+	}
+
+	target, err := url.Parse(destination)
+	if err != nil {
+		return destination
+	}
+
+	var pairs []string
+	existing := make(map[string]struct{})
+	if target.RawQuery != "" {
+		for _, pair := range strings.Split(target.RawQuery, "&") {
+			key, _, _ := strings.Cut(pair, "=")
+			if unescaped, err := url.QueryUnescape(key); err == nil {
+				key = unescaped
+			}
+			if _, ok := incoming[key]; ok && mode == model.QueryPassthroughOverride {
+				continue
+			}
+			existing[key] = struct{}{}
+			pairs = append(pairs, pair)
+		}
+	}
+
+	added := make(url.Values, len(incoming))
+	for key, values := range incoming {
+		if _, ok := existing[key]; ok {
+			continue
+		}
+		added[key] = values
+	}
+	if encoded := added.Encode(); encoded != "" {
+		pairs = append(pairs, encoded)
+	}
+
+	target.RawQuery = strings.Join(pairs, "&")
+	return target.String()
+}
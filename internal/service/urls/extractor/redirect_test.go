@@ -0,0 +1,250 @@
+package extractor
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"yp-go-short-url-service/internal/model"
+	services "yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mergeQuery(t *testing.T) {
+	incoming := url.Values{"utm_source": {"mail"}, "ref": {"promo"}}
+
+	tests := []struct {
+		name        string
+		destination string
+		incoming    url.Values
+		mode        model.QueryPassthrough
+		expected    string
+	}{
+		{
+			name:        "passthrough is off",
+			destination: "https://example.com/page?ref=site",
+			incoming:    incoming,
+			mode:        model.QueryPassthroughOff,
+			expected:    "https://example.com/page?ref=site",
+		},
+		{
+			name:        "no incoming parameters",
+			destination: "https://example.com/page?ref=site",
+			mode:        model.QueryPassthroughMerge,
+			expected:    "https://example.com/page?ref=site",
+		},
+		{
+			name:        "destination values win on merge",
+			destination: "https://example.com/page?ref=site&b=1",
+			incoming:    incoming,
+			mode:        model.QueryPassthroughMerge,
+			expected:    "https://example.com/page?ref=site&b=1&utm_source=mail",
+		},
+		{
+			name:        "incoming values win on override",
+			destination: "https://example.com/page?ref=site&b=1&ref=other",
+			incoming:    incoming,
+			mode:        model.QueryPassthroughOverride,
+			expected:    "https://example.com/page?b=1&ref=promo&utm_source=mail",
+		},
+		{
+			name:        "fragment and raw encoding are preserved",
+			destination: "https://example.com/page?q=a%20b#section",
+			incoming:    url.Values{"tag": {"x y"}},
+			mode:        model.QueryPassthroughMerge,
+			expected:    "https://example.com/page?q=a%20b&tag=x+y#section",
+		},
+		{
+			name:        "repeated incoming values are kept",
+			destination: "https://example.com/page",
+			incoming:    url.Values{"tag": {"a", "b"}},
+			mode:        model.QueryPassthroughOverride,
+			expected:    "https://example.com/page?tag=a&tag=b",
+		},
+		{
+			name:        "unparsable destination is returned unchanged",
+			destination: "http://[::1",
+			incoming:    incoming,
+			mode:        model.QueryPassthroughMerge,
+			expected:    "http://[::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mergeQuery(tt.destination, tt.incoming, tt.mode))
+		})
+	}
+}
+
+func Test_buildRedirect(t *testing.T) {
+	req := model.RedirectRequest{Query: url.Values{"utm_source": {"mail"}}}
+
+	t.Run("default settings", func(t *testing.T) {
+		redirect, err := buildRedirect(&model.URLsModel{LongURL: "https://example.com"}, req)
+		require.NoError(t, err)
+		assert.Equal(t, &model.Redirect{Location: "https://example.com", StatusCode: http.StatusTemporaryRedirect}, redirect)
+	})
+
+	t.Run("redirect code and passthrough", func(t *testing.T) {
+		redirect, err := buildRedirect(&model.URLsModel{
+			LongURL:          "https://example.com",
+			RedirectCode:     http.StatusMovedPermanently,
+			QueryPassthrough: model.QueryPassthroughMerge,
+		}, req)
+		require.NoError(t, err)
+		assert.Equal(t, &model.Redirect{Location: "https://example.com?utm_source=mail", StatusCode: http.StatusMovedPermanently}, redirect)
+	})
+
+	t.Run("prefix link forwards path and query", func(t *testing.T) {
+		redirect, err := buildRedirect(&model.URLsModel{LongURL: "https://example.com/base?lang=ru", IsPrefix: true}, model.RedirectRequest{
+			Query:      url.Values{"x": {"1"}, "lang": {"en"}},
+			PathSuffix: "/docs/page",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/base/docs/page?lang=ru&x=1", redirect.Location)
+	})
+
+	t.Run("routing rule replaces destination", func(t *testing.T) {
+		link := &model.URLsModel{
+			LongURL:          "https://example.com",
+			QueryPassthrough: model.QueryPassthroughMerge,
+			RoutingRules:     model.RoutingRules{{Device: model.DeviceAndroid, Destination: "https://play.google.com/store/apps/details?id=app"}},
+		}
+
+		redirect, err := buildRedirect(link, model.RedirectRequest{Query: req.Query, UserAgent: "Mozilla/5.0 (Linux; Android 14)"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://play.google.com/store/apps/details?id=app&utm_source=mail", redirect.Location)
+
+		// Без подходящего правила используется адрес назначения ссылки
+		redirect, err = buildRedirect(link, model.RedirectRequest{UserAgent: "Mozilla/5.0 (Windows NT 10.0)"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", redirect.Location)
+	})
+
+	t.Run("split variant replaces destination", func(t *testing.T) {
+		link := &model.URLsModel{
+			LongURL:          "https://example.com",
+			QueryPassthrough: model.QueryPassthroughMerge,
+			RoutingRules:     model.RoutingRules{{Device: model.DeviceIOS, Destination: "https://apps.apple.com/app/id1"}},
+			Variants: model.SplitVariants{
+				{Name: "a", Destination: "https://example.com/a", Weight: 1},
+				{Name: "b", Destination: "https://example.com/b", Weight: 1},
+			},
+		}
+
+		redirect, err := buildRedirect(link, model.RedirectRequest{Query: req.Query, Variant: "b"})
+		require.NoError(t, err)
+		assert.Equal(t, &model.Redirect{Location: "https://example.com/b?utm_source=mail", StatusCode: http.StatusTemporaryRedirect, Variant: "b"}, redirect)
+
+		// Неизвестный вариант выбирается заново по весам
+		redirect, err = buildRedirect(link, model.RedirectRequest{Variant: "c"})
+		require.NoError(t, err)
+		assert.Contains(t, []string{"a", "b"}, redirect.Variant)
+
+		// Правило условного перенаправления важнее вариантов
+		redirect, err = buildRedirect(link, model.RedirectRequest{UserAgent: "Mozilla/5.0 (iPhone)", Variant: "b"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://apps.apple.com/app/id1", redirect.Location)
+		assert.Empty(t, redirect.Variant)
+	})
+
+	t.Run("path suffix on regular link", func(t *testing.T) {
+		_, err := buildRedirect(&model.URLsModel{LongURL: "https://example.com"}, model.RedirectRequest{PathSuffix: "/docs"})
+		assert.True(t, services.IsNotFoundError(err))
+
+		// Завершающий "/" после кода обычной ссылки игнорируется
+		redirect, err := buildRedirect(&model.URLsModel{LongURL: "https://example.com"}, model.RedirectRequest{PathSuffix: "/"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", redirect.Location)
+	})
+}
+
+func Test_appendPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		suffix      string
+		expected    string
+		wantErr     bool
+	}{
+		{
+			name:        "no suffix",
+			destination: "https://example.com/base",
+			expected:    "https://example.com/base",
+		},
+		{
+			name:        "destination without path",
+			destination: "https://example.com",
+			suffix:      "/docs/page",
+			expected:    "https://example.com/docs/page",
+		},
+		{
+			name:        "destination with trailing slash, query and fragment",
+			destination: "https://example.com/base/?v=2#top",
+			suffix:      "/docs",
+			expected:    "https://example.com/base/docs?v=2#top",
+		},
+		{
+			name:        "trailing slash is preserved",
+			destination: "https://example.com/base",
+			suffix:      "/docs/",
+			expected:    "https://example.com/base/docs/",
+		},
+		{
+			name:        "only slash",
+			destination: "https://example.com/base",
+			suffix:      "/",
+			expected:    "https://example.com/base/",
+		},
+		{
+			name:        "segments are escaped",
+			destination: "https://example.com/base",
+			suffix:      "/a%2Fb/c d/привет",
+			expected:    "https://example.com/base/a%2Fb/c%20d/%D0%BF%D1%80%D0%B8%D0%B2%D0%B5%D1%82",
+		},
+		{
+			name:        "empty and dot segments are dropped",
+			destination: "https://example.com/base",
+			suffix:      "//./docs//page",
+			expected:    "https://example.com/base/docs/page",
+		},
+		{
+			name:        "scheme-relative suffix stays on destination host",
+			destination: "https://example.com",
+			suffix:      "//evil.example/x",
+			expected:    "https://example.com/evil.example/x",
+		},
+		{
+			name:        "parent segment",
+			destination: "https://example.com/base",
+			suffix:      "/docs/../../admin",
+			wantErr:     true,
+		},
+		{
+			name:        "encoded parent segment",
+			destination: "https://example.com/base",
+			suffix:      "/%2e%2e/admin",
+			wantErr:     true,
+		},
+		{
+			name:        "malformed escape",
+			destination: "https://example.com/base",
+			suffix:      "/%zz",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := appendPath(tt.destination, tt.suffix)
+			if tt.wantErr {
+				assert.True(t, services.IsInvalidPathSuffixError(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
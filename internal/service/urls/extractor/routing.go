@@ -0,0 +1,118 @@
+package extractor
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+	"yp-go-short-url-service/internal/model"
+)
+
+// matchRoutingRule возвращает первое правило условного перенаправления, которому удовлетворяет запрос перехода,
+// или nil, если ни одно правило не подходит. Условия одного правила объединяются по "и":
+// правило срабатывает, только если совпали семейство устройства, язык и IP-адрес, когда они заданы.
+func matchRoutingRule(rules model.RoutingRules, req model.RedirectRequest) *model.RoutingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	device := deviceFamily(req.UserAgent)
+	language := preferredLanguage(req.AcceptLanguage)
+	addr, addrErr := netip.ParseAddr(strings.TrimSpace(req.ClientIP))
+	addr = addr.Unmap()
+
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Device != "" && rule.Device != device {
+			continue
+		}
+		if len(rule.Languages) > 0 && !matchLanguage(rule.Languages, language) {
+			continue
+		}
+		if len(rule.CIDRs) > 0 && (addrErr != nil || !matchCIDR(rule.CIDRs, addr)) {
+			continue
+		}
+		return rule
+	}
+
+	return nil
+}
+
+// deviceFamily определяет семейство устройства клиента по заголовку User-Agent.
+// Мобильные устройства, отличные от iOS и Android, а также запросы без User-Agent не относятся ни к одному семейству.
+func deviceFamily(userAgent string) model.DeviceFamily {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return ""
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad") || strings.Contains(ua, "ipod"):
+		return model.DeviceIOS
+	case strings.Contains(ua, "android"):
+		return model.DeviceAndroid
+	case strings.Contains(ua, "mobile") || strings.Contains(ua, "tablet"):
+		return ""
+	default:
+		return model.DeviceDesktop
+	}
+}
+
+// preferredLanguage возвращает наиболее предпочтительный язык из заголовка Accept-Language в нижнем регистре.
+// Языки с нулевым весом и "*" пропускаются; при равном весе выбирается указанный раньше.
+// Возвращает пустую строку, если заголовок пуст или не содержит подходящих языков.
+func preferredLanguage(header string) string {
+	var (
+		best       string
+		bestWeight float64
+	)
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(q), 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+
+		if weight > bestWeight {
+			best, bestWeight = tag, weight
+		}
+	}
+
+	return best
+}
+
+// matchLanguage сообщает, совпадает ли язык клиента с одним из языков правила.
+// Язык правила подходит, если равен языку клиента или является его началом до "-": "en" подходит для "en-gb".
+func matchLanguage(languages []string, language string) bool {
+	if language == "" {
+		return false
+	}
+
+	for _, candidate := range languages {
+		if language == candidate || strings.HasPrefix(language, candidate+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCIDR сообщает, входит ли IP-адрес клиента хотя бы в один из диапазонов правила.
+func matchCIDR(cidrs []string, addr netip.Addr) bool {
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package extractor
+
+import (
+	"testing"
+	"yp-go-short-url-service/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_deviceFamily(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		expected  model.DeviceFamily
+	}{
+		{name: "iphone", userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148", expected: model.DeviceIOS},
+		{name: "ipad", userAgent: "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15", expected: model.DeviceIOS},
+		{name: "android phone", userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Mobile Safari/537.36", expected: model.DeviceAndroid},
+		{name: "windows", userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36", expected: model.DeviceDesktop},
+		{name: "macos", userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) AppleWebKit/605.1.15 Safari/605.1.15", expected: model.DeviceDesktop},
+		{name: "other mobile", userAgent: "Mozilla/5.0 (Mobile; KAIOS/3.0) Gecko/20100101 Firefox/84.0", expected: ""},
+		{name: "empty", userAgent: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, deviceFamily(tt.userAgent))
+		})
+	}
+}
+
+func Test_preferredLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "single language", header: "ru-RU", expected: "ru-ru"},
+		{name: "first of equal weight", header: "en-US,en;q=0.9,ru;q=0.8", expected: "en-us"},
+		{name: "highest weight wins", header: "de;q=0.5, fr;q=0.9", expected: "fr"},
+		{name: "zero weight and wildcard are skipped", header: "*, en;q=0, es;q=0.1", expected: "es"},
+		{name: "malformed weight is skipped", header: "en;q=abc, it;q=0.2", expected: "it"},
+		{name: "empty", header: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, preferredLanguage(tt.header))
+		})
+	}
+}
+
+func Test_matchRoutingRule(t *testing.T) {
+	const (
+		iPhoneUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"
+		androidUA = "Mozilla/5.0 (Linux; Android 14; Pixel 8) Mobile"
+		desktopUA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64)"
+	)
+
+	rules := model.RoutingRules{
+		{Device: model.DeviceIOS, Destination: "https://apps.apple.com/app"},
+		{Device: model.DeviceAndroid, Languages: []string{"ru"}, Destination: "https://play.google.com/ru"},
+		{Device: model.DeviceAndroid, Destination: "https://play.google.com/app"},
+		{CIDRs: []string{"10.0.0.0/8", "2001:db8::/32"}, Destination: "https://intranet.example.com"},
+		{Languages: []string{"de", "fr-ch"}, Destination: "https://example.com/eu"},
+	}
+
+	tests := []struct {
+		name     string
+		req      model.RedirectRequest
+		expected string
+	}{
+		{name: "device", req: model.RedirectRequest{UserAgent: iPhoneUA, ClientIP: "10.1.1.1"}, expected: "https://apps.apple.com/app"},
+		{name: "all conditions of rule must match", req: model.RedirectRequest{UserAgent: androidUA, AcceptLanguage: "ru-RU,ru;q=0.9"}, expected: "https://play.google.com/ru"},
+		{name: "next rule when language differs", req: model.RedirectRequest{UserAgent: androidUA, AcceptLanguage: "en"}, expected: "https://play.google.com/app"},
+		{name: "ipv4 range", req: model.RedirectRequest{UserAgent: desktopUA, ClientIP: "10.20.30.40"}, expected: "https://intranet.example.com"},
+		{name: "ipv4-mapped ipv6 address", req: model.RedirectRequest{ClientIP: "::ffff:10.0.0.1"}, expected: "https://intranet.example.com"},
+		{name: "ipv6 range", req: model.RedirectRequest{ClientIP: "2001:db8::1"}, expected: "https://intranet.example.com"},
+		{name: "language range", req: model.RedirectRequest{AcceptLanguage: "de-AT"}, expected: "https://example.com/eu"},
+		{name: "language subtag does not match parent", req: model.RedirectRequest{AcceptLanguage: "fr"}},
+		{name: "invalid ip does not match ranges", req: model.RedirectRequest{ClientIP: "unknown"}},
+		{name: "no rule matches", req: model.RedirectRequest{UserAgent: desktopUA, AcceptLanguage: "en", ClientIP: "192.0.2.1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := matchRoutingRule(rules, tt.req)
+			if tt.expected == "" {
+				assert.Nil(t, rule)
+				return
+			}
+			require.NotNil(t, rule)
+			assert.Equal(t, tt.expected, rule.Destination)
+		})
+	}
+}
@@ -9,26 +9,42 @@ import (
 	baseObserver "yp-go-short-url-service/internal/observer/base"
 	"yp-go-short-url-service/internal/repository"
 	"yp-go-short-url-service/internal/service"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 // NewLinkExtractorService создает новый сервис для извлечения URL.
-// Принимает репозитории для чтения URL и шину событий для уведомлений, возвращает реализацию интерфейса URLExtractorService.
+// Принимает репозитории для чтения URL и учета переходов по ссылкам с лимитом, буфер переходов
+// шину событий для уведомлений и кэш ссылок по короткому коду, возвращает реализацию интерфейса URLExtractorService.
+// Если clickAggregator равен nil, переходы не учитываются. Если urlCache равен nil, ссылка читается
+// из репозитория при каждом переходе; расход перехода по ссылке с лимитом сбрасывает ее запись в кэше.
 func NewLinkExtractorService(
 	urlRepository repository.URLRepositoryReader,
+	clickConsumer repository.URLClickConsumer,
 	userURLsRepository repository.UserURLsRepositoryReader,
+	clickAggregator service.ClickAggregator,
 	eventBus baseObserver.Subject[audit.Event],
+	urlCache service.Cache,
 ) service.URLExtractorService {
 	return &linkExtractorService{
 		urlRepository:      urlRepository,
+		clickConsumer:      clickConsumer,
 		userURLsRepository: userURLsRepository,
+		clickAggregator:    clickAggregator,
 		eventBus:           eventBus,
+		urlCache:           urlCache,
+		passwordAttempts:   newPasswordAttempts(maxPasswordAttempts, passwordAttemptsWindow),
 	}
 }
 
 type linkExtractorService struct {
 	urlRepository      repository.URLRepositoryReader
+	clickConsumer      repository.URLClickConsumer
 	userURLsRepository repository.UserURLsRepositoryReader
+	clickAggregator    service.ClickAggregator
 	eventBus           baseObserver.Subject[audit.Event]
+	urlCache           service.Cache
+	passwordAttempts   *passwordAttempts
 }
 
 // ExtractUserURLs извлекает все URL, принадлежащие указанному пользователю.
@@ -59,9 +75,183 @@ func (s *linkExtractorService) ExtractUserURLs(ctx context.Context, userID strin
 	return urls, nil
 }
 
-// ExtractLongURL извлекает длинный URL по короткому идентификатору.
-// Возвращает длинный URL или ошибку, если URL не найден, удален или произошла ошибка при извлечении.
-func (s *linkExtractorService) ExtractLongURL(ctx context.Context, shortURL string) (string, error) {
+// ExtractUserDeletedURLs извлекает удаленные URL указанного пользователя (корзину).
+// Возвращает список моделей URL или ошибку, если извлечение не удалось.
+func (s *linkExtractorService) ExtractUserDeletedURLs(ctx context.Context, userID string) ([]*model.URLsModel, error) {
+	logger := middleware.GetLogger(ctx)
+
+	urls, err := s.userURLsRepository.GetDeletedByUserID(ctx, userID)
+	if err != nil {
+		logger.Errorw("Failed to extract user deleted URLs from storage",
+			"error", err,
+			"user_id", userID,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+		return nil, err
+	}
+
+	logger.Infow("Successfully extracted user deleted URLs from storage",
+		"user_id", userID,
+		"urls_count", len(urls),
+		"request_id", middleware.ExtractRequestID(ctx),
+	)
+
+	return urls, nil
+}
+
+// ExtractLongURL выполняет переход по короткому идентификатору.
+// Для ссылки с лимитом каждый успешный вызов расходует один переход.
+// Возвращает адрес назначения, в который перенесены параметры запроса req согласно настройке ссылки,
+// и статус перенаправления; nil без ошибки, если ссылка не найдена. Возвращает ошибку, если ссылка удалена,
+// отключена владельцем, истек ее срок действия, исчерпан лимит переходов, ссылка защищена паролем
+// или произошла ошибка при извлечении.
+// Для ссылки со страницей предупреждения переход не выполняется: возвращается результат перехода вместе
+// с ошибкой ErrInterstitialRequired, а переход завершается вызовом ConfirmLongURL.
+func (s *linkExtractorService) ExtractLongURL(ctx context.Context, shortURL string, req model.RedirectRequest) (*model.Redirect, error) {
+	return s.extract(ctx, shortURL, req, false)
+}
+
+// ConfirmLongURL выполняет переход так же, как ExtractLongURL, но без страницы предупреждения:
+// вызывается после того, как пользователь подтвердил переход. Пароль ссылки по-прежнему требуется.
+func (s *linkExtractorService) ConfirmLongURL(ctx context.Context, shortURL string, req model.RedirectRequest) (*model.Redirect, error) {
+	return s.extract(ctx, shortURL, req, true)
+}
+
+// extract выполняет переход по короткому идентификатору.
+// confirmed - признак того, что переход уже подтвержден на странице предупреждения.
+func (s *linkExtractorService) extract(
+	ctx context.Context,
+	shortURL string,
+	req model.RedirectRequest,
+	confirmed bool,
+) (*model.Redirect, error) {
+	url, err := s.findActiveURL(ctx, shortURL)
+	if err != nil || url == nil {
+		return nil, err
+	}
+
+	redirect, err := s.redirectFor(ctx, url, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if url.IsPasswordProtected() {
+		middleware.GetLogger(ctx).Infow("Short URL is password protected",
+			"short_url", shortURL,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+		return nil, service.ErrPasswordRequired
+	}
+
+	if url.ShowInterstitial && !confirmed {
+		if url.ClickLimitReached() {
+			return nil, service.ErrClickLimitReached
+		}
+		middleware.GetLogger(ctx).Infow("Short URL requires confirmation before redirect",
+			"short_url", shortURL,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+		return redirect, service.ErrInterstitialRequired
+	}
+
+	return s.follow(ctx, url, redirect)
+}
+
+// UnlockLongURL выполняет переход по защищенной паролем ссылке после проверки пароля.
+// Для ссылки без пароля работает так же, как ConfirmLongURL: ввод пароля считается подтверждением перехода,
+// поэтому страница предупреждения не показывается. Неудачные попытки учитываются для каждой ссылки:
+// после maxPasswordAttempts неудач в течение passwordAttemptsWindow возвращается ErrTooManyPasswordAttempts,
+// неверный пароль приводит к ErrWrongPassword и событию аудита "unlock_failed".
+func (s *linkExtractorService) UnlockLongURL(
+	ctx context.Context,
+	shortURL, password string,
+	req model.RedirectRequest,
+) (*model.Redirect, error) {
+	logger := middleware.GetLogger(ctx)
+	requestID := middleware.ExtractRequestID(ctx)
+
+	url, err := s.findActiveURL(ctx, shortURL)
+	if err != nil || url == nil {
+		return nil, err
+	}
+
+	redirect, err := s.redirectFor(ctx, url, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if url.IsPasswordProtected() {
+		if !s.passwordAttempts.reserve(shortURL) {
+			logger.Warnw("Too many password attempts for short URL",
+				"short_url", shortURL,
+				"request_id", requestID,
+			)
+			return nil, service.ErrTooManyPasswordAttempts
+		}
+
+		// Попытка уже учтена как неудачная и освобождается только при верном пароле
+		if err := bcrypt.CompareHashAndPassword([]byte(*url.PasswordHash), []byte(password)); err != nil {
+			logger.Warnw("Wrong password for short URL",
+				"short_url", shortURL,
+				"request_id", requestID,
+			)
+			s.notify(ctx, audit.EventUnlockFailed, url.LongURL)
+			return nil, service.ErrWrongPassword
+		}
+
+		s.passwordAttempts.reset(shortURL)
+	}
+
+	return s.follow(ctx, url, redirect)
+}
+
+// redirectFor формирует результат перехода по ссылке до проверки пароля и расхода перехода,
+// чтобы путь после короткого кода, который нельзя перенести, не расходовал лимит переходов.
+func (s *linkExtractorService) redirectFor(
+	ctx context.Context,
+	url *model.URLsModel,
+	req model.RedirectRequest,
+) (*model.Redirect, error) {
+	redirect, err := buildRedirect(url, req)
+	if err != nil {
+		middleware.GetLogger(ctx).Infow("Path suffix can not be forwarded for short URL",
+			"error", err,
+			"short_url", url.ShortURL,
+			"path_suffix", req.PathSuffix,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+		return nil, err
+	}
+
+	return redirect, nil
+}
+
+// ResolveURL находит действующую ссылку по короткому идентификатору, не расходуя переход и не учитывая его
+// в статистике. Пароль ссылки не проверяется. Возвращает ErrURLNotFound, если ссылка не найдена, и те же ошибки,
+// что и ExtractLongURL, если ссылка удалена, отключена владельцем, истекла или исчерпан лимит переходов.
+func (s *linkExtractorService) ResolveURL(ctx context.Context, shortURL string) (*model.URLsModel, error) {
+	url, err := s.findActiveURL(ctx, shortURL)
+	if err != nil {
+		return nil, err
+	}
+	if url == nil {
+		return nil, service.ErrURLNotFound
+	}
+
+	if url.ClickLimitReached() {
+		middleware.GetLogger(ctx).Infow("Short URL click limit reached",
+			"short_url", shortURL,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+		return nil, service.ErrClickLimitReached
+	}
+
+	return url, nil
+}
+
+// findActiveURL находит ссылку по короткому идентификатору и проверяет, что она не удалена, не отключена владельцем и не истекла.
+// Возвращает nil без ошибки, если ссылка не найдена.
+func (s *linkExtractorService) findActiveURL(ctx context.Context, shortURL string) (*model.URLsModel, error) {
 	logger := middleware.GetLogger(ctx)
 	requestID := middleware.ExtractRequestID(ctx)
 
@@ -70,17 +260,17 @@ func (s *linkExtractorService) ExtractLongURL(ctx context.Context, shortURL stri
 		"request_id", requestID,
 	)
 
-	url, err := s.urlRepository.GetByShortURL(ctx, shortURL)
+	url, err := s.getByShortURL(ctx, shortURL)
 	if err != nil {
 		if repository.IsNotFoundError(err) {
-			return "", nil
+			return nil, nil
 		}
 		logger.Errorw("Failed to extract long URL from storage",
 			"error", err,
 			"short_url", shortURL,
 			"request_id", requestID,
 		)
-		return "", err
+		return nil, err
 	}
 
 	if url == nil {
@@ -88,7 +278,7 @@ func (s *linkExtractorService) ExtractLongURL(ctx context.Context, shortURL stri
 			"short_url", shortURL,
 			"request_id", requestID,
 		)
-		return "", nil
+		return nil, nil
 	}
 
 	if url.IsDeleted {
@@ -96,20 +286,103 @@ func (s *linkExtractorService) ExtractLongURL(ctx context.Context, shortURL stri
 			"short_url", shortURL,
 			"request_id", requestID,
 		)
-		return "", service.ErrURLWasDeleted
+		return nil, service.ErrURLWasDeleted
+	}
+
+	if url.IsDisabled {
+		logger.Infow("Short URL is disabled by owner",
+			"short_url", shortURL,
+			"request_id", requestID,
+		)
+		return nil, service.ErrURLDisabled
+	}
+
+	if url.ExpiredAt(time.Now()) {
+		logger.Infow("Short URL has expired",
+			"short_url", shortURL,
+			"expires_at", url.ExpiresAt,
+			"request_id", requestID,
+		)
+		return nil, service.ErrURLExpired
+	}
+
+	return url, nil
+}
+
+// getByShortURL читает ссылку по короткому идентификатору через кэш, если он задан.
+func (s *linkExtractorService) getByShortURL(ctx context.Context, shortURL string) (*model.URLsModel, error) {
+	if s.urlCache == nil {
+		return s.urlRepository.GetByShortURL(ctx, shortURL)
+	}
+	return s.urlCache.GetOrLoad(ctx, shortURL, s.urlRepository.GetByShortURL)
+}
+
+// follow завершает переход по ссылке с результатом redirect: расходует переход для ссылки с лимитом,
+// записывает переход в журнал и отправляет событие аудита.
+func (s *linkExtractorService) follow(ctx context.Context, url *model.URLsModel, redirect *model.Redirect) (*model.Redirect, error) {
+	if url.MaxClicks != nil {
+		if err := s.consumeClick(ctx, url); err != nil {
+			return nil, err
+		}
 	}
 
-	logger.Infow("Successfully extracted long URL from storage",
+	middleware.GetLogger(ctx).Infow("Successfully extracted long URL from storage",
 		"long_url", url.LongURL,
-		"short_url", shortURL,
-		"request_id", requestID,
+		"location", redirect.Location,
+		"variant", redirect.Variant,
+		"short_url", url.ShortURL,
+		"request_id", middleware.ExtractRequestID(ctx),
 	)
 
-	s.notifyFollowURL(ctx, url.LongURL)
-	return url.LongURL, nil
+	s.recordClick(ctx, url, redirect.Variant)
+	s.notify(ctx, audit.EventFollow, url.LongURL)
+	return redirect, nil
+}
+
+// consumeClick расходует один переход по ссылке с лимитом.
+// Сам счетчик уменьшается атомарно в репозитории, поэтому параллельные переходы не превышают лимит.
+func (s *linkExtractorService) consumeClick(ctx context.Context, url *model.URLsModel) error {
+	logger := middleware.GetLogger(ctx)
+	requestID := middleware.ExtractRequestID(ctx)
+
+	if url.ClickLimitReached() {
+		logger.Infow("Short URL click limit reached",
+			"short_url", url.ShortURL,
+			"request_id", requestID,
+		)
+		return service.ErrClickLimitReached
+	}
+
+	clicksLeft, err := s.clickConsumer.ConsumeClick(ctx, url.ShortURL)
+	// Остаток переходов изменился, поэтому запись ссылки в кэше больше не актуальна
+	if s.urlCache != nil {
+		s.urlCache.Invalidate(ctx, url.ShortURL)
+	}
+	if err != nil {
+		if repository.IsClickLimitReachedError(err) {
+			logger.Infow("Short URL click limit reached",
+				"short_url", url.ShortURL,
+				"request_id", requestID,
+			)
+			return service.ErrClickLimitReached
+		}
+		logger.Errorw("Failed to consume short URL click",
+			"error", err,
+			"short_url", url.ShortURL,
+			"request_id", requestID,
+		)
+		return err
+	}
+
+	logger.Debugw("Short URL click consumed",
+		"short_url", url.ShortURL,
+		"clicks_left", clicksLeft,
+		"request_id", requestID,
+	)
+	return nil
 }
 
-func (s *linkExtractorService) notifyFollowURL(ctx context.Context, longURL string) {
+func (s *linkExtractorService) notify(ctx context.Context, action audit.EventActionType, longURL string) {
 	// Если eventBus не инициализирован, пропускаем отправку события
 	if s.eventBus == nil {
 		return
@@ -127,13 +400,13 @@ func (s *linkExtractorService) notifyFollowURL(ctx context.Context, longURL stri
 
 	event := audit.Event{
 		Timestamp: int(time.Now().Unix()),
-		Action:    audit.EventFollow,
+		Action:    action,
 		UserID:    userID,
 		URL:       longURL,
 	}
 	go func() {
 		if err := s.eventBus.NotifyAll(ctx, event); err != nil {
-			logger.Errorw("Failed to send follow URL notification event err=", err)
+			logger.Errorw("Failed to send URL notification event", "action", action, "error", err)
 		}
 	}()
 }
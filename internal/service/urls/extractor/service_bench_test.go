@@ -25,7 +25,7 @@ func BenchmarkExtractLongURL(b *testing.B) {
 	mockRepo := mock.NewMockURLRepositoryReader(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepositoryReader(ctrl)
 
-	service := NewLinkExtractorService(mockRepo, mockUserURLsRepo, nil)
+	service := NewLinkExtractorService(mockRepo, nil, mockUserURLsRepo, nil, nil, nil)
 	ctx := setupBenchmarkContext()
 
 	shortURL := "abc12345"
@@ -42,7 +42,7 @@ func BenchmarkExtractLongURL(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = service.ExtractLongURL(ctx, shortURL)
+		_, _ = service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
 	}
 }
 
@@ -54,7 +54,7 @@ func BenchmarkExtractUserURLs(b *testing.B) {
 	mockRepo := mock.NewMockURLRepositoryReader(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepositoryReader(ctrl)
 
-	service := NewLinkExtractorService(mockRepo, mockUserURLsRepo, nil)
+	service := NewLinkExtractorService(mockRepo, nil, mockUserURLsRepo, nil, nil, nil)
 	ctx := setupBenchmarkContext()
 
 	userID := "user123"
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 	"yp-go-short-url-service/internal/observer/audit"
@@ -11,11 +12,16 @@ import (
 
 	"yp-go-short-url-service/internal/middleware"
 	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
 	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+	mockService "yp-go-short-url-service/internal/service/mock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestNewLinkExtractorService(t *testing.T) {
@@ -25,11 +31,13 @@ func TestNewLinkExtractorService(t *testing.T) {
 
 	// Создаем моки репозиториев
 	mockURLRepo := mock.NewMockURLRepositoryReader(ctrl)
+	mockClickConsumer := mock.NewMockURLClickConsumer(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepositoryReader(ctrl)
+	mockClickAggregator := mockService.NewMockClickAggregator(ctrl)
 	auditEventBus := mockObserver.NewMockSubject[audit.Event](ctrl)
 
 	// Создаем сервис через тестовый конструктор
-	service := NewLinkExtractorService(mockURLRepo, mockUserURLsRepo, auditEventBus)
+	service := NewLinkExtractorService(mockURLRepo, mockClickConsumer, mockUserURLsRepo, mockClickAggregator, auditEventBus, nil)
 
 	// Проверяем, что сервис создан корректно
 	assert.NotNil(t, service)
@@ -72,11 +80,12 @@ func Test_linkExtractorService_ExtractLongURL(t *testing.T) {
 			Return(testURL, nil)
 
 		// Вызываем метод
-		result, err := service.ExtractLongURL(ctx, shortURL)
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
 
 		// Проверяем результат
 		assert.NoError(t, err)
-		assert.Equal(t, longURL, result)
+		require.NotNil(t, result)
+		assert.Equal(t, longURL, result.Location)
 	})
 
 	t.Run("short URL not found", func(t *testing.T) {
@@ -86,11 +95,11 @@ func Test_linkExtractorService_ExtractLongURL(t *testing.T) {
 			Return(nil, nil)
 
 		// Вызываем метод
-		result, err := service.ExtractLongURL(ctx, shortURL)
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
 
 		// Проверяем результат
 		assert.NoError(t, err)
-		assert.Equal(t, "", result)
+		assert.Nil(t, result)
 	})
 
 	t.Run("database error", func(t *testing.T) {
@@ -102,12 +111,65 @@ func Test_linkExtractorService_ExtractLongURL(t *testing.T) {
 			Return(nil, expectedErr)
 
 		// Вызываем метод
-		result, err := service.ExtractLongURL(ctx, shortURL)
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
 
 		// Проверяем результат
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
-		assert.Equal(t, "", result)
+		assert.Nil(t, result)
+	})
+
+	t.Run("disabled URL", func(t *testing.T) {
+		disabledURL := &model.URLsModel{
+			ID:         1,
+			ShortURL:   shortURL,
+			LongURL:    longURL,
+			IsDisabled: true,
+		}
+		mockRepo.EXPECT().
+			GetByShortURL(ctx, shortURL).
+			Return(disabledURL, nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+
+		assert.ErrorIs(t, err, services.ErrURLDisabled)
+		assert.Nil(t, result)
+	})
+
+	t.Run("expired URL", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		expiredURL := &model.URLsModel{
+			ID:        1,
+			ShortURL:  shortURL,
+			LongURL:   longURL,
+			ExpiresAt: &expiresAt,
+		}
+
+		// Ожидаем вызов GetByShortURL с истекшей ссылкой
+		mockRepo.EXPECT().
+			GetByShortURL(ctx, shortURL).
+			Return(expiredURL, nil)
+
+		// Вызываем метод
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+
+		// Проверяем результат
+		assert.ErrorIs(t, err, services.ErrURLExpired)
+		assert.Nil(t, result)
+	})
+
+	t.Run("URL marked as expired", func(t *testing.T) {
+		// Ожидаем вызов GetByShortURL со ссылкой, помеченной фоновой задачей
+		mockRepo.EXPECT().
+			GetByShortURL(ctx, shortURL).
+			Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL, IsExpired: true}, nil)
+
+		// Вызываем метод
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+
+		// Проверяем результат
+		assert.ErrorIs(t, err, services.ErrURLExpired)
+		assert.Nil(t, result)
 	})
 
 	t.Run("empty short URL", func(t *testing.T) {
@@ -119,11 +181,11 @@ func Test_linkExtractorService_ExtractLongURL(t *testing.T) {
 			Return(nil, nil)
 
 		// Вызываем метод
-		result, err := service.ExtractLongURL(ctx, emptyShortURL)
+		result, err := service.ExtractLongURL(ctx, emptyShortURL, model.RedirectRequest{})
 
 		// Проверяем результат
 		assert.NoError(t, err)
-		assert.Equal(t, "", result)
+		assert.Nil(t, result)
 	})
 
 	t.Run("context without logger", func(t *testing.T) {
@@ -136,11 +198,12 @@ func Test_linkExtractorService_ExtractLongURL(t *testing.T) {
 			Return(testURL, nil)
 
 		// Вызываем метод
-		result, err := service.ExtractLongURL(ctxWithoutLogger, shortURL)
+		result, err := service.ExtractLongURL(ctxWithoutLogger, shortURL, model.RedirectRequest{})
 
 		// Проверяем результат - должен работать даже без логгера
 		assert.NoError(t, err)
-		assert.Equal(t, longURL, result)
+		require.NotNil(t, result)
+		assert.Equal(t, longURL, result.Location)
 	})
 }
 
@@ -171,11 +234,11 @@ func Test_linkExtractorService_ExtractLongURL_EdgeCases(t *testing.T) {
 			Return(nil, nil)
 
 		// Вызываем метод
-		result, err := service.ExtractLongURL(ctx, veryLongShortURL)
+		result, err := service.ExtractLongURL(ctx, veryLongShortURL, model.RedirectRequest{})
 
 		// Проверяем результат
 		assert.NoError(t, err)
-		assert.Equal(t, "", result)
+		assert.Nil(t, result)
 	})
 
 	t.Run("special characters in short URL", func(t *testing.T) {
@@ -187,11 +250,11 @@ func Test_linkExtractorService_ExtractLongURL_EdgeCases(t *testing.T) {
 			Return(nil, nil)
 
 		// Вызываем метод
-		result, err := service.ExtractLongURL(ctx, specialShortURL)
+		result, err := service.ExtractLongURL(ctx, specialShortURL, model.RedirectRequest{})
 
 		// Проверяем результат
 		assert.NoError(t, err)
-		assert.Equal(t, "", result)
+		assert.Nil(t, result)
 	})
 
 	t.Run("URL with empty long URL field", func(t *testing.T) {
@@ -210,11 +273,12 @@ func Test_linkExtractorService_ExtractLongURL_EdgeCases(t *testing.T) {
 			Return(testURLWithEmptyLong, nil)
 
 		// Вызываем метод
-		result, err := service.ExtractLongURL(ctx, shortURL)
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
 
 		// Проверяем результат
 		assert.NoError(t, err)
-		assert.Equal(t, "", result)
+		require.NotNil(t, result)
+		assert.Empty(t, result.Location)
 	})
 }
 
@@ -256,6 +320,446 @@ func Benchmark_linkExtractorService_ExtractLongURL(b *testing.B) {
 	// Запускаем benchmark
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = service.ExtractLongURL(ctx, shortURL)
+		_, _ = service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+	}
+}
+
+func Test_linkExtractorService_ExtractLongURL_ClickLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryReader(ctrl)
+	mockClickConsumer := mock.NewMockURLClickConsumer(ctrl)
+
+	service := &linkExtractorService{
+		urlRepository:      mockRepo,
+		clickConsumer:      mockClickConsumer,
+		userURLsRepository: mock.NewMockUserURLsRepositoryReader(ctrl),
+	}
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+
+	shortURL := "invite1"
+	longURL := "https://example.com/invite"
+	limitedURL := func(clicksLeft int64) *model.URLsModel {
+		maxClicks := int64(1)
+		return &model.URLsModel{ShortURL: shortURL, LongURL: longURL, MaxClicks: &maxClicks, ClicksLeft: &clicksLeft}
+	}
+
+	t.Run("click is consumed", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(limitedURL(1), nil)
+		mockClickConsumer.EXPECT().ConsumeClick(ctx, shortURL).Return(int64(0), nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, longURL, result.Location)
+	})
+
+	t.Run("limit reached concurrently", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(limitedURL(1), nil)
+		mockClickConsumer.EXPECT().ConsumeClick(ctx, shortURL).Return(int64(0), repository.ErrClickLimitReached)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.ErrorIs(t, err, services.ErrClickLimitReached)
+		assert.Nil(t, result)
+	})
+
+	t.Run("exhausted link is rejected without consuming", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(limitedURL(0), nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.ErrorIs(t, err, services.ErrClickLimitReached)
+		assert.Nil(t, result)
+	})
+
+	t.Run("storage error while consuming", func(t *testing.T) {
+		dbErr := errors.New("database connection failed")
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(limitedURL(1), nil)
+		mockClickConsumer.EXPECT().ConsumeClick(ctx, shortURL).Return(int64(0), dbErr)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.ErrorIs(t, err, dbErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("link without limit does not consume clicks", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL}, nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, longURL, result.Location)
+	})
+
+	t.Run("rejected path suffix does not consume clicks", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(limitedURL(1), nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{PathSuffix: "/docs"})
+		assert.True(t, services.IsNotFoundError(err))
+		assert.Nil(t, result)
+
+		prefixURL := limitedURL(1)
+		prefixURL.IsPrefix = true
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(prefixURL, nil)
+
+		result, err = service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{PathSuffix: "/../admin"})
+		assert.True(t, services.IsInvalidPathSuffixError(err))
+		assert.Nil(t, result)
+	})
+}
+
+func Test_linkExtractorService_ExtractLongURL_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryReader(ctrl)
+	mockClickConsumer := mock.NewMockURLClickConsumer(ctrl)
+	mockCache := mockService.NewMockCache(ctrl)
+
+	service := &linkExtractorService{
+		urlRepository:      mockRepo,
+		clickConsumer:      mockClickConsumer,
+		userURLsRepository: mock.NewMockUserURLsRepositoryReader(ctrl),
+		urlCache:           mockCache,
 	}
+
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	shortURL := "cached1"
+	longURL := "https://example.com/cached"
+
+	t.Run("link is read through the cache", func(t *testing.T) {
+		mockCache.EXPECT().
+			GetOrLoad(ctx, shortURL, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, shortURL string, load services.CacheLoader) (*model.URLsModel, error) {
+				return load(ctx, shortURL)
+			})
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL}, nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, longURL, result.Location)
+	})
+
+	t.Run("cache hit does not read the repository", func(t *testing.T) {
+		mockCache.EXPECT().GetOrLoad(ctx, shortURL, gomock.Any()).Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL}, nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, longURL, result.Location)
+	})
+
+	t.Run("cached not found", func(t *testing.T) {
+		mockCache.EXPECT().GetOrLoad(ctx, "missing", gomock.Any()).Return(nil, nil)
+
+		result, err := service.ExtractLongURL(ctx, "missing", model.RedirectRequest{})
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("consumed click invalidates the link", func(t *testing.T) {
+		maxClicks, clicksLeft := int64(2), int64(2)
+		mockCache.EXPECT().
+			GetOrLoad(ctx, shortURL, gomock.Any()).
+			Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL, MaxClicks: &maxClicks, ClicksLeft: &clicksLeft}, nil)
+		mockClickConsumer.EXPECT().ConsumeClick(ctx, shortURL).Return(int64(1), nil)
+		mockCache.EXPECT().Invalidate(ctx, shortURL)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, longURL, result.Location)
+	})
+}
+
+func Test_linkExtractorService_ExtractLongURL_Interstitial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryReader(ctrl)
+	mockClickConsumer := mock.NewMockURLClickConsumer(ctrl)
+	mockAggregator := mockService.NewMockClickAggregator(ctrl)
+
+	service := &linkExtractorService{
+		urlRepository:      mockRepo,
+		clickConsumer:      mockClickConsumer,
+		userURLsRepository: mock.NewMockUserURLsRepositoryReader(ctrl),
+		clickAggregator:    mockAggregator,
+		passwordAttempts:   newPasswordAttempts(maxPasswordAttempts, passwordAttemptsWindow),
+	}
+
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	shortURL := "warn1"
+	longURL := "https://example.com/external"
+	maxClicks, clicksLeft, noClicksLeft := int64(2), int64(1), int64(0)
+
+	t.Run("redirect requires confirmation and is not counted", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).
+			Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL, ShowInterstitial: true, MaxClicks: &maxClicks, ClicksLeft: &clicksLeft}, nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.ErrorIs(t, err, services.ErrInterstitialRequired)
+		require.NotNil(t, result)
+		assert.Equal(t, longURL, result.Location)
+	})
+
+	t.Run("confirmed redirect is counted", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).
+			Return(&model.URLsModel{ID: 1, ShortURL: shortURL, LongURL: longURL, ShowInterstitial: true, MaxClicks: &maxClicks, ClicksLeft: &clicksLeft}, nil)
+		mockClickConsumer.EXPECT().ConsumeClick(ctx, shortURL).Return(int64(0), nil)
+		mockAggregator.EXPECT().Record(gomock.Any())
+
+		result, err := service.ConfirmLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, longURL, result.Location)
+	})
+
+	t.Run("exhausted link is not confirmed", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).
+			Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL, ShowInterstitial: true, MaxClicks: &maxClicks, ClicksLeft: &noClicksLeft}, nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.ErrorIs(t, err, services.ErrClickLimitReached)
+		assert.Nil(t, result)
+	})
+
+	t.Run("confirmation does not bypass password", func(t *testing.T) {
+		hash := "hash"
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).
+			Return(&model.URLsModel{ShortURL: shortURL, LongURL: longURL, ShowInterstitial: true, PasswordHash: &hash}, nil)
+
+		result, err := service.ConfirmLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.ErrorIs(t, err, services.ErrPasswordRequired)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_linkExtractorService_ResolveURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryReader(ctrl)
+	// Буфер и счетчик переходов не должны вызываться: проверка ссылки не считается переходом
+	service := &linkExtractorService{
+		urlRepository:      mockRepo,
+		clickConsumer:      mock.NewMockURLClickConsumer(ctrl),
+		userURLsRepository: mock.NewMockUserURLsRepositoryReader(ctrl),
+		clickAggregator:    mockService.NewMockClickAggregator(ctrl),
+	}
+
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	hash := "hash"
+	maxClicks, clicksLeft, noClicksLeft := int64(3), int64(2), int64(0)
+
+	tests := []struct {
+		name        string
+		url         *model.URLsModel
+		expectedErr error
+	}{
+		{
+			name: "active URL with click limit and password",
+			url:  &model.URLsModel{ShortURL: "abc123", MaxClicks: &maxClicks, ClicksLeft: &clicksLeft, PasswordHash: &hash},
+		},
+		{
+			name:        "not found",
+			expectedErr: services.ErrURLNotFound,
+		},
+		{
+			name:        "deleted",
+			url:         &model.URLsModel{ShortURL: "abc123", IsDeleted: true},
+			expectedErr: services.ErrURLWasDeleted,
+		},
+		{
+			name:        "click limit reached",
+			url:         &model.URLsModel{ShortURL: "abc123", MaxClicks: &maxClicks, ClicksLeft: &noClicksLeft},
+			expectedErr: services.ErrClickLimitReached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.EXPECT().GetByShortURL(ctx, "abc123").Return(tt.url, nil)
+
+			url, err := service.ResolveURL(ctx, "abc123")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, url)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.url, url)
+		})
+	}
+}
+
+func Test_linkExtractorService_UnlockLongURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepositoryReader(ctrl)
+	auditEventBus := mockObserver.NewMockSubject[audit.Event](ctrl)
+
+	service := &linkExtractorService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepositoryReader(ctrl),
+		eventBus:           auditEventBus,
+		passwordAttempts:   newPasswordAttempts(2, time.Minute),
+	}
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+
+	shortURL := "secret1"
+	longURL := "https://example.com/private"
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	passwordHash := string(hash)
+	protectedURL := &model.URLsModel{ShortURL: shortURL, LongURL: longURL, PasswordHash: &passwordHash}
+
+	events := make(chan audit.Event, 10)
+	auditEventBus.EXPECT().
+		NotifyAll(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, event audit.Event) error {
+			events <- event
+			return nil
+		}).
+		AnyTimes()
+
+	t.Run("extract requires password", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(protectedURL, nil)
+
+		result, err := service.ExtractLongURL(ctx, shortURL, model.RedirectRequest{})
+		assert.ErrorIs(t, err, services.ErrPasswordRequired)
+		assert.Nil(t, result)
+	})
+
+	t.Run("correct password", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(protectedURL, nil)
+
+		result, err := service.UnlockLongURL(ctx, shortURL, "s3cret", model.RedirectRequest{})
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, longURL, result.Location)
+		assert.Equal(t, audit.EventActionType(audit.EventFollow), (<-events).Action)
+	})
+
+	t.Run("wrong password is audited and attempts are limited", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, shortURL).Return(protectedURL, nil).Times(3)
+
+		for i := 0; i < 2; i++ {
+			result, err := service.UnlockLongURL(ctx, shortURL, "guess", model.RedirectRequest{})
+			assert.ErrorIs(t, err, services.ErrWrongPassword)
+			assert.Nil(t, result)
+
+			event := <-events
+			assert.Equal(t, audit.EventActionType(audit.EventUnlockFailed), event.Action)
+			assert.Equal(t, longURL, event.URL)
+		}
+
+		// Даже верный пароль не принимается, пока лимит попыток исчерпан
+		result, err := service.UnlockLongURL(ctx, shortURL, "s3cret", model.RedirectRequest{})
+		assert.ErrorIs(t, err, services.ErrTooManyPasswordAttempts)
+		assert.Nil(t, result)
+	})
+
+	t.Run("link without password ignores it", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, "open1").Return(&model.URLsModel{ShortURL: "open1", LongURL: longURL}, nil)
+
+		result, err := service.UnlockLongURL(ctx, "open1", "anything", model.RedirectRequest{})
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, longURL, result.Location)
+		<-events
+	})
+
+	t.Run("deleted link", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, "gone1").Return(&model.URLsModel{ShortURL: "gone1", IsDeleted: true, PasswordHash: &passwordHash}, nil)
+
+		result, err := service.UnlockLongURL(ctx, "gone1", "s3cret", model.RedirectRequest{})
+		assert.ErrorIs(t, err, services.ErrURLWasDeleted)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_linkExtractorService_UnlockLongURL_ConcurrentAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Стоимость хеширования по умолчанию делает проверку пароля достаточно долгой, чтобы запросы пересекались
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	passwordHash := string(hash)
+
+	mockRepo := mock.NewMockURLRepositoryReader(ctrl)
+	mockRepo.EXPECT().
+		GetByShortURL(gomock.Any(), "secret1").
+		Return(&model.URLsModel{ShortURL: "secret1", LongURL: "https://example.com/private", PasswordHash: &passwordHash}, nil).
+		AnyTimes()
+
+	service := &linkExtractorService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepositoryReader(ctrl),
+		passwordAttempts:   newPasswordAttempts(maxPasswordAttempts, passwordAttemptsWindow),
+	}
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	// Одновременные попытки с неверным паролем не обходят лимит, пока идет проверка пароля
+	const requests = 30
+	errs := make(chan error, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.UnlockLongURL(ctx, "secret1", "guess", model.RedirectRequest{})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var wrong, blocked int
+	for err := range errs {
+		switch {
+		case errors.Is(err, services.ErrWrongPassword):
+			wrong++
+		case errors.Is(err, services.ErrTooManyPasswordAttempts):
+			blocked++
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, maxPasswordAttempts, wrong)
+	assert.Equal(t, requests-maxPasswordAttempts, blocked)
+}
+
+func Test_linkExtractorService_ExtractUserDeletedURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserURLsRepo := mock.NewMockUserURLsRepositoryReader(ctrl)
+	service := &linkExtractorService{userURLsRepository: mockUserURLsRepo}
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+
+	t.Run("returns user trash", func(t *testing.T) {
+		deleted := []*model.URLsModel{{ShortURL: "abc123", LongURL: "https://example.com", IsDeleted: true}}
+		mockUserURLsRepo.EXPECT().GetDeletedByUserID(ctx, "user1").Return(deleted, nil)
+
+		result, err := service.ExtractUserDeletedURLs(ctx, "user1")
+		assert.NoError(t, err)
+		assert.Equal(t, deleted, result)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		expectedErr := errors.New("database connection failed")
+		mockUserURLsRepo.EXPECT().GetDeletedByUserID(ctx, "user1").Return(nil, expectedErr)
+
+		result, err := service.ExtractUserDeletedURLs(ctx, "user1")
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, result)
+	})
 }
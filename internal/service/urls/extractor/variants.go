@@ -0,0 +1,41 @@
+package extractor
+
+import (
+	"math/rand/v2"
+	"yp-go-short-url-service/internal/model"
+)
+
+// pickVariant выбирает вариант адреса назначения для перехода. Если за посетителем уже закреплен вариант sticky
+// и он есть у ссылки, выбирается он, чтобы посетитель всегда попадал на один и тот же вариант.
+// Иначе вариант выбирается случайно с вероятностью, пропорциональной весу: roll(n) должна возвращать
+// равномерно распределенное число из [0, n). Возвращает nil, если у ссылки нет вариантов.
+func pickVariant(variants model.SplitVariants, sticky string, roll func(n int) int) *model.SplitVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	if variant := variants.Find(sticky); variant != nil {
+		return variant
+	}
+
+	total := 0
+	for _, variant := range variants {
+		total += max(variant.Weight, 0)
+	}
+	if total == 0 {
+		return &variants[0]
+	}
+
+	point := roll(total)
+	for i := range variants {
+		point -= max(variants[i].Weight, 0)
+		if point < 0 {
+			return &variants[i]
+		}
+	}
+	return &variants[len(variants)-1]
+}
+
+// randomVariant выбирает вариант адреса назначения с помощью генератора псевдослучайных чисел.
+func randomVariant(variants model.SplitVariants, sticky string) *model.SplitVariant {
+	return pickVariant(variants, sticky, rand.IntN)
+}
@@ -0,0 +1,48 @@
+package extractor
+
+import (
+	"testing"
+	"yp-go-short-url-service/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pickVariant(t *testing.T) {
+	variants := model.SplitVariants{
+		{Name: "a", Destination: "https://example.com/a", Weight: 70},
+		{Name: "b", Destination: "https://example.com/b", Weight: 30},
+	}
+	fixed := func(value int) func(int) int {
+		return func(n int) int {
+			require.Equal(t, 100, n)
+			return value
+		}
+	}
+
+	t.Run("no variants", func(t *testing.T) {
+		assert.Nil(t, pickVariant(nil, "a", fixed(0)))
+	})
+
+	t.Run("weights split the range", func(t *testing.T) {
+		assert.Equal(t, "a", pickVariant(variants, "", fixed(0)).Name)
+		assert.Equal(t, "a", pickVariant(variants, "", fixed(69)).Name)
+		assert.Equal(t, "b", pickVariant(variants, "", fixed(70)).Name)
+		assert.Equal(t, "b", pickVariant(variants, "", fixed(99)).Name)
+	})
+
+	t.Run("sticky variant wins", func(t *testing.T) {
+		assert.Equal(t, "b", pickVariant(variants, "b", fixed(0)).Name)
+		// Вариант, которого у ссылки больше нет, выбирается заново
+		assert.Equal(t, "a", pickVariant(variants, "removed", fixed(10)).Name)
+	})
+
+	t.Run("random selection follows weights", func(t *testing.T) {
+		counts := make(map[string]int)
+		for range 10000 {
+			counts[randomVariant(variants, "").Name]++
+		}
+		assert.InDelta(t, 7000, counts["a"], 500)
+		assert.InDelta(t, 3000, counts["b"], 500)
+	})
+}
@@ -0,0 +1,86 @@
+package linkcheck
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+const (
+	// dialTimeout - ограничение времени установки соединения клиента проверки.
+	dialTimeout = 10 * time.Second
+	// tlsHandshakeTimeout - ограничение времени TLS рукопожатия клиента проверки.
+	tlsHandshakeTimeout = 10 * time.Second
+)
+
+// errNonPublicAddress возвращается при попытке подключиться к непубличному IP-адресу.
+var errNonPublicAddress = errors.New("destination address is not public")
+
+// nonPublicPrefixes - диапазоны специального назначения, не покрытые методами netip.Addr:
+// "эта сеть", разделяемое адресное пространство провайдеров (CGNAT), сети для тестирования
+// производительности и зарезервированный диапазон IPv4.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// NewPublicHTTPClient возвращает HTTP клиент для проверки адресов назначения, который подключается только
+// к публичным IP-адресам. Адрес проверяется после разрешения имени непосредственно перед подключением,
+// поэтому запрет действует для каждого перенаправления и не обходится DNS-записями, указывающими
+// на внутренние адреса. Переменные окружения прокси не учитываются.
+func NewPublicHTTPClient() *http.Client {
+	return newRestrictedHTTPClient(func(addr netip.AddrPort) bool {
+		return isPublicAddr(addr.Addr())
+	})
+}
+
+// newRestrictedHTTPClient возвращает HTTP клиент, подключающийся только к адресам, для которых allow возвращает true.
+func newRestrictedHTTPClient(allow func(netip.AddrPort) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addr, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", errNonPublicAddress, address)
+			}
+			if !allow(netip.AddrPortFrom(addr.Addr().Unmap(), addr.Port())) {
+				return fmt.Errorf("%w: %s", errNonPublicAddress, addr.Addr())
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: tlsHandshakeTimeout,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// isPublicAddr сообщает, является ли IP-адрес публичным: не петлевым, не частным (RFC 1918, ULA),
+// не локальным для канала (в том числе адреса метаданных облака 169.254.169.254), не групповым,
+// не неопределенным и не входящим в nonPublicPrefixes.
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/mock"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_isPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "8.8.8.8", want: true},
+		{addr: "93.184.216.34", want: true},
+		{addr: "2001:4860:4860::8888", want: true},
+		{addr: "127.0.0.1", want: false},
+		{addr: "127.10.0.1", want: false},
+		{addr: "::1", want: false},
+		{addr: "10.0.0.1", want: false},
+		{addr: "172.16.0.1", want: false},
+		{addr: "192.168.1.1", want: false},
+		{addr: "169.254.169.254", want: false},
+		{addr: "fe80::1", want: false},
+		{addr: "fd00:ec2::254", want: false},
+		{addr: "0.0.0.0", want: false},
+		{addr: "::", want: false},
+		{addr: "0.1.2.3", want: false},
+		{addr: "100.64.0.1", want: false},
+		{addr: "224.0.0.1", want: false},
+		{addr: "255.255.255.255", want: false},
+		{addr: "::ffff:127.0.0.1", want: false},
+		{addr: "::ffff:169.254.169.254", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.want, isPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestLinkChecker_check_NonPublicDestination(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to internal address: %s %s", r.Method, r.URL.Path)
+	}))
+	defer internal.Close()
+
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL+"/latest/meta-data", http.StatusFound)
+	}))
+	defer redirector.Close()
+
+	repo := mock.NewMockLinkHealthRepository(gomock.NewController(t))
+	ctx := context.Background()
+	limiter := newHostLimiter(0)
+
+	t.Run("loopback destination is rejected", func(t *testing.T) {
+		checker := newTestChecker(t, repo, NewPublicHTTPClient(), Options{Concurrency: 1, Timeout: time.Second})
+
+		health := checker.check(ctx, limiter, internal.URL+"/admin")
+		assert.Zero(t, health.StatusCode)
+		assert.Contains(t, health.Error, errNonPublicAddress.Error())
+		assert.False(t, health.IsHealthy())
+	})
+
+	t.Run("nil client rejects loopback destination", func(t *testing.T) {
+		checker := newTestChecker(t, repo, nil, Options{Concurrency: 1, Timeout: time.Second})
+
+		health := checker.check(ctx, limiter, internal.URL+"/admin")
+		assert.Contains(t, health.Error, errNonPublicAddress.Error())
+	})
+
+	t.Run("redirect to loopback destination is rejected", func(t *testing.T) {
+		// Разрешен только адрес перенаправляющего сервера, имитирующего внешний сайт
+		allowed := netip.MustParseAddrPort(redirector.Listener.Addr().String())
+		client := newRestrictedHTTPClient(func(addr netip.AddrPort) bool { return addr == allowed })
+		checker := newTestChecker(t, repo, client, Options{Concurrency: 1, Timeout: time.Second})
+
+		health := checker.check(ctx, limiter, redirector.URL+"/go")
+		assert.Equal(t, model.RedirectChain{internal.URL + "/latest/meta-data"}, health.RedirectChain)
+		assert.Contains(t, health.Error, errNonPublicAddress.Error())
+		assert.False(t, health.IsHealthy())
+	})
+}
@@ -0,0 +1,297 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/service"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// checkBatchSize - количество ссылок, выбираемых из базы данных и сохраняемых за один шаг прохода.
+	checkBatchSize = 100
+	// maxRedirects - максимальная длина цепочки перенаправлений; более длинная цепочка считается ошибкой.
+	maxRedirects = 10
+	// maxBodyBytes - сколько байт тела ответа на GET читается перед закрытием соединения.
+	maxBodyBytes = 64 << 10
+	// maxBrokenLinks - максимальное количество ссылок в ответе GetBrokenLinks.
+	maxBrokenLinks = 1000
+	// userAgent - значение заголовка User-Agent запросов проверки.
+	userAgent = "yp-go-short-url-service link checker"
+)
+
+// Options содержит параметры фоновой проверки доступности адресов назначения.
+type Options struct {
+	// Interval - период запуска проверки; неположительное значение отключает фоновый запуск.
+	Interval time.Duration
+	// Concurrency - максимальное число одновременных запросов; не меньше 1.
+	Concurrency int
+	// HostInterval - минимальный интервал между запросами к одному хосту.
+	HostInterval time.Duration
+	// Timeout - ограничение времени одного запроса; неположительное значение снимает ограничение.
+	Timeout time.Duration
+}
+
+// NewLinkChecker создает фоновый процесс, который с периодом opts.Interval проверяет доступность адресов
+// назначения всех действующих ссылок. Адрес запрашивается методом HEAD, а если сервер его не поддерживает
+// (405 или 501) - методом GET; перенаправления проходятся вручную, не более maxRedirects, и сохраняются цепочкой.
+// Проверка успешна, если получен ответ со статусом меньше 400.
+// client используется для всех запросов, что позволяет подменять транспорт в тестах; его CheckRedirect
+// не учитывается. Если client равен nil, используется NewPublicHTTPClient, который не подключается
+// к внутренним адресам: адреса назначения задаются пользователями, а результат проверки им виден.
+// Возвращает реализацию интерфейса LinkChecker.
+func NewLinkChecker(
+	linkHealthRepository repository.LinkHealthRepository,
+	client *http.Client,
+	opts Options,
+	logger *zap.SugaredLogger,
+) service.LinkChecker {
+	if client == nil {
+		client = NewPublicHTTPClient()
+	}
+	// Копия клиента не следует перенаправлениям, чтобы каждое из них попало в цепочку
+	noRedirectClient := *client
+	noRedirectClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	checker := &linkChecker{
+		linkHealthRepository: linkHealthRepository,
+		client:               &noRedirectClient,
+		opts:                 opts,
+		logger:               logger,
+		ctx:                  ctx,
+		cancel:               cancel,
+		wg:                   &sync.WaitGroup{},
+	}
+
+	if opts.Interval > 0 {
+		checker.wg.Add(1)
+		go checker.run()
+	}
+
+	return checker
+}
+
+type linkChecker struct {
+	linkHealthRepository repository.LinkHealthRepository
+	client               *http.Client
+	opts                 Options
+	logger               *zap.SugaredLogger
+
+	// ctx отменяется при остановке и прерывает текущий проход
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+// run периодически вызывает CheckAll до остановки.
+func (c *linkChecker) run() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := c.CheckAll(c.ctx); err != nil && c.ctx.Err() == nil {
+				c.logger.Errorw("Failed to check link destinations", "error", err)
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// CheckAll проверяет адреса назначения всех действующих ссылок страницами по checkBatchSize и сохраняет
+// результаты после каждой страницы. При отмене контекста ссылки, проверка которых не завершилась,
+// не сохраняются. Возвращает число сохраненных результатов.
+func (c *linkChecker) CheckAll(ctx context.Context) (int, error) {
+	limiter := newHostLimiter(c.opts.HostInterval)
+
+	var (
+		afterID uint
+		checked int
+	)
+	for {
+		targets, err := c.linkHealthRepository.GetCheckTargets(ctx, afterID, checkBatchSize)
+		if err != nil {
+			return checked, err
+		}
+		if len(targets) == 0 {
+			break
+		}
+
+		results := c.checkBatch(ctx, limiter, targets)
+		if err := ctx.Err(); err != nil {
+			return checked, err
+		}
+		if err := c.linkHealthRepository.SaveResults(ctx, results); err != nil {
+			return checked, err
+		}
+
+		checked += len(results)
+		afterID = targets[len(targets)-1].ID
+	}
+
+	c.logger.Infow("Checked link destinations", "count", checked)
+	return checked, nil
+}
+
+// GetBrokenLinks возвращает не более maxBrokenLinks ссылок, недоступных не менее minFailures проверок подряд.
+// Значение minFailures меньше 1 считается равным 1.
+func (c *linkChecker) GetBrokenLinks(ctx context.Context, minFailures int) ([]*model.BrokenLink, error) {
+	return c.linkHealthRepository.GetBrokenLinks(ctx, max(minFailures, 1), maxBrokenLinks)
+}
+
+// Stop прерывает текущий проход, останавливает фоновый процесс и дожидается его завершения.
+// Повторные вызовы безопасны.
+func (c *linkChecker) Stop() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+// checkBatch проверяет ссылки не более чем в opts.Concurrency потоков.
+// Результаты возвращаются в порядке ссылок.
+func (c *linkChecker) checkBatch(ctx context.Context, limiter *hostLimiter, targets []*model.LinkCheckTarget) []*model.LinkCheckResult {
+	results := make([]*model.LinkCheckResult, len(targets))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(c.opts.Concurrency, len(targets)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = &model.LinkCheckResult{
+					URLID:      targets[i].ID,
+					LinkHealth: c.check(ctx, limiter, targets[i].LongURL),
+				}
+			}
+		}()
+	}
+
+	for i := range targets {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// check запрашивает адрес назначения, проходя перенаправления, и возвращает результат проверки.
+func (c *linkChecker) check(ctx context.Context, limiter *hostLimiter, rawURL string) model.LinkHealth {
+	health := model.LinkHealth{CheckedAt: time.Now()}
+
+	current := rawURL
+	for {
+		statusCode, location, err := c.probe(ctx, limiter, current)
+		if err != nil {
+			health.Error = err.Error()
+			return health
+		}
+		health.StatusCode = statusCode
+
+		if !isRedirect(statusCode) || location == "" {
+			return health
+		}
+		if len(health.RedirectChain) >= maxRedirects {
+			health.Error = fmt.Sprintf("stopped after %d redirects", maxRedirects)
+			return health
+		}
+
+		next, err := resolveLocation(current, location)
+		if err != nil {
+			health.Error = err.Error()
+			return health
+		}
+		health.RedirectChain = append(health.RedirectChain, next)
+		current = next
+	}
+}
+
+// probe выполняет запрос HEAD, а если сервер его не поддерживает - GET, и возвращает статус ответа
+// и заголовок Location.
+func (c *linkChecker) probe(ctx context.Context, limiter *hostLimiter, rawURL string) (int, string, error) {
+	statusCode, location, err := c.request(ctx, limiter, http.MethodHead, rawURL)
+	if err != nil || (statusCode != http.StatusMethodNotAllowed && statusCode != http.StatusNotImplemented) {
+		return statusCode, location, err
+	}
+	return c.request(ctx, limiter, http.MethodGet, rawURL)
+}
+
+func (c *linkChecker) request(ctx context.Context, limiter *hostLimiter, method, rawURL string) (int, string, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid url: %w", err)
+	}
+	if err := limiter.wait(ctx, target.Host); err != nil {
+		return 0, "", err
+	}
+
+	if c.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	if method == http.MethodGet {
+		// Небольшая часть тела дочитывается, чтобы соединение можно было переиспользовать
+		if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyBytes)); err != nil && !errors.Is(err, context.Canceled) {
+			c.logger.Debugw("Failed to drain response body", "url", rawURL, "error", err)
+		}
+	}
+
+	return resp.StatusCode, resp.Header.Get("Location"), nil
+}
+
+// isRedirect сообщает, является ли статус перенаправлением с заголовком Location.
+func isRedirect(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
+	}
+}
+
+// resolveLocation разрешает значение заголовка Location относительно адреса запроса.
+func resolveLocation(base, location string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid url: %w", err)
+	}
+	next, err := baseURL.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("invalid redirect location %q", location)
+	}
+	return next.String(), nil
+}
@@ -0,0 +1,272 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func newTestChecker(t *testing.T, repo *mock.MockLinkHealthRepository, client *http.Client, opts Options) *linkChecker {
+	t.Helper()
+
+	checker := NewLinkChecker(repo, client, opts, zap.NewNop().Sugar())
+	t.Cleanup(checker.Stop)
+	return checker.(*linkChecker)
+}
+
+func TestLinkChecker_check(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		assert.Equal(t, userAgent, r.Header.Get("User-Agent"))
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/moved":
+			http.Redirect(w, r, "/step", http.StatusMovedPermanently)
+		case "/step":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		case "/get-only":
+			if r.Method != http.MethodGet {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			_, _ = w.Write([]byte("body"))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	checker := newTestChecker(t, mock.NewMockLinkHealthRepository(gomock.NewController(t)), server.Client(),
+		Options{Concurrency: 1, Timeout: 50 * time.Millisecond})
+	ctx := context.Background()
+	limiter := newHostLimiter(0)
+
+	t.Run("healthy link", func(t *testing.T) {
+		before := time.Now()
+		health := checker.check(ctx, limiter, server.URL+"/ok")
+		assert.Equal(t, http.StatusOK, health.StatusCode)
+		assert.Empty(t, health.Error)
+		assert.Empty(t, health.RedirectChain)
+		assert.False(t, health.CheckedAt.Before(before))
+		assert.True(t, health.IsHealthy())
+	})
+
+	t.Run("redirect chain is recorded", func(t *testing.T) {
+		health := checker.check(ctx, limiter, server.URL+"/moved")
+		assert.Equal(t, http.StatusOK, health.StatusCode)
+		assert.Equal(t, model.RedirectChain{server.URL + "/step", server.URL + "/ok"}, health.RedirectChain)
+		assert.True(t, health.IsHealthy())
+	})
+
+	t.Run("redirect loop is reported", func(t *testing.T) {
+		health := checker.check(ctx, limiter, server.URL+"/loop")
+		assert.Len(t, health.RedirectChain, maxRedirects)
+		assert.Contains(t, health.Error, "redirects")
+		assert.False(t, health.IsHealthy())
+	})
+
+	t.Run("falls back to GET when HEAD is not allowed", func(t *testing.T) {
+		mu.Lock()
+		methods = nil
+		mu.Unlock()
+
+		health := checker.check(ctx, limiter, server.URL+"/get-only")
+		assert.Equal(t, http.StatusOK, health.StatusCode)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{"HEAD /get-only", "GET /get-only"}, methods)
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		health := checker.check(ctx, limiter, server.URL+"/missing")
+		assert.Equal(t, http.StatusNotFound, health.StatusCode)
+		assert.False(t, health.IsHealthy())
+	})
+
+	t.Run("request timeout", func(t *testing.T) {
+		health := checker.check(ctx, limiter, server.URL+"/slow")
+		assert.Zero(t, health.StatusCode)
+		assert.NotEmpty(t, health.Error)
+		assert.False(t, health.IsHealthy())
+	})
+
+	t.Run("unreachable host", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		health := checker.check(ctx, limiter, closed.URL)
+		assert.NotEmpty(t, health.Error)
+		assert.False(t, health.IsHealthy())
+	})
+}
+
+func TestHostLimiter_wait(t *testing.T) {
+	ctx := context.Background()
+	limiter := newHostLimiter(50 * time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.wait(ctx, "example.com"))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// Другие хосты ограничиваются независимо
+	start = time.Now()
+	require.NoError(t, limiter.wait(ctx, "example.org"))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, limiter.wait(cancelled, "example.com"), context.Canceled)
+}
+
+func TestLinkChecker_CheckAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockLinkHealthRepository(ctrl)
+	checker := newTestChecker(t, mockRepo, server.Client(), Options{Concurrency: 4})
+	ctx := context.Background()
+
+	t.Run("pages through targets and saves results", func(t *testing.T) {
+		firstPage := make([]*model.LinkCheckTarget, checkBatchSize)
+		for i := range firstPage {
+			firstPage[i] = &model.LinkCheckTarget{ID: uint(i + 1), LongURL: server.URL + "/ok"}
+		}
+		secondPage := []*model.LinkCheckTarget{{ID: checkBatchSize + 5, LongURL: server.URL + "/broken"}}
+
+		gomock.InOrder(
+			mockRepo.EXPECT().GetCheckTargets(ctx, uint(0), checkBatchSize).Return(firstPage, nil),
+			mockRepo.EXPECT().
+				SaveResults(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, results []*model.LinkCheckResult) error {
+					require.Len(t, results, checkBatchSize)
+					for i, result := range results {
+						assert.Equal(t, uint(i+1), result.URLID)
+						assert.Equal(t, http.StatusNoContent, result.StatusCode)
+					}
+					return nil
+				}),
+			mockRepo.EXPECT().GetCheckTargets(ctx, uint(checkBatchSize), checkBatchSize).Return(secondPage, nil),
+			mockRepo.EXPECT().
+				SaveResults(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, results []*model.LinkCheckResult) error {
+					require.Len(t, results, 1)
+					assert.Equal(t, uint(checkBatchSize+5), results[0].URLID)
+					assert.Equal(t, http.StatusInternalServerError, results[0].StatusCode)
+					assert.False(t, results[0].IsHealthy())
+					return nil
+				}),
+			mockRepo.EXPECT().GetCheckTargets(ctx, uint(checkBatchSize+5), checkBatchSize).Return(nil, nil),
+		)
+
+		checked, err := checker.CheckAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, checkBatchSize+1, checked)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		dbErr := errors.New("database connection failed")
+		mockRepo.EXPECT().GetCheckTargets(ctx, uint(0), checkBatchSize).Return(nil, dbErr)
+
+		checked, err := checker.CheckAll(ctx)
+		assert.ErrorIs(t, err, dbErr)
+		assert.Zero(t, checked)
+	})
+
+	t.Run("cancelled check does not save results", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		mockRepo.EXPECT().
+			GetCheckTargets(cancelled, uint(0), checkBatchSize).
+			Return([]*model.LinkCheckTarget{{ID: 1, LongURL: server.URL}}, nil)
+
+		_, err := checker.CheckAll(cancelled)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestLinkChecker_GetBrokenLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockLinkHealthRepository(ctrl)
+	checker := newTestChecker(t, mockRepo, nil, Options{})
+	ctx := context.Background()
+
+	broken := []*model.BrokenLink{{ShortURL: "abc", LongURL: "https://example.com"}}
+	mockRepo.EXPECT().GetBrokenLinks(ctx, 3, maxBrokenLinks).Return(broken, nil)
+	mockRepo.EXPECT().GetBrokenLinks(ctx, 1, maxBrokenLinks).Return(nil, nil)
+
+	links, err := checker.GetBrokenLinks(ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, broken, links)
+
+	links, err = checker.GetBrokenLinks(ctx, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+}
+
+func TestLinkChecker_Background(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockLinkHealthRepository(ctrl)
+
+	called := make(chan struct{}, 1)
+	mockRepo.EXPECT().
+		GetCheckTargets(gomock.Any(), uint(0), checkBatchSize).
+		DoAndReturn(func(ctx context.Context, afterID uint, limit int) ([]*model.LinkCheckTarget, error) {
+			select {
+			case called <- struct{}{}:
+			default:
+			}
+			return nil, nil
+		}).
+		MinTimes(1)
+
+	checker := NewLinkChecker(mockRepo, nil, Options{Interval: 10 * time.Millisecond}, zap.NewNop().Sugar())
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("link checker did not run")
+	}
+
+	checker.Stop()
+	// Повторная остановка безопасна
+	checker.Stop()
+}
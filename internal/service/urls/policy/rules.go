@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultSchemes - схемы, разрешенные, если в файле политики список схем не задан.
+var defaultSchemes = []string{"http", "https"}
+
+// Rules описывает содержимое JSON-файла политики адресов назначения.
+// Запрещающие правила Deny проверяются раньше разрешающих; если в Allow задано хотя бы одно правило,
+// разрешены только адреса, подходящие под одно из них.
+type Rules struct {
+	// Schemes - разрешенные схемы URL; пустой список означает http и https.
+	Schemes []string `json:"schemes"`
+	// Allow - разрешающие правила.
+	Allow RuleList `json:"allow"`
+	// Deny - запрещающие правила.
+	Deny RuleList `json:"deny"`
+}
+
+// RuleList - набор правил одного вида (разрешающих или запрещающих).
+type RuleList struct {
+	// Domains - домены, совпадающие с хостом URL целиком, например "example.com".
+	Domains []string `json:"domains"`
+	// Suffixes - суффиксы доменов: "example.com" подходит для самого домена и всех его поддоменов.
+	Suffixes []string `json:"suffixes"`
+	// Patterns - регулярные выражения в синтаксисе RE2, которые проверяются на всем URL.
+	Patterns []string `json:"patterns"`
+}
+
+// ruleSet - скомпилированные правила политики, заменяемые целиком при перечитывании файла.
+type ruleSet struct {
+	schemes map[string]struct{}
+	allow   matcher
+	deny    matcher
+}
+
+// matcher - скомпилированный набор правил одного вида.
+type matcher struct {
+	domains  map[string]struct{}
+	suffixes []string
+	patterns []*regexp.Regexp
+}
+
+// parseRules разбирает JSON-файл политики; неизвестные поля считаются ошибкой, чтобы опечатка
+// в имени списка не отключала правила незаметно.
+func parseRules(data []byte) (*Rules, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var rules Rules
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// compile проверяет правила и приводит домены и схемы к нижнему регистру.
+// Возвращает ошибку, если значение пустое или регулярное выражение не компилируется.
+func (r *Rules) compile() (*ruleSet, error) {
+	schemes := r.Schemes
+	if len(schemes) == 0 {
+		schemes = defaultSchemes
+	}
+
+	set := &ruleSet{schemes: make(map[string]struct{}, len(schemes))}
+	for _, scheme := range schemes {
+		scheme = strings.ToLower(strings.TrimSpace(scheme))
+		if scheme == "" {
+			return nil, fmt.Errorf("empty scheme")
+		}
+		set.schemes[scheme] = struct{}{}
+	}
+
+	var err error
+	if set.allow, err = r.Allow.compile(); err != nil {
+		return nil, fmt.Errorf("allow: %w", err)
+	}
+	if set.deny, err = r.Deny.compile(); err != nil {
+		return nil, fmt.Errorf("deny: %w", err)
+	}
+
+	return set, nil
+}
+
+func (l RuleList) compile() (matcher, error) {
+	m := matcher{domains: make(map[string]struct{}, len(l.Domains))}
+
+	for _, domain := range l.Domains {
+		domain = normalizeHost(domain)
+		if domain == "" {
+			return m, fmt.Errorf("empty domain")
+		}
+		m.domains[domain] = struct{}{}
+	}
+
+	for _, suffix := range l.Suffixes {
+		suffix = normalizeHost(suffix)
+		if suffix == "" {
+			return m, fmt.Errorf("empty suffix")
+		}
+		m.suffixes = append(m.suffixes, suffix)
+	}
+
+	for _, pattern := range l.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return m, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		m.patterns = append(m.patterns, re)
+	}
+
+	return m, nil
+}
+
+// isEmpty сообщает, что в наборе нет ни одного правила.
+func (m matcher) isEmpty() bool {
+	return len(m.domains) == 0 && len(m.suffixes) == 0 && len(m.patterns) == 0
+}
+
+// matchDomain сообщает, совпадает ли хост с одним из доменов набора.
+func (m matcher) matchDomain(host string) bool {
+	_, ok := m.domains[host]
+	return ok
+}
+
+// matchSuffix возвращает первый суффикс набора, которым оканчивается хост, или пустую строку.
+// Суффикс совпадает только по границе домена: "example.com" не подходит для "badexample.com".
+func (m matcher) matchSuffix(host string) string {
+	for _, suffix := range m.suffixes {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return suffix
+		}
+	}
+	return ""
+}
+
+// matchPattern возвращает первое регулярное выражение набора, подходящее под URL, или nil.
+func (m matcher) matchPattern(rawURL string) *regexp.Regexp {
+	for _, re := range m.patterns {
+		if re.MatchString(rawURL) {
+			return re
+		}
+	}
+	return nil
+}
+
+// normalizeHost приводит домен к нижнему регистру и отбрасывает точки по краям,
+// в том числе завершающую точку полного доменного имени.
+func normalizeHost(host string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(host)), ".")
+}
@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"yp-go-short-url-service/internal/service"
+
+	"go.uber.org/zap"
+)
+
+// NewDestinationPolicy создает политику адресов назначения создаваемых и изменяемых ссылок.
+// Правила загружаются из JSON-файла path (формат описан в Rules); если путь пуст, разрешены любые
+// http(s) адреса. Адреса, указывающие на хост baseURL, отклоняются всегда, чтобы короткая ссылка
+// не вела на другую короткую ссылку сервиса. Если путь задан, правила перечитываются при получении SIGHUP;
+// при ошибке перечитывания продолжают действовать прежние правила.
+// Возвращает ошибку, если файл не удалось прочитать или правила заданы некорректно.
+func NewDestinationPolicy(path, baseURL string, logger *zap.SugaredLogger) (service.DestinationPolicy, error) {
+	var signals chan os.Signal
+	if path != "" {
+		signals = make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGHUP)
+	}
+
+	policy, err := newDestinationPolicy(path, baseURL, logger, signals)
+	if err != nil {
+		if signals != nil {
+			signal.Stop(signals)
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// newDestinationPolicy загружает правила и запускает перечитывание по сигналам из канала signals,
+// если он передан.
+func newDestinationPolicy(path, baseURL string, logger *zap.SugaredLogger, signals chan os.Signal) (*destinationPolicy, error) {
+	policy := &destinationPolicy{
+		path:     path,
+		selfHost: selfHost(baseURL),
+		logger:   logger,
+		signals:  signals,
+		stopChan: make(chan struct{}),
+		wg:       &sync.WaitGroup{},
+	}
+
+	if err := policy.Reload(); err != nil {
+		return nil, err
+	}
+
+	if signals != nil {
+		policy.wg.Add(1)
+		go policy.run()
+	}
+
+	return policy, nil
+}
+
+type destinationPolicy struct {
+	path     string
+	selfHost string
+	rules    atomic.Pointer[ruleSet]
+	logger   *zap.SugaredLogger
+	signals  chan os.Signal
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       *sync.WaitGroup
+}
+
+// run перечитывает правила при каждом сигнале до получения сигнала остановки.
+func (p *destinationPolicy) run() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.signals:
+			if err := p.Reload(); err != nil {
+				p.logger.Errorw("Failed to reload destination policy, keeping previous rules",
+					"path", p.path,
+					"error", err,
+				)
+			}
+		case <-p.stopChan:
+			return
+		}
+	}
+}
+
+// Reload перечитывает правила из файла политики и атомарно заменяет ими действующие.
+// При ошибке чтения или разбора файла действующие правила не меняются.
+func (p *destinationPolicy) Reload() error {
+	rules := &Rules{}
+	if p.path != "" {
+		data, err := os.ReadFile(p.path)
+		if err != nil {
+			return fmt.Errorf("could not read destination policy file %s: %w", p.path, err)
+		}
+		if rules, err = parseRules(data); err != nil {
+			return fmt.Errorf("could not parse destination policy file %s: %w", p.path, err)
+		}
+	}
+
+	set, err := rules.compile()
+	if err != nil {
+		return fmt.Errorf("invalid destination policy file %s: %w", p.path, err)
+	}
+	p.rules.Store(set)
+
+	p.logger.Infow("Destination policy loaded",
+		"path", p.path,
+		"schemes", len(set.schemes),
+		"allow_rules", len(set.allow.domains)+len(set.allow.suffixes)+len(set.allow.patterns),
+		"deny_rules", len(set.deny.domains)+len(set.deny.suffixes)+len(set.deny.patterns),
+	)
+	return nil
+}
+
+// Check проверяет адрес назначения по действующим правилам в следующем порядке: разбор URL, схема,
+// ссылка на хост самого сервиса, запрещающие правила (домены, суффиксы, шаблоны), разрешающие правила.
+// Шаблоны проверяются на URL в том виде, в котором он передан, без начальных и конечных пробелов.
+// Возвращает PolicyViolationError с кодом причины первого нарушенного правила или nil, если URL разрешен.
+func (p *destinationPolicy) Check(rawURL string) error {
+	rawURL = strings.TrimSpace(rawURL)
+	set := p.rules.Load()
+
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return violation(service.PolicyReasonInvalidURL, rawURL, "url cannot be parsed")
+	}
+
+	scheme := strings.ToLower(target.Scheme)
+	if _, ok := set.schemes[scheme]; !ok {
+		return violation(service.PolicyReasonSchemeNotAllowed, rawURL, fmt.Sprintf("scheme %q", scheme))
+	}
+
+	host := normalizeHost(target.Hostname())
+	if host == "" {
+		return violation(service.PolicyReasonInvalidURL, rawURL, "url has no host")
+	}
+
+	if p.selfHost != "" && host == p.selfHost {
+		return violation(service.PolicyReasonSelfReference, rawURL, fmt.Sprintf("host %q", host))
+	}
+
+	if set.deny.matchDomain(host) {
+		return violation(service.PolicyReasonDomainDenied, rawURL, fmt.Sprintf("host %q", host))
+	}
+	if suffix := set.deny.matchSuffix(host); suffix != "" {
+		return violation(service.PolicyReasonSuffixDenied, rawURL, fmt.Sprintf("host %q matches %q", host, suffix))
+	}
+	if re := set.deny.matchPattern(rawURL); re != nil {
+		return violation(service.PolicyReasonPatternDenied, rawURL, fmt.Sprintf("url matches %q", re))
+	}
+
+	if !set.allow.isEmpty() &&
+		!set.allow.matchDomain(host) && set.allow.matchSuffix(host) == "" && set.allow.matchPattern(rawURL) == nil {
+		return violation(service.PolicyReasonNotAllowed, rawURL, fmt.Sprintf("host %q", host))
+	}
+
+	return nil
+}
+
+// Stop прекращает перечитывание правил по сигналу. Повторные вызовы безопасны.
+func (p *destinationPolicy) Stop() {
+	p.stopOnce.Do(func() {
+		if p.signals != nil {
+			signal.Stop(p.signals)
+		}
+		close(p.stopChan)
+	})
+	p.wg.Wait()
+}
+
+func violation(reason service.PolicyReason, rawURL, detail string) error {
+	return &service.PolicyViolationError{Reason: reason, URL: rawURL, Detail: detail}
+}
+
+// selfHost возвращает хост базового URL сервиса или пустую строку, если базовый URL не разбирается.
+func selfHost(baseURL string) string {
+	base, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return ""
+	}
+	return normalizeHost(base.Hostname())
+}
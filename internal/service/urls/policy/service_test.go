@@ -0,0 +1,161 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testBaseURL = "http://short.example:8080/"
+
+func writePolicyFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestDestinationPolicy_Check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	writePolicyFile(t, path, `{
+		"schemes": ["https", "HTTP"],
+		"allow": {"suffixes": ["example.com", "example.org"], "patterns": ["^https://partner\\.test/"]},
+		"deny": {
+			"domains": ["blocked.example.com"],
+			"suffixes": [".ads.example.com"],
+			"patterns": ["(?i)/phishing/"]
+		}
+	}`)
+
+	policy, err := NewDestinationPolicy(path, testBaseURL, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer policy.Stop()
+
+	tests := []struct {
+		name   string
+		url    string
+		reason service.PolicyReason
+	}{
+		{name: "allowed suffix", url: "https://www.example.com/page"},
+		{name: "allowed domain equal to suffix", url: "http://EXAMPLE.org."},
+		{name: "allowed by pattern", url: "https://partner.test/offer"},
+		{name: "unparsable url", url: "https://exa mple.com/%zz", reason: service.PolicyReasonInvalidURL},
+		{name: "missing host", url: "https:///path", reason: service.PolicyReasonInvalidURL},
+		{name: "scheme not allowed", url: "ftp://www.example.com/file", reason: service.PolicyReasonSchemeNotAllowed},
+		{name: "javascript scheme", url: "javascript:alert(1)", reason: service.PolicyReasonSchemeNotAllowed},
+		{name: "own host", url: "https://short.example/abc123", reason: service.PolicyReasonSelfReference},
+		{name: "denied domain", url: "https://blocked.example.com/", reason: service.PolicyReasonDomainDenied},
+		{name: "denied suffix", url: "https://cdn.ads.example.com/banner", reason: service.PolicyReasonSuffixDenied},
+		{name: "denied pattern", url: "https://www.example.com/PHISHING/login", reason: service.PolicyReasonPatternDenied},
+		{name: "suffix matches only on label boundary", url: "https://badexample.com/", reason: service.PolicyReasonNotAllowed},
+		{name: "not in allowlist", url: "https://other.net/", reason: service.PolicyReasonNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.url)
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, service.IsPolicyViolationError(err))
+			assert.Equal(t, tt.reason, service.PolicyViolationReason(err))
+		})
+	}
+}
+
+func TestDestinationPolicy_WithoutFile(t *testing.T) {
+	policy, err := NewDestinationPolicy("", testBaseURL, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer policy.Stop()
+
+	assert.NoError(t, policy.Check("https://any.example.net/path"))
+	assert.NoError(t, policy.Check("http://localhost:3000"))
+	assert.Equal(t, service.PolicyReasonSchemeNotAllowed, service.PolicyViolationReason(policy.Check("file:///etc/passwd")))
+	assert.Equal(t, service.PolicyReasonSelfReference, service.PolicyViolationReason(policy.Check("http://short.example:8080/abc")))
+	assert.NoError(t, policy.Reload())
+}
+
+func TestNewDestinationPolicy_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "malformed json", content: `{"deny":`},
+		{name: "unknown field", content: `{"denny": {"domains": ["example.com"]}}`},
+		{name: "invalid pattern", content: `{"deny": {"patterns": ["("]}}`},
+		{name: "empty domain", content: `{"allow": {"domains": [" "]}}`},
+		{name: "empty scheme", content: `{"schemes": [""]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "policy.json")
+			writePolicyFile(t, path, tt.content)
+
+			policy, err := NewDestinationPolicy(path, testBaseURL, zap.NewNop().Sugar())
+			assert.Error(t, err)
+			assert.Nil(t, policy)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		policy, err := NewDestinationPolicy(filepath.Join(dir, "missing.json"), testBaseURL, zap.NewNop().Sugar())
+		assert.Error(t, err)
+		assert.Nil(t, policy)
+	})
+}
+
+func TestDestinationPolicy_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	writePolicyFile(t, path, `{"deny": {"domains": ["first.example"]}}`)
+
+	policy, err := NewDestinationPolicy(path, testBaseURL, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer policy.Stop()
+
+	assert.Error(t, policy.Check("https://first.example/"))
+	assert.NoError(t, policy.Check("https://second.example/"))
+
+	writePolicyFile(t, path, `{"deny": {"domains": ["second.example"]}}`)
+	require.NoError(t, policy.Reload())
+	assert.NoError(t, policy.Check("https://first.example/"))
+	assert.Error(t, policy.Check("https://second.example/"))
+
+	t.Run("invalid file keeps previous rules", func(t *testing.T) {
+		writePolicyFile(t, path, `{"deny": {"patterns": ["["]}}`)
+		assert.Error(t, policy.Reload())
+		assert.NoError(t, policy.Check("https://first.example/"))
+		assert.Error(t, policy.Check("https://second.example/"))
+	})
+}
+
+func TestDestinationPolicy_ReloadOnSignal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	writePolicyFile(t, path, `{}`)
+
+	signals := make(chan os.Signal, 1)
+	policy, err := newDestinationPolicy(path, testBaseURL, zap.NewNop().Sugar(), signals)
+	require.NoError(t, err)
+	defer policy.Stop()
+
+	assert.NoError(t, policy.Check("https://spam.example/"))
+
+	writePolicyFile(t, path, `{"deny": {"suffixes": ["spam.example"]}}`)
+	signals <- syscall.SIGHUP
+
+	assert.Eventually(t, func() bool {
+		return service.PolicyViolationReason(policy.Check("https://spam.example/")) == service.PolicyReasonSuffixDenied
+	}, time.Second, 10*time.Millisecond)
+
+	policy.Stop()
+	// Повторная остановка безопасна
+	policy.Stop()
+}
@@ -0,0 +1,69 @@
+package policy
+
+import (
+	"context"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/service"
+)
+
+// NewPolicyShortenerService оборачивает сервис сокращения URL проверкой адресов назначения политикой policy.
+// Запрещенный адрес приводит к PolicyViolationError до обращения к обернутому сервису;
+// разрешенные запросы передаются ему без изменений.
+func NewPolicyShortenerService(next service.URLShortenerService, policy service.DestinationPolicy) service.URLShortenerService {
+	return &policyShortenerService{next: next, policy: policy}
+}
+
+type policyShortenerService struct {
+	next   service.URLShortenerService
+	policy service.DestinationPolicy
+}
+
+// ShortURL проверяет longURL политикой и создает короткую ссылку обернутым сервисом.
+func (s *policyShortenerService) ShortURL(ctx context.Context, longURL string) (string, error) {
+	if err := s.check(ctx, longURL); err != nil {
+		return "", err
+	}
+	return s.next.ShortURL(ctx, longURL)
+}
+
+// ShortURLWithOptions проверяет политикой longURL, а также адреса назначения правил условного
+// перенаправления и вариантов из opts, и создает короткую ссылку обернутым сервисом.
+func (s *policyShortenerService) ShortURLWithOptions(ctx context.Context, longURL string, opts service.ShortenOptions) (string, error) {
+	if err := s.check(ctx, longURL); err != nil {
+		return "", err
+	}
+	for _, rule := range opts.RoutingRules {
+		if err := s.check(ctx, rule.Destination); err != nil {
+			return "", err
+		}
+	}
+	for _, variant := range opts.Variants {
+		if err := s.check(ctx, variant.Destination); err != nil {
+			return "", err
+		}
+	}
+	return s.next.ShortURLWithOptions(ctx, longURL, opts)
+}
+
+// ShortURLsByBatch проверяет политикой "original_url" каждого элемента пакета и создает короткие ссылки
+// обернутым сервисом. Если хотя бы один адрес запрещен, пакет отклоняется целиком.
+func (s *policyShortenerService) ShortURLsByBatch(ctx context.Context, longURLs []map[string]string) ([]map[string]string, error) {
+	for _, item := range longURLs {
+		if err := s.check(ctx, item["original_url"]); err != nil {
+			return nil, err
+		}
+	}
+	return s.next.ShortURLsByBatch(ctx, longURLs)
+}
+
+func (s *policyShortenerService) check(ctx context.Context, rawURL string) error {
+	err := s.policy.Check(rawURL)
+	if err != nil {
+		middleware.GetLogger(ctx).Warnw("URL rejected by destination policy",
+			"reason", service.PolicyViolationReason(err),
+			"long_url", rawURL,
+			"request_id", middleware.ExtractRequestID(ctx),
+		)
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+	"yp-go-short-url-service/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func setupPolicyShortener(t *testing.T) (service.URLShortenerService, *mock.MockURLShortenerService, *mock.MockDestinationPolicy) {
+	ctrl := gomock.NewController(t)
+	next := mock.NewMockURLShortenerService(ctrl)
+	policy := mock.NewMockDestinationPolicy(ctrl)
+
+	return NewPolicyShortenerService(next, policy), next, policy
+}
+
+func TestPolicyShortenerService_ShortURL(t *testing.T) {
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	t.Run("allowed url is shortened", func(t *testing.T) {
+		shortener, next, policy := setupPolicyShortener(t)
+		policy.EXPECT().Check("https://example.com").Return(nil)
+		next.EXPECT().ShortURL(ctx, "https://example.com").Return("abc123", nil)
+
+		shortURL, err := shortener.ShortURL(ctx, "https://example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc123", shortURL)
+	})
+
+	t.Run("rejected url never reaches the service", func(t *testing.T) {
+		shortener, next, policy := setupPolicyShortener(t)
+		rejection := &service.PolicyViolationError{Reason: service.PolicyReasonDomainDenied, URL: "https://blocked.example"}
+		policy.EXPECT().Check("https://blocked.example").Return(rejection)
+		next.EXPECT().ShortURL(gomock.Any(), gomock.Any()).Times(0)
+
+		shortURL, err := shortener.ShortURL(ctx, "https://blocked.example")
+		assert.ErrorIs(t, err, rejection)
+		assert.Empty(t, shortURL)
+	})
+}
+
+func TestPolicyShortenerService_ShortURLWithOptions(t *testing.T) {
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	opts := service.ShortenOptions{
+		RoutingRules: model.RoutingRules{{Device: model.DeviceIOS, Destination: "https://apps.example.com/ios"}},
+		Variants: model.SplitVariants{
+			{Name: "a", Destination: "https://example.com/a", Weight: 1},
+			{Name: "b", Destination: "https://short.example/b", Weight: 1},
+		},
+	}
+
+	t.Run("all destinations are checked", func(t *testing.T) {
+		shortener, next, policy := setupPolicyShortener(t)
+		gomock.InOrder(
+			policy.EXPECT().Check("https://example.com").Return(nil),
+			policy.EXPECT().Check("https://apps.example.com/ios").Return(nil),
+			policy.EXPECT().Check("https://example.com/a").Return(nil),
+			policy.EXPECT().Check("https://short.example/b").Return(nil),
+		)
+		next.EXPECT().ShortURLWithOptions(ctx, "https://example.com", opts).Return("split1", nil)
+
+		shortURL, err := shortener.ShortURLWithOptions(ctx, "https://example.com", opts)
+		assert.NoError(t, err)
+		assert.Equal(t, "split1", shortURL)
+	})
+
+	t.Run("rejected variant destination", func(t *testing.T) {
+		shortener, next, policy := setupPolicyShortener(t)
+		policy.EXPECT().Check(gomock.Any()).Return(nil).Times(3)
+		policy.EXPECT().Check("https://short.example/b").
+			Return(&service.PolicyViolationError{Reason: service.PolicyReasonSelfReference})
+		next.EXPECT().ShortURLWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := shortener.ShortURLWithOptions(ctx, "https://example.com", opts)
+		assert.Equal(t, service.PolicyReasonSelfReference, service.PolicyViolationReason(err))
+	})
+}
+
+func TestPolicyShortenerService_ShortURLsByBatch(t *testing.T) {
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	batch := []map[string]string{
+		{"correlation_id": "1", "original_url": "https://example.com/1"},
+		{"correlation_id": "2", "original_url": "ftp://example.com/2"},
+	}
+
+	t.Run("whole batch is rejected", func(t *testing.T) {
+		shortener, next, policy := setupPolicyShortener(t)
+		policy.EXPECT().Check("https://example.com/1").Return(nil)
+		policy.EXPECT().Check("ftp://example.com/2").
+			Return(&service.PolicyViolationError{Reason: service.PolicyReasonSchemeNotAllowed})
+		next.EXPECT().ShortURLsByBatch(gomock.Any(), gomock.Any()).Times(0)
+
+		result, err := shortener.ShortURLsByBatch(ctx, batch)
+		assert.True(t, service.IsPolicyViolationError(err))
+		assert.Nil(t, result)
+	})
+
+	t.Run("allowed batch is shortened", func(t *testing.T) {
+		shortener, next, policy := setupPolicyShortener(t)
+		policy.EXPECT().Check(gomock.Any()).Return(nil).Times(2)
+		next.EXPECT().ShortURLsByBatch(ctx, batch).Return(batch, nil)
+
+		result, err := shortener.ShortURLsByBatch(ctx, batch)
+		require.NoError(t, err)
+		assert.Equal(t, batch, result)
+	})
+}
@@ -0,0 +1,88 @@
+package shortener
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/service"
+)
+
+const (
+	// minAliasLength - минимальная длина пользовательского короткого кода
+	minAliasLength = 3
+	// maxAliasLength - максимальная длина пользовательского короткого кода
+	maxAliasLength = 32
+)
+
+// reservedAliases содержит зарезервированные слова, которые нельзя использовать как короткий код,
+// так как они совпадают с маршрутами сервиса или могут ввести пользователей в заблуждение.
+var reservedAliases = map[string]struct{}{
+	"api":     {},
+	"swagger": {},
+	"ping":    {},
+	"debug":   {},
+	"docs":    {},
+	"static":  {},
+	"assets":  {},
+	"admin":   {},
+	"health":  {},
+	"login":   {},
+	"logout":  {},
+}
+
+// validateAlias проверяет пользовательский короткий код: длину, допустимые символы и зарезервированные слова.
+// Возвращает ошибку, оборачивающую service.ErrInvalidAlias, с описанием причины.
+func validateAlias(alias string) error {
+	if len(alias) < minAliasLength || len(alias) > maxAliasLength {
+		return fmt.Errorf("%w: length must be between %d and %d characters",
+			service.ErrInvalidAlias, minAliasLength, maxAliasLength)
+	}
+
+	for _, char := range alias {
+		if !isAliasChar(char) {
+			return fmt.Errorf("%w: only latin letters, digits, '-' and '_' are allowed", service.ErrInvalidAlias)
+		}
+	}
+
+	if _, ok := reservedAliases[strings.ToLower(alias)]; ok {
+		return fmt.Errorf("%w: %q is a reserved word", service.ErrInvalidAlias, alias)
+	}
+
+	return nil
+}
+
+func isAliasChar(char rune) bool {
+	return strings.ContainsRune(base62Chars, char) || char == '-' || char == '_'
+}
+
+// ensureAliasAvailable проверяет, что пользовательский короткий код еще не занят другой ссылкой.
+// Возвращает service.ErrAliasAlreadyExists, если код занят.
+func (s *urlShortenerService) ensureAliasAvailable(ctx context.Context, alias string) error {
+	logger := middleware.GetLogger(ctx)
+	requestID := middleware.ExtractRequestID(ctx)
+
+	existing, err := s.urlRepository.GetByShortURL(ctx, alias)
+	if err != nil {
+		if repository.IsNotFoundError(err) {
+			return nil
+		}
+		logger.Errorw("Database error while checking alias availability",
+			"error", err,
+			"alias", alias,
+			"request_id", requestID,
+		)
+		return err
+	}
+
+	if existing != nil {
+		logger.Infow("Alias is already taken",
+			"alias", alias,
+			"request_id", requestID,
+		)
+		return service.ErrAliasAlreadyExists
+	}
+
+	return nil
+}
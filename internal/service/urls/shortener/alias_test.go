@@ -0,0 +1,223 @@
+package shortener
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func Test_validateAlias(t *testing.T) {
+	tests := []struct {
+		name    string
+		alias   string
+		wantErr bool
+	}{
+		{name: "valid alias", alias: "q4-report", wantErr: false},
+		{name: "valid alias with underscore", alias: "team_docs2025", wantErr: false},
+		{name: "too short", alias: "ab", wantErr: true},
+		{name: "too long", alias: strings.Repeat("a", maxAliasLength+1), wantErr: true},
+		{name: "invalid characters", alias: "q4/report", wantErr: true},
+		{name: "non-latin characters", alias: "отчет", wantErr: true},
+		{name: "reserved word", alias: "api", wantErr: true},
+		{name: "reserved word in other case", alias: "Swagger", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAlias(tt.alias)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, services.IsInvalidAliasError(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_urlShortenerService_ShortURLWithOptions_Alias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	service := &urlShortenerService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
+	}
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+
+	longURL := "https://example.com/reports/q4"
+	alias := "q4-report"
+
+	t.Run("alias is used as short code", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, alias).Return(nil, repository.ErrURLNotFound)
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				assert.Equal(t, alias, url.ShortURL)
+				assert.Equal(t, longURL, url.LongURL)
+				return nil
+			})
+
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Alias: alias})
+		assert.NoError(t, err)
+		assert.Equal(t, alias, shortURL)
+	})
+
+	t.Run("alias creates separate link for already shortened URL", func(t *testing.T) {
+		stored := make(map[string]*model.URLsModel)
+		// Поиск по длинному URL вернул бы уже созданную ссылку вместо ссылки с собственным кодом
+		mockRepo.EXPECT().
+			GetByLongURL(ctx, longURL).
+			DoAndReturn(func(context.Context, string) (*model.URLsModel, error) {
+				for _, url := range stored {
+					return url, nil
+				}
+				return nil, repository.ErrURLNotFound
+			})
+		mockRepo.EXPECT().GetByShortURL(ctx, "foo").Return(nil, repository.ErrURLNotFound)
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				stored[url.ShortURL] = url
+				return nil
+			}).
+			Times(2)
+
+		generated, err := service.ShortURL(ctx, longURL)
+		require.NoError(t, err)
+
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Alias: "foo"})
+		require.NoError(t, err)
+		assert.Equal(t, "foo", shortURL)
+		assert.NotEqual(t, generated, shortURL)
+		require.Contains(t, stored, "foo")
+		assert.Equal(t, longURL, stored["foo"].LongURL)
+	})
+
+	t.Run("invalid alias is rejected before storage access", func(t *testing.T) {
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Alias: "ping"})
+		assert.Error(t, err)
+		assert.True(t, services.IsInvalidAliasError(err))
+		assert.Empty(t, shortURL)
+	})
+
+	t.Run("alias taken by another link", func(t *testing.T) {
+		mockRepo.EXPECT().
+			GetByShortURL(ctx, alias).
+			Return(&model.URLsModel{ShortURL: alias, LongURL: "https://other.example.com"}, nil)
+
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Alias: alias})
+		assert.ErrorIs(t, err, services.ErrAliasAlreadyExists)
+		assert.Empty(t, shortURL)
+	})
+
+	t.Run("alias taken concurrently is reported as conflict", func(t *testing.T) {
+		mockRepo.EXPECT().GetByShortURL(ctx, alias).Return(nil, repository.ErrURLNotFound)
+		mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(repository.ErrShortURLExists)
+
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Alias: alias})
+		assert.ErrorIs(t, err, services.ErrAliasAlreadyExists)
+		assert.Empty(t, shortURL)
+	})
+
+	t.Run("storage error while checking alias", func(t *testing.T) {
+		dbErr := errors.New("database connection failed")
+		mockRepo.EXPECT().GetByShortURL(ctx, alias).Return(nil, dbErr)
+
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Alias: alias})
+		assert.ErrorIs(t, err, dbErr)
+		assert.Empty(t, shortURL)
+	})
+}
+
+func Test_urlShortenerService_ShortURLsByBatch_Alias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	service := &urlShortenerService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
+	}
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+
+	t.Run("alias is used for batch item", func(t *testing.T) {
+		input := []map[string]string{
+			{"correlation_id": "1", "original_url": "https://example.com/a", "alias": "link-a"},
+			{"correlation_id": "2", "original_url": "https://example.com/b"},
+		}
+
+		mockRepo.EXPECT().GetByShortURL(ctx, "link-a").Return(nil, repository.ErrURLNotFound)
+		mockRepo.EXPECT().GetByLongURL(ctx, "https://example.com/b").Return(nil, repository.ErrURLNotFound)
+		mockRepo.EXPECT().CreateBatch(ctx, gomock.Len(2)).Return(nil)
+
+		result, err := service.ShortURLsByBatch(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, "link-a", result[0]["short_url"])
+		assert.NotEmpty(t, result[1]["short_url"])
+	})
+
+	t.Run("alias in batch creates separate link for already shortened URL", func(t *testing.T) {
+		input := []map[string]string{
+			{"correlation_id": "1", "original_url": "https://example.com/x"},
+			{"correlation_id": "2", "original_url": "https://example.com/x", "alias": "foo"},
+		}
+
+		mockRepo.EXPECT().
+			GetByLongURL(ctx, "https://example.com/x").
+			Return(&model.URLsModel{ShortURL: "exist1", LongURL: "https://example.com/x"}, nil)
+		mockRepo.EXPECT().GetByShortURL(ctx, "foo").Return(nil, repository.ErrURLNotFound)
+		mockRepo.EXPECT().
+			CreateBatch(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, urls []*model.URLsModel) error {
+				require.Len(t, urls, 1)
+				assert.Equal(t, "foo", urls[0].ShortURL)
+				assert.Equal(t, "https://example.com/x", urls[0].LongURL)
+				return nil
+			})
+
+		result, err := service.ShortURLsByBatch(ctx, input)
+		require.NoError(t, err)
+		assert.Equal(t, "exist1", result[0]["short_url"])
+		assert.Equal(t, "foo", result[1]["short_url"])
+	})
+
+	t.Run("duplicate alias within batch", func(t *testing.T) {
+		input := []map[string]string{
+			{"correlation_id": "1", "original_url": "https://example.com/c", "alias": "same"},
+			{"correlation_id": "2", "original_url": "https://example.com/d", "alias": "same"},
+		}
+
+		mockRepo.EXPECT().GetByShortURL(ctx, "same").Return(nil, repository.ErrURLNotFound)
+
+		_, err := service.ShortURLsByBatch(ctx, input)
+		assert.ErrorIs(t, err, services.ErrAliasAlreadyExists)
+	})
+
+	t.Run("invalid alias in batch", func(t *testing.T) {
+		input := []map[string]string{
+			{"correlation_id": "1", "original_url": "https://example.com/e", "alias": "a"},
+		}
+
+		_, err := service.ShortURLsByBatch(ctx, input)
+		assert.True(t, services.IsInvalidAliasError(err))
+	})
+}
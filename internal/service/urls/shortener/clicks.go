@@ -0,0 +1,47 @@
+package shortener
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"yp-go-short-url-service/internal/service"
+)
+
+// batchMaxClicksKey - ключ необязательного лимита переходов в элементах пакетного запроса
+const batchMaxClicksKey = "max_clicks"
+
+// resolveMaxClicks проверяет лимит переходов из параметров запроса.
+// Возвращает nil, если лимит не задан (ноль), и ошибку ErrInvalidMaxClicks для отрицательного значения.
+func resolveMaxClicks(maxClicks int64) (*int64, error) {
+	if maxClicks < 0 {
+		return nil, fmt.Errorf("%w: max_clicks must be positive", service.ErrInvalidMaxClicks)
+	}
+	if maxClicks == 0 {
+		return nil, nil
+	}
+
+	return &maxClicks, nil
+}
+
+// parseBatchMaxClicks извлекает лимит переходов из элемента пакетного запроса по ключу "max_clicks".
+func parseBatchMaxClicks(item map[string]string) (*int64, error) {
+	value := strings.TrimSpace(item[batchMaxClicksKey])
+	if value == "" {
+		return nil, nil
+	}
+
+	maxClicks, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid max_clicks %q", service.ErrInvalidMaxClicks, value)
+	}
+
+	return resolveMaxClicks(maxClicks)
+}
+
+// uniqueCodeSource возвращает исходные данные для генерации кода ссылки, которая не переиспользуется
+// (с лимитом переходов или паролем). К URL добавляется случайная соль: иначе детерминированный
+// хеш-генератор выдавал бы один и тот же код для каждой новой такой ссылки на тот же URL.
+func uniqueCodeSource(longURL string) string {
+	return longURL + "#" + rand.Text()
+}
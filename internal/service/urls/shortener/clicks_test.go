@@ -0,0 +1,115 @@
+package shortener
+
+import (
+	"context"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func Test_parseBatchMaxClicks(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    map[string]string
+		want    *int64
+		wantErr bool
+	}{
+		{name: "no limit", item: map[string]string{}, want: nil},
+		{name: "zero means no limit", item: map[string]string{"max_clicks": "0"}, want: nil},
+		{name: "one-time link", item: map[string]string{"max_clicks": "1"}, want: ptrInt64(1)},
+		{name: "negative", item: map[string]string{"max_clicks": "-1"}, wantErr: true},
+		{name: "not a number", item: map[string]string{"max_clicks": "once"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBatchMaxClicks(tt.item)
+			if tt.wantErr {
+				assert.True(t, services.IsInvalidMaxClicksError(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_urlShortenerService_ShortURLWithOptions_MaxClicks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	service := &urlShortenerService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
+	}
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+
+	longURL := "https://example.com/invite"
+
+	t.Run("limited links are never reused", func(t *testing.T) {
+		var codes []string
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				require.NotNil(t, url.MaxClicks)
+				assert.Equal(t, int64(1), *url.MaxClicks)
+				codes = append(codes, url.ShortURL)
+				return nil
+			}).
+			Times(2)
+
+		for i := 0; i < 2; i++ {
+			shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{MaxClicks: 1})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, shortURL)
+		}
+
+		// Каждая одноразовая ссылка получает собственный код
+		require.Len(t, codes, 2)
+		assert.NotEqual(t, codes[0], codes[1])
+	})
+
+	t.Run("negative limit is rejected", func(t *testing.T) {
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{MaxClicks: -3})
+		assert.True(t, services.IsInvalidMaxClicksError(err))
+		assert.Empty(t, shortURL)
+	})
+
+	t.Run("batch item with limit skips deduplication", func(t *testing.T) {
+		input := []map[string]string{
+			{"correlation_id": "1", "original_url": longURL},
+			{"correlation_id": "2", "original_url": longURL, "max_clicks": "1"},
+		}
+
+		mockRepo.EXPECT().GetByLongURL(ctx, longURL).Return(nil, repository.ErrURLNotFound)
+		mockRepo.EXPECT().
+			CreateBatch(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, urls []*model.URLsModel) error {
+				require.Len(t, urls, 2)
+				assert.Nil(t, urls[0].MaxClicks)
+				require.NotNil(t, urls[1].MaxClicks)
+				assert.Equal(t, int64(1), *urls[1].MaxClicks)
+				return nil
+			})
+
+		result, err := service.ShortURLsByBatch(ctx, input)
+		require.NoError(t, err)
+		assert.NotEqual(t, result[0]["short_url"], result[1]["short_url"])
+	})
+}
+
+func ptrInt64(v int64) *int64 {
+	return &v
+}
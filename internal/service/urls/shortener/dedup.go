@@ -0,0 +1,32 @@
+package shortener
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DedupPolicy определяет, в какой области повторное сокращение одного и того же длинного URL
+// возвращает уже существующую короткую ссылку.
+type DedupPolicy string
+
+const (
+	// DedupPerUser - существующая ссылка переиспользуется только в пределах одного пользователя.
+	// Разные пользователи получают собственные короткие ссылки на один и тот же длинный URL.
+	// Для анонимных запросов действует глобальный поиск.
+	DedupPerUser DedupPolicy = "user"
+	// DedupGlobal - на каждый длинный URL существует одна короткая ссылка для всех пользователей.
+	DedupGlobal DedupPolicy = "global"
+)
+
+// ParseDedupPolicy преобразует строковое значение настройки в DedupPolicy.
+// Пустое значение соответствует DedupPerUser. Возвращает ошибку для неизвестной политики.
+func ParseDedupPolicy(value string) (DedupPolicy, error) {
+	switch DedupPolicy(strings.ToLower(strings.TrimSpace(value))) {
+	case DedupPerUser, "":
+		return DedupPerUser, nil
+	case DedupGlobal:
+		return DedupGlobal, nil
+	default:
+		return "", fmt.Errorf("unknown url dedup policy %q", value)
+	}
+}
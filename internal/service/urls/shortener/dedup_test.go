@@ -0,0 +1,119 @@
+package shortener
+
+import (
+	"context"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestParseDedupPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    DedupPolicy
+		wantErr bool
+	}{
+		{name: "empty defaults to per-user", value: "", want: DedupPerUser},
+		{name: "per-user", value: "user", want: DedupPerUser},
+		{name: "global in other case", value: " Global ", want: DedupGlobal},
+		{name: "unknown", value: "tenant", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParseDedupPolicy(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, policy)
+		})
+	}
+}
+
+func Test_urlShortenerService_ShortURL_DedupPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
+
+	logger, _ := zap.NewDevelopment()
+	baseCtx := middleware.WithLogger(context.Background(), logger.Sugar())
+	userCtx := context.WithValue(baseCtx, middleware.JWTTokenContextKey, &model.UserModel{ID: "user-b"})
+
+	longURL := "https://example.com/shared"
+	generator := NewHashCodeGenerator(shortURLSize)
+	firstCode, _ := generator.Generate(longURL, 0)
+	secondCode, _ := generator.Generate(longURL, 1)
+
+	t.Run("per-user policy returns own link", func(t *testing.T) {
+		service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil, generator, DedupPerUser, nil)
+
+		mockUserURLsRepo.EXPECT().
+			GetByUserIDAndLongURL(userCtx, "user-b", longURL).
+			Return(&model.URLsModel{ShortURL: "own123", LongURL: longURL}, nil)
+
+		shortURL, err := service.ShortURL(userCtx, longURL)
+		assert.ErrorIs(t, err, services.ErrURLAlreadyExists)
+		assert.Equal(t, "own123", shortURL)
+	})
+
+	t.Run("per-user policy creates separate link when another user owns the URL", func(t *testing.T) {
+		service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil, generator, DedupPerUser, nil)
+
+		mockUserURLsRepo.EXPECT().
+			GetByUserIDAndLongURL(userCtx, "user-b", longURL).
+			Return(nil, repository.ErrURLNotFound)
+		gomock.InOrder(
+			// Хеш-код уже занят ссылкой другого пользователя
+			mockUserURLsRepo.EXPECT().
+				CreateURLWithUser(userCtx, gomock.Any(), "user-b").
+				Return(repository.ErrShortURLExists),
+			mockUserURLsRepo.EXPECT().
+				CreateURLWithUser(userCtx, gomock.Any(), "user-b").
+				DoAndReturn(func(ctx context.Context, url *model.URLsModel, userID string) error {
+					assert.Equal(t, secondCode, url.ShortURL)
+					return nil
+				}),
+		)
+
+		shortURL, err := service.ShortURL(userCtx, longURL)
+		require.NoError(t, err)
+		assert.Equal(t, secondCode, shortURL)
+	})
+
+	t.Run("per-user policy falls back to global lookup for anonymous requests", func(t *testing.T) {
+		service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil, generator, DedupPerUser, nil)
+
+		mockRepo.EXPECT().
+			GetByLongURL(baseCtx, longURL).
+			Return(&model.URLsModel{ShortURL: firstCode, LongURL: longURL}, nil)
+
+		shortURL, err := service.ShortURL(baseCtx, longURL)
+		assert.ErrorIs(t, err, services.ErrURLAlreadyExists)
+		assert.Equal(t, firstCode, shortURL)
+	})
+
+	t.Run("global policy ignores ownership", func(t *testing.T) {
+		service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil, generator, DedupGlobal, nil)
+
+		mockRepo.EXPECT().
+			GetByLongURL(userCtx, longURL).
+			Return(&model.URLsModel{ShortURL: firstCode, LongURL: longURL}, nil)
+
+		shortURL, err := service.ShortURL(userCtx, longURL)
+		assert.ErrorIs(t, err, services.ErrURLAlreadyExists)
+		assert.Equal(t, firstCode, shortURL)
+	})
+}
@@ -22,7 +22,7 @@ func ExampleNewURLShortenerService() {
 	auditEventBus := observerMock.NewMockSubject[audit.Event](ctrl)
 
 	// Создаем сервис для сокращения URL
-	service := shortener.NewURLShortenerService(mockURLRepo, mockUserURLsRepo, auditEventBus)
+	service := shortener.NewURLShortenerService(mockURLRepo, mockUserURLsRepo, auditEventBus, nil, "", nil)
 
 	// Сервис готов к использованию
 	_ = service
@@ -40,7 +40,7 @@ func ExampleNewURLShortenerService_shortURL() {
 	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
 	auditEventBus := observerMock.NewMockSubject[audit.Event](ctrl)
 
-	service := shortener.NewURLShortenerService(mockURLRepo, mockUserURLsRepo, auditEventBus)
+	service := shortener.NewURLShortenerService(mockURLRepo, mockUserURLsRepo, auditEventBus, nil, "", nil)
 
 	ctx := context.Background()
 	longURL := "https://example.com/very/long/url/path"
@@ -65,7 +65,7 @@ func ExampleNewURLShortenerService_shortURLsByBatch() {
 	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
 	auditEventBus := observerMock.NewMockSubject[audit.Event](ctrl)
 
-	service := shortener.NewURLShortenerService(mockURLRepo, mockUserURLsRepo, auditEventBus)
+	service := shortener.NewURLShortenerService(mockURLRepo, mockUserURLsRepo, auditEventBus, nil, "", nil)
 
 	ctx := context.Background()
 
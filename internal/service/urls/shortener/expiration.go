@@ -0,0 +1,41 @@
+package shortener
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"yp-go-short-url-service/internal/service"
+)
+
+// Ключи необязательного срока действия в элементах пакетного запроса.
+const (
+	// batchExpiresAtKey - момент истечения ссылки в формате RFC 3339
+	batchExpiresAtKey = "expires_at"
+	// batchTTLKey - время жизни ссылки в секундах
+	batchTTLKey = "ttl"
+)
+
+// parseBatchExpiration извлекает срок действия из элемента пакетного запроса
+// по ключам "expires_at" (RFC 3339) и "ttl" (секунды) и вычисляет момент истечения ссылки.
+func parseBatchExpiration(item map[string]string, now time.Time) (*time.Time, error) {
+	var opts service.ShortenOptions
+
+	if value := strings.TrimSpace(item[batchExpiresAtKey]); value != "" {
+		expiresAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid expires_at %q", service.ErrInvalidExpiration, value)
+		}
+		opts.ExpiresAt = &expiresAt
+	}
+
+	if value := strings.TrimSpace(item[batchTTLKey]); value != "" {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid ttl %q", service.ErrInvalidExpiration, value)
+		}
+		opts.TTL = time.Duration(seconds) * time.Second
+	}
+
+	return service.ResolveExpiration(opts.ExpiresAt, opts.TTL, now)
+}
@@ -0,0 +1,125 @@
+package shortener
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func Test_parseBatchExpiration(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		item    map[string]string
+		want    *time.Time
+		wantErr bool
+	}{
+		{name: "no expiration", item: map[string]string{}, want: nil},
+		{name: "ttl in seconds", item: map[string]string{"ttl": "90"}, want: ptrTime(now.Add(90 * time.Second))},
+		{name: "expires_at", item: map[string]string{"expires_at": "2025-01-02T15:00:00+03:00"}, want: ptrTime(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC))},
+		{name: "invalid ttl", item: map[string]string{"ttl": "1h"}, wantErr: true},
+		{name: "invalid expires_at", item: map[string]string{"expires_at": "tomorrow"}, wantErr: true},
+		{name: "expires_at in the past", item: map[string]string{"expires_at": "2024-12-31T00:00:00Z"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBatchExpiration(tt.item, now)
+			if tt.wantErr {
+				assert.True(t, services.IsInvalidExpirationError(err))
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.True(t, tt.want.Equal(*got))
+		})
+	}
+}
+
+func Test_urlShortenerService_ShortURLWithOptions_Expiration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	service := &urlShortenerService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
+	}
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+
+	longURL := "https://example.com/promo"
+
+	t.Run("ttl is stored as expiration moment", func(t *testing.T) {
+		before := time.Now()
+		// Ссылка со сроком действия не переиспользует существующую: поиск по длинному URL не выполняется
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				require.NotNil(t, url.ExpiresAt)
+				assert.False(t, url.ExpiresAt.Before(before.Add(time.Hour)))
+				assert.True(t, url.ExpiresAt.Before(time.Now().Add(time.Hour+time.Second)))
+				return nil
+			})
+
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{TTL: time.Hour})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, shortURL)
+	})
+
+	t.Run("invalid expiration is rejected before storage access", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{ExpiresAt: &past})
+		assert.True(t, services.IsInvalidExpirationError(err))
+		assert.Empty(t, shortURL)
+	})
+
+	t.Run("batch item with ttl", func(t *testing.T) {
+		input := []map[string]string{
+			{"correlation_id": "1", "original_url": longURL, "ttl": "60"},
+		}
+
+		mockRepo.EXPECT().
+			CreateBatch(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, urls []*model.URLsModel) error {
+				require.Len(t, urls, 1)
+				assert.NotNil(t, urls[0].ExpiresAt)
+				return nil
+			})
+
+		result, err := service.ShortURLsByBatch(ctx, input)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("batch item with invalid ttl", func(t *testing.T) {
+		input := []map[string]string{
+			{"correlation_id": "1", "original_url": longURL, "ttl": "-5"},
+		}
+
+		result, err := service.ShortURLsByBatch(ctx, input)
+		assert.True(t, services.IsInvalidExpirationError(err))
+		assert.Nil(t, result)
+	})
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
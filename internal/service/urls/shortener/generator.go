@@ -0,0 +1,166 @@
+package shortener
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Стратегии генерации коротких кодов.
+const (
+	// StrategyHash - код вычисляется из MD5-хеша длинного URL, при коллизии хеш пересчитывается с солью.
+	StrategyHash = "hash"
+	// StrategyRandom - код состоит из криптографически случайных символов base62.
+	StrategyRandom = "random"
+	// StrategyCounter - код получается обфускацией монотонно растущего счетчика.
+	StrategyCounter = "counter"
+)
+
+const (
+	// MinCodeLength - минимально допустимая длина сгенерированного короткого кода
+	MinCodeLength = 4
+	// MaxCodeLength - максимально допустимая длина сгенерированного короткого кода
+	MaxCodeLength = 10
+	// maxGenerateAttempts - количество попыток сгенерировать свободный короткий код
+	maxGenerateAttempts = 5
+)
+
+// counterMultiplier - нечетный множитель, взаимно простой с 62, для обфускации счетчика.
+// Умножение на него по модулю 62^n является биекцией, поэтому разные значения счетчика дают разные коды.
+const counterMultiplier uint64 = 0x9E3779B97F4A7C15
+
+// CodeGenerator генерирует короткие коды для длинных URL.
+// attempt - номер попытки (начиная с 0); при коллизии сервис повторяет генерацию с увеличенным номером,
+// и реализация должна вернуть другой код.
+type CodeGenerator interface {
+	Generate(longURL string, attempt int) (string, error)
+}
+
+// NewCodeGenerator создает генератор коротких кодов по названию стратегии и длине кода.
+// Пустая стратегия соответствует StrategyHash. Возвращает ошибку для неизвестной стратегии
+// или длины вне диапазона [MinCodeLength, MaxCodeLength].
+func NewCodeGenerator(strategy string, length int) (CodeGenerator, error) {
+	if length < MinCodeLength || length > MaxCodeLength {
+		return nil, fmt.Errorf("short code length must be between %d and %d, got %d", MinCodeLength, MaxCodeLength, length)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(strategy)) {
+	case StrategyHash, "":
+		return NewHashCodeGenerator(length), nil
+	case StrategyRandom:
+		return NewRandomCodeGenerator(length), nil
+	case StrategyCounter:
+		return NewCounterCodeGenerator(length, uint64(time.Now().UnixNano())), nil
+	default:
+		return nil, fmt.Errorf("unknown short code strategy %q", strategy)
+	}
+}
+
+type hashCodeGenerator struct {
+	length int
+}
+
+// NewHashCodeGenerator создает генератор, вычисляющий код из MD5-хеша длинного URL.
+// Первая попытка совпадает с shortenURLBase62, последующие добавляют к URL номер попытки в качестве соли.
+func NewHashCodeGenerator(length int) CodeGenerator {
+	return &hashCodeGenerator{length: length}
+}
+
+// Generate возвращает код для longURL. Для одинаковых longURL и attempt результат одинаков.
+func (g *hashCodeGenerator) Generate(longURL string, attempt int) (string, error) {
+	data := longURL
+	if attempt > 0 {
+		data = longURL + "#" + strconv.Itoa(attempt)
+	}
+
+	hash := md5.Sum([]byte(data))
+	return encodeHashBase62(hash[:hashSize], g.length), nil
+}
+
+type randomCodeGenerator struct {
+	length int
+}
+
+// NewRandomCodeGenerator создает генератор случайных кодов на основе crypto/rand.
+func NewRandomCodeGenerator(length int) CodeGenerator {
+	return &randomCodeGenerator{length: length}
+}
+
+// Generate возвращает новый случайный код независимо от longURL и attempt.
+func (g *randomCodeGenerator) Generate(_ string, _ int) (string, error) {
+	result := make([]byte, 0, g.length)
+	buf := make([]byte, g.length*2)
+
+	for len(result) < g.length {
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("failed to read random bytes: %w", err)
+		}
+		for _, b := range buf {
+			// Отбрасываем значения, нарушающие равномерность распределения (248 = 62 * 4)
+			if b >= 248 {
+				continue
+			}
+			result = append(result, base62Chars[b%62])
+			if len(result) == g.length {
+				break
+			}
+		}
+	}
+
+	return string(result), nil
+}
+
+type counterCodeGenerator struct {
+	length  int
+	modulus uint64
+	counter atomic.Uint64
+}
+
+// NewCounterCodeGenerator создает генератор, обфусцирующий монотонный счетчик, начиная со значения start.
+// Коды не повторяются, пока счетчик не пройдет полный цикл 62^length значений.
+func NewCounterCodeGenerator(length int, start uint64) CodeGenerator {
+	modulus := uint64(1)
+	for i := 0; i < length; i++ {
+		modulus *= 62
+	}
+
+	g := &counterCodeGenerator{length: length, modulus: modulus}
+	g.counter.Store(start % modulus)
+	return g
+}
+
+// Generate возвращает код для следующего значения счетчика. Каждый вызов, включая повторные попытки,
+// продвигает счетчик.
+func (g *counterCodeGenerator) Generate(_ string, _ int) (string, error) {
+	value := g.counter.Add(1) % g.modulus
+
+	hi, lo := bits.Mul64(value, counterMultiplier)
+	obfuscated := bits.Rem64(hi, lo, g.modulus)
+
+	code := make([]byte, g.length)
+	for i := g.length - 1; i >= 0; i-- {
+		code[i] = base62Chars[obfuscated%62]
+		obfuscated /= 62
+	}
+
+	return string(code), nil
+}
+
+// encodeHashBase62 кодирует байты хеша в base62 и приводит результат к длине length,
+// дополняя слева нулями, если число оказалось слишком коротким.
+func encodeHashBase62(hash []byte, length int) string {
+	num := new(big.Int).SetBytes(hash)
+
+	code := toBase62(num)
+	if len(code) < length {
+		code = strings.Repeat(string(base62Chars[0]), length-len(code)) + code
+	}
+
+	return code[:length]
+}
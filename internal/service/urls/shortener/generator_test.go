@@ -0,0 +1,175 @@
+package shortener
+
+import (
+	"context"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository"
+	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestNewCodeGenerator(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		length   int
+		wantType CodeGenerator
+		wantErr  bool
+	}{
+		{name: "hash strategy", strategy: StrategyHash, length: 8, wantType: &hashCodeGenerator{}},
+		{name: "empty strategy defaults to hash", strategy: "", length: 8, wantType: &hashCodeGenerator{}},
+		{name: "random strategy", strategy: "Random", length: 6, wantType: &randomCodeGenerator{}},
+		{name: "counter strategy", strategy: StrategyCounter, length: 10, wantType: &counterCodeGenerator{}},
+		{name: "unknown strategy", strategy: "uuid", length: 8, wantErr: true},
+		{name: "too short", strategy: StrategyHash, length: MinCodeLength - 1, wantErr: true},
+		{name: "too long", strategy: StrategyHash, length: MaxCodeLength + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := NewCodeGenerator(tt.strategy, tt.length)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, generator)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantType, generator)
+		})
+	}
+}
+
+func TestCodeGenerators_Format(t *testing.T) {
+	for _, strategy := range []string{StrategyHash, StrategyRandom, StrategyCounter} {
+		for _, length := range []int{MinCodeLength, 8, MaxCodeLength} {
+			generator, err := NewCodeGenerator(strategy, length)
+			require.NoError(t, err)
+
+			for attempt := 0; attempt < 20; attempt++ {
+				code, err := generator.Generate("https://example.com/page", attempt)
+				require.NoError(t, err)
+				assert.Len(t, code, length, "strategy %s", strategy)
+				for _, char := range code {
+					assert.Contains(t, base62Chars, string(char))
+				}
+			}
+		}
+	}
+}
+
+func TestHashCodeGenerator_Generate(t *testing.T) {
+	generator := NewHashCodeGenerator(shortURLSize)
+	longURL := "https://example.com/some/long/url"
+
+	first, err := generator.Generate(longURL, 0)
+	require.NoError(t, err)
+	// Первая попытка совпадает с исходным алгоритмом
+	assert.Equal(t, shortenURLBase62(longURL), first)
+
+	again, err := generator.Generate(longURL, 0)
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	salted, err := generator.Generate(longURL, 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, salted)
+}
+
+func TestCounterCodeGenerator_Unique(t *testing.T) {
+	generator := NewCounterCodeGenerator(MinCodeLength, 0)
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 10000; i++ {
+		code, err := generator.Generate("", 0)
+		require.NoError(t, err)
+		_, exists := seen[code]
+		require.False(t, exists, "duplicate code %s", code)
+		seen[code] = struct{}{}
+	}
+}
+
+func TestCounterCodeGenerator_Obfuscated(t *testing.T) {
+	generator := NewCounterCodeGenerator(8, 0)
+
+	first, err := generator.Generate("", 0)
+	require.NoError(t, err)
+	second, err := generator.Generate("", 0)
+	require.NoError(t, err)
+
+	// Соседние значения счетчика не должны давать соседние коды
+	assert.NotEqual(t, first[:len(first)-1], second[:len(second)-1])
+}
+
+func Test_urlShortenerService_ShortURL_CollisionRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	generator := NewHashCodeGenerator(shortURLSize)
+	service := &urlShortenerService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      generator,
+	}
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+
+	longURL := "https://example.com/collision"
+	firstCode, _ := generator.Generate(longURL, 0)
+	secondCode, _ := generator.Generate(longURL, 1)
+
+	t.Run("retries with salted code", func(t *testing.T) {
+		mockRepo.EXPECT().GetByLongURL(ctx, longURL).Return(nil, repository.ErrURLNotFound)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Create(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+					assert.Equal(t, firstCode, url.ShortURL)
+					return repository.ErrShortURLExists
+				}),
+			mockRepo.EXPECT().
+				Create(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+					assert.Equal(t, secondCode, url.ShortURL)
+					return nil
+				}),
+		)
+
+		shortURL, err := service.ShortURL(ctx, longURL)
+		assert.NoError(t, err)
+		assert.Equal(t, secondCode, shortURL)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		mockRepo.EXPECT().GetByLongURL(ctx, longURL).Return(nil, repository.ErrURLNotFound)
+		mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(repository.ErrShortURLExists).Times(maxGenerateAttempts)
+
+		shortURL, err := service.ShortURL(ctx, longURL)
+		assert.ErrorIs(t, err, services.ErrCodeGenerationFailed)
+		assert.Empty(t, shortURL)
+	})
+
+	t.Run("batch is regenerated on collision", func(t *testing.T) {
+		input := []map[string]string{
+			{"correlation_id": "1", "original_url": longURL},
+		}
+
+		mockRepo.EXPECT().GetByLongURL(ctx, longURL).Return(nil, repository.ErrURLNotFound).Times(2)
+		gomock.InOrder(
+			mockRepo.EXPECT().CreateBatch(ctx, gomock.Any()).Return(repository.ErrShortURLExists),
+			mockRepo.EXPECT().CreateBatch(ctx, gomock.Any()).Return(nil),
+		)
+
+		result, err := service.ShortURLsByBatch(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, secondCode, result[0]["short_url"])
+	})
+}
@@ -0,0 +1,37 @@
+package shortener
+
+import (
+	"fmt"
+	"yp-go-short-url-service/internal/service"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// batchPasswordKey - ключ необязательного пароля ссылки в элементах пакетного запроса
+	batchPasswordKey = "password"
+	// minPasswordLength - минимальная длина пароля ссылки
+	minPasswordLength = 4
+	// maxPasswordLength - максимальная длина пароля ссылки в байтах (ограничение bcrypt)
+	maxPasswordLength = 72
+)
+
+// hashPassword проверяет пароль ссылки и возвращает его bcrypt-хеш.
+// Возвращает nil для пустого пароля и ошибку ErrInvalidPassword, если длина пароля вне допустимого диапазона.
+func hashPassword(password string) (*string, error) {
+	if password == "" {
+		return nil, nil
+	}
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return nil, fmt.Errorf("%w: password length must be between %d and %d bytes",
+			service.ErrInvalidPassword, minPasswordLength, maxPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash url password: %w", err)
+	}
+
+	passwordHash := string(hash)
+	return &passwordHash, nil
+}
@@ -0,0 +1,106 @@
+package shortener
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func Test_hashPassword(t *testing.T) {
+	t.Run("empty password means no protection", func(t *testing.T) {
+		hash, err := hashPassword("")
+		require.NoError(t, err)
+		assert.Nil(t, hash)
+	})
+
+	t.Run("password is hashed", func(t *testing.T) {
+		hash, err := hashPassword("s3cret")
+		require.NoError(t, err)
+		require.NotNil(t, hash)
+		assert.NotEqual(t, "s3cret", *hash)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(*hash), []byte("s3cret")))
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		_, err := hashPassword("abc")
+		assert.True(t, services.IsInvalidPasswordError(err))
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		_, err := hashPassword(strings.Repeat("a", maxPasswordLength+1))
+		assert.True(t, services.IsInvalidPasswordError(err))
+	})
+}
+
+func Test_urlShortenerService_ShortURLWithOptions_Password(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	service := &urlShortenerService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
+	}
+
+	logger, _ := zap.NewDevelopment()
+	ctx := middleware.WithLogger(context.Background(), logger.Sugar())
+
+	longURL := "https://example.com/private"
+
+	t.Run("protected links are never reused", func(t *testing.T) {
+		var codes []string
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				require.True(t, url.IsPasswordProtected())
+				assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(*url.PasswordHash), []byte("s3cret")))
+				codes = append(codes, url.ShortURL)
+				return nil
+			}).
+			Times(2)
+
+		for i := 0; i < 2; i++ {
+			shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Password: "s3cret"})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, shortURL)
+		}
+
+		require.Len(t, codes, 2)
+		assert.NotEqual(t, codes[0], codes[1])
+	})
+
+	t.Run("invalid password is rejected", func(t *testing.T) {
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Password: "123"})
+		assert.True(t, services.IsInvalidPasswordError(err))
+		assert.Empty(t, shortURL)
+	})
+
+	t.Run("batch item with password skips deduplication", func(t *testing.T) {
+		input := []map[string]string{
+			{"correlation_id": "1", "original_url": longURL, "password": "s3cret"},
+		}
+
+		mockRepo.EXPECT().
+			CreateBatch(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, urls []*model.URLsModel) error {
+				require.Len(t, urls, 1)
+				assert.True(t, urls[0].IsPasswordProtected())
+				return nil
+			})
+
+		result, err := service.ShortURLsByBatch(ctx, input)
+		require.NoError(t, err)
+		assert.NotEmpty(t, result[0]["short_url"])
+	})
+}
@@ -0,0 +1,68 @@
+package shortener
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/service"
+)
+
+const (
+	// batchRedirectCodeKey - ключ необязательного типа перенаправления в элементах пакетного запроса
+	batchRedirectCodeKey = "redirect_code"
+	// batchQueryPassthroughKey - ключ необязательного режима переноса параметров запроса в элементах пакетного запроса
+	batchQueryPassthroughKey = "query_passthrough"
+	// batchPrefixKey - ключ необязательного признака ссылки-префикса в элементах пакетного запроса
+	batchPrefixKey = "prefix"
+)
+
+// resolveRedirect проверяет тип перенаправления и режим переноса параметров запроса.
+// Нулевой тип перенаправления заменяется на DefaultRedirectCode. Возвращает ошибку ErrInvalidRedirect,
+// если статус не входит в 301, 302, 307, 308 или режим переноса неизвестен.
+func resolveRedirect(code int, passthrough model.QueryPassthrough) (int, model.QueryPassthrough, error) {
+	if code == 0 {
+		code = model.DefaultRedirectCode
+	}
+	if !model.IsValidRedirectCode(code) {
+		return 0, "", fmt.Errorf("%w: redirect_code must be one of 301, 302, 307, 308", service.ErrInvalidRedirect)
+	}
+
+	passthrough = model.QueryPassthrough(strings.ToLower(strings.TrimSpace(string(passthrough))))
+	if !passthrough.IsValid() {
+		return 0, "", fmt.Errorf("%w: unknown query_passthrough %q", service.ErrInvalidRedirect, passthrough)
+	}
+
+	return code, passthrough, nil
+}
+
+// parseBatchRedirect извлекает тип перенаправления и режим переноса параметров запроса из элемента пакетного
+// запроса по ключам "redirect_code" и "query_passthrough".
+func parseBatchRedirect(item map[string]string) (int, model.QueryPassthrough, error) {
+	var code int
+	if value := strings.TrimSpace(item[batchRedirectCodeKey]); value != "" {
+		var err error
+		code, err = strconv.Atoi(value)
+		if err != nil {
+			return 0, "", fmt.Errorf("%w: invalid redirect_code %q", service.ErrInvalidRedirect, value)
+		}
+	}
+
+	return resolveRedirect(code, model.QueryPassthrough(item[batchQueryPassthroughKey]))
+}
+
+// parseBatchPrefix извлекает признак ссылки-префикса из элемента пакетного запроса по ключу "prefix".
+// Возвращает ошибку ErrInvalidRedirect, если значение не является логическим.
+func parseBatchPrefix(item map[string]string) (bool, error) {
+	value := strings.TrimSpace(item[batchPrefixKey])
+	if value == "" {
+		return false, nil
+	}
+
+	prefix, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: invalid prefix %q", service.ErrInvalidRedirect, value)
+	}
+
+	return prefix, nil
+}
@@ -0,0 +1,194 @@
+package shortener
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func Test_resolveRedirect(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		code, passthrough, err := resolveRedirect(0, "")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTemporaryRedirect, code)
+		assert.Equal(t, model.QueryPassthroughOff, passthrough)
+	})
+
+	t.Run("passthrough mode is normalized", func(t *testing.T) {
+		code, passthrough, err := resolveRedirect(http.StatusMovedPermanently, " Override ")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusMovedPermanently, code)
+		assert.Equal(t, model.QueryPassthroughOverride, passthrough)
+	})
+
+	t.Run("unsupported redirect code", func(t *testing.T) {
+		_, _, err := resolveRedirect(http.StatusMultipleChoices, "")
+		assert.True(t, services.IsInvalidRedirectError(err))
+	})
+
+	t.Run("unknown passthrough mode", func(t *testing.T) {
+		_, _, err := resolveRedirect(0, "append")
+		assert.True(t, services.IsInvalidRedirectError(err))
+	})
+}
+
+func Test_parseBatchRedirect(t *testing.T) {
+	code, passthrough, err := parseBatchRedirect(map[string]string{"redirect_code": "308", "query_passthrough": "merge"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPermanentRedirect, code)
+	assert.Equal(t, model.QueryPassthroughMerge, passthrough)
+
+	_, _, err = parseBatchRedirect(map[string]string{"redirect_code": "permanent"})
+	assert.True(t, services.IsInvalidRedirectError(err))
+}
+
+func Test_parseBatchPrefix(t *testing.T) {
+	prefix, err := parseBatchPrefix(map[string]string{})
+	require.NoError(t, err)
+	assert.False(t, prefix)
+
+	prefix, err = parseBatchPrefix(map[string]string{"prefix": "true"})
+	require.NoError(t, err)
+	assert.True(t, prefix)
+
+	_, err = parseBatchPrefix(map[string]string{"prefix": "maybe"})
+	assert.True(t, services.IsInvalidRedirectError(err))
+}
+
+func Test_urlShortenerService_ShortURLWithOptions_Redirect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	service := &urlShortenerService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
+	}
+
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	longURL := "https://example.com/landing"
+
+	t.Run("links with redirect settings are never reused", func(t *testing.T) {
+		var codes []string
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				assert.Equal(t, http.StatusMovedPermanently, url.RedirectCode)
+				assert.Equal(t, model.QueryPassthroughMerge, url.QueryPassthrough)
+				codes = append(codes, url.ShortURL)
+				return nil
+			}).
+			Times(2)
+
+		opts := services.ShortenOptions{RedirectCode: http.StatusMovedPermanently, QueryPassthrough: model.QueryPassthroughMerge}
+		for i := 0; i < 2; i++ {
+			shortURL, err := service.ShortURLWithOptions(ctx, longURL, opts)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, shortURL)
+		}
+
+		require.Len(t, codes, 2)
+		assert.NotEqual(t, codes[0], codes[1])
+	})
+
+	t.Run("prefix links are never reused", func(t *testing.T) {
+		var codes []string
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				assert.True(t, url.IsPrefix)
+				codes = append(codes, url.ShortURL)
+				return nil
+			}).
+			Times(2)
+
+		for i := 0; i < 2; i++ {
+			shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Prefix: true})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, shortURL)
+		}
+
+		require.Len(t, codes, 2)
+		assert.NotEqual(t, codes[0], codes[1])
+	})
+
+	t.Run("links with routing rules are never reused", func(t *testing.T) {
+		rules := model.RoutingRules{{Device: "IOS", Destination: "https://apps.apple.com/app/id1"}}
+		var codes []string
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				assert.Equal(t, model.RoutingRules{{Device: model.DeviceIOS, Destination: "https://apps.apple.com/app/id1"}}, url.RoutingRules)
+				codes = append(codes, url.ShortURL)
+				return nil
+			}).
+			Times(2)
+
+		for i := 0; i < 2; i++ {
+			shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{RoutingRules: rules})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, shortURL)
+		}
+
+		require.Len(t, codes, 2)
+		assert.NotEqual(t, codes[0], codes[1])
+	})
+
+	t.Run("invalid routing rules are rejected", func(t *testing.T) {
+		rules := model.RoutingRules{{Languages: []string{"ru"}, Destination: "ftp://example.com"}}
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{RoutingRules: rules})
+		assert.True(t, services.IsInvalidRoutingRulesError(err))
+		assert.Empty(t, shortURL)
+	})
+
+	t.Run("links with split variants are never reused", func(t *testing.T) {
+		variants := model.SplitVariants{
+			{Name: "A", Destination: "https://example.com/a", Weight: 70},
+			{Name: "B", Destination: "https://example.com/b", Weight: 30},
+		}
+		var codes []string
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				assert.Equal(t, model.SplitVariants{
+					{Name: "a", Destination: "https://example.com/a", Weight: 70},
+					{Name: "b", Destination: "https://example.com/b", Weight: 30},
+				}, url.Variants)
+				codes = append(codes, url.ShortURL)
+				return nil
+			}).
+			Times(2)
+
+		for i := 0; i < 2; i++ {
+			shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Variants: variants})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, shortURL)
+		}
+
+		require.Len(t, codes, 2)
+		assert.NotEqual(t, codes[0], codes[1])
+	})
+
+	t.Run("invalid split variants are rejected", func(t *testing.T) {
+		variants := model.SplitVariants{{Name: "a", Destination: "https://example.com/a", Weight: 0}, {Name: "b", Destination: "https://example.com/b", Weight: 1}}
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Variants: variants})
+		assert.True(t, services.IsInvalidVariantsError(err))
+		assert.Empty(t, shortURL)
+	})
+
+	t.Run("invalid redirect code is rejected", func(t *testing.T) {
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{RedirectCode: http.StatusOK})
+		assert.True(t, services.IsInvalidRedirectError(err))
+		assert.Empty(t, shortURL)
+	})
+}
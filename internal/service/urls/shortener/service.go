@@ -12,16 +12,32 @@ import (
 )
 
 // NewURLShortenerService создает новый сервис для сокращения URL.
-// Принимает репозитории для работы с URL и шину событий для уведомлений, возвращает реализацию интерфейса URLShortenerService.
+// Принимает репозитории для работы с URL, шину событий для уведомлений, генератор коротких кодов
+// и политику дедупликации, возвращает реализацию интерфейса URLShortenerService.
+// Если генератор не передан, используется хеш-генератор; пустая политика соответствует DedupPerUser.
+// Созданные ссылки сбрасывают записи об отсутствии их коротких кодов в кэше urlCache, если он задан.
 func NewURLShortenerService(
 	urlRepository repository.URLRepository,
 	userURLsRepository repository.UserURLsRepository,
 	eventBus baseObserver.Subject[audit.Event],
+	codeGenerator CodeGenerator,
+	dedupPolicy DedupPolicy,
+	urlCache service.Cache,
 ) service.URLShortenerService {
+	if codeGenerator == nil {
+		codeGenerator = NewHashCodeGenerator(shortURLSize)
+	}
+	if dedupPolicy == "" {
+		dedupPolicy = DedupPerUser
+	}
+
 	return &urlShortenerService{
 		urlRepository:      urlRepository,
 		userURLsRepository: userURLsRepository,
 		eventBus:           eventBus,
+		codeGenerator:      codeGenerator,
+		dedupPolicy:        dedupPolicy,
+		urlCache:           urlCache,
 	}
 }
 
@@ -29,50 +45,147 @@ type urlShortenerService struct {
 	urlRepository      repository.URLRepository
 	userURLsRepository repository.UserURLsRepository
 	eventBus           baseObserver.Subject[audit.Event]
+	codeGenerator      CodeGenerator
+	dedupPolicy        DedupPolicy
+	urlCache           service.Cache
 }
 
 // ShortURLsByBatch создает короткие ссылки для массива длинных URL в пакетном режиме.
-// Принимает массив словарей с ключами "correlation_id" и "original_url".
+// Принимает массив словарей с ключами "correlation_id", "original_url" и необязательными
+// "alias", "expires_at" (RFC 3339), "ttl" (секунды), "max_clicks", "password", "redirect_code", "query_passthrough"
+// и "prefix".
 // Возвращает тот же массив с добавленными ключами "short_url" для каждого элемента.
-// Если URL уже существует, использует существующий короткий URL.
+// Если URL уже существует, использует существующий короткий URL; ссылки с собственным коротким кодом,
+// сроком действия, лимитом переходов, паролем, особыми настройками перенаправления или переносом пути
+// всегда создаются заново.
+// При коллизии сгенерированных кодов пакет обрабатывается повторно с новыми кодами.
 func (s *urlShortenerService) ShortURLsByBatch(ctx context.Context, longURLs []map[string]string) ([]map[string]string, error) {
 	logger := middleware.GetLogger(ctx)
+	requestID := middleware.ExtractRequestID(ctx)
 
-	urlsForCreation, err := s.processURLsForBatch(ctx, longURLs)
-	if err != nil {
-		return nil, err
-	}
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		urlsForCreation, hasGenerated, err := s.processURLsForBatch(ctx, longURLs, attempt)
+		if err != nil {
+			return nil, err
+		}
 
-	if err := s.saveURLsToStorage(ctx, urlsForCreation); err != nil {
-		return nil, err
+		err = s.saveURLsToStorage(ctx, urlsForCreation)
+		if err == nil {
+			logger.Infow("URL records created successfully in database")
+			return longURLs, nil
+		}
+
+		if !hasGenerated || !repository.IsShortURLExistsError(err) {
+			return nil, mapShortURLConflict(err)
+		}
+
+		logger.Warnw("Short code collision while saving batch, regenerating codes",
+			"attempt", attempt,
+			"request_id", requestID,
+		)
 	}
 
-	logger.Infow("URL records created successfully in database")
-	return longURLs, nil
+	return nil, service.ErrCodeGenerationFailed
 }
 
-// processURLsForBatch обрабатывает массив URL'ов для пакетного создания
-func (s *urlShortenerService) processURLsForBatch(ctx context.Context, longURLs []map[string]string) ([]*model.URLsModel, error) {
+// processURLsForBatch обрабатывает массив URL'ов для пакетного создания.
+// attempt - номер попытки генерации кодов; возвращает также признак того, что в пакете есть сгенерированные коды.
+func (s *urlShortenerService) processURLsForBatch(
+	ctx context.Context,
+	longURLs []map[string]string,
+	attempt int,
+) ([]*model.URLsModel, bool, error) {
 	var urlsForCreation []*model.URLsModel
+	var hasGenerated bool
+	// Короткие коды, уже занятые элементами текущего пакета
+	batchCodes := make(map[string]struct{})
+	// Сгенерированные коды для длинных URL, уже встретившихся в текущем пакете
+	batchLongURLs := make(map[string]string)
+
+	now := time.Now()
 
 	for _, longURLItem := range longURLs {
 		longURL := longURLItem["original_url"]
+		alias := longURLItem["alias"]
 
-		processedURL, err := s.processSingleURL(ctx, longURLItem, longURL)
+		expiresAt, err := parseBatchExpiration(longURLItem, now)
 		if err != nil {
-			return nil, err
+			return nil, false, err
+		}
+
+		maxClicks, err := parseBatchMaxClicks(longURLItem)
+		if err != nil {
+			return nil, false, err
+		}
+
+		passwordHash, err := hashPassword(longURLItem[batchPasswordKey])
+		if err != nil {
+			return nil, false, err
+		}
+
+		redirectCode, queryPassthrough, err := parseBatchRedirect(longURLItem)
+		if err != nil {
+			return nil, false, err
+		}
+
+		prefix, err := parseBatchPrefix(longURLItem)
+		if err != nil {
+			return nil, false, err
+		}
+
+		// Ссылки с собственным коротким кодом, сроком действия, лимитом переходов, защищенные паролем,
+		// с особыми настройками перенаправления и ссылки-префиксы не переиспользуются
+		reusable := alias == "" && expiresAt == nil && maxClicks == nil && passwordHash == nil && !prefix &&
+			redirectCode == model.DefaultRedirectCode && queryPassthrough == model.QueryPassthroughOff
+
+		if alias != "" {
+			if err := validateAlias(alias); err != nil {
+				return nil, false, err
+			}
+		} else if shortURL, ok := batchLongURLs[longURL]; ok && reusable {
+			longURLItem["short_url"] = shortURL
+			continue
+		}
+
+		var processedURL *model.URLsModel
+		if !reusable {
+			processedURL, err = s.createNewShortURL(ctx, longURLItem, longURL, uniqueCodeSource(longURL), attempt, batchCodes)
+		} else {
+			processedURL, err = s.processSingleURL(ctx, longURLItem, longURL, attempt, batchCodes)
+		}
+		if err != nil {
+			return nil, false, err
 		}
 
 		if processedURL != nil {
+			processedURL.ExpiresAt = expiresAt
+			processedURL.MaxClicks = maxClicks
+			processedURL.PasswordHash = passwordHash
+			processedURL.RedirectCode = redirectCode
+			processedURL.QueryPassthrough = queryPassthrough
+			processedURL.IsPrefix = prefix
+			batchCodes[processedURL.ShortURL] = struct{}{}
+			if alias == "" {
+				if reusable {
+					batchLongURLs[longURL] = processedURL.ShortURL
+				}
+				hasGenerated = true
+			}
 			urlsForCreation = append(urlsForCreation, processedURL)
 		}
 	}
 
-	return urlsForCreation, nil
+	return urlsForCreation, hasGenerated, nil
 }
 
 // processSingleURL обрабатывает один URL из пакета
-func (s *urlShortenerService) processSingleURL(ctx context.Context, longURLItem map[string]string, longURL string) (*model.URLsModel, error) {
+func (s *urlShortenerService) processSingleURL(
+	ctx context.Context,
+	longURLItem map[string]string,
+	longURL string,
+	attempt int,
+	batchCodes map[string]struct{},
+) (*model.URLsModel, error) {
 	logger := middleware.GetLogger(ctx)
 	requestID := middleware.ExtractRequestID(ctx)
 
@@ -98,7 +211,7 @@ func (s *urlShortenerService) processSingleURL(ctx context.Context, longURLItem
 	}
 
 	// Создаем новый короткий URL
-	return s.createNewShortURL(ctx, longURLItem, longURL)
+	return s.createNewShortURL(ctx, longURLItem, longURL, longURL, attempt, batchCodes)
 }
 
 // handleExistingURL обрабатывает случай, когда короткий URL уже существует
@@ -121,12 +234,40 @@ func (s *urlShortenerService) handleExistingURL(
 	return nil, nil // Не создаем новую запись
 }
 
-// createNewShortURL создает новый короткий URL
-func (s *urlShortenerService) createNewShortURL(ctx context.Context, longURLItem map[string]string, longURL string) (*model.URLsModel, error) {
+// createNewShortURL создает новый короткий URL.
+// codeSource - данные, из которых генерируется код; обычно совпадают с longURL.
+func (s *urlShortenerService) createNewShortURL(
+	ctx context.Context,
+	longURLItem map[string]string,
+	longURL string,
+	codeSource string,
+	attempt int,
+	batchCodes map[string]struct{},
+) (*model.URLsModel, error) {
 	logger := middleware.GetLogger(ctx)
 	requestID := middleware.ExtractRequestID(ctx)
 
-	shortURL := shortenURLBase62(longURL)
+	var shortURL string
+	if alias := longURLItem["alias"]; alias != "" {
+		if _, ok := batchCodes[alias]; ok {
+			return nil, service.ErrAliasAlreadyExists
+		}
+		if err := s.ensureAliasAvailable(ctx, alias); err != nil {
+			return nil, err
+		}
+		shortURL = alias
+	} else {
+		var err error
+		shortURL, err = s.generateBatchCode(codeSource, attempt, batchCodes)
+		if err != nil {
+			logger.Errorw("Failed to generate short URL",
+				"error", err,
+				"long_url", longURL,
+				"request_id", requestID,
+			)
+			return nil, err
+		}
+	}
 	logger.Debugw("Generated short URL",
 		"short_url", shortURL,
 		"request_id", requestID,
@@ -145,6 +286,21 @@ func (s *urlShortenerService) createNewShortURL(ctx context.Context, longURLItem
 	return &newURL, nil
 }
 
+// generateBatchCode генерирует короткий код, не совпадающий с кодами, уже занятыми в пакете.
+func (s *urlShortenerService) generateBatchCode(longURL string, attempt int, batchCodes map[string]struct{}) (string, error) {
+	for i := 0; i < maxGenerateAttempts; i++ {
+		shortURL, err := s.codeGenerator.Generate(longURL, attempt+i)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := batchCodes[shortURL]; !ok {
+			return shortURL, nil
+		}
+	}
+
+	return "", service.ErrCodeGenerationFailed
+}
+
 // saveURLsToStorage сохраняет URL'ы в базу данных
 func (s *urlShortenerService) saveURLsToStorage(ctx context.Context, urlsForCreation []*model.URLsModel) error {
 	logger := middleware.GetLogger(ctx)
@@ -175,6 +331,7 @@ func (s *urlShortenerService) saveURLsToStorage(ctx context.Context, urlsForCrea
 		}
 	}
 
+	s.invalidate(ctx, urlsForCreation...)
 	return nil
 }
 
@@ -182,24 +339,133 @@ func (s *urlShortenerService) saveURLsToStorage(ctx context.Context, urlsForCrea
 // Если URL уже существует, возвращает существующий короткий URL с ошибкой ErrURLAlreadyExists.
 // Возвращает короткий URL и ошибку, если создание не удалось.
 func (s *urlShortenerService) ShortURL(ctx context.Context, longURL string) (string, error) {
+	return s.ShortURLWithOptions(ctx, longURL, service.ShortenOptions{})
+}
+
+// ShortURLWithOptions создает короткую ссылку из длинного URL с дополнительными параметрами.
+// Если указан opts.Alias, он проверяется и используется как короткий код вместо сгенерированного, а ссылка
+// создается заново, даже если на этот URL уже есть ссылка; если код занят другой ссылкой, возвращается
+// ErrAliasAlreadyExists.
+// Если задан opts.ExpiresAt или opts.TTL, ссылка перестает работать после указанного момента и всегда
+// создается заново; некорректный срок действия приводит к ErrInvalidExpiration.
+// Если задан opts.MaxClicks, ссылка перестает работать после указанного числа переходов и всегда создается заново;
+// отрицательный лимит приводит к ErrInvalidMaxClicks.
+// Если задан opts.Password, переход по ссылке возможен только после ввода пароля; такая ссылка тоже всегда
+// создается заново, а пароль недопустимой длины приводит к ErrInvalidPassword.
+// Если задан opts.Title или opts.ShowInterstitial, ссылка также всегда создается заново;
+// некорректный заголовок приводит к ErrInvalidTitle.
+// opts.RedirectCode и opts.QueryPassthrough задают тип перенаправления и перенос параметров запроса;
+// ссылка с настройками, отличными от умолчаний, всегда создается заново, а некорректные настройки
+// приводят к ErrInvalidRedirect.
+// Если задан opts.Prefix, ссылка переносит сегменты пути после короткого кода в адрес назначения
+// и тоже всегда создается заново.
+// Если заданы opts.RoutingRules или opts.Variants, ссылка всегда создается заново; некорректные правила
+// приводят к ErrInvalidRoutingRules, некорректные варианты адреса назначения - к ErrInvalidVariants.
+// Если URL уже существует, возвращает существующий короткий URL с ошибкой ErrURLAlreadyExists.
+func (s *urlShortenerService) ShortURLWithOptions(ctx context.Context, longURL string, opts service.ShortenOptions) (string, error) {
 	logger := middleware.GetLogger(ctx)
 	requestID := middleware.ExtractRequestID(ctx)
 
 	logger.Infow("Starting URL shortening process",
 		"long_url", longURL,
+		"alias", opts.Alias,
 		"request_id", requestID,
 	)
 
-	shortURLFromStorage, err := s.extractShortURLIfExists(ctx, longURL)
+	if opts.Alias != "" {
+		if err := validateAlias(opts.Alias); err != nil {
+			logger.Warnw("Invalid alias",
+				"error", err,
+				"alias", opts.Alias,
+				"request_id", requestID,
+			)
+			return "", err
+		}
+	}
+
+	expiresAt, err := service.ResolveExpiration(opts.ExpiresAt, opts.TTL, time.Now())
 	if err != nil {
-		logger.Errorw("Failed to extract short URL from storage",
+		logger.Warnw("Invalid expiration",
+			"error", err,
+			"request_id", requestID,
+		)
+		return "", err
+	}
+
+	maxClicks, err := resolveMaxClicks(opts.MaxClicks)
+	if err != nil {
+		logger.Warnw("Invalid max clicks",
+			"error", err,
+			"request_id", requestID,
+		)
+		return "", err
+	}
+
+	passwordHash, err := hashPassword(opts.Password)
+	if err != nil {
+		logger.Warnw("Invalid password",
+			"error", err,
+			"request_id", requestID,
+		)
+		return "", err
+	}
+
+	title, err := service.ResolveTitle(opts.Title)
+	if err != nil {
+		logger.Warnw("Invalid title",
 			"error", err,
-			"long_url", longURL,
 			"request_id", requestID,
 		)
 		return "", err
 	}
 
+	redirectCode, queryPassthrough, err := resolveRedirect(opts.RedirectCode, opts.QueryPassthrough)
+	if err != nil {
+		logger.Warnw("Invalid redirect settings",
+			"error", err,
+			"request_id", requestID,
+		)
+		return "", err
+	}
+
+	routingRules, err := service.NormalizeRoutingRules(opts.RoutingRules)
+	if err != nil {
+		logger.Warnw("Invalid routing rules",
+			"error", err,
+			"request_id", requestID,
+		)
+		return "", err
+	}
+
+	variants, err := service.NormalizeVariants(opts.Variants)
+	if err != nil {
+		logger.Warnw("Invalid split variants",
+			"error", err,
+			"request_id", requestID,
+		)
+		return "", err
+	}
+
+	// Ссылки с собственным коротким кодом, сроком действия, лимитом переходов, паролем, заголовком, страницей
+	// предупреждения, особыми настройками, правилами перенаправления или вариантами адреса назначения
+	// не переиспользуются: каждая выдается отдельно
+	reusable := opts.Alias == "" && expiresAt == nil && maxClicks == nil && passwordHash == nil && title == "" && !opts.ShowInterstitial && !opts.Prefix &&
+		redirectCode == model.DefaultRedirectCode && queryPassthrough == model.QueryPassthroughOff &&
+		len(routingRules) == 0 && len(variants) == 0
+
+	var shortURLFromStorage *string
+	if reusable {
+		shortURLFromStorage, err = s.extractShortURLIfExists(ctx, longURL)
+		if err != nil {
+			logger.Errorw("Failed to extract short URL from storage",
+				"error", err,
+				"long_url", longURL,
+				"request_id", requestID,
+			)
+			return "", err
+		}
+	}
+
 	if shortURLFromStorage != nil {
 		logger.Infow("Short URL already exists in storage",
 			"short_url", *shortURLFromStorage,
@@ -211,31 +477,76 @@ func (s *urlShortenerService) ShortURL(ctx context.Context, longURL string) (str
 		return *shortURLFromStorage, service.ErrURLAlreadyExists
 	}
 
-	logger.Infow("Short URL not found in storage, generating new one",
-		"long_url", longURL,
-		"request_id", requestID,
-	)
-
-	shortURL := shortenURLBase62(longURL)
-	logger.Debugw("Generated short URL",
-		"short_url", shortURL,
-		"request_id", requestID,
-	)
+	if opts.Alias != "" {
+		if err := s.ensureAliasAvailable(ctx, opts.Alias); err != nil {
+			return "", err
+		}
+	} else {
+		logger.Infow("Short URL not found in storage, generating new one",
+			"long_url", longURL,
+			"request_id", requestID,
+		)
+	}
 
 	newURL := model.URLsModel{
-		ShortURL: shortURL,
-		LongURL:  longURL,
+		LongURL:          longURL,
+		ExpiresAt:        expiresAt,
+		MaxClicks:        maxClicks,
+		PasswordHash:     passwordHash,
+		Title:            title,
+		ShowInterstitial: opts.ShowInterstitial,
+		RedirectCode:     redirectCode,
+		QueryPassthrough: queryPassthrough,
+		IsPrefix:         opts.Prefix,
+		RoutingRules:     routingRules,
+		Variants:         variants,
 	}
 
-	err = s.saveShortURLToStorage(ctx, &newURL)
-	if err != nil {
+	codeSource := longURL
+	if !reusable {
+		codeSource = uniqueCodeSource(longURL)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if opts.Alias != "" {
+			newURL.ShortURL = opts.Alias
+		} else {
+			newURL.ShortURL, err = s.codeGenerator.Generate(codeSource, attempt)
+			if err != nil {
+				logger.Errorw("Failed to generate short URL",
+					"error", err,
+					"long_url", longURL,
+					"request_id", requestID,
+				)
+				return "", err
+			}
+			logger.Debugw("Generated short URL",
+				"short_url", newURL.ShortURL,
+				"attempt", attempt,
+				"request_id", requestID,
+			)
+		}
+
+		err = s.saveShortURLToStorage(ctx, &newURL)
+		if err == nil {
+			break
+		}
+
 		logger.Errorw("Failed to save short URL to storage",
 			"error", err,
-			"short_url", shortURL,
+			"short_url", newURL.ShortURL,
 			"long_url", longURL,
 			"request_id", requestID,
 		)
-		return "", err
+		if opts.Alias != "" {
+			return "", mapShortURLConflict(err)
+		}
+		if !repository.IsShortURLExistsError(err) {
+			return "", err
+		}
+		if attempt+1 >= maxGenerateAttempts {
+			return "", service.ErrCodeGenerationFailed
+		}
 	}
 	s.sendNotificationEvent(ctx, longURL)
 
@@ -253,7 +564,7 @@ func (s *urlShortenerService) extractShortURLIfExists(ctx context.Context, longU
 	logger := middleware.GetLogger(ctx)
 	requestID := middleware.ExtractRequestID(ctx)
 
-	urlResponse, err := s.urlRepository.GetByLongURL(ctx, longURL)
+	urlResponse, err := s.findExistingURL(ctx, longURL)
 	if err != nil {
 		logger.Debugw("Failed to extract short URL from storage", "error", err, "request_id", requestID)
 		if repository.IsNotFoundError(err) {
@@ -277,6 +588,18 @@ func (s *urlShortenerService) extractShortURLIfExists(ctx context.Context, longU
 	return &urlResponse.ShortURL, nil
 }
 
+// findExistingURL ищет уже созданную ссылку на longURL согласно политике дедупликации.
+// При DedupPerUser и известном пользователе поиск ограничен ссылками этого пользователя.
+func (s *urlShortenerService) findExistingURL(ctx context.Context, longURL string) (*model.URLsModel, error) {
+	if s.dedupPolicy != DedupGlobal {
+		if user := middleware.GetJWTUserFromContext(ctx); user != nil {
+			return s.userURLsRepository.GetByUserIDAndLongURL(ctx, user.ID, longURL)
+		}
+	}
+
+	return s.urlRepository.GetByLongURL(ctx, longURL)
+}
+
 func (s *urlShortenerService) saveShortURLToStorage(ctx context.Context, url *model.URLsModel) error {
 	logger := middleware.GetLogger(ctx)
 	requestID := middleware.ExtractRequestID(ctx)
@@ -289,6 +612,7 @@ func (s *urlShortenerService) saveShortURLToStorage(ctx context.Context, url *mo
 			logger.Errorw("Failed to associate user with URL",
 				"error", err,
 			)
+			return err
 		}
 	} else {
 		logger.Warnw("JWT user is nil, skipping user association",
@@ -310,9 +634,27 @@ func (s *urlShortenerService) saveShortURLToStorage(ctx context.Context, url *mo
 			"long_url", url.LongURL,
 			"request_id", requestID)
 	}
+
+	s.invalidate(ctx, url)
 	return nil
 }
 
+// invalidate сбрасывает в кэше записи о коротких кодах созданных ссылок: до создания код мог быть
+// закэширован как несуществующий.
+func (s *urlShortenerService) invalidate(ctx context.Context, urls ...*model.URLsModel) {
+	if s.urlCache == nil {
+		return
+	}
+
+	shortURLs := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if url != nil {
+			shortURLs = append(shortURLs, url.ShortURL)
+		}
+	}
+	s.urlCache.Invalidate(ctx, shortURLs...)
+}
+
 func (s *urlShortenerService) sendNotificationEvent(ctx context.Context, url string) {
 	if s.eventBus == nil {
 		return
@@ -342,3 +684,12 @@ func (s *urlShortenerService) sendNotificationEvent(ctx context.Context, url str
 		}
 	}()
 }
+
+// mapShortURLConflict преобразует ошибку конфликта короткого URL на уровне репозитория
+// в ошибку сервиса ErrAliasAlreadyExists. Остальные ошибки возвращаются без изменений.
+func mapShortURLConflict(err error) error {
+	if repository.IsShortURLExistsError(err) {
+		return service.ErrAliasAlreadyExists
+	}
+	return err
+}
@@ -26,7 +26,7 @@ func BenchmarkShortURL_NewURL(b *testing.B) {
 	mockRepo := mock.NewMockURLRepository(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
 
-	service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil)
+	service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil, nil, "", nil)
 	ctx := setupBenchmarkContext()
 
 	longURL := "https://example.com/very/long/url/path/that/needs/to/be/shortened"
@@ -56,7 +56,7 @@ func BenchmarkShortURL_ExistingURL(b *testing.B) {
 	mockRepo := mock.NewMockURLRepository(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
 
-	service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil)
+	service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil, nil, "", nil)
 	ctx := setupBenchmarkContext()
 
 	longURL := "https://example.com/existing/url"
@@ -84,7 +84,7 @@ func BenchmarkShortURLsByBatch(b *testing.B) {
 	mockRepo := mock.NewMockURLRepository(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
 
-	service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil)
+	service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil, nil, "", nil)
 	ctx := setupBenchmarkContext()
 
 	batchSize := 10
@@ -122,7 +122,7 @@ func BenchmarkShortURLsByBatch_Large(b *testing.B) {
 	mockRepo := mock.NewMockURLRepository(ctrl)
 	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
 
-	service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil)
+	service := NewURLShortenerService(mockRepo, mockUserURLsRepo, nil, nil, "", nil)
 	ctx := setupBenchmarkContext()
 
 	batchSize := 100
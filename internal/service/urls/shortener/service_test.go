@@ -13,6 +13,7 @@ import (
 	"yp-go-short-url-service/internal/repository"
 	"yp-go-short-url-service/internal/repository/mock"
 	services "yp-go-short-url-service/internal/service"
+	serviceMock "yp-go-short-url-service/internal/service/mock"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -30,7 +31,7 @@ func TestNewLinkShortenerService(t *testing.T) {
 	auditEventBus := observerMock.NewMockSubject[audit.Event](ctrl)
 
 	// Создаем сервис через тестовый конструктор
-	service := NewURLShortenerService(mockURLRepo, mockUserURLsRepo, auditEventBus)
+	service := NewURLShortenerService(mockURLRepo, mockUserURLsRepo, auditEventBus, nil, "", nil)
 
 	// Проверяем, что сервис создан корректно
 	assert.NotNil(t, service)
@@ -49,6 +50,7 @@ func Test_linkShortenerService_ShortURL(t *testing.T) {
 	service := &urlShortenerService{
 		urlRepository:      mockRepo,
 		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
 	}
 
 	// Создаем контекст с логгером для тестов
@@ -206,6 +208,7 @@ func Test_linkShortenerService_ShortURL_EdgeCases(t *testing.T) {
 	service := &urlShortenerService{
 		urlRepository:      mockRepo,
 		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
 	}
 
 	// Создаем контекст с логгером для тестов
@@ -302,6 +305,7 @@ func Test_linkShortenerService_extractShortURLIfExists(t *testing.T) {
 	service := &urlShortenerService{
 		urlRepository:      mockRepo,
 		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
 	}
 
 	// Создаем контекст с логгером для тестов
@@ -376,6 +380,7 @@ func Test_linkShortenerService_saveShortURLToStorage(t *testing.T) {
 	service := &urlShortenerService{
 		urlRepository:      mockRepo,
 		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
 	}
 
 	// Создаем контекст с логгером для тестов
@@ -417,6 +422,56 @@ func Test_linkShortenerService_saveShortURLToStorage(t *testing.T) {
 	})
 }
 
+func Test_urlShortenerService_CacheInvalidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	mockUserURLsRepo := mock.NewMockUserURLsRepository(ctrl)
+	mockCache := serviceMock.NewMockCache(ctrl)
+
+	service := &urlShortenerService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mockUserURLsRepo,
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
+		urlCache:           mockCache,
+	}
+
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	userCtx := context.WithValue(ctx, middleware.JWTTokenContextKey, &model.UserModel{ID: "owner"})
+
+	t.Run("created link", func(t *testing.T) {
+		url := &model.URLsModel{ShortURL: "new1", LongURL: "https://example.com/1"}
+		mockRepo.EXPECT().Create(ctx, url).Return(nil)
+		mockCache.EXPECT().Invalidate(ctx, "new1")
+
+		assert.NoError(t, service.saveShortURLToStorage(ctx, url))
+	})
+
+	t.Run("created user link", func(t *testing.T) {
+		url := &model.URLsModel{ShortURL: "new2", LongURL: "https://example.com/2"}
+		mockUserURLsRepo.EXPECT().CreateURLWithUser(userCtx, url, "owner").Return(nil)
+		mockCache.EXPECT().Invalidate(userCtx, "new2")
+
+		assert.NoError(t, service.saveShortURLToStorage(userCtx, url))
+	})
+
+	t.Run("created batch", func(t *testing.T) {
+		urls := []*model.URLsModel{{ShortURL: "batch1"}, {ShortURL: "batch2"}}
+		mockRepo.EXPECT().CreateBatch(ctx, urls).Return(nil)
+		mockCache.EXPECT().Invalidate(ctx, "batch1", "batch2")
+
+		assert.NoError(t, service.saveURLsToStorage(ctx, urls))
+	})
+
+	t.Run("failed creation keeps the cache", func(t *testing.T) {
+		url := &model.URLsModel{ShortURL: "new3", LongURL: "https://example.com/3"}
+		mockRepo.EXPECT().Create(ctx, url).Return(repository.ErrShortURLExists)
+
+		assert.Error(t, service.saveShortURLToStorage(ctx, url))
+	})
+}
+
 func Test_urlShortenerService_ShortURLsByBatch(t *testing.T) {
 	// Создаем контроллер для моков
 	ctrl := gomock.NewController(t)
@@ -429,6 +484,7 @@ func Test_urlShortenerService_ShortURLsByBatch(t *testing.T) {
 	service := &urlShortenerService{
 		urlRepository:      mockRepo,
 		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
 	}
 
 	// Создаем контекст с логгером для тестов
@@ -692,6 +748,7 @@ func Test_urlShortenerService_ShortURL_ConflictScenarios(t *testing.T) {
 	service := &urlShortenerService{
 		urlRepository:      mockRepo,
 		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
 	}
 
 	// Создаем контекст с логгером для тестов
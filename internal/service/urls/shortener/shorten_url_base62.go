@@ -12,12 +12,7 @@ const hashSize int = 8
 func shortenURLBase62(longURL string) string {
 	hash := md5.Sum([]byte(longURL))
 
-	num := new(big.Int)
-
-	num.SetBytes(hash[:hashSize])
-
-	shortURL := toBase62(num)
-	return shortURL[:shortURLSize]
+	return encodeHashBase62(hash[:hashSize], shortURLSize)
 }
 
 func toBase62(num *big.Int) string {
@@ -0,0 +1,59 @@
+package shortener
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"yp-go-short-url-service/internal/middleware"
+	"yp-go-short-url-service/internal/model"
+	"yp-go-short-url-service/internal/repository/mock"
+	services "yp-go-short-url-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func Test_urlShortenerService_ShortURLWithOptions_Preview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockURLRepository(ctrl)
+	service := &urlShortenerService{
+		urlRepository:      mockRepo,
+		userURLsRepository: mock.NewMockUserURLsRepository(ctrl),
+		codeGenerator:      NewHashCodeGenerator(shortURLSize),
+	}
+
+	ctx := middleware.WithLogger(context.Background(), zap.NewNop().Sugar())
+	longURL := "https://example.com/report"
+
+	t.Run("links with preview settings are never reused", func(t *testing.T) {
+		var codes []string
+		mockRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url *model.URLsModel) error {
+				assert.Equal(t, "Отчет", url.Title)
+				assert.True(t, url.ShowInterstitial)
+				codes = append(codes, url.ShortURL)
+				return nil
+			}).
+			Times(2)
+
+		for i := 0; i < 2; i++ {
+			shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Title: " Отчет ", ShowInterstitial: true})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, shortURL)
+		}
+
+		require.Len(t, codes, 2)
+		assert.NotEqual(t, codes[0], codes[1])
+	})
+
+	t.Run("invalid title is rejected", func(t *testing.T) {
+		shortURL, err := service.ShortURLWithOptions(ctx, longURL, services.ShortenOptions{Title: strings.Repeat("a", services.MaxTitleLength+1)})
+		assert.True(t, services.IsInvalidTitleError(err))
+		assert.Empty(t, shortURL)
+	})
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"yp-go-short-url-service/internal/model"
+)
+
+const (
+	// MaxSplitVariants - максимальное число вариантов адреса назначения у одной ссылки.
+	MaxSplitVariants = 10
+	// MaxVariantWeight - максимальный вес одного варианта адреса назначения.
+	MaxVariantWeight = 10000
+	// maxVariantNameLength - максимальная длина имени варианта.
+	maxVariantNameLength = 32
+)
+
+// NormalizeVariants проверяет варианты адреса назначения и приводит их к каноническому виду:
+// имена переводятся в нижний регистр, у имен и адресов отбрасываются пробелы по краям.
+// Пустой список возвращается как nil. Возвращает ошибку ErrInvalidVariants, если вариантов меньше двух
+// или больше MaxSplitVariants, имя варианта пустое, повторяется или содержит символы, отличные от латинских
+// букв, цифр, "-" и "_", вес не в диапазоне от 1 до MaxVariantWeight или адрес назначения
+// не является абсолютным HTTP(S) URL.
+func NormalizeVariants(variants model.SplitVariants) (model.SplitVariants, error) {
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	if len(variants) < 2 || len(variants) > MaxSplitVariants {
+		return nil, fmt.Errorf("%w: from 2 to %d variants are required", ErrInvalidVariants, MaxSplitVariants)
+	}
+
+	normalized := make(model.SplitVariants, 0, len(variants))
+	seen := make(map[string]struct{}, len(variants))
+	for i, variant := range variants {
+		result, err := normalizeVariant(variant)
+		if err != nil {
+			return nil, fmt.Errorf("%w: variant %d: %s", ErrInvalidVariants, i+1, err)
+		}
+		if _, ok := seen[result.Name]; ok {
+			return nil, fmt.Errorf("%w: variant %d: duplicate name %q", ErrInvalidVariants, i+1, result.Name)
+		}
+		seen[result.Name] = struct{}{}
+		normalized = append(normalized, result)
+	}
+
+	return normalized, nil
+}
+
+func normalizeVariant(variant model.SplitVariant) (model.SplitVariant, error) {
+	result := model.SplitVariant{
+		Name:        strings.ToLower(strings.TrimSpace(variant.Name)),
+		Destination: strings.TrimSpace(variant.Destination),
+		Weight:      variant.Weight,
+	}
+
+	if !isVariantName(result.Name) {
+		return result, fmt.Errorf("name must be 1 to %d latin letters, digits, '-' or '_'", maxVariantNameLength)
+	}
+	if result.Weight < 1 || result.Weight > MaxVariantWeight {
+		return result, fmt.Errorf("weight must be from 1 to %d", MaxVariantWeight)
+	}
+	if !IsHTTPURL(result.Destination) {
+		return result, fmt.Errorf("destination must be an absolute http or https url")
+	}
+
+	return result, nil
+}
+
+// isVariantName сообщает, допустимо ли имя варианта: имя попадает в cookie и статистику,
+// поэтому ограничено строчными латинскими буквами, цифрами, "-" и "_".
+func isVariantName(name string) bool {
+	if name == "" || len(name) > maxVariantNameLength {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
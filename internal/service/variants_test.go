@@ -0,0 +1,58 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"yp-go-short-url-service/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeVariants(t *testing.T) {
+	t.Run("empty list", func(t *testing.T) {
+		variants, err := NormalizeVariants(model.SplitVariants{})
+		require.NoError(t, err)
+		assert.Nil(t, variants)
+	})
+
+	t.Run("variants are normalized", func(t *testing.T) {
+		variants, err := NormalizeVariants(model.SplitVariants{
+			{Name: " A ", Destination: " https://example.com/a ", Weight: 70},
+			{Name: "landing_b", Destination: "https://example.com/b", Weight: 30},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, model.SplitVariants{
+			{Name: "a", Destination: "https://example.com/a", Weight: 70},
+			{Name: "landing_b", Destination: "https://example.com/b", Weight: 30},
+		}, variants)
+	})
+
+	valid := model.SplitVariant{Name: "b", Destination: "https://example.com/b", Weight: 1}
+	tooMany := make(model.SplitVariants, MaxSplitVariants+1)
+	for i := range tooMany {
+		tooMany[i] = model.SplitVariant{Name: strings.Repeat("v", i+1), Destination: "https://example.com", Weight: 1}
+	}
+
+	tests := []struct {
+		name     string
+		variants model.SplitVariants
+	}{
+		{name: "single variant", variants: model.SplitVariants{valid}},
+		{name: "too many variants", variants: tooMany},
+		{name: "empty name", variants: model.SplitVariants{{Destination: "https://example.com/a", Weight: 1}, valid}},
+		{name: "name with spaces", variants: model.SplitVariants{{Name: "landing a", Destination: "https://example.com/a", Weight: 1}, valid}},
+		{name: "name too long", variants: model.SplitVariants{{Name: strings.Repeat("a", 33), Destination: "https://example.com/a", Weight: 1}, valid}},
+		{name: "duplicate name", variants: model.SplitVariants{{Name: "B", Destination: "https://example.com/a", Weight: 1}, valid}},
+		{name: "zero weight", variants: model.SplitVariants{{Name: "a", Destination: "https://example.com/a"}, valid}},
+		{name: "weight too large", variants: model.SplitVariants{{Name: "a", Destination: "https://example.com/a", Weight: MaxVariantWeight + 1}, valid}},
+		{name: "relative destination", variants: model.SplitVariants{{Name: "a", Destination: "/a", Weight: 1}, valid}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeVariants(tt.variants)
+			assert.True(t, IsInvalidVariantsError(err))
+		})
+	}
+}
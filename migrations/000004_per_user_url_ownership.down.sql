@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_user_urls_url_id_unique;
+
+-- Глобальная уникальность длинного URL восстанавливается, только если ее не нарушают ссылки, созданные
+-- разными пользователями после этой миграции. Иначе ограничение не добавляется, чтобы не удалять чужие
+-- ссылки, и остается обычный индекс по long_url; повторное применение миграции корректно в обоих случаях.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM urls GROUP BY long_url HAVING COUNT(*) > 1) THEN
+        ALTER TABLE urls ADD CONSTRAINT urls_long_url_key UNIQUE (long_url);
+        DROP INDEX IF EXISTS idx_urls_long_url;
+    END IF;
+END
+$$;
@@ -0,0 +1,6 @@
+-- Длинный URL больше не уникален глобально: каждый пользователь владеет собственными ссылками
+ALTER TABLE urls DROP CONSTRAINT IF EXISTS urls_long_url_key;
+CREATE INDEX IF NOT EXISTS idx_urls_long_url ON urls(long_url);
+
+-- Каждая запись URL принадлежит не более чем одному пользователю
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_urls_url_id_unique ON user_urls(url_id);
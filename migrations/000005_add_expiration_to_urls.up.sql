@@ -0,0 +1,6 @@
+-- Необязательный срок действия короткой ссылки
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+-- Флаг, который фоновый процесс выставляет ссылкам с наступившим сроком действия
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS is_expired BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_urls_expires_at ON urls(expires_at) WHERE is_expired = false;
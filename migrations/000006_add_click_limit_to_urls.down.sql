@@ -0,0 +1,2 @@
+ALTER TABLE urls DROP COLUMN IF EXISTS clicks_left;
+ALTER TABLE urls DROP COLUMN IF EXISTS max_clicks;
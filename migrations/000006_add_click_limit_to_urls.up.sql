@@ -0,0 +1,4 @@
+-- Необязательный лимит переходов по короткой ссылке
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS max_clicks BIGINT CHECK (max_clicks > 0);
+-- Оставшееся количество переходов; уменьшается атомарно при каждом переходе
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS clicks_left BIGINT CHECK (clicks_left >= 0);
@@ -0,0 +1,2 @@
+-- bcrypt-хеш пароля для защищенных ссылок
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS password_hash TEXT;
@@ -0,0 +1,2 @@
+-- Ссылка, отключенная владельцем, не перенаправляет до повторного включения
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS is_disabled BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,17 @@
+-- Очередь задач пакетного удаления и восстановления ссылок; переживает перезапуск сервиса
+CREATE TABLE IF NOT EXISTS delete_jobs (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    short_urls JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    results JSONB,
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    lease_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_delete_jobs_status_next_run_at ON delete_jobs(status, next_run_at);
@@ -0,0 +1,6 @@
+-- Счетчики переходов по ссылкам; пополняются пачками из буфера переходов и удаляются вместе со ссылкой
+CREATE TABLE IF NOT EXISTS click_counters (
+    url_id INTEGER PRIMARY KEY REFERENCES urls(id) ON DELETE CASCADE,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
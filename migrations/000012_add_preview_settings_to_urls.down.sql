@@ -0,0 +1,2 @@
+ALTER TABLE urls DROP COLUMN IF EXISTS show_interstitial;
+ALTER TABLE urls DROP COLUMN IF EXISTS title;
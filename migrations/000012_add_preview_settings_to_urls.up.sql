@@ -0,0 +1,3 @@
+-- Заголовок ссылки для страницы предпросмотра и показ страницы-предупреждения перед переходом
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS title TEXT NOT NULL DEFAULT '';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS show_interstitial BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE urls DROP COLUMN IF EXISTS query_passthrough;
+ALTER TABLE urls DROP COLUMN IF EXISTS redirect_code;
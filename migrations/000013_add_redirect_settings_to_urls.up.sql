@@ -0,0 +1,3 @@
+-- Тип перенаправления по ссылке и режим переноса параметров запроса в адрес назначения
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS redirect_code INTEGER NOT NULL DEFAULT 307;
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS query_passthrough TEXT NOT NULL DEFAULT '';
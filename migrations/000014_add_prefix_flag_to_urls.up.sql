@@ -0,0 +1,2 @@
+-- Ссылка-префикс переносит сегменты пути после короткого кода в конец адреса назначения
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS is_prefix BOOLEAN NOT NULL DEFAULT FALSE;
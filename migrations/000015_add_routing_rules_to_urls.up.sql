@@ -0,0 +1,2 @@
+-- Правила условного перенаправления ссылки в виде JSON; пустая строка означает отсутствие правил
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS routing_rules TEXT NOT NULL DEFAULT '';
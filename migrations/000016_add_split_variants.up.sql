@@ -0,0 +1,4 @@
+-- Варианты адреса назначения ссылки для разделения трафика в виде JSON; пустая строка означает отсутствие вариантов
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS variants TEXT NOT NULL DEFAULT '';
+-- Имя варианта адреса назначения, на который выполнен переход
+ALTER TABLE clicks ADD COLUMN IF NOT EXISTS variant TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_link_health_failures;
+DROP TABLE IF EXISTS link_health;
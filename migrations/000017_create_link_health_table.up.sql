@@ -0,0 +1,13 @@
+-- Результаты фоновой проверки доступности адресов назначения; по одной строке на ссылку,
+-- удаляются вместе со ссылкой
+CREATE TABLE IF NOT EXISTS link_health (
+    url_id INTEGER PRIMARY KEY REFERENCES urls(id) ON DELETE CASCADE,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    redirect_chain TEXT NOT NULL DEFAULT '',
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    checked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- Частичный индекс для выборки недоступных ссылок
+CREATE INDEX IF NOT EXISTS idx_link_health_failures ON link_health (consecutive_failures) WHERE consecutive_failures > 0;
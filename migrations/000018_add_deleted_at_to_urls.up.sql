@@ -0,0 +1,9 @@
+-- Момент пометки ссылки удаленной; в отличие от updated_at не меняется при других изменениях,
+-- поэтому по нему отсчитывается срок хранения в корзине
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Для ссылок, удаленных до появления колонки, момент удаления берется из updated_at
+UPDATE urls SET deleted_at = updated_at WHERE is_deleted = true AND deleted_at IS NULL;
+
+-- Частичный индекс для окончательного удаления ссылок из корзины
+CREATE INDEX IF NOT EXISTS idx_urls_deleted_at ON urls (deleted_at) WHERE is_deleted = true;